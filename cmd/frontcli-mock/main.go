@@ -0,0 +1,59 @@
+// Command frontcli-mock serves an in-memory mock of the Front API for trying
+// frontcli without a Front account:
+//
+//	frontcli-mock --addr 127.0.0.1:8089 &
+//	FRONT_API_BASE_URL=http://127.0.0.1:8089 FRONT_API_TOKEN=mock frontcli conv list
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/dedene/frontapp-cli/internal/mockfront"
+)
+
+type cli struct {
+	Addr  string `help:"Address to listen on" default:"127.0.0.1:8089"`
+	Empty bool   `help:"Start without the sample workspace"`
+}
+
+func main() {
+	var c cli
+
+	kong.Parse(&c,
+		kong.Name("frontcli-mock"),
+		kong.Description("Mock Front API server with in-memory state, for use with frontcli"),
+	)
+
+	if err := run(c); err != nil {
+		fmt.Fprintln(os.Stderr, "frontcli-mock:", err)
+		os.Exit(1)
+	}
+}
+
+func run(c cli) error {
+	ln, err := net.Listen("tcp", c.Addr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Mock Front API listening on http://%s\n", ln.Addr())
+	fmt.Fprintf(os.Stderr, "  export FRONT_API_BASE_URL=http://%s FRONT_API_TOKEN=mock\n", ln.Addr())
+
+	srv := &http.Server{
+		Handler:           mockfront.New(!c.Empty),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
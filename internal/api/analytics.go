@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// AnalyticsFilters restricts an analytics report or export to a subset of
+// resources. Empty slices are omitted.
+type AnalyticsFilters struct {
+	InboxIDs    []string `json:"inbox_ids,omitempty"`
+	TeammateIDs []string `json:"teammate_ids,omitempty"`
+	TagIDs      []string `json:"tag_ids,omitempty"`
+	ChannelIDs  []string `json:"channel_ids,omitempty"`
+}
+
+// AnalyticsReportRequest is the body for creating an analytics report.
+type AnalyticsReportRequest struct {
+	Start    float64           `json:"start"` // Unix timestamp
+	End      float64           `json:"end"`   // Unix timestamp
+	Timezone string            `json:"timezone,omitempty"`
+	Metrics  []string          `json:"metrics"`
+	Filters  *AnalyticsFilters `json:"filters,omitempty"`
+}
+
+// AnalyticsExportRequest is the body for creating an analytics export.
+type AnalyticsExportRequest struct {
+	Start    float64           `json:"start"` // Unix timestamp
+	End      float64           `json:"end"`   // Unix timestamp
+	Timezone string            `json:"timezone,omitempty"`
+	Type     string            `json:"type"` // events, messages
+	Filters  *AnalyticsFilters `json:"filters,omitempty"`
+}
+
+// CreateAnalyticsReport starts computing an analytics report.
+func (c *Client) CreateAnalyticsReport(ctx context.Context, req AnalyticsReportRequest) (*AnalyticsReport, error) {
+	var report AnalyticsReport
+	if err := c.Post(ctx, "/analytics/reports", req, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// GetAnalyticsReport gets the status and metrics of an analytics report.
+func (c *Client) GetAnalyticsReport(ctx context.Context, uid string) (*AnalyticsReport, error) {
+	uid, err := SanitizeID(uid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report ID %q: %w", uid, err)
+	}
+
+	var report AnalyticsReport
+	if err := c.Get(ctx, "/analytics/reports/"+uid, &report); err != nil {
+		return nil, enrichErrorWithContext(err, uid, "analytics report")
+	}
+
+	return &report, nil
+}
+
+// CreateAnalyticsExport starts an analytics data export.
+func (c *Client) CreateAnalyticsExport(ctx context.Context, req AnalyticsExportRequest) (*AnalyticsExport, error) {
+	var export AnalyticsExport
+	if err := c.Post(ctx, "/analytics/exports", req, &export); err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// GetAnalyticsExport gets the status of an analytics export.
+func (c *Client) GetAnalyticsExport(ctx context.Context, uid string) (*AnalyticsExport, error) {
+	uid, err := SanitizeID(uid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid export ID %q: %w", uid, err)
+	}
+
+	var export AnalyticsExport
+	if err := c.Get(ctx, "/analytics/exports/"+uid, &export); err != nil {
+		return nil, enrichErrorWithContext(err, uid, "analytics export")
+	}
+
+	return &export, nil
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxAuditSummary caps the request body kept in an audit entry.
+const maxAuditSummary = 200
+
+// AuditEntry is one call that changed data, as kept in the audit log.
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Account string    `json:"account"`
+	Command string    `json:"command,omitempty"`
+	Method  string    `json:"method"`
+	Path    string    `json:"path"`
+	Summary string    `json:"summary,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// AuditLog appends an AuditEntry, one JSON object per line, to the file at
+// Path for every request but GET, whether it succeeded or not. Request
+// bodies are summarized with secrets redacted.
+type AuditLog struct {
+	Path    string
+	Account string
+	Command string
+
+	mu sync.Mutex
+}
+
+func (l *AuditLog) record(method, path, contentType string, body []byte, err error) error {
+	entry := AuditEntry{
+		Time:    time.Now().UTC(),
+		Account: l.Account,
+		Command: l.Command,
+		Method:  method,
+		Path:    path,
+	}
+
+	if len(body) > 0 && contentType == ContentType {
+		entry.Summary = auditSummary(body)
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	line, mErr := json.Marshal(entry)
+	if mErr != nil {
+		return mErr
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Created on the first change, so read-only commands leave no trace.
+	if dErr := os.MkdirAll(filepath.Dir(l.Path), 0o700); dErr != nil {
+		return fmt.Errorf("create audit log dir: %w", dErr)
+	}
+
+	f, oErr := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if oErr != nil {
+		return fmt.Errorf("open audit log: %w", oErr)
+	}
+
+	if _, wErr := f.Write(append(line, '\n')); wErr != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("write audit log: %w", wErr)
+	}
+
+	return f.Close()
+}
+
+// auditSummary is body as compact, redacted JSON, cut to maxAuditSummary.
+func auditSummary(body []byte) string {
+	summary := tracedBody(body)
+	if len(summary) > maxAuditSummary {
+		summary = summary[:maxAuditSummary] + "..."
+	}
+
+	return strings.TrimSpace(summary)
+}
+
+// ReadAuditLog returns the entries in the audit log at path, oldest first.
+// Lines that cannot be parsed are skipped.
+func ReadAuditLog(path string) ([]AuditEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path under the config dir
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []AuditEntry
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// SetAuditLog records every request but GET in log. A nil log disables
+// auditing.
+func (c *Client) SetAuditLog(log *AuditLog) {
+	c.audit = log
+}
+
+// audited reports whether a request with method is written to the audit log.
+func (c *Client) audited(method string) bool {
+	return c.audit != nil && method != http.MethodGet
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ResponseCache stores GET response bodies on disk together with their ETag,
+// so repeated requests can be sent conditionally and answered from disk on a
+// 304 Not Modified. Entries are keyed by request URL.
+type ResponseCache struct {
+	dir string
+}
+
+type cacheEntry struct {
+	ETag string          `json:"etag"`
+	Body json.RawMessage `json:"body"`
+}
+
+// NewResponseCache returns a cache that keeps its entries in dir.
+func NewResponseCache(dir string) *ResponseCache {
+	return &ResponseCache{dir: dir}
+}
+
+func (c *ResponseCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// lookup returns the cached entry for key, if any. Unreadable entries are
+// treated as misses.
+func (c *ResponseCache) lookup(key string) (cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.ETag == "" {
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// store saves body under key. The entry is written to a temporary file and
+// renamed so concurrent readers never see a partial entry.
+func (c *ResponseCache) store(key, etag string, body []byte) error {
+	if !json.Valid(body) {
+		return nil
+	}
+
+	data, err := json.Marshal(cacheEntry{ETag: etag, Body: body})
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("ensure cache dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("create cache entry: %w", err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("save cache entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestClientServesNotModifiedFromCache(t *testing.T) {
+	var requests, notModified int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tag_1","name":"vip"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+	client.SetCache(NewResponseCache(t.TempDir()))
+
+	for i := range 2 {
+		var tag Tag
+		if err := client.Get(context.Background(), "/tags/tag_1", &tag); err != nil {
+			t.Fatalf("Get #%d: %v", i+1, err)
+		}
+
+		if tag.Name != "vip" {
+			t.Fatalf("Get #%d: name = %q", i+1, tag.Name)
+		}
+	}
+
+	if requests != 2 || notModified != 1 {
+		t.Fatalf("requests = %d, not modified = %d", requests, notModified)
+	}
+}
+
+func TestClientCacheSkipsWrites(t *testing.T) {
+	dir := t.TempDir()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("%s sent If-None-Match", r.Method)
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"id":"tag_1"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+	client.SetCache(NewResponseCache(dir))
+
+	for range 2 {
+		if err := client.Patch(context.Background(), "/tags/tag_1", map[string]string{"name": "x"}, nil); err != nil {
+			t.Fatalf("Patch: %v", err)
+		}
+	}
+
+	if _, ok := client.cache.lookup(srv.URL + "/tags/tag_1"); ok {
+		t.Fatal("PATCH response was cached")
+	}
+}
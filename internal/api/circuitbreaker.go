@@ -10,7 +10,12 @@ const (
 	CircuitBreakerResetTime = 30 * time.Second
 )
 
+// CircuitBreaker refuses requests for Cooldown once Threshold server errors
+// in a row have been seen, so a struggling API is not hammered further.
 type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
 	mu          sync.Mutex
 	failures    int
 	lastFailure time.Time
@@ -18,7 +23,7 @@ type CircuitBreaker struct {
 }
 
 func NewCircuitBreaker() *CircuitBreaker {
-	return &CircuitBreaker{}
+	return &CircuitBreaker{Threshold: CircuitBreakerThreshold, Cooldown: CircuitBreakerResetTime}
 }
 
 func (cb *CircuitBreaker) RecordSuccess() {
@@ -28,6 +33,8 @@ func (cb *CircuitBreaker) RecordSuccess() {
 	cb.open = false
 }
 
+// RecordFailure counts a server error and reports whether it opened the
+// breaker.
 func (cb *CircuitBreaker) RecordFailure() bool {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
@@ -35,7 +42,7 @@ func (cb *CircuitBreaker) RecordFailure() bool {
 	cb.failures++
 	cb.lastFailure = time.Now()
 
-	if cb.failures >= CircuitBreakerThreshold {
+	if cb.failures >= cb.Threshold && !cb.open {
 		cb.open = true
 
 		return true
@@ -52,7 +59,7 @@ func (cb *CircuitBreaker) IsOpen() bool {
 		return false
 	}
 
-	if time.Since(cb.lastFailure) > CircuitBreakerResetTime {
+	if time.Since(cb.lastFailure) > cb.Cooldown {
 		cb.open = false
 		cb.failures = 0
 
@@ -61,3 +68,14 @@ func (cb *CircuitBreaker) IsOpen() bool {
 
 	return true
 }
+
+// state describes an open breaker for CircuitBreakerError.
+func (cb *CircuitBreaker) state() *CircuitBreakerError {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return &CircuitBreakerError{
+		Failures: cb.failures,
+		RetryIn:  max(cb.Cooldown-time.Since(cb.lastFailure), 0).Round(time.Second),
+	}
+}
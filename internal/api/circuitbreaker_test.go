@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCircuitBreakerRefusesAfterThreshold(t *testing.T) {
+	hits := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits++
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	newClient := func(cb *CircuitBreaker) (*Client, *bytes.Buffer) {
+		client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+		client.SetRetryPolicy(RetryPolicy{})
+		client.SetCircuitBreaker(cb)
+
+		var trace bytes.Buffer
+		client.EnableTracing(&trace, false)
+
+		return client, &trace
+	}
+
+	client, trace := newClient(&CircuitBreaker{Threshold: 2, Cooldown: time.Minute})
+
+	for range 3 {
+		_, _ = client.Raw(context.Background(), http.MethodGet, "/me", nil)
+	}
+
+	var refused *CircuitBreakerError
+
+	_, err := client.Raw(context.Background(), http.MethodGet, "/me", nil)
+	if !errors.As(err, &refused) || refused.Failures != 2 || hits != 2 {
+		t.Fatalf("err %v, hits %d", err, hits)
+	}
+
+	if !strings.Contains(trace.String(), "circuit breaker opened after 2 server errors") ||
+		!strings.Contains(trace.String(), "refusing GET /me") {
+		t.Errorf("trace = %q", trace.String())
+	}
+
+	// Without a breaker every request goes out.
+	hits = 0
+	client, _ = newClient(nil)
+
+	for range 3 {
+		_, _ = client.Raw(context.Background(), http.MethodGet, "/me", nil)
+	}
+
+	if hits != 3 {
+		t.Fatalf("hits without breaker = %d, want 3", hits)
+	}
+}
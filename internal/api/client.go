@@ -7,14 +7,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 
 	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/log"
 )
 
 const (
@@ -63,51 +70,236 @@ type Client struct {
 	baseURL     string
 	httpClient  *http.Client
 	tokenSource oauth2.TokenSource
+	transport   *RetryTransport
+	retry       RetryPolicy
 	rateLimiter *RateLimiter
+	cache       *ResponseCache
+	names       nameCache
+	readOnly    bool
+	audit       *AuditLog
+	timeout     time.Duration
+	ctx         context.Context //nolint:containedctx // cancels every request, see SetContext
+
+	scopeMu      sync.Mutex
+	scopes       []string
+	warnedScopes map[string]bool
 }
 
 // NewClient creates a new API client with the given token source.
 func NewClient(ts oauth2.TokenSource) *Client {
+	transport := NewRetryTransport(http.DefaultTransport)
+	// do() retries rate limits itself, so it can refresh the token and
+	// pace retries with the rate limiter.
+	transport.MaxRetries429 = 0
+
 	return &Client{
 		baseURL:     BaseURL,
 		tokenSource: ts,
+		transport:   transport,
+		retry:       DefaultRetryPolicy(),
 		httpClient: &http.Client{
-			Transport: NewRetryTransport(http.DefaultTransport),
+			Transport: transport,
 		},
 		rateLimiter: NewRateLimiter(),
 	}
 }
 
+// SetRetryPolicy changes how rate-limited and failed requests are retried.
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+	c.transport.MaxRetries5xx = p.MaxServerErrorRetries
+	c.transport.MaxRetriesNetwork = p.MaxNetworkRetries
+	c.transport.BaseDelay = p.BaseDelay
+	c.transport.budget = &retryBudget{limit: p.Budget}
+}
+
 // NewClientWithBaseURL creates a new API client with a custom base URL.
 func NewClientWithBaseURL(ts oauth2.TokenSource, baseURL string) *Client {
 	client := NewClient(ts)
+	client.SetBaseURL(baseURL)
+
+	return client
+}
+
+// SetBaseURL points the client at another API host, such as a mock server.
+// An empty URL is ignored.
+func (c *Client) SetBaseURL(baseURL string) {
 	if strings.TrimSpace(baseURL) != "" {
-		client.baseURL = strings.TrimRight(baseURL, "/")
+		c.baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
 	}
+}
 
-	return client
+// BaseURL is the API host the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// SetReadOnly makes the client refuse every request but GET, so a command
+// can be run without any risk of changing data.
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
+// SetTimeout bounds each call to the API, retries included. Zero means no
+// limit.
+func (c *Client) SetTimeout(d time.Duration) {
+	c.timeout = d
+}
+
+// SetContext ties every request to ctx as well as to its own context, so
+// canceling ctx (on Ctrl-C, say) aborts the requests in flight. The cause
+// of the cancellation is returned from the aborted calls.
+func (c *Client) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// requestContext applies the client's timeout and context to ctx.
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	stop := func() bool { return false }
+	if c.ctx != nil {
+		stop = context.AfterFunc(c.ctx, func() { cancel(context.Cause(c.ctx)) })
+	}
+
+	cancelTimeout := context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, c.timeout, fmt.Errorf("%w after %s", ErrTimeout, c.timeout))
+	}
+
+	return ctx, func() {
+		cancelTimeout()
+		stop()
+		cancel(nil)
+	}
+}
+
+// requestError replaces the error of a call cut short by the client's
+// timeout or context with the reason, as "context canceled" tells nothing.
+func requestError(ctx context.Context, method, path string, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	if cause := context.Cause(ctx); errors.Is(cause, ErrTimeout) || errors.Is(cause, ErrInterrupted) {
+		return fmt.Errorf("%s %s: %w", method, path, cause)
+	}
+
+	return err
+}
+
+// SetCircuitBreaker replaces the breaker that pauses requests after repeated
+// server errors. A nil breaker disables it.
+func (c *Client) SetCircuitBreaker(cb *CircuitBreaker) {
+	c.transport.CircuitBreaker = cb
+}
+
+// SetThrottle picks how requests are paced against Front's rate limit and
+// how many run at once.
+func (c *Client) SetThrottle(strategy ThrottleStrategy) {
+	if c.rateLimiter != nil {
+		c.rateLimiter.SetStrategy(strategy)
+	}
+}
+
+// RateLimit is the quota Front reported on the client's last response.
+func (c *Client) RateLimit() RateLimitStatus {
+	if c.rateLimiter == nil {
+		return RateLimitStatus{}
+	}
+
+	return c.rateLimiter.Status()
 }
 
-// NewClientFromAuth creates a client using stored auth credentials.
+// SetCache enables conditional GET requests backed by cache. A nil cache
+// disables caching.
+func (c *Client) SetCache(cache *ResponseCache) {
+	c.cache = cache
+}
+
+// NewClientFromAuth creates a client using FRONT_API_TOKEN,
+// FRONT_REFRESH_TOKEN or the stored credentials for email.
 func NewClientFromAuth(clientName, email string) (*Client, error) {
+	if token := os.Getenv(auth.APITokenEnv); token != "" {
+		return NewClient(auth.NewAPITokenSource(token)), nil
+	}
+
+	if token := os.Getenv(auth.RefreshTokenEnv); token != "" {
+		return NewClient(oauth2.ReuseTokenSource(nil, auth.NewRefreshTokenSource(clientName, token))), nil
+	}
+
 	store, err := auth.OpenDefault()
 	if err != nil {
 		return nil, fmt.Errorf("open keyring: %w", err)
 	}
 
 	ts := auth.NewTokenSource(clientName, email, store)
+	client := NewClient(ts)
 
-	return NewClient(ts), nil
+	if tok, err := store.GetToken(clientName, email); err == nil {
+		client.SetScopes(tok.Scopes)
+	}
+
+	return client, nil
 }
 
 func (c *Client) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	return c.doBody(ctx, method, path, ContentType, body, out)
+}
+
+// doBody is do with a request body of any content type.
+func (c *Client) doBody(ctx context.Context, method, path, contentType string, body []byte, out interface{}) (err error) {
+	ctx, cancel := c.requestContext(ctx)
+
+	defer func() {
+		err = requestError(ctx, method, path, err)
+
+		cancel()
+
+		// Refused read-only calls never reach Front.
+		if c.audited(method) && !errors.Is(err, ErrReadOnly) {
+			if auditErr := c.audit.record(method, path, contentType, body, err); auditErr != nil {
+				log.Warn("audit log not written", "error", auditErr)
+			}
+		}
+	}()
+
 	if err := validatePath(path); err != nil {
 		return fmt.Errorf("unsafe API path %q: %w", path, err)
 	}
 
+	c.checkScope(method, path)
+
+	if c.readOnly && method != http.MethodGet {
+		return fmt.Errorf("%s %s: %w", method, path, ErrReadOnly)
+	}
+
+	if c.rateLimiter != nil {
+		release, err := c.rateLimiter.acquire(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer release()
+	}
+
 	reqURL := c.baseURL + path
 
-	for attempt := 0; attempt < 2; attempt++ {
+	cache := c.cache
+	if method != http.MethodGet {
+		cache = nil
+	}
+
+	// One key for every attempt, so Front applies a retried POST only once.
+	var idempotencyKey string
+	if method == http.MethodPost {
+		idempotencyKey = newIdempotencyKey()
+	}
+
+	reauthorized := false
+	rateLimitRetries := 0
+
+	for {
 		if c.rateLimiter != nil {
 			if err := c.rateLimiter.Wait(ctx); err != nil {
 				return err
@@ -135,7 +327,20 @@ func (c *Client) do(ctx context.Context, method, path string, body []byte, out i
 		req.Header.Set("Accept", ContentType)
 
 		if body != nil {
-			req.Header.Set("Content-Type", ContentType)
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		if idempotencyKey != "" {
+			req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+		}
+
+		var cached cacheEntry
+
+		var hit bool
+		if cache != nil {
+			if cached, hit = cache.lookup(reqURL); hit {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
 		}
 
 		resp, err := c.httpClient.Do(req)
@@ -147,6 +352,18 @@ func (c *Client) do(ctx context.Context, method, path string, body []byte, out i
 			c.rateLimiter.UpdateFromHeaders(resp.Header)
 		}
 
+		if hit && resp.StatusCode == http.StatusNotModified {
+			drainAndClose(resp.Body)
+
+			if out != nil {
+				if err := json.Unmarshal(cached.Body, out); err != nil {
+					return fmt.Errorf("decode cached response: %w", err)
+				}
+			}
+
+			return nil
+		}
+
 		if resp.StatusCode == http.StatusUnauthorized {
 			if ts, ok := c.tokenSource.(*auth.TokenSource); ok {
 				ts.Invalidate()
@@ -154,7 +371,9 @@ func (c *Client) do(ctx context.Context, method, path string, body []byte, out i
 
 			drainAndClose(resp.Body)
 
-			if attempt == 0 {
+			if !reauthorized {
+				reauthorized = true
+
 				continue
 			}
 
@@ -174,6 +393,21 @@ func (c *Client) do(ctx context.Context, method, path string, body []byte, out i
 			}
 		}
 
+		if resp.StatusCode == http.StatusTooManyRequests && rateLimitRetries < c.retry.MaxRateLimitRetries {
+			delay := retryDelay(rateLimitRetries, c.retry.BaseDelay, resp)
+			drainAndClose(resp.Body)
+
+			log.Debug("rate limited, retrying", "path", path, "attempt", rateLimitRetries+1, "delay", delay)
+
+			if err := c.transport.budget.sleep(ctx, delay, method+" "+path+" after HTTP 429"); err != nil {
+				return err
+			}
+
+			rateLimitRetries++
+
+			continue
+		}
+
 		if resp.StatusCode == http.StatusTooManyRequests {
 			retryAfter := 0
 			if ra := resp.Header.Get("Retry-After"); ra != "" {
@@ -196,6 +430,26 @@ func (c *Client) do(ctx context.Context, method, path string, body []byte, out i
 			}
 		}
 
+		if etag := resp.Header.Get("ETag"); cache != nil && etag != "" && resp.StatusCode == http.StatusOK {
+			data, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+
+			if err != nil {
+				return fmt.Errorf("read response: %w", err)
+			}
+
+			// A cache that cannot be written only costs the next request.
+			_ = cache.store(reqURL, etag, data)
+
+			if out != nil {
+				if err := json.Unmarshal(data, out); err != nil {
+					return fmt.Errorf("decode response: %w", err)
+				}
+			}
+
+			return nil
+		}
+
 		if out != nil && resp.StatusCode != http.StatusNoContent {
 			defer resp.Body.Close()
 
@@ -210,12 +464,6 @@ func (c *Client) do(ctx context.Context, method, path string, body []byte, out i
 
 		return nil
 	}
-
-	return &APIError{
-		StatusCode: http.StatusUnauthorized,
-		Message:    "unauthorized",
-		Details:    "token may be expired; try logging in again",
-	}
 }
 
 // Get performs a GET request.
@@ -239,6 +487,50 @@ func (c *Client) Post(ctx context.Context, path string, body interface{}, out in
 	return c.do(ctx, http.MethodPost, path, bodyBytes, out)
 }
 
+// PostMultipart performs a multipart/form-data POST with the given form
+// fields and files, for endpoints that take attachments. Files are sent as
+// attachments[0], attachments[1], ...
+func (c *Client) PostMultipart(ctx context.Context, path string, fields map[string]string, files []string, out interface{}) error {
+	var buf bytes.Buffer
+
+	form := multipart.NewWriter(&buf)
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := form.WriteField(k, fields[k]); err != nil {
+			return fmt.Errorf("write form field %s: %w", k, err)
+		}
+	}
+
+	for i, name := range files {
+		data, err := os.ReadFile(name) //nolint:gosec // user-provided attachment
+		if err != nil {
+			return fmt.Errorf("read attachment: %w", err)
+		}
+
+		part, err := form.CreateFormFile(fmt.Sprintf("attachments[%d]", i), filepath.Base(name))
+		if err != nil {
+			return fmt.Errorf("write attachment %s: %w", name, err)
+		}
+
+		if _, err := part.Write(data); err != nil {
+			return fmt.Errorf("write attachment %s: %w", name, err)
+		}
+	}
+
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("close form: %w", err)
+	}
+
+	return c.doBody(ctx, http.MethodPost, path, form.FormDataContentType(), buf.Bytes(), out)
+}
+
 // Patch performs a PATCH request.
 func (c *Client) Patch(ctx context.Context, path string, body interface{}, out interface{}) error {
 	var bodyBytes []byte
@@ -260,8 +552,60 @@ func (c *Client) Delete(ctx context.Context, path string) error {
 	return c.do(ctx, http.MethodDelete, path, nil, nil)
 }
 
+// DeleteWithBody performs a DELETE request with a JSON body, for endpoints
+// that remove members (teammates, contacts) from a collection.
+func (c *Client) DeleteWithBody(ctx context.Context, path string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal body: %w", err)
+	}
+
+	return c.do(ctx, http.MethodDelete, path, data, nil)
+}
+
+// Raw performs a request with a pre-encoded JSON body and returns the response
+// body undecoded, for endpoints the client doesn't wrap. path may also be an
+// absolute API URL. A response without content returns nil.
+func (c *Client) Raw(ctx context.Context, method, path string, body []byte) (json.RawMessage, error) {
+	path, err := pagePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var out json.RawMessage
+	if err := c.do(ctx, method, path, body, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 // Download performs a GET request and writes the response body to the writer.
 func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
+	return c.download(ctx, path, "", w)
+}
+
+// MessageSource writes the original RFC 822 source of a message, headers
+// included, to w.
+func (c *Client) MessageSource(ctx context.Context, id string, w io.Writer) error {
+	if err := c.download(ctx, "/messages/"+url.PathEscape(id), "message/rfc822", w); err != nil {
+		return enrichErrorWithContext(err, id, "message")
+	}
+
+	return nil
+}
+
+// download GETs path, asking for the accept media type when set, and copies
+// the response body to w.
+func (c *Client) download(ctx context.Context, path, accept string, w io.Writer) (err error) {
+	ctx, cancel := c.requestContext(ctx)
+
+	defer func() {
+		err = requestError(ctx, http.MethodGet, path, err)
+
+		cancel()
+	}()
+
 	if w == nil {
 		return errWriterRequired
 	}
@@ -270,6 +614,17 @@ func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
 		return fmt.Errorf("unsafe API path %q: %w", path, err)
 	}
 
+	c.checkScope(http.MethodGet, path)
+
+	if c.rateLimiter != nil {
+		release, err := c.rateLimiter.acquire(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer release()
+	}
+
 	reqURL := c.baseURL + path
 
 	for attempt := 0; attempt < 2; attempt++ {
@@ -292,6 +647,10 @@ func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
 		req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
 		req.Header.Set("User-Agent", UserAgent)
 
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			return fmt.Errorf("do request: %w", err)
@@ -348,19 +707,34 @@ func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
 	}
 }
 
-// Me returns the authenticated user's info.
+// Me returns the authenticated user's info, fetched once per client.
 func (c *Client) Me(ctx context.Context) (*Me, error) {
-	var me Me
-	if err := c.Get(ctx, "/me", &me); err != nil {
-		return nil, err
+	c.names.mu.Lock()
+	defer c.names.mu.Unlock()
+
+	return c.meLocked(ctx)
+}
+
+// meLocked fetches /me once per client and shares it with ResolveTeammate.
+func (c *Client) meLocked(ctx context.Context) (*Me, error) {
+	if c.names.me == nil {
+		var me Me
+		if err := c.Get(ctx, "/me", &me); err != nil {
+			return nil, err
+		}
+
+		c.names.me = &me
 	}
 
-	return &me, nil
+	return c.names.me, nil
 }
 
 // ListConversations lists conversations with optional filters.
 func (c *Client) ListConversations(ctx context.Context, opts ListConversationsOptions) (*ListResponse[Conversation], error) {
-	path := "/conversations?" + opts.Query()
+	path, err := opts.Path()
+	if err != nil {
+		return nil, err
+	}
 
 	var resp ListResponse[Conversation]
 	if err := c.Get(ctx, path, &resp); err != nil {
@@ -420,6 +794,36 @@ func (c *Client) GetMessage(ctx context.Context, id string) (*Message, error) {
 	return &msg, nil
 }
 
+// MessageSeenReceipts lists who has seen an outbound message, and when.
+func (c *Client) MessageSeenReceipts(ctx context.Context, id string) ([]SeenReceipt, error) {
+	id, err := SanitizeID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message ID %q: %w", id, err)
+	}
+
+	var resp ListResponse[SeenReceipt]
+	if err := c.Get(ctx, "/messages/"+id+"/seen", &resp); err != nil {
+		return nil, enrichErrorWithContext(err, id, "message")
+	}
+
+	return resp.Results, nil
+}
+
+// MarkMessageSeen marks an outbound message as seen by its recipient. Front
+// expects it only for an actual seen action and allows 10 an hour.
+func (c *Client) MarkMessageSeen(ctx context.Context, id string) error {
+	id, err := SanitizeID(id)
+	if err != nil {
+		return fmt.Errorf("invalid message ID %q: %w", id, err)
+	}
+
+	if err := c.Post(ctx, "/messages/"+id+"/seen", nil, nil); err != nil {
+		return enrichErrorWithContext(err, id, "message")
+	}
+
+	return nil
+}
+
 // ListInboxes lists all inboxes.
 func (c *Client) ListInboxes(ctx context.Context) (*ListResponse[Inbox], error) {
 	var resp ListResponse[Inbox]
@@ -445,6 +849,21 @@ func (c *Client) GetInbox(ctx context.Context, id string) (*Inbox, error) {
 	return &inbox, nil
 }
 
+// GetTeam gets a single team, including its inboxes and members.
+func (c *Client) GetTeam(ctx context.Context, id string) (*Team, error) {
+	id, err := SanitizeID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid team ID %q: %w", id, err)
+	}
+
+	var team Team
+	if err := c.Get(ctx, "/teams/"+id, &team); err != nil {
+		return nil, enrichErrorWithContext(err, id, "team")
+	}
+
+	return &team, nil
+}
+
 // ListTags lists all tags.
 func (c *Client) ListTags(ctx context.Context) (*ListResponse[Tag], error) {
 	var resp ListResponse[Tag]
@@ -455,6 +874,23 @@ func (c *Client) ListTags(ctx context.Context) (*ListResponse[Tag], error) {
 	return &resp, nil
 }
 
+// CreateTag creates a top-level tag named name. The tag is remembered for
+// later name lookups in this client.
+func (c *Client) CreateTag(ctx context.Context, name string) (*Tag, error) {
+	var tag Tag
+	if err := c.Post(ctx, "/tags", map[string]string{"name": name}, &tag); err != nil {
+		return nil, err
+	}
+
+	c.names.mu.Lock()
+	if c.names.tags != nil {
+		c.names.tags = append(c.names.tags, tag)
+	}
+	c.names.mu.Unlock()
+
+	return &tag, nil
+}
+
 // GetTag gets a single tag by ID.
 func (c *Client) GetTag(ctx context.Context, id string) (*Tag, error) {
 	id, err := SanitizeID(id)
@@ -521,14 +957,9 @@ func (c *Client) GetChannel(ctx context.Context, id string) (*Channel, error) {
 }
 
 // ListContacts lists contacts.
-func (c *Client) ListContacts(ctx context.Context, limit int) (*ListResponse[Contact], error) {
-	path := "/contacts"
-	if limit > 0 {
-		path += fmt.Sprintf("?limit=%d", limit)
-	}
-
+func (c *Client) ListContacts(ctx context.Context, opts ListContactsOptions) (*ListResponse[Contact], error) {
 	var resp ListResponse[Contact]
-	if err := c.Get(ctx, path, &resp); err != nil {
+	if err := c.Get(ctx, opts.Path(), &resp); err != nil {
 		return nil, err
 	}
 
@@ -537,23 +968,7 @@ func (c *Client) ListContacts(ctx context.Context, limit int) (*ListResponse[Con
 
 // ListContactsPage fetches a page of contacts using a page token.
 func (c *Client) ListContactsPage(ctx context.Context, pageURL string) (*ListResponse[Contact], error) {
-	// pageURL is a full URL; extract path+query
-	parsed, err := url.Parse(pageURL)
-	if err != nil {
-		return nil, fmt.Errorf("parse page URL: %w", err)
-	}
-
-	path := parsed.Path
-	if parsed.RawQuery != "" {
-		path += "?" + parsed.RawQuery
-	}
-
-	var resp ListResponse[Contact]
-	if err := c.Get(ctx, path, &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
+	return GetPage[Contact](ctx, c, pageURL)
 }
 
 // GetContact gets a single contact by ID.
@@ -571,14 +986,133 @@ func (c *Client) GetContact(ctx context.Context, id string) (*Contact, error) {
 	return &contact, nil
 }
 
+// GetAccount gets a single account by ID.
+func (c *Client) GetAccount(ctx context.Context, id string) (*Account, error) {
+	id, err := SanitizeID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID %q: %w", id, err)
+	}
+
+	var account Account
+	if err := c.Get(ctx, "/accounts/"+id, &account); err != nil {
+		return nil, enrichErrorWithContext(err, id, "account")
+	}
+
+	return &account, nil
+}
+
+// GetTemplate gets a single message template by ID.
+func (c *Client) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	id, err := SanitizeID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID %q: %w", id, err)
+	}
+
+	var tmpl Template
+	if err := c.Get(ctx, "/message_templates/"+id, &tmpl); err != nil {
+		return nil, enrichErrorWithContext(err, id, "template")
+	}
+
+	return &tmpl, nil
+}
+
+// GetRule gets a single rule by ID.
+func (c *Client) GetRule(ctx context.Context, id string) (*Rule, error) {
+	id, err := SanitizeID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule ID %q: %w", id, err)
+	}
+
+	var rule Rule
+	if err := c.Get(ctx, "/rules/"+id, &rule); err != nil {
+		return nil, enrichErrorWithContext(err, id, "rule")
+	}
+
+	return &rule, nil
+}
+
+// GetEvent gets a single event by ID.
+func (c *Client) GetEvent(ctx context.Context, id string) (*Event, error) {
+	id, err := SanitizeID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID %q: %w", id, err)
+	}
+
+	var event Event
+	if err := c.Get(ctx, "/events/"+id, &event); err != nil {
+		return nil, enrichErrorWithContext(err, id, "event")
+	}
+
+	return &event, nil
+}
+
+// ListEventsOptions contains options for listing events.
+type ListEventsOptions struct {
+	Types  []string // assign, archive, tag, inbound, outbound, comment, ...
+	Before float64  // Unix timestamp
+	After  float64  // Unix timestamp
+	Limit  int
+}
+
+func (o ListEventsOptions) Query() string {
+	params := url.Values{}
+
+	for _, t := range o.Types {
+		params.Add("q[types][]", t)
+	}
+
+	if o.Before > 0 {
+		params.Set("q[before]", strconv.FormatFloat(o.Before, 'f', -1, 64))
+	}
+
+	if o.After > 0 {
+		params.Set("q[after]", strconv.FormatFloat(o.After, 'f', -1, 64))
+	}
+
+	if o.Limit > 0 {
+		params.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	return params.Encode()
+}
+
 // ListConversationsOptions contains options for listing conversations.
 type ListConversationsOptions struct {
-	InboxID   string
-	TagID     string
-	Statuses  []string // assigned, unassigned, archived, trashed, snoozed
-	Limit     int
-	PageToken string
-	SortOrder string // asc, desc (default: desc = most recent first)
+	InboxIDs      []string // in any of these inboxes
+	TagIDs        []string // with any of these tags
+	AssigneeID    string   // teammate ID; lists that teammate's conversations
+	ContactID     string   // contact ID or alias (alt:email:...)
+	Statuses      []string // assigned, unassigned, archived, trashed, snoozed
+	UpdatedAfter  float64  // Unix timestamp; 0 = no bound
+	UpdatedBefore float64  // Unix timestamp; 0 = no bound
+	Limit         int
+	PageToken     string
+	SortOrder     string // asc, desc (default: desc = most recent first)
+}
+
+var errAssigneeAndContact = errors.New("cannot filter by both assignee and contact")
+
+// Path returns the list endpoint with the query string. Assignee and contact
+// filters are scoped endpoints (/teammates/{id}/conversations and
+// /contacts/{id}/conversations), so they cannot be combined.
+func (o ListConversationsOptions) Path() (string, error) {
+	base := "/conversations"
+
+	switch {
+	case o.AssigneeID != "" && o.ContactID != "":
+		return "", errAssigneeAndContact
+	case o.AssigneeID != "":
+		id, err := SanitizeID(o.AssigneeID)
+		if err != nil {
+			return "", fmt.Errorf("invalid teammate ID %q: %w", o.AssigneeID, err)
+		}
+
+		base = "/teammates/" + id + "/conversations"
+	case o.ContactID != "":
+		base = "/contacts/" + url.PathEscape(o.ContactID) + "/conversations"
+	}
+
+	return base + "?" + o.Query(), nil
 }
 
 // ParseStatus converts a user-friendly status to API statuses.
@@ -598,18 +1132,26 @@ func ParseStatus(status string) []string {
 func (o ListConversationsOptions) Query() string {
 	params := url.Values{}
 
-	if o.InboxID != "" {
-		params.Set("q[inbox_id]", o.InboxID)
+	for _, id := range o.InboxIDs {
+		params.Add("q[inbox_id]", id)
 	}
 
-	if o.TagID != "" {
-		params.Set("q[tag_id]", o.TagID)
+	for _, id := range o.TagIDs {
+		params.Add("q[tag_id]", id)
 	}
 
 	for _, status := range o.Statuses {
 		params.Add("q[statuses][]", status)
 	}
 
+	if o.UpdatedAfter > 0 {
+		params.Set("q[updated_after]", strconv.FormatFloat(o.UpdatedAfter, 'f', -1, 64))
+	}
+
+	if o.UpdatedBefore > 0 {
+		params.Set("q[updated_before]", strconv.FormatFloat(o.UpdatedBefore, 'f', -1, 64))
+	}
+
 	if o.Limit > 0 {
 		params.Set("limit", strconv.Itoa(o.Limit))
 	}
@@ -625,3 +1167,44 @@ func (o ListConversationsOptions) Query() string {
 
 	return params.Encode()
 }
+
+// ListContactsOptions filter and order the contacts list. Listing contacts
+// updated after the last run, oldest first, lets a caller sync incrementally.
+type ListContactsOptions struct {
+	Limit         int
+	UpdatedAfter  float64 // Unix timestamp
+	UpdatedBefore float64 // Unix timestamp
+	SortBy        string  // created_at or updated_at
+	SortOrder     string  // asc or desc
+}
+
+// Path returns the /contacts endpoint with the query string.
+func (o ListContactsOptions) Path() string {
+	params := url.Values{}
+
+	if o.Limit > 0 {
+		params.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	if o.UpdatedAfter > 0 {
+		params.Set("q[updated_after]", strconv.FormatFloat(o.UpdatedAfter, 'f', -1, 64))
+	}
+
+	if o.UpdatedBefore > 0 {
+		params.Set("q[updated_before]", strconv.FormatFloat(o.UpdatedBefore, 'f', -1, 64))
+	}
+
+	if o.SortBy != "" {
+		params.Set("sort_by", o.SortBy)
+	}
+
+	if o.SortOrder != "" {
+		params.Set("sort_order", o.SortOrder)
+	}
+
+	if len(params) == 0 {
+		return "/contacts"
+	}
+
+	return "/contacts?" + params.Encode()
+}
@@ -2,10 +2,15 @@ package api
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -58,3 +63,251 @@ func TestClientRetriesOn401(t *testing.T) {
 		t.Fatalf("expected 2 requests, got %d", requests)
 	}
 }
+
+func TestClientRetriesRateLimits(t *testing.T) {
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"me"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+
+	var out map[string]any
+	if err := client.Get(context.Background(), "/me", &out); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+
+	requests = 0
+	client.SetRetryPolicy(RetryPolicy{})
+
+	var rateErr *RateLimitError
+	if err := client.Get(context.Background(), "/me", &out); !errors.As(err, &rateErr) {
+		t.Fatalf("with retries disabled: %v", err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected 1 request without retries, got %d", requests)
+	}
+}
+
+func TestClientRetryBudgetGivesUp(t *testing.T) {
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+	client.SetCircuitBreaker(nil)
+	// Each 5xx retry waits 20ms, so 50ms covers two of them.
+	client.SetRetryPolicy(RetryPolicy{MaxServerErrorRetries: 5, BaseDelay: 10 * time.Millisecond, Budget: 50 * time.Millisecond})
+
+	err := client.Get(context.Background(), "/me", nil)
+	if !errors.Is(err, ErrRetryBudget) || !strings.Contains(err.Error(), "GET /me after HTTP 503") || requests != 3 {
+		t.Fatalf("err %v after %d requests", err, requests)
+	}
+
+	// The budget is shared: the next call gets no retries at all.
+	requests = 0
+
+	if err := client.Get(context.Background(), "/me", nil); !errors.Is(err, ErrRetryBudget) || requests != 1 {
+		t.Fatalf("second call: err %v after %d requests", err, requests)
+	}
+}
+
+func TestListConversationsOptionsPath(t *testing.T) {
+	tests := []struct {
+		opts ListConversationsOptions
+		want string
+	}{
+		{ListConversationsOptions{Limit: 10}, "/conversations?limit=10"},
+		{
+			ListConversationsOptions{AssigneeID: "tea_1", Statuses: []string{"assigned"}},
+			"/teammates/tea_1/conversations?q%5Bstatuses%5D%5B%5D=assigned",
+		},
+		{
+			ListConversationsOptions{ContactID: ContactRef("bob@example.com")},
+			"/contacts/alt:email:bob@example.com/conversations?",
+		},
+		{
+			ListConversationsOptions{UpdatedAfter: 1700000000, UpdatedBefore: 1700086400.5},
+			"/conversations?q%5Bupdated_after%5D=1700000000&q%5Bupdated_before%5D=1700086400.5",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.opts.Path()
+		if err != nil {
+			t.Fatalf("Path(%+v): %v", tt.opts, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("Path(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+
+	if _, err := (ListConversationsOptions{AssigneeID: "tea_1", ContactID: "crd_1"}).Path(); err == nil {
+		t.Fatal("expected an error for assignee and contact together")
+	}
+}
+
+// flakyTransport fails the first request without a response, as a dropped
+// connection would.
+type flakyTransport struct {
+	base   http.RoundTripper
+	failed bool
+	keys   []string
+}
+
+func (f *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.keys = append(f.keys, req.Header.Get(IdempotencyKeyHeader))
+
+	if !f.failed {
+		f.failed = true
+
+		return nil, errors.New("connection reset by peer")
+	}
+
+	return f.base.RoundTrip(req)
+}
+
+func TestClientRetriesPostWithIdempotencyKey(t *testing.T) {
+	var bodies []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+	client.SetRetryPolicy(RetryPolicy{MaxNetworkRetries: 1})
+
+	flaky := &flakyTransport{base: http.DefaultTransport}
+	client.transport.Base = flaky
+
+	if err := client.Post(context.Background(), "/channels/cha_1/messages", map[string]string{"body": "hi"}, nil); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	if len(flaky.keys) != 2 || flaky.keys[0] == "" || flaky.keys[0] != flaky.keys[1] {
+		t.Fatalf("idempotency keys = %q, want the same key on both attempts", flaky.keys)
+	}
+
+	if len(bodies) != 1 || bodies[0] != `{"body":"hi"}` {
+		t.Fatalf("server received %q", bodies)
+	}
+
+	flaky.failed, flaky.keys = false, nil
+
+	if err := client.Patch(context.Background(), "/conversations/cnv_1", map[string]string{"status": "open"}, nil); err == nil {
+		t.Fatal("PATCH without an idempotency key was retried after a network error")
+	}
+
+	if len(flaky.keys) != 1 || flaky.keys[0] != "" {
+		t.Fatalf("PATCH attempts = %q, want a single unkeyed attempt", flaky.keys)
+	}
+}
+
+func TestNewIdempotencyKeyIsUUID(t *testing.T) {
+	key := newIdempotencyKey()
+
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(key) {
+		t.Fatalf("key %q is not a version 4 UUID", key)
+	}
+
+	if key == newIdempotencyKey() {
+		t.Fatal("keys repeat")
+	}
+}
+
+func TestListContactsOptionsPath(t *testing.T) {
+	tests := []struct {
+		opts ListContactsOptions
+		want string
+	}{
+		{ListContactsOptions{}, "/contacts"},
+		{ListContactsOptions{Limit: 25}, "/contacts?limit=25"},
+		{
+			ListContactsOptions{Limit: 100, UpdatedAfter: 1700000000, SortBy: "updated_at", SortOrder: "asc"},
+			"/contacts?limit=100&q%5Bupdated_after%5D=1700000000&sort_by=updated_at&sort_order=asc",
+		},
+		{ListContactsOptions{UpdatedBefore: 1700086400.5}, "/contacts?q%5Bupdated_before%5D=1700086400.5"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.opts.Path(); got != tt.want {
+			t.Errorf("Path(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestReadOnlyClientRefusesWrites(t *testing.T) {
+	var methods []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+	client.SetReadOnly(true)
+
+	if err := client.Get(context.Background(), "/me", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if err := client.Patch(context.Background(), "/conversations/cnv_1", map[string]string{"status": "archived"}, nil); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("Patch err = %v, want ErrReadOnly", err)
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Fatalf("requests sent: %v", methods)
+	}
+}
+
+func TestClientTimeoutAndContextAbortRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+	client.SetRetryPolicy(RetryPolicy{})
+	client.SetTimeout(50 * time.Millisecond)
+
+	if err := client.Get(context.Background(), "/me", nil); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("timeout err = %v, want ErrTimeout", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	client.SetTimeout(0)
+	client.SetContext(ctx)
+
+	time.AfterFunc(50*time.Millisecond, func() { cancel(ErrInterrupted) })
+
+	if err := client.Get(context.Background(), "/me", nil); !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("interrupt err = %v, want ErrInterrupted", err)
+	}
+}
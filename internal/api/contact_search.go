@@ -0,0 +1,270 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/log"
+)
+
+// ErrStopPaging ends FetchPagesConcurrently early without reporting an error.
+var ErrStopPaging = errors.New("stop paging")
+
+// ErrServerSearchUnsupported is returned by a ContactSearchServer search when
+// Front does not filter contacts by the query.
+var ErrServerSearchUnsupported = errors.New("the API does not support this contact search; try a client-side scan")
+
+// ContactSearchMethod selects where contacts are filtered.
+type ContactSearchMethod string
+
+const (
+	// ContactSearchAuto uses Front's filter and scans when it isn't honoured.
+	ContactSearchAuto ContactSearchMethod = "auto"
+	// ContactSearchServer only uses Front's filter.
+	ContactSearchServer ContactSearchMethod = "server"
+	// ContactSearchScan pages through every contact and filters locally.
+	ContactSearchScan ContactSearchMethod = "scan"
+)
+
+// contactSearchPageSize is the largest page Front serves for /contacts.
+const contactSearchPageSize = 100
+
+// contactWindowCutoffs split the contact scan into updated_at ranges that can
+// be paged independently. Recent ranges come first so they fill the limit
+// first; the last range is open-ended.
+var contactWindowCutoffs = []time.Duration{
+	30 * 24 * time.Hour,
+	90 * 24 * time.Hour,
+	365 * 24 * time.Hour,
+	2 * 365 * 24 * time.Hour,
+	4 * 365 * 24 * time.Hour,
+}
+
+// ContactSearchOptions bound a contact search.
+type ContactSearchOptions struct {
+	Limit    int                 // stop after this many matches
+	MaxPages int                 // total pages to scan across all workers (0 = no limit)
+	Workers  int                 // listings fetched at once (0 = one per updated_at range)
+	Method   ContactSearchMethod // where to filter ("" = ContactSearchAuto)
+}
+
+// FetchPagesConcurrently walks several independent listings at once, handing
+// each page to fn along with the index of the path it belongs to. Page tokens
+// are opaque cursors, so every path is still paged serially; up to workers
+// paths are in flight at a time and the client's rate limiter paces the
+// combined request rate. fn is never called concurrently. Returning
+// ErrStopPaging from fn ends all listings without error.
+func FetchPagesConcurrently[T any](
+	ctx context.Context,
+	c *Client,
+	paths []string,
+	workers, maxPages int,
+	fn func(index int, page *ListResponse[T]) error,
+) error {
+	var (
+		mu      sync.Mutex
+		fetched int
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	if workers > 0 {
+		g.SetLimit(workers)
+	}
+
+	for i, path := range paths {
+		g.Go(func() error {
+			pageURL := path
+
+			for pageURL != "" {
+				mu.Lock()
+				if maxPages > 0 && fetched >= maxPages {
+					mu.Unlock()
+
+					return nil
+				}
+
+				fetched++
+				mu.Unlock()
+
+				resp, err := GetPage[T](gctx, c, pageURL)
+				if err != nil {
+					return err
+				}
+
+				mu.Lock()
+				err = fn(i, resp)
+				mu.Unlock()
+
+				if err != nil {
+					return err
+				}
+
+				pageURL = resp.Pagination.Next
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil && !errors.Is(err, ErrStopPaging) {
+		return err
+	}
+
+	return nil
+}
+
+// SearchContacts returns contacts whose name or handle contains query
+// (case-insensitive). By default Front's ?q= filter is tried first; when the
+// server does not honour it, the address book is scanned client-side, split
+// into updated_at ranges that are paged concurrently. opts.Method forces one
+// or the other.
+func (c *Client) SearchContacts(ctx context.Context, query string, opts ContactSearchOptions) ([]Contact, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	switch opts.Method {
+	case "", ContactSearchAuto, ContactSearchServer:
+	case ContactSearchScan:
+		return c.scanContacts(ctx, query, opts, time.Now())
+	default:
+		return nil, fmt.Errorf("unknown contact search method %q", opts.Method)
+	}
+
+	matches, ok, err := c.searchContactsServer(ctx, query, opts)
+	if err != nil || ok {
+		return matches, err
+	}
+
+	if opts.Method == ContactSearchServer {
+		return nil, ErrServerSearchUnsupported
+	}
+
+	log.Debug("contact search not filtered by the API, scanning client-side", "query", query)
+
+	return c.scanContacts(ctx, query, opts, time.Now())
+}
+
+// searchContactsServer asks Front to filter contacts. ok is false when the
+// endpoint rejected the query or returned contacts that don't match it, i.e.
+// the filter isn't supported and the caller should fall back to a scan.
+func (c *Client) searchContactsServer(ctx context.Context, query string, opts ContactSearchOptions) ([]Contact, bool, error) {
+	pageURL := fmt.Sprintf("/contacts?limit=%d&q=%s", contactSearchPageSize, url.QueryEscape(query))
+
+	var matches []Contact
+
+	for page := 0; pageURL != ""; page++ {
+		if opts.MaxPages > 0 && page >= opts.MaxPages {
+			break
+		}
+
+		resp, err := GetPage[Contact](ctx, c, pageURL)
+		if err != nil {
+			var apiErr *APIError
+			if page == 0 && errors.As(err, &apiErr) &&
+				(apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+				return nil, false, nil
+			}
+
+			return nil, false, err
+		}
+
+		for _, contact := range resp.Results {
+			if !contactMatches(contact, query) {
+				return nil, false, nil
+			}
+		}
+
+		matches = append(matches, resp.Results...)
+		if opts.Limit > 0 && len(matches) >= opts.Limit {
+			return matches[:opts.Limit], true, nil
+		}
+
+		pageURL = resp.Pagination.Next
+	}
+
+	return matches, true, nil
+}
+
+// scanContacts pages through every contact and filters client-side. Matches
+// are returned in range order (most recently updated first).
+func (c *Client) scanContacts(ctx context.Context, query string, opts ContactSearchOptions, now time.Time) ([]Contact, error) {
+	paths := contactWindowPaths(now)
+	perWindow := make([][]Contact, len(paths))
+	total := 0
+
+	err := FetchPagesConcurrently(ctx, c, paths, opts.Workers, opts.MaxPages, func(i int, page *ListResponse[Contact]) error {
+		for _, contact := range page.Results {
+			if contactMatches(contact, query) {
+				perWindow[i] = append(perWindow[i], contact)
+				total++
+			}
+		}
+
+		if opts.Limit > 0 && total >= opts.Limit {
+			return ErrStopPaging
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Guard against bounds being treated inclusively at the shared second.
+	seen := make(map[string]bool)
+
+	var matches []Contact
+
+	for _, contact := range slices.Concat(perWindow...) {
+		if seen[contact.ID] {
+			continue
+		}
+
+		seen[contact.ID] = true
+		matches = append(matches, contact)
+	}
+
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+
+	return matches, nil
+}
+
+// contactWindowPaths returns one /contacts listing per updated_at range,
+// together covering every contact exactly once.
+func contactWindowPaths(now time.Time) []string {
+	paths := make([]string, 0, len(contactWindowCutoffs)+1)
+	before := now.Add(time.Minute).Unix()
+
+	for _, cutoff := range contactWindowCutoffs {
+		after := now.Add(-cutoff).Unix()
+		paths = append(paths, fmt.Sprintf("/contacts?limit=%d&q[updated_after]=%d&q[updated_before]=%d",
+			contactSearchPageSize, after-1, before))
+		before = after
+	}
+
+	return append(paths, fmt.Sprintf("/contacts?limit=%d&q[updated_before]=%d", contactSearchPageSize, before))
+}
+
+func contactMatches(contact Contact, query string) bool {
+	if strings.Contains(strings.ToLower(contact.Name), query) {
+		return true
+	}
+
+	for _, h := range contact.Handles {
+		if strings.Contains(strings.ToLower(h.Handle), query) {
+			return true
+		}
+	}
+
+	return false
+}
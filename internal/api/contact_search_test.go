@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const testContacts = `{"_results":[{"id":"crd_1","name":"Alice Smith","handles":[{"handle":"alice@example.com","source":"email"}]},` +
+	`{"id":"crd_2","name":"Bob","handles":[{"handle":"bob@example.com","source":"email"}]}]}`
+
+func newContactSearchClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+}
+
+func TestSearchContactsUsesServerFilter(t *testing.T) {
+	var scans atomic.Int32
+
+	client := newContactSearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("q") == "alice" {
+			_, _ = w.Write([]byte(`{"_results":[{"id":"crd_1","name":"Alice Smith"}]}`))
+
+			return
+		}
+
+		scans.Add(1)
+		_, _ = w.Write([]byte(testContacts))
+	})
+
+	got, err := client.SearchContacts(context.Background(), "Alice", ContactSearchOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchContacts: %v", err)
+	}
+
+	if len(got) != 1 || got[0].ID != "crd_1" || scans.Load() != 0 {
+		t.Fatalf("got %+v with %d scan requests", got, scans.Load())
+	}
+}
+
+func TestSearchContactsFallsBackToScan(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusBadRequest} {
+		var scans atomic.Int32
+
+		client := newContactSearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			if r.URL.Query().Has("q") {
+				if status != http.StatusOK {
+					w.WriteHeader(status)
+					_, _ = w.Write([]byte(`{"_error":{"message":"invalid q"}}`))
+
+					return
+				}
+
+				// The filter is ignored: every contact comes back.
+				_, _ = w.Write([]byte(testContacts))
+
+				return
+			}
+
+			scans.Add(1)
+
+			if r.URL.Query().Has("q[updated_after]") {
+				_, _ = w.Write([]byte(`{"_results":[]}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(testContacts))
+		})
+
+		got, err := client.SearchContacts(context.Background(), "bob@", ContactSearchOptions{Limit: 10})
+		if err != nil {
+			t.Fatalf("%d: SearchContacts: %v", status, err)
+		}
+
+		if len(got) != 1 || got[0].ID != "crd_2" {
+			t.Fatalf("%d: got %+v", status, got)
+		}
+
+		if want := int32(len(contactWindowCutoffs) + 1); scans.Load() != want {
+			t.Fatalf("%d: scanned %d ranges, want %d", status, scans.Load(), want)
+		}
+	}
+}
+
+func TestContactWindowPathsCoverAllTime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	paths := contactWindowPaths(now)
+
+	if len(paths) != len(contactWindowCutoffs)+1 {
+		t.Fatalf("got %d paths", len(paths))
+	}
+
+	last := paths[len(paths)-1]
+	if strings.Contains(last, "updated_after") || !strings.Contains(last, "q[updated_before]=") {
+		t.Fatalf("last range should be open-ended: %s", last)
+	}
+
+	if !strings.Contains(paths[0], "q[updated_before]=1700000060") {
+		t.Fatalf("first range should end after now: %s", paths[0])
+	}
+}
+
+func TestFetchPagesConcurrentlyStopsEarly(t *testing.T) {
+	var requests atomic.Int32
+
+	client := newContactSearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"_results":[{"id":"crd_1"}],"_pagination":{"next":"/contacts?page_token=more"}}`))
+	})
+
+	pages := 0
+
+	err := FetchPagesConcurrently(context.Background(), client, []string{"/contacts"}, 1, 0,
+		func(_ int, _ *ListResponse[Contact]) error {
+			pages++
+			if pages == 3 {
+				return ErrStopPaging
+			}
+
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("FetchPagesConcurrently: %v", err)
+	}
+
+	if pages != 3 || requests.Load() != 3 {
+		t.Fatalf("pages = %d, requests = %d", pages, requests.Load())
+	}
+}
+
+func TestSearchContactsMethod(t *testing.T) {
+	var filtered, scans atomic.Int32
+
+	client := newContactSearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Has("q") {
+			filtered.Add(1)
+		} else {
+			scans.Add(1)
+		}
+
+		// The filter is ignored: every contact comes back.
+		_, _ = w.Write([]byte(testContacts))
+	})
+
+	_, err := client.SearchContacts(context.Background(), "bob@", ContactSearchOptions{Limit: 10, Method: ContactSearchServer})
+	if !errors.Is(err, ErrServerSearchUnsupported) || scans.Load() != 0 {
+		t.Fatalf("server: err = %v after %d scan requests", err, scans.Load())
+	}
+
+	filtered.Store(0)
+
+	got, err := client.SearchContacts(context.Background(), "bob@", ContactSearchOptions{Limit: 10, Method: ContactSearchScan})
+	if err != nil || filtered.Load() != 0 || len(got) != 1 || got[0].ID != "crd_2" {
+		t.Fatalf("scan: got %+v, err %v, %d filtered requests", got, err, filtered.Load())
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const (
@@ -13,12 +15,19 @@ const (
 	ExitAuth      = 3
 	ExitNotFound  = 4
 	ExitRateLimit = 5
+
+	// ExitInterrupted follows the shell convention for SIGINT (128 + 2).
+	ExitInterrupted = 130
 )
 
 var (
 	ErrNotAuthenticated = errors.New("not authenticated")
 	ErrRateLimited      = errors.New("rate limit exceeded")
 	ErrNotFound         = errors.New("not found")
+	ErrReadOnly         = errors.New("read-only client refuses to modify data")
+	ErrTimeout          = errors.New("request timed out")
+	ErrInterrupted      = errors.New("interrupted")
+	ErrRetryBudget      = errors.New("retry budget exhausted")
 )
 
 type APIError struct {
@@ -50,10 +59,15 @@ func (e *APIError) ExitCode() int {
 	}
 }
 
-type CircuitBreakerError struct{}
+// CircuitBreakerError is returned, without contacting Front, while the
+// circuit breaker is open.
+type CircuitBreakerError struct {
+	Failures int           // server errors in a row that opened it
+	RetryIn  time.Duration // until requests are let through again
+}
 
 func (e *CircuitBreakerError) Error() string {
-	return "circuit breaker is open: too many consecutive failures"
+	return fmt.Sprintf("circuit breaker is open after %d consecutive server errors; requests resume in %s", e.Failures, e.RetryIn)
 }
 
 type AuthError struct {
@@ -68,6 +82,10 @@ func (e *AuthError) Unwrap() error {
 	return e.Err
 }
 
+func (e *AuthError) ExitCode() int {
+	return ExitAuth
+}
+
 type NotFoundError struct {
 	Resource string
 	ID       string
@@ -81,6 +99,10 @@ func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("%s not found", e.Resource)
 }
 
+func (e *NotFoundError) ExitCode() int {
+	return ExitNotFound
+}
+
 type RateLimitError struct {
 	RetryAfter int // seconds
 }
@@ -93,6 +115,10 @@ func (e *RateLimitError) Error() string {
 	return "rate limit exceeded"
 }
 
+func (e *RateLimitError) ExitCode() int {
+	return ExitRateLimit
+}
+
 // WrongResourceTypeError indicates the user provided an ID of the wrong resource type.
 type WrongResourceTypeError struct {
 	ExpectedType string // e.g., "conversation"
@@ -103,3 +129,33 @@ type WrongResourceTypeError struct {
 func (e *WrongResourceTypeError) Error() string {
 	return fmt.Sprintf("'%s' is a %s ID, but a %s ID was expected", e.ID, e.ActualType, e.ExpectedType)
 }
+
+func (e *WrongResourceTypeError) ExitCode() int {
+	return ExitUsage
+}
+
+// NameResolutionError indicates a resource name matched nothing, or more than
+// one resource.
+type NameResolutionError struct {
+	Resource string   // e.g., "tag"
+	Name     string   // The name that was provided
+	Matches  []string // "ID (label)" for each candidate when ambiguous
+}
+
+func (e *NameResolutionError) Error() string {
+	if len(e.Matches) > 1 {
+		return fmt.Sprintf("%s name '%s' is ambiguous: matches %s", e.Resource, e.Name, strings.Join(e.Matches, ", "))
+	}
+
+	return fmt.Sprintf("no %s named '%s'", e.Resource, e.Name)
+}
+
+// ExitCode is not-found for an unknown name and usage for an ambiguous one,
+// which the caller fixes by passing an ID.
+func (e *NameResolutionError) ExitCode() int {
+	if len(e.Matches) > 1 {
+		return ExitUsage
+	}
+
+	return ExitNotFound
+}
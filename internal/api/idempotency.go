@@ -0,0 +1,35 @@
+package api
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader carries the key that lets Front recognise a retried
+// POST and apply it only once.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey returns a random (version 4) UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+
+	_, _ = rand.Read(b[:])
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// retryableAfterNetworkError reports whether req can be sent again when the
+// previous attempt failed without a response: the server may have applied
+// it, so only reads and requests carrying an idempotency key are safe.
+func retryableAfterNetworkError(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
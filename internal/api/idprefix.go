@@ -38,6 +38,7 @@ var ResourcePrefixes = map[string]string{
 	"tea_": "teammate",
 	"tag_": "tag",
 	"inb_": "inbox",
+	"tim_": "team",
 	"chn_": "channel",
 	"ctc_": "contact",
 	"acc_": "account",
@@ -48,6 +49,8 @@ var ResourcePrefixes = map[string]string{
 	"evt_": "event",
 	"drf_": "draft",
 	"top_": "topic",
+	"rsp_": "template",
+	"rsf_": "template folder",
 }
 
 // ExtractPrefix returns the prefix portion of a Front ID (e.g., "cnv_" from "cnv_abc123").
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GetPage fetches a single page of a list endpoint. pageURL may be an API path
+// or an absolute URL taken from a previous page's _pagination.next.
+func GetPage[T any](ctx context.Context, c *Client, pageURL string) (*ListResponse[T], error) {
+	path, err := pagePath(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListResponse[T]
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// PageToken extracts the page_token query parameter from a pagination URL.
+// Returns an empty string when the URL has no token.
+func PageToken(pageURL string) string {
+	if strings.TrimSpace(pageURL) == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Query().Get("page_token")
+}
+
+// WithPageToken returns path with its page_token query parameter set to token.
+// An empty token returns path unchanged.
+func WithPageToken(path, token string) string {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return path
+	}
+
+	base, rawQuery, _ := strings.Cut(path, "?")
+
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		params = url.Values{}
+	}
+
+	params.Set("page_token", token)
+
+	return base + "?" + params.Encode()
+}
+
+// pagePath reduces an absolute pagination URL to the path and query the
+// client expects. Relative paths are returned as-is.
+func pagePath(pageURL string) (string, error) {
+	if strings.HasPrefix(pageURL, "/") {
+		return pageURL, nil
+	}
+
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("parse page URL: %w", err)
+	}
+
+	path := parsed.Path
+	if parsed.RawQuery != "" {
+		path += "?" + parsed.RawQuery
+	}
+
+	return path, nil
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestPageToken(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"absolute URL", "https://api2.frontapp.com/conversations?limit=25&page_token=abc", "abc"},
+		{"relative path", "/contacts?page_token=xyz", "xyz"},
+		{"no token", "https://api2.frontapp.com/conversations?limit=25", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PageToken(tt.url); got != tt.want {
+				t.Errorf("PageToken(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPageToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		want  string
+	}{
+		{"no query", "/tags", "abc", "/tags?page_token=abc"},
+		{"existing query", "/contacts?limit=10", "abc", "/contacts?limit=10&page_token=abc"},
+		{"replaces token", "/contacts?page_token=old", "new", "/contacts?page_token=new"},
+		{"empty token", "/tags", "", "/tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WithPageToken(tt.path, tt.token); got != tt.want {
+				t.Errorf("WithPageToken(%q, %q) = %q, want %q", tt.path, tt.token, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPageAcceptsAbsoluteURL(t *testing.T) {
+	var gotURI string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		_, _ = io.WriteString(w, `{"_results":[{"id":"tag_1"}]}`)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+
+	resp, err := GetPage[Tag](context.Background(), client, "https://api2.frontapp.com/tags?page_token=abc")
+	if err != nil {
+		t.Fatalf("GetPage: %v", err)
+	}
+
+	if gotURI != "/tags?page_token=abc" {
+		t.Fatalf("unexpected request URI: %s", gotURI)
+	}
+
+	if len(resp.Results) != 1 || resp.Results[0].ID != "tag_1" {
+		t.Fatalf("unexpected results: %#v", resp.Results)
+	}
+}
@@ -9,8 +9,52 @@ import (
 	"time"
 )
 
+// ThrottleStrategy trades speed against the risk of hitting Front's rate
+// limit.
+type ThrottleStrategy string
+
+const (
+	// ThrottleAggressive spends the whole quota, burst included, as fast as
+	// it can and only waits once it is gone.
+	ThrottleAggressive ThrottleStrategy = "aggressive"
+	// ThrottleBalanced spreads the quota and up to half the burst evenly
+	// over the rest of the window. It is the default.
+	ThrottleBalanced ThrottleStrategy = "balanced"
+	// ThrottleConservative leaves the burst and a fifth of the quota alone,
+	// for other integrations sharing the company's limit.
+	ThrottleConservative ThrottleStrategy = "conservative"
+)
+
+// throttleProfile is how a strategy paces requests.
+type throttleProfile struct {
+	pace      bool // spread requests over the window, not just stop when spent
+	burstPart int  // use at most limit/burstPart of the burst; 0 means none
+	reserve   int  // leave limit/reserve of the quota unused; 0 means none
+	inFlight  int  // requests running at once across all goroutines
+}
+
+var throttleProfiles = map[ThrottleStrategy]throttleProfile{
+	ThrottleAggressive:   {pace: false, burstPart: 1, inFlight: 16},
+	ThrottleBalanced:     {pace: true, burstPart: 2, inFlight: 8},
+	ThrottleConservative: {pace: true, reserve: 5, inFlight: 2},
+}
+
+// ParseThrottleStrategy checks that s names a strategy.
+func ParseThrottleStrategy(s string) (ThrottleStrategy, error) {
+	strategy := ThrottleStrategy(s)
+	if _, ok := throttleProfiles[strategy]; !ok {
+		return "", fmt.Errorf("unknown throttle strategy %q (use aggressive, balanced or conservative)", s)
+	}
+
+	return strategy, nil
+}
+
+// RateLimiter paces requests by the quota in Front's rate-limit headers and
+// bounds how many run at once, so parallel fetchers share one budget.
 type RateLimiter struct {
 	mu             sync.Mutex
+	profile        throttleProfile
+	slots          chan struct{}
 	limit          int
 	remaining      int
 	burstLimit     int
@@ -19,7 +63,39 @@ type RateLimiter struct {
 }
 
 func NewRateLimiter() *RateLimiter {
-	return &RateLimiter{}
+	r := &RateLimiter{}
+	r.SetStrategy(ThrottleBalanced)
+
+	return r
+}
+
+// SetStrategy switches to strategy. Call it before any request is made.
+func (r *RateLimiter) SetStrategy(strategy ThrottleStrategy) {
+	profile, ok := throttleProfiles[strategy]
+	if !ok {
+		profile = throttleProfiles[ThrottleBalanced]
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.profile = profile
+	r.slots = make(chan struct{}, profile.inFlight)
+}
+
+// acquire waits for one of the strategy's in-flight slots. Call release when
+// the request is done.
+func (r *RateLimiter) acquire(ctx context.Context) (release func(), err error) {
+	r.mu.Lock()
+	slots := r.slots
+	r.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("rate limit wait interrupted: %w", ctx.Err())
+	}
 }
 
 func (r *RateLimiter) UpdateFromHeaders(h http.Header) {
@@ -40,8 +116,33 @@ func (r *RateLimiter) UpdateFromHeaders(h http.Header) {
 	}
 }
 
+// RateLimitStatus is the quota Front reported on the last response. A zero
+// Limit means no response carried rate-limit headers yet.
+type RateLimitStatus struct {
+	Limit          int       `json:"limit"`
+	Remaining      int       `json:"remaining"`
+	BurstLimit     int       `json:"burst_limit,omitempty"`
+	BurstRemaining int       `json:"burst_remaining,omitempty"`
+	ResetAt        time.Time `json:"reset_at,omitzero"`
+}
+
+// Status returns the quota from the headers seen so far.
+func (r *RateLimiter) Status() RateLimitStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return RateLimitStatus{
+		Limit:          r.limit,
+		Remaining:      r.remaining,
+		BurstLimit:     r.burstLimit,
+		BurstRemaining: r.burstRemaining,
+		ResetAt:        r.resetAt,
+	}
+}
+
 func (r *RateLimiter) Wait(ctx context.Context) error {
 	r.mu.Lock()
+	profile := r.profile
 	limit := r.limit
 	remaining := r.remaining
 	burstRemaining := r.burstRemaining
@@ -54,20 +155,23 @@ func (r *RateLimiter) Wait(ctx context.Context) error {
 
 	extra := 0
 
-	if burstRemaining > 0 {
-		maxExtra := limit / 2
-		if burstRemaining < maxExtra {
-			extra = burstRemaining
-		} else {
-			extra = maxExtra
-		}
+	if burstRemaining > 0 && profile.burstPart > 0 {
+		extra = min(burstRemaining, limit/profile.burstPart)
 	}
 
 	effectiveRemaining := remaining + extra
+	if profile.reserve > 0 {
+		effectiveRemaining -= limit / profile.reserve
+	}
+
 	if effectiveRemaining <= 1 {
 		return sleepUntil(ctx, resetAt)
 	}
 
+	if !profile.pace {
+		return nil
+	}
+
 	interval := time.Until(resetAt) / time.Duration(effectiveRemaining)
 	if interval <= 0 {
 		return nil
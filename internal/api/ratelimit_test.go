@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestRateLimiterStrategiesPace(t *testing.T) {
+	h := http.Header{}
+	h.Set("x-ratelimit-limit", "100")
+	h.Set("x-ratelimit-remaining", "15")
+	h.Set("x-ratelimit-reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	aggressive := NewRateLimiter()
+	aggressive.SetStrategy(ThrottleAggressive)
+	aggressive.UpdateFromHeaders(h)
+
+	if err := aggressive.Wait(ctx); err != nil {
+		t.Fatalf("aggressive waited with quota left: %v", err)
+	}
+
+	// Conservative keeps 20 of 100 in reserve, so 15 left means wait for the
+	// reset.
+	conservative := NewRateLimiter()
+	conservative.SetStrategy(ThrottleConservative)
+	conservative.UpdateFromHeaders(h)
+
+	if err := conservative.Wait(ctx); err == nil {
+		t.Fatal("conservative did not wait for the reset")
+	}
+}
+
+func TestClientThrottleBoundsRequestsInFlight(t *testing.T) {
+	var (
+		mu                sync.Mutex
+		inFlight, maxSeen int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxSeen = max(maxSeen, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+	client.SetThrottle(ThrottleConservative)
+
+	var wg sync.WaitGroup
+
+	for range 6 {
+		wg.Go(func() {
+			if err := client.Patch(context.Background(), "/conversations/cnv_1", map[string]string{}, nil); err != nil {
+				t.Errorf("Patch: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if maxSeen != 2 {
+		t.Fatalf("max requests in flight = %d, want 2", maxSeen)
+	}
+}
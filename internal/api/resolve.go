@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// nameCache memoizes the resource lists used to resolve names, so resolving
+// several names in one command lists each resource at most once.
+type nameCache struct {
+	mu        sync.Mutex
+	inboxes   []Inbox
+	tags      []Tag
+	teammates []Teammate
+	teams     []Team
+	me        *Me
+}
+
+// ResolveInbox returns the ID of the inbox named nameOrID (case-insensitive).
+// Inbox IDs are returned unchanged.
+func (c *Client) ResolveInbox(ctx context.Context, nameOrID string) (string, error) {
+	if nameOrID == "" || isIDOf(nameOrID, "inbox") {
+		return nameOrID, nil
+	}
+
+	c.names.mu.Lock()
+	defer c.names.mu.Unlock()
+
+	if c.names.inboxes == nil {
+		inboxes, err := listAll[Inbox](ctx, c, "/inboxes")
+		if err != nil {
+			return "", err
+		}
+
+		c.names.inboxes = inboxes
+	}
+
+	return matchName("inbox", nameOrID, c.names.inboxes, func(inbox Inbox) (string, []string) {
+		return inbox.ID, []string{inbox.Name}
+	})
+}
+
+// ResolveTag returns the ID of the tag named nameOrID (case-insensitive). Tag
+// IDs are returned unchanged.
+func (c *Client) ResolveTag(ctx context.Context, nameOrID string) (string, error) {
+	if nameOrID == "" || isIDOf(nameOrID, "tag") {
+		return nameOrID, nil
+	}
+
+	c.names.mu.Lock()
+	defer c.names.mu.Unlock()
+
+	if c.names.tags == nil {
+		tags, err := listAll[Tag](ctx, c, "/tags")
+		if err != nil {
+			return "", err
+		}
+
+		c.names.tags = tags
+	}
+
+	return matchName("tag", nameOrID, c.names.tags, func(tag Tag) (string, []string) {
+		return tag.ID, []string{tag.Name}
+	})
+}
+
+// ResolveTeam returns the ID of the team named nameOrID (case-insensitive).
+// Team IDs are returned unchanged.
+func (c *Client) ResolveTeam(ctx context.Context, nameOrID string) (string, error) {
+	if nameOrID == "" || isIDOf(nameOrID, "team") {
+		return nameOrID, nil
+	}
+
+	c.names.mu.Lock()
+	defer c.names.mu.Unlock()
+
+	if c.names.teams == nil {
+		teams, err := listAll[Team](ctx, c, "/teams")
+		if err != nil {
+			return "", err
+		}
+
+		c.names.teams = teams
+	}
+
+	return matchName("team", nameOrID, c.names.teams, func(team Team) (string, []string) {
+		return team.ID, []string{team.Name}
+	})
+}
+
+// ResolveTeammate returns the ID of the teammate identified by nameOrID: an
+// email address, username, full name, or "me" for the authenticated user.
+// Teammate IDs are returned unchanged.
+func (c *Client) ResolveTeammate(ctx context.Context, nameOrID string) (string, error) {
+	if nameOrID == "" || isIDOf(nameOrID, "teammate") {
+		return nameOrID, nil
+	}
+
+	c.names.mu.Lock()
+	defer c.names.mu.Unlock()
+
+	if strings.EqualFold(strings.TrimSpace(nameOrID), "me") {
+		me, err := c.meLocked(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return me.ID, nil
+	}
+
+	teammates, err := c.teammatesLocked(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return matchName("teammate", nameOrID, teammates, func(tm Teammate) (string, []string) {
+		names := []string{tm.Email, tm.Username}
+		if full := strings.TrimSpace(tm.FirstName + " " + tm.LastName); full != "" {
+			names = append(names, full)
+		}
+
+		return tm.ID, names
+	})
+}
+
+// Teammates returns every teammate in the company, fetched once per client
+// and shared with ResolveTeammate.
+func (c *Client) Teammates(ctx context.Context) ([]Teammate, error) {
+	c.names.mu.Lock()
+	defer c.names.mu.Unlock()
+
+	return c.teammatesLocked(ctx)
+}
+
+func (c *Client) teammatesLocked(ctx context.Context) ([]Teammate, error) {
+	if c.names.teammates == nil {
+		teammates, err := listAll[Teammate](ctx, c, "/teammates")
+		if err != nil {
+			return nil, err
+		}
+
+		c.names.teammates = teammates
+	}
+
+	return c.names.teammates, nil
+}
+
+// ContactRef returns the reference the API accepts for a contact: IDs and
+// aliases are returned unchanged, email addresses become alt:email: aliases.
+func ContactRef(idOrHandle string) string {
+	idOrHandle = strings.TrimSpace(idOrHandle)
+	if strings.Contains(idOrHandle, "@") && !strings.HasPrefix(idOrHandle, "alt:") {
+		return "alt:email:" + idOrHandle
+	}
+
+	return idOrHandle
+}
+
+// isIDOf reports whether value is an ID of the given resource type, or a Front
+// resource alias (alt:email:..., alt:username:...) that the API resolves
+// itself.
+func isIDOf(value, resource string) bool {
+	value = strings.TrimSpace(value)
+
+	return strings.HasPrefix(value, "alt:") || (GetResourceType(value) == resource && !strings.ContainsAny(value, " @"))
+}
+
+// matchName finds the single item whose names match name case-insensitively.
+// When several items match, an exact case-sensitive match wins if it is unique.
+func matchName[T any](resource, name string, items []T, namesOf func(T) (string, []string)) (string, error) {
+	name = strings.TrimSpace(name)
+
+	var folded, exact, labels []string
+
+	for _, item := range items {
+		id, names := namesOf(item)
+
+		for _, n := range names {
+			if n == "" || !strings.EqualFold(n, name) {
+				continue
+			}
+
+			folded = append(folded, id)
+			labels = append(labels, fmt.Sprintf("%s (%s)", id, n))
+
+			if n == name {
+				exact = append(exact, id)
+			}
+
+			break
+		}
+	}
+
+	switch {
+	case len(folded) == 1:
+		return folded[0], nil
+	case len(exact) == 1:
+		return exact[0], nil
+	case len(folded) == 0:
+		return "", &NameResolutionError{Resource: resource, Name: name}
+	default:
+		return "", &NameResolutionError{Resource: resource, Name: name, Matches: labels}
+	}
+}
+
+// listAll fetches every page of a list endpoint.
+func listAll[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	var all []T
+
+	for path != "" {
+		resp, err := GetPage[T](ctx, c, path)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, resp.Results...)
+		path = resp.Pagination.Next
+	}
+
+	if all == nil {
+		all = []T{}
+	}
+
+	return all, nil
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func newResolveTestClient(t *testing.T, requests map[string]int) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/tags":
+			if r.URL.Query().Get("page_token") == "" {
+				_, _ = w.Write([]byte(`{"_results":[{"id":"tag_1","name":"VIP"},{"id":"tag_2","name":"vip"}],` +
+					`"_pagination":{"next":"/tags?page_token=p2"}}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tag_3","name":"Billing"},{"id":"tag_4","name":"urgent"},{"id":"tag_5","name":"Urgent"}]}`))
+		case "/teammates":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tea_1","email":"alice@example.com","username":"alice",` +
+				`"first_name":"Alice","last_name":"Smith"}]}`))
+		case "/me":
+			_, _ = w.Write([]byte(`{"id":"tea_me","email":"me@example.com"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+}
+
+func TestResolveTag(t *testing.T) {
+	requests := map[string]int{}
+	client := newResolveTestClient(t, requests)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"tag_99", "tag_99"},
+		{"billing", "tag_3"},
+		{"VIP", "tag_1"},
+		{"vip", "tag_2"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		got, err := client.ResolveTag(ctx, tt.name)
+		if err != nil {
+			t.Fatalf("ResolveTag(%q): %v", tt.name, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("ResolveTag(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	var nameErr *NameResolutionError
+
+	_, err := client.ResolveTag(ctx, "URGENT")
+	if !errors.As(err, &nameErr) || len(nameErr.Matches) != 2 {
+		t.Fatalf("ResolveTag(URGENT) error = %v, want ambiguity", err)
+	}
+
+	_, err = client.ResolveTag(ctx, "missing")
+	if !errors.As(err, &nameErr) || len(nameErr.Matches) != 0 {
+		t.Fatalf("ResolveTag(missing) error = %v, want not found", err)
+	}
+
+	// Both pages are listed once and reused for every lookup.
+	if requests["/tags"] != 2 {
+		t.Fatalf("/tags requested %d times, want 2", requests["/tags"])
+	}
+}
+
+func TestResolveTeammate(t *testing.T) {
+	client := newResolveTestClient(t, map[string]int{})
+	ctx := context.Background()
+
+	for name, want := range map[string]string{
+		"alice@example.com": "tea_1",
+		"ALICE":             "tea_1",
+		"alice smith":       "tea_1",
+		"me":                "tea_me",
+		"tea_2":             "tea_2",
+		"alt:email:a@b.c":   "alt:email:a@b.c",
+	} {
+		got, err := client.ResolveTeammate(ctx, name)
+		if err != nil {
+			t.Fatalf("ResolveTeammate(%q): %v", name, err)
+		}
+
+		if got != want {
+			t.Fatalf("ResolveTeammate(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/log"
+)
+
+// RequiredScope returns the scope a request needs, as resource:access where
+// access is read, write, delete or send (the permissions an OAuth app is
+// granted per resource). ok is false for paths outside any resource.
+func RequiredScope(method, path string) (string, bool) {
+	path, _, _ = strings.Cut(strings.TrimPrefix(path, "/"), "?")
+	segments := strings.Split(path, "/")
+
+	resource := segments[0]
+	if resource == "" || resource == "me" {
+		return "", false
+	}
+
+	access := "write"
+
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		access = "read"
+	case http.MethodDelete:
+		access = "delete"
+	case http.MethodPost:
+		// Sending and replying post to .../messages.
+		if len(segments) > 1 && segments[len(segments)-1] == "messages" {
+			resource, access = "messages", "send"
+		}
+	}
+
+	return resource + ":" + access, true
+}
+
+// hasScope reports whether granted covers scope. A bare resource or
+// resource:* grants every access to it; * grants everything.
+func hasScope(granted []string, scope string) bool {
+	resource, _, _ := strings.Cut(scope, ":")
+
+	return slices.ContainsFunc(granted, func(g string) bool {
+		return g == scope || g == "*" || g == resource || g == resource+":*"
+	})
+}
+
+// SetScopes records the scopes the token was granted. When set, requests
+// needing a scope outside them print a warning before they are sent, so a
+// 403 is explained. An empty list disables the check.
+func (c *Client) SetScopes(scopes []string) {
+	c.scopeMu.Lock()
+	defer c.scopeMu.Unlock()
+
+	c.scopes = scopes
+	c.warnedScopes = map[string]bool{}
+}
+
+// checkScope warns, once per scope, when the request needs a scope the token
+// lacks. The request is still sent: scope names are advisory.
+func (c *Client) checkScope(method, path string) {
+	scope, ok := RequiredScope(method, path)
+	if !ok {
+		return
+	}
+
+	c.scopeMu.Lock()
+	defer c.scopeMu.Unlock()
+
+	if len(c.scopes) == 0 || hasScope(c.scopes, scope) || c.warnedScopes[scope] {
+		return
+	}
+
+	c.warnedScopes[scope] = true
+
+	log.Warn("token was not granted the scope this request needs; it will likely be refused",
+		"scope", scope, "fix", "frontcli auth login --force-consent --scopes "+scope)
+}
@@ -0,0 +1,46 @@
+package api
+
+import "testing"
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{"GET", "/conversations?limit=5", "conversations:read"},
+		{"PATCH", "/conversations/cnv_1", "conversations:write"},
+		{"DELETE", "/tags/tag_1", "tags:delete"},
+		{"POST", "/channels/cha_1/messages", "messages:send"},
+		{"POST", "/conversations/cnv_1/messages", "messages:send"},
+		{"POST", "/conversations/cnv_1/comments", "conversations:write"},
+	}
+
+	for _, tt := range tests {
+		if got, ok := RequiredScope(tt.method, tt.path); !ok || got != tt.want {
+			t.Errorf("RequiredScope(%s %s) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+
+	if _, ok := RequiredScope("GET", "/me"); ok {
+		t.Error("/me should need no scope")
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	granted := []string{"conversations:read", "tags:*", "contacts"}
+
+	for scope, want := range map[string]bool{
+		"conversations:read":  true,
+		"conversations:write": false,
+		"tags:delete":         true,
+		"contacts:write":      true,
+		"messages:send":       false,
+	} {
+		if got := hasScope(granted, scope); got != want {
+			t.Errorf("hasScope(%s) = %v, want %v", scope, got, want)
+		}
+	}
+
+	if !hasScope([]string{"*"}, "messages:send") {
+		t.Error("* should grant everything")
+	}
+}
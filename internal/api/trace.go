@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTracedBody caps how much of a body is logged.
+const maxTracedBody = 64 << 10
+
+// redactedKeys are JSON keys whose values never appear in traces.
+var redactedKeys = map[string]bool{
+	"access_token":  true,
+	"api_token":     true,
+	"client_secret": true,
+	"password":      true,
+	"refresh_token": true,
+	"secret":        true,
+	"token":         true,
+}
+
+// TraceTransport logs every request that passes through it: method, URL,
+// status, duration and rate-limit headers, plus redacted bodies when Bodies
+// is set. The Authorization header is never logged.
+type TraceTransport struct {
+	Base   http.RoundTripper
+	Out    io.Writer
+	Bodies bool
+
+	mu sync.Mutex
+}
+
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Bodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			_ = body.Close()
+
+			t.logf("> %s %s\n%s", req.Method, req.URL, tracedBody(data))
+		}
+	} else {
+		t.logf("> %s %s", req.Method, req.URL)
+	}
+
+	start := time.Now()
+
+	resp, err := t.Base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	if err != nil {
+		t.logf("< %s %s failed after %s: %v", req.Method, req.URL, elapsed, err)
+
+		return nil, err
+	}
+
+	line := fmt.Sprintf("< %s %s (%s)%s", resp.Status, req.URL.Path, elapsed, rateLimitSummary(resp.Header))
+
+	if t.Bodies && resp.Body != nil {
+		data, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+
+		if readErr != nil {
+			return nil, fmt.Errorf("read response: %w", readErr)
+		}
+
+		if len(data) > 0 {
+			line += "\n" + tracedBody(data)
+		}
+	}
+
+	t.logf("%s", line)
+
+	return resp, nil
+}
+
+func (t *TraceTransport) logf(format string, args ...any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fmt.Fprintf(t.Out, format+"\n", args...)
+}
+
+// rateLimitSummary formats Front's rate-limit headers, if present.
+func rateLimitSummary(h http.Header) string {
+	remaining, limit := h.Get("x-ratelimit-remaining"), h.Get("x-ratelimit-limit")
+	if remaining == "" && limit == "" {
+		return ""
+	}
+
+	summary := fmt.Sprintf(" ratelimit=%s/%s", remaining, limit)
+
+	if burst := h.Get("x-ratelimit-burst-remaining"); burst != "" {
+		summary += " burst=" + burst
+	}
+
+	if reset := h.Get("x-ratelimit-reset"); reset != "" {
+		summary += " reset=" + reset
+	}
+
+	if retryAfter := h.Get("Retry-After"); retryAfter != "" {
+		summary += " retry-after=" + retryAfter
+	}
+
+	return summary
+}
+
+// tracedBody returns data for logging: JSON with secrets redacted, anything
+// else as-is, truncated to maxTracedBody.
+func tracedBody(data []byte) string {
+	var v any
+	if err := json.Unmarshal(data, &v); err == nil {
+		if redacted, err := json.Marshal(redact(v)); err == nil {
+			data = redacted
+		}
+	}
+
+	if len(data) > maxTracedBody {
+		return string(data[:maxTracedBody]) + fmt.Sprintf("... (%d bytes)", len(data))
+	}
+
+	return string(data)
+}
+
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			if redactedKeys[strings.ToLower(k)] {
+				v[k] = "[REDACTED]"
+			} else {
+				v[k] = redact(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	}
+
+	return v
+}
+
+// EnableTracing logs each HTTP request to w; with bodies, redacted request
+// and response bodies are logged too. Retries are logged individually, and
+// so is the circuit breaker opening or refusing a request.
+func (c *Client) EnableTracing(w io.Writer, bodies bool) {
+	c.transport.trace = &TraceTransport{Base: c.transport.Base, Out: w, Bodies: bodies}
+	c.transport.Base = c.transport.trace
+}
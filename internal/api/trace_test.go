@@ -0,0 +1,50 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestTracingLogsRequestsAndRedactsBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Ratelimit-Remaining", "41")
+		w.Header().Set("X-Ratelimit-Limit", "50")
+		_, _ = w.Write([]byte(`{"id":"chn_1","settings":{"token":"hook-secret"}}`))
+	}))
+	defer srv.Close()
+
+	var trace bytes.Buffer
+
+	client := NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "bearer-secret"}), srv.URL)
+	client.EnableTracing(&trace, true)
+
+	var out map[string]any
+	if err := client.Post(context.Background(), "/channels", map[string]any{"name": "x", "password": "pw"}, &out); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	// The caller still gets the unredacted response.
+	if out["settings"].(map[string]any)["token"] != "hook-secret" {
+		t.Fatalf("response body was altered: %v", out)
+	}
+
+	got := trace.String()
+	for _, want := range []string{"> POST " + srv.URL + "/channels", "< 200 OK /channels", "ratelimit=41/50", `"password":"[REDACTED]"`, `"token":"[REDACTED]"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("trace missing %q:\n%s", want, got)
+		}
+	}
+
+	for _, secret := range []string{"hook-secret", "bearer-secret", `"pw"`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("trace leaks %q:\n%s", secret, got)
+		}
+	}
+}
@@ -2,29 +2,62 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand/v2"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
+
+	"github.com/dedene/frontapp-cli/internal/log"
 )
 
 const (
 	MaxRateLimitRetries   = 3
 	Max5xxRetries         = 1
+	MaxNetworkRetries     = 2
 	RateLimitBaseDelay    = 1 * time.Second
 	ServerErrorRetryDelay = 2 * time.Second
 )
 
 // RetryTransport wraps an http.RoundTripper with retry logic for
-// rate limits (429) and server errors (5xx).
+// rate limits (429), server errors (5xx) and, for requests that are safe to
+// repeat, network failures.
 type RetryTransport struct {
-	Base           http.RoundTripper
-	MaxRetries429  int
-	MaxRetries5xx  int
-	BaseDelay      time.Duration
-	CircuitBreaker *CircuitBreaker
+	Base              http.RoundTripper
+	MaxRetries429     int
+	MaxRetries5xx     int
+	MaxRetriesNetwork int
+	BaseDelay         time.Duration
+	CircuitBreaker    *CircuitBreaker
+
+	// budget caps the backoff slept over all requests; nil means no cap.
+	budget *retryBudget
+
+	// trace, when set, also gets the circuit breaker's state changes and
+	// refusals, which never reach the traced transport below.
+	trace *TraceTransport
+}
+
+// RetryPolicy controls how the client retries failed requests.
+type RetryPolicy struct {
+	MaxRateLimitRetries   int           // retries after 429 Too Many Requests
+	MaxServerErrorRetries int           // retries after 5xx responses
+	MaxNetworkRetries     int           // retries after network failures, for reads and keyed POSTs
+	BaseDelay             time.Duration // first backoff step without Retry-After
+	Budget                time.Duration // total backoff over all requests; zero means no limit
+}
+
+// DefaultRetryPolicy is the policy clients start with.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRateLimitRetries:   MaxRateLimitRetries,
+		MaxServerErrorRetries: Max5xxRetries,
+		MaxNetworkRetries:     MaxNetworkRetries,
+		BaseDelay:             RateLimitBaseDelay,
+	}
 }
 
 func NewRetryTransport(base http.RoundTripper) *RetryTransport {
@@ -33,17 +66,24 @@ func NewRetryTransport(base http.RoundTripper) *RetryTransport {
 	}
 
 	return &RetryTransport{
-		Base:           base,
-		MaxRetries429:  MaxRateLimitRetries,
-		MaxRetries5xx:  Max5xxRetries,
-		BaseDelay:      RateLimitBaseDelay,
-		CircuitBreaker: NewCircuitBreaker(),
+		Base:              base,
+		MaxRetries429:     MaxRateLimitRetries,
+		MaxRetries5xx:     Max5xxRetries,
+		MaxRetriesNetwork: MaxNetworkRetries,
+		BaseDelay:         RateLimitBaseDelay,
+		CircuitBreaker:    NewCircuitBreaker(),
 	}
 }
 
 func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if t.CircuitBreaker != nil && t.CircuitBreaker.IsOpen() {
-		return nil, &CircuitBreakerError{}
+		refused := t.CircuitBreaker.state()
+
+		log.Debug("circuit breaker open, refusing request", "path", req.URL.Path, "failures", refused.Failures, "retry_in", refused.RetryIn)
+		t.tracef("! circuit breaker open (%d server errors in a row): refusing %s %s for another %s",
+			refused.Failures, req.Method, req.URL.Path, refused.RetryIn)
+
+		return nil, refused
 	}
 
 	if err := ensureReplayableBody(req); err != nil {
@@ -54,6 +94,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	var err error
 	retries429 := 0
 	retries5xx := 0
+	retriesNetwork := 0
 
 	for {
 		if req.GetBody != nil {
@@ -71,7 +112,20 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 		resp, err = t.Base.RoundTrip(req)
 		if err != nil {
-			return nil, fmt.Errorf("round trip: %w", err)
+			if req.Context().Err() != nil || errors.Is(err, errNoInteraction) ||
+				retriesNetwork >= t.MaxRetriesNetwork || !retryableAfterNetworkError(req) {
+				return nil, fmt.Errorf("round trip: %w", err)
+			}
+
+			log.Debug("network error, retrying", "err", err, "path", req.URL.Path, "attempt", retriesNetwork+1)
+
+			if err := t.sleep(req.Context(), t.serverErrorDelay(), req.Method+" "+req.URL.Path+" after a network error"); err != nil {
+				return nil, err
+			}
+
+			retriesNetwork++
+
+			continue
 		}
 
 		if resp.StatusCode < 400 {
@@ -90,7 +144,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			delay := t.calculateBackoff(retries429, resp)
 			drainAndClose(resp.Body)
 
-			if err := t.sleep(req.Context(), delay); err != nil {
+			if err := t.sleep(req.Context(), delay, fmt.Sprintf("%s %s after HTTP 429", req.Method, req.URL.Path)); err != nil {
 				return nil, err
 			}
 
@@ -100,8 +154,11 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 
 		if resp.StatusCode >= 500 {
-			if t.CircuitBreaker != nil {
-				t.CircuitBreaker.RecordFailure()
+			if t.CircuitBreaker != nil && t.CircuitBreaker.RecordFailure() {
+				log.Warn("circuit breaker opened: pausing requests after repeated server errors",
+					"failures", t.CircuitBreaker.Threshold, "cooldown", t.CircuitBreaker.Cooldown)
+				t.tracef("! circuit breaker opened after %d server errors in a row; requests pause for %s",
+					t.CircuitBreaker.Threshold, t.CircuitBreaker.Cooldown)
 			}
 
 			if retries5xx >= t.MaxRetries5xx {
@@ -110,7 +167,9 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 			drainAndClose(resp.Body)
 
-			if err := t.sleep(req.Context(), ServerErrorRetryDelay); err != nil {
+			log.Debug("server error, retrying", "status", resp.StatusCode, "path", req.URL.Path, "attempt", retries5xx+1)
+
+			if err := t.sleep(req.Context(), t.serverErrorDelay(), fmt.Sprintf("%s %s after HTTP %d", req.Method, req.URL.Path, resp.StatusCode)); err != nil {
 				return nil, err
 			}
 
@@ -123,7 +182,30 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 }
 
+func (t *RetryTransport) tracef(format string, args ...any) {
+	if t.trace != nil {
+		t.trace.logf(format, args...)
+	}
+}
+
+// serverErrorDelay scales the fixed 5xx delay with BaseDelay, so a shorter
+// base delay also shortens server error retries.
+func (t *RetryTransport) serverErrorDelay() time.Duration {
+	if t.BaseDelay <= 0 {
+		return 0
+	}
+
+	return ServerErrorRetryDelay * t.BaseDelay / RateLimitBaseDelay
+}
+
 func (t *RetryTransport) calculateBackoff(attempt int, resp *http.Response) time.Duration {
+	return retryDelay(attempt, t.BaseDelay, resp)
+}
+
+// retryDelay is how long to wait before retry number attempt (from 0): the
+// response's Retry-After when present, otherwise exponential backoff from
+// base with up to 50% jitter.
+func retryDelay(attempt int, base time.Duration, resp *http.Response) time.Duration {
 	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
 		if seconds, err := strconv.Atoi(retryAfter); err == nil {
 			if seconds < 0 {
@@ -143,11 +225,11 @@ func (t *RetryTransport) calculateBackoff(attempt int, resp *http.Response) time
 		}
 	}
 
-	if t.BaseDelay <= 0 {
+	if base <= 0 {
 		return 0
 	}
 
-	baseDelay := t.BaseDelay * time.Duration(1<<attempt)
+	baseDelay := base * time.Duration(1<<attempt)
 	if baseDelay <= 0 {
 		return 0
 	}
@@ -162,7 +244,44 @@ func (t *RetryTransport) calculateBackoff(attempt int, resp *http.Response) time
 	return baseDelay + jitter
 }
 
-func (t *RetryTransport) sleep(ctx context.Context, d time.Duration) error {
+func (t *RetryTransport) sleep(ctx context.Context, d time.Duration, retrying string) error {
+	return t.budget.sleep(ctx, d, retrying)
+}
+
+// retryBudget is the backoff a client may sleep between retries, summed over
+// all its requests, so a command gives up during an outage instead of
+// sleeping through retry after retry.
+type retryBudget struct {
+	limit time.Duration
+
+	mu    sync.Mutex
+	spent time.Duration
+}
+
+// sleep waits d before retrying, charging it to the budget. retrying names
+// the request and why, for the error once the budget is spent. A nil budget
+// has no limit.
+func (b *retryBudget) sleep(ctx context.Context, d time.Duration, retrying string) error {
+	if b != nil && b.limit > 0 {
+		b.mu.Lock()
+		spent := b.spent
+		over := spent+d > b.limit
+
+		if !over {
+			b.spent += d
+		}
+		b.mu.Unlock()
+
+		if over {
+			return fmt.Errorf("giving up on %s: %w (%s of %s already spent waiting, the next retry would wait %s)",
+				retrying, ErrRetryBudget, spent.Round(time.Second), b.limit, d.Round(time.Second))
+		}
+	}
+
+	return sleepContext(ctx, d)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
 	if d <= 0 {
 		return nil
 	}
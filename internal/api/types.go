@@ -1,6 +1,11 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Conversation represents a Front conversation.
 type Conversation struct {
@@ -13,23 +18,115 @@ type Conversation struct {
 	Inboxes      []Inbox    `json:"inboxes,omitempty"`
 	CreatedAt    float64    `json:"created_at"` // Unix timestamp
 	WaitingSince float64    `json:"waiting_since,omitempty"`
+	Reminders    []Reminder `json:"scheduled_reminders,omitempty"`
 	Links        Links      `json:"_links,omitempty"` //nolint:tagliatelle // Front API //nolint:tagliatelle // Front API uses underscore prefix
 }
 
+// Reminder is a scheduled reminder on a conversation.
+type Reminder struct {
+	ScheduledAt float64 `json:"scheduled_at"`
+	CreatedAt   float64 `json:"created_at,omitempty"`
+	UpdatedAt   float64 `json:"updated_at,omitempty"`
+	Links       Links   `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// Owner returns the ID of the teammate the reminder belongs to.
+func (r *Reminder) Owner() string {
+	return lastPathSegment(r.Links.Related["owner"])
+}
+
 // Message represents a message in a conversation.
 type Message struct {
-	ID          string       `json:"id"`
-	Type        string       `json:"type"` // email, sms, intercom, custom, etc.
-	IsInbound   bool         `json:"is_inbound"`
-	CreatedAt   float64      `json:"created_at"`
-	Blurb       string       `json:"blurb"`
-	Author      *Author      `json:"author,omitempty"`
-	Recipients  []Recipient  `json:"recipients,omitempty"`
-	Body        string       `json:"body"`
-	Text        string       `json:"text"`
-	Subject     string       `json:"subject,omitempty"`
-	Attachments []Attachment `json:"attachments,omitempty"`
-	Links       Links        `json:"_links,omitempty"` //nolint:tagliatelle // Front API //nolint:tagliatelle // Front API uses underscore prefix
+	ID          string           `json:"id"`
+	Type        string           `json:"type"` // email, sms, intercom, custom, etc.
+	IsInbound   bool             `json:"is_inbound"`
+	CreatedAt   float64          `json:"created_at"`
+	Blurb       string           `json:"blurb"`
+	Author      *Author          `json:"author,omitempty"`
+	Recipients  []Recipient      `json:"recipients,omitempty"`
+	Body        string           `json:"body"`
+	Text        string           `json:"text"`
+	Subject     string           `json:"subject,omitempty"`
+	Attachments []Attachment     `json:"attachments,omitempty"`
+	ErrorType   string           `json:"error_type,omitempty"` // set when an outbound message failed to deliver
+	Metadata    *MessageMetadata `json:"metadata,omitempty"`
+	Links       Links            `json:"_links,omitempty"` //nolint:tagliatelle // Front API //nolint:tagliatelle // Front API uses underscore prefix
+}
+
+// MessageMetadata holds channel-specific message details, including the
+// original email headers.
+type MessageMetadata struct {
+	Headers          map[string]any `json:"headers,omitempty"`
+	ExternalID       string         `json:"external_id,omitempty"`
+	ThreadRef        string         `json:"thread_ref,omitempty"`
+	HaveBeenAnswered bool           `json:"have_been_answered,omitempty"`
+}
+
+// ConversationID returns the ID of the conversation the message belongs to.
+func (m *Message) ConversationID() string {
+	return lastPathSegment(m.Links.Related["conversation"])
+}
+
+// Header returns the value of an email header, matching the name without
+// regard to case, dashes or underscores (Front reports Message-ID as
+// message_id). Non-string values are formatted as JSON.
+func (m *Message) Header(name string) string {
+	if m.Metadata == nil {
+		return ""
+	}
+
+	want := headerKey(name)
+
+	for k, v := range m.Metadata.Headers {
+		if headerKey(k) != want {
+			continue
+		}
+
+		if s, ok := v.(string); ok {
+			return s
+		}
+
+		data, _ := json.Marshal(v)
+
+		return string(data)
+	}
+
+	return ""
+}
+
+// DeliveryFailed reports whether Front could not deliver the message.
+func (m *Message) DeliveryFailed() bool {
+	return m.ErrorType != ""
+}
+
+// SeenReceipt records when a recipient first saw an outbound message.
+type SeenReceipt struct {
+	FirstSeenAt string     `json:"first_seen_at"`
+	SeenBy      *Recipient `json:"seen_by,omitempty"`
+	Links       Links      `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// SeenAt returns FirstSeenAt as a Unix timestamp. Front sends an RFC 3339
+// time; 0 means it could not be read.
+func (r *SeenReceipt) SeenAt() float64 {
+	if t, err := time.Parse(time.RFC3339, r.FirstSeenAt); err == nil {
+		return float64(t.Unix())
+	}
+
+	f, _ := strconv.ParseFloat(r.FirstSeenAt, 64)
+
+	return f
+}
+
+func headerKey(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+}
+
+// MessageAccepted is returned when Front queues an outbound message. The
+// message can be fetched as /messages/alt:uid:{MessageUID} once processed.
+type MessageAccepted struct {
+	Status     string `json:"status"`
+	MessageUID string `json:"message_uid"`
 }
 
 // Draft represents a draft message.
@@ -71,6 +168,16 @@ type Inbox struct {
 	Links        Links                  `json:"_links,omitempty"` //nolint:tagliatelle // Front API
 }
 
+// Team represents a Front team (workspace). Inboxes and Members are only
+// populated when fetching a single team.
+type Team struct {
+	ID      string     `json:"id"`
+	Name    string     `json:"name"`
+	Inboxes []Inbox    `json:"inboxes,omitempty"`
+	Members []Teammate `json:"members,omitempty"`
+	Links   Links      `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
 // Teammate represents a Front teammate.
 type Teammate struct {
 	ID          string `json:"id"`
@@ -99,6 +206,20 @@ type Contact struct {
 	UpdatedAt    float64                `json:"updated_at,omitempty"`
 }
 
+// Account represents a company account in Front's CRM.
+type Account struct {
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description,omitempty"`
+	LogoURL      string                 `json:"logo_url,omitempty"`
+	Domains      []string               `json:"domains,omitempty"`
+	ExternalID   string                 `json:"external_id,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+	CreatedAt    float64                `json:"created_at,omitempty"`
+	UpdatedAt    float64                `json:"updated_at,omitempty"`
+	Links        Links                  `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
 // ContactNote represents a note on a contact.
 type ContactNote struct {
 	ID        string  `json:"id"`
@@ -120,6 +241,18 @@ type Group struct {
 	Name string `json:"name"`
 }
 
+// Signature represents a teammate or team signature.
+type Signature struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Body       string   `json:"body,omitempty"`
+	SenderInfo string   `json:"sender_info,omitempty"`
+	IsDefault  bool     `json:"is_default,omitempty"`
+	IsPrivate  bool     `json:"is_private,omitempty"`
+	ChannelIDs []string `json:"channel_ids,omitempty"`
+	Links      Links    `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
 // Channel represents a Front channel.
 type Channel struct {
 	ID        string `json:"id"`
@@ -132,15 +265,42 @@ type Channel struct {
 	Links     Links  `json:"_links,omitempty"` //nolint:tagliatelle // Front API
 }
 
+// InboxID returns the ID of the inbox the channel delivers into.
+func (ch *Channel) InboxID() string {
+	return lastPathSegment(ch.Links.Related["inbox"])
+}
+
+// ScheduledMessage is an outbound message queued to be sent later.
+type ScheduledMessage struct {
+	ID          string  `json:"id"`
+	ScheduledAt float64 `json:"scheduled_at"`
+	Subject     string  `json:"subject,omitempty"`
+	Body        string  `json:"body,omitempty"`
+	CreatedAt   float64 `json:"created_at,omitempty"`
+	Links       Links   `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// ConversationID returns the ID of the conversation the message is sent in.
+func (m *ScheduledMessage) ConversationID() string {
+	return lastPathSegment(m.Links.Related["conversation"])
+}
+
 // Comment represents an internal comment on a conversation.
 type Comment struct {
 	ID       string  `json:"id"`
 	Author   *Author `json:"author,omitempty"`
 	Body     string  `json:"body"`
 	PostedAt float64 `json:"posted_at"`
+	IsPinned bool    `json:"is_pinned,omitempty"`
 	Links    Links   `json:"_links,omitempty"` //nolint:tagliatelle // Front API
 }
 
+// ParentID returns the ID of the comment this one replies to, or "" for a
+// comment that starts a thread.
+func (c *Comment) ParentID() string {
+	return lastPathSegment(c.Links.Related["parent"])
+}
+
 // Template represents a canned response template.
 type Template struct {
 	ID                string       `json:"id"`
@@ -152,6 +312,148 @@ type Template struct {
 	Links             Links        `json:"_links,omitempty"` //nolint:tagliatelle // Front API
 }
 
+// TemplateFolder represents a folder of message templates.
+type TemplateFolder struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Links Links  `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// ParentID returns the ID of the parent folder, or "" for top-level folders.
+func (f *TemplateFolder) ParentID() string {
+	return lastPathSegment(f.Links.Related["parent_folder"])
+}
+
+// Shift represents a work schedule that teammates can be assigned to.
+type Shift struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	Color     string               `json:"color,omitempty"`
+	Timezone  string               `json:"timezone,omitempty"`
+	Times     map[string]ShiftTime `json:"times,omitempty"` // keyed by day: mon, tue, ...
+	CreatedAt float64              `json:"created_at,omitempty"`
+	UpdatedAt float64              `json:"updated_at,omitempty"`
+	Links     Links                `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// ShiftTime is the working interval for one day of a shift, as "HH:MM".
+type ShiftTime struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Event represents an activity event (assign, archive, tag, inbound, ...).
+type Event struct {
+	ID           string         `json:"id"`
+	Type         string         `json:"type"`
+	EmittedAt    float64        `json:"emitted_at"`
+	Conversation *Conversation  `json:"conversation,omitempty"`
+	Source       *EventResource `json:"source,omitempty"`
+	Target       *EventResource `json:"target,omitempty"`
+	Links        Links          `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// EventResource is the source or target of an event. Data holds the raw
+// resource, whose shape depends on Meta.Type (teammate, tag, inbox, ...).
+type EventResource struct {
+	Meta EventMeta       `json:"_meta"` //nolint:tagliatelle // Front API
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// EventMeta describes the resource type of an event source or target.
+type EventMeta struct {
+	Type string `json:"type"`
+}
+
+// Rule represents an automation rule.
+type Rule struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Actions   []string `json:"actions,omitempty"`
+	IsPrivate bool     `json:"is_private"`
+	Links     Links    `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// Owner returns the ID of the teammate or team owning a private rule, or ""
+// for company rules.
+func (r *Rule) Owner() string {
+	return lastPathSegment(r.Links.Related["owner"])
+}
+
+// AnalyticsReport represents an analytics report. Reports are computed
+// asynchronously; Metrics is populated once Status is "done".
+type AnalyticsReport struct {
+	Status   string            `json:"status"` // running, done, failed
+	Progress int               `json:"progress"`
+	Metrics  []AnalyticsMetric `json:"metrics,omitempty"`
+	Links    Links             `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// UID returns the report identifier from its self link.
+func (r *AnalyticsReport) UID() string {
+	return lastPathSegment(r.Links.Self)
+}
+
+// AnalyticsMetric is a single computed metric. Value is a number for
+// number/duration/percentage metrics and an array of rows for table metrics.
+type AnalyticsMetric struct {
+	ID    string          `json:"id"`
+	Type  string          `json:"type"` // number, duration, percentage, string, table
+	Value json.RawMessage `json:"value"`
+}
+
+// AnalyticsExport represents an analytics data export.
+type AnalyticsExport struct {
+	Status    string  `json:"status"` // running, done, failed
+	Progress  int     `json:"progress"`
+	URL       string  `json:"url,omitempty"`
+	Filename  string  `json:"filename,omitempty"`
+	Size      int64   `json:"size,omitempty"`
+	CreatedAt float64 `json:"created_at,omitempty"`
+	Links     Links   `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// UID returns the export identifier from its self link.
+func (e *AnalyticsExport) UID() string {
+	return lastPathSegment(e.Links.Self)
+}
+
+// webAppURL is where Front's web app opens a conversation or message by ID.
+const webAppURL = "https://app.frontapp.com/open/"
+
+// WebURL returns the Front web app link for a conversation or message ID.
+func WebURL(id string) string {
+	return webAppURL + id
+}
+
+// WebURL returns the conversation's link in the Front web app.
+func (c *Conversation) WebURL() string {
+	return WebURL(linkedID(c.Links, c.ID))
+}
+
+// WebURL returns the message's link in the Front web app.
+func (m *Message) WebURL() string {
+	return WebURL(linkedID(m.Links, m.ID))
+}
+
+// linkedID is the resource ID from its self link, or id without one.
+func linkedID(links Links, id string) string {
+	if links.Self != "" {
+		return lastPathSegment(links.Self)
+	}
+
+	return id
+}
+
+func lastPathSegment(link string) string {
+	link = strings.TrimRight(link, "/")
+	if i := strings.LastIndex(link, "/"); i >= 0 {
+		return link[i+1:]
+	}
+
+	return link
+}
+
 // Attachment represents a message attachment.
 type Attachment struct {
 	ID          string `json:"id,omitempty"`
@@ -163,11 +465,17 @@ type Attachment struct {
 
 // Recipient represents a message recipient.
 type Recipient struct {
+	Name   string `json:"name,omitempty"`
 	Handle string `json:"handle,omitempty"`
 	Role   string `json:"role,omitempty"`   // to, cc, bcc, from
 	Links  Links  `json:"_links,omitempty"` //nolint:tagliatelle // Front API
 }
 
+// ContactID returns the ID of the contact behind the recipient's handle, if any.
+func (r *Recipient) ContactID() string {
+	return lastPathSegment(r.Links.Related["contact"])
+}
+
 // Author represents the author of a message or comment.
 type Author struct {
 	ID        string `json:"id,omitempty"`
@@ -196,11 +504,13 @@ type ListResponse[T any] struct {
 	Results    []T        `json:"_results"`              //nolint:tagliatelle // Front API
 	Pagination Pagination `json:"_pagination,omitempty"` //nolint:tagliatelle // Front API
 	Links      Links      `json:"_links,omitempty"`      //nolint:tagliatelle // Front API
+	Total      int        `json:"_total,omitempty"`      //nolint:tagliatelle // Front API (search only)
 }
 
 // Me represents the authenticated user.
 type Me struct {
 	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"` // company, for API tokens
 	Email       string `json:"email"`
 	Username    string `json:"username,omitempty"`
 	FirstName   string `json:"first_name,omitempty"`
@@ -227,3 +537,55 @@ func FormatTimestamp(ts float64) string {
 
 	return time.Unix(int64(ts), 0).Format(time.RFC3339)
 }
+
+// KnowledgeBase represents a Front knowledge base.
+type KnowledgeBase struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Locales   []string `json:"locales,omitempty"`
+	CreatedAt float64  `json:"created_at,omitempty"`
+	UpdatedAt float64  `json:"updated_at,omitempty"`
+	Links     Links    `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// KBCategory represents a category within a knowledge base.
+type KBCategory struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Description      string  `json:"description,omitempty"`
+	ParentCategoryID string  `json:"parent_category_id,omitempty"`
+	Locale           string  `json:"locale,omitempty"`
+	CreatedAt        float64 `json:"created_at,omitempty"`
+	UpdatedAt        float64 `json:"updated_at,omitempty"`
+	Links            Links   `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// KBArticle represents a knowledge base article.
+type KBArticle struct {
+	ID         string  `json:"id"`
+	Subject    string  `json:"subject"`
+	Content    string  `json:"content,omitempty"`
+	Status     string  `json:"status,omitempty"`
+	Locale     string  `json:"locale,omitempty"`
+	CategoryID string  `json:"category_id,omitempty"`
+	CreatedAt  float64 `json:"created_at,omitempty"`
+	UpdatedAt  float64 `json:"updated_at,omitempty"`
+	Links      Links   `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// CustomField represents a custom field definition.
+type CustomField struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type"`
+	Values      []CustomFieldValue `json:"values,omitempty"`
+	Links       Links              `json:"_links,omitempty"` //nolint:tagliatelle // Front API
+}
+
+// CustomFieldValue is an allowed value of an enum custom field.
+type CustomFieldValue struct {
+	Value string `json:"value"`
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestVCRRecordsAndReplays(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+
+		switch r.URL.Path {
+		case "/me":
+			_, _ = io.WriteString(w, `{"id":"tea_1","email":"me@example.com","token":"s3cret"}`)
+		case "/tags":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"tag_1"}`)
+		}
+	}))
+
+	cassette := filepath.Join(t.TempDir(), "fixtures", "me.json")
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "live-token"})
+
+	recorder := NewClientWithBaseURL(ts, srv.URL)
+	recorder.EnableVCR(cassette, VCRRecord)
+
+	var me map[string]any
+	if err := recorder.Get(context.Background(), "/me", &me); err != nil {
+		t.Fatalf("record Get: %v", err)
+	}
+
+	if err := recorder.Post(context.Background(), "/tags", map[string]string{"name": "vip"}, nil); err != nil {
+		t.Fatalf("record Post: %v", err)
+	}
+
+	srv.Close()
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+
+	for _, secret := range []string{"s3cret", "live-token", "session=abc"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	player := NewClientWithBaseURL(ts, srv.URL)
+	player.EnableVCR(cassette, VCRReplay)
+
+	me = nil
+	if err := player.Get(context.Background(), "/me", &me); err != nil {
+		t.Fatalf("replay Get: %v", err)
+	}
+
+	if me["email"] != "me@example.com" || me["token"] != "[REDACTED]" {
+		t.Fatalf("replayed /me = %v", me)
+	}
+
+	if err := player.Post(context.Background(), "/tags", map[string]string{"name": "other"}, nil); err == nil {
+		t.Fatal("replayed a POST whose body does not match the recording")
+	}
+
+	if err := player.Post(context.Background(), "/tags", map[string]string{"name": "vip"}, nil); err != nil {
+		t.Fatalf("replay Post: %v", err)
+	}
+
+	if err := player.Get(context.Background(), "/me", &me); err == nil {
+		t.Fatal("replayed an interaction twice")
+	}
+}
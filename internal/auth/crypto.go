@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+const (
+	sealSaltSize   = 16
+	sealKeySize    = 32
+	sealIterations = 600_000
+)
+
+var errDecrypt = errors.New("decrypt: wrong password or corrupted data")
+
+// passwordCipher is AES-256-GCM under a key derived from a password
+// (PBKDF2-SHA256). Sealed data is salt || nonce || ciphertext. Deriving the
+// key is deliberately slow, so callers sealing repeatedly keep the cipher.
+type passwordCipher struct {
+	salt []byte
+	gcm  cipher.AEAD
+}
+
+// newPasswordCipher derives a cipher for password with a fresh salt.
+func newPasswordCipher(password string) (*passwordCipher, error) {
+	salt := make([]byte, sealSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	return derivePasswordCipher(password, salt)
+}
+
+func derivePasswordCipher(password string, salt []byte) (*passwordCipher, error) {
+	key, err := pbkdf2.Key(sha256.New, password, salt, sealIterations, sealKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("init cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("init gcm: %w", err)
+	}
+
+	return &passwordCipher{salt: salt, gcm: gcm}, nil
+}
+
+func (p *passwordCipher) seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, p.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(p.salt)+len(nonce)+len(plaintext)+p.gcm.Overhead())
+	out = append(out, p.salt...)
+	out = append(out, nonce...)
+
+	return p.gcm.Seal(out, nonce, plaintext, nil), nil
+}
+
+// openSealed decrypts data sealed by a passwordCipher and returns the cipher
+// for sealing again under the same key.
+func openSealed(password string, sealed []byte) ([]byte, *passwordCipher, error) {
+	if len(sealed) < sealSaltSize {
+		return nil, nil, errDecrypt
+	}
+
+	p, err := derivePasswordCipher(password, sealed[:sealSaltSize])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rest := sealed[sealSaltSize:]
+	if len(rest) < p.gcm.NonceSize() {
+		return nil, nil, errDecrypt
+	}
+
+	plaintext, err := p.gcm.Open(nil, rest[:p.gcm.NonceSize()], rest[p.gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, nil, errDecrypt
+	}
+
+	return plaintext, p, nil
+}
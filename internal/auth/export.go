@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+// RefreshTokenEnv names the environment variable holding an OAuth refresh
+// token. When set it is used instead of the keyring, so machines without one
+// can run with credentials provisioned from `auth export`.
+const RefreshTokenEnv = "FRONT_REFRESH_TOKEN" //nolint:gosec // env var name
+
+const (
+	exportPrefix          = "frontcli:v1:"
+	encryptedExportPrefix = "frontcli:v1e:"
+)
+
+var (
+	errInvalidExport   = errors.New("not a frontcli credential export")
+	ErrExportEncrypted = errors.New("credential export is encrypted; a password is required")
+)
+
+// Export is a portable copy of one account's credentials: the stored token
+// plus the OAuth client it was issued to.
+type Export struct {
+	Client       string                   `json:"client"`
+	Email        string                   `json:"email"`
+	RefreshToken string                   `json:"refresh_token,omitempty"`
+	APIToken     string                   `json:"api_token,omitempty"`
+	Scopes       []string                 `json:"scopes,omitempty"`
+	CreatedAt    time.Time                `json:"created_at,omitempty"`
+	Credentials  *config.OAuthCredentials `json:"credentials,omitempty"`
+}
+
+// Token returns the exported token in the form the store keeps it.
+func (e Export) Token() Token {
+	return Token{
+		Client:       e.Client,
+		Email:        e.Email,
+		Scopes:       e.Scopes,
+		CreatedAt:    e.CreatedAt,
+		RefreshToken: e.RefreshToken,
+		APIToken:     e.APIToken,
+	}
+}
+
+// EncodeExport serializes e as a single-line blob. With a password the blob
+// is encrypted (AES-GCM); without one it is only base64-encoded.
+func EncodeExport(e Export, password string) (string, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("encode export: %w", err)
+	}
+
+	if password == "" {
+		return exportPrefix + base64.RawURLEncoding.EncodeToString(payload), nil
+	}
+
+	cipher, err := newPasswordCipher(password)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := cipher.seal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return encryptedExportPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// IsEncryptedExport reports whether blob needs a password to decode.
+func IsEncryptedExport(blob string) bool {
+	return strings.HasPrefix(strings.TrimSpace(blob), encryptedExportPrefix)
+}
+
+// DecodeExport parses a blob produced by EncodeExport.
+func DecodeExport(blob, password string) (Export, error) {
+	blob = strings.TrimSpace(blob)
+
+	var (
+		payload []byte
+		err     error
+	)
+
+	switch {
+	case strings.HasPrefix(blob, encryptedExportPrefix):
+		if password == "" {
+			return Export{}, ErrExportEncrypted
+		}
+
+		sealed, decErr := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(blob, encryptedExportPrefix))
+		if decErr != nil {
+			return Export{}, errInvalidExport
+		}
+
+		payload, _, err = openSealed(password, sealed)
+	case strings.HasPrefix(blob, exportPrefix):
+		payload, err = base64.RawURLEncoding.DecodeString(strings.TrimPrefix(blob, exportPrefix))
+		if err != nil {
+			err = errInvalidExport
+		}
+	default:
+		err = errInvalidExport
+	}
+
+	if err != nil {
+		return Export{}, err
+	}
+
+	var e Export
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return Export{}, fmt.Errorf("decode export: %w", err)
+	}
+
+	if e.Email == "" || (e.RefreshToken == "" && e.APIToken == "") {
+		return Export{}, errInvalidExport
+	}
+
+	return e, nil
+}
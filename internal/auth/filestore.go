@@ -0,0 +1,296 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/99designs/keyring"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+// Token store backends, chosen by FRONT_TOKEN_STORE or token_store in the
+// config file.
+const (
+	TokenStoreKeyring       = "keyring"
+	TokenStoreEncryptedFile = "encrypted-file"
+
+	tokenStoreEnv = "FRONT_TOKEN_STORE" //nolint:gosec // env var name
+)
+
+var errInvalidTokenStore = errors.New("invalid token store")
+
+// EncryptedFileStore keeps every token in a single AES-GCM encrypted file.
+// It has no dependencies beyond the filesystem, for machines where the
+// system keyring is unavailable or unreliable.
+type EncryptedFileStore struct {
+	mu       sync.Mutex
+	path     string
+	password keyring.PromptFunc
+	cipher   *passwordCipher
+	tokens   map[string]storedToken // decrypted file, loaded once
+}
+
+// NewEncryptedFileStore opens the store at path. password is asked for the
+// first time the file is read or created.
+func NewEncryptedFileStore(path string, password keyring.PromptFunc) *EncryptedFileStore {
+	return &EncryptedFileStore{path: path, password: password}
+}
+
+func (s *EncryptedFileStore) Keys() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(tokens))
+	for k := range tokens {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (s *EncryptedFileStore) SetToken(client, email string, tok Token) error {
+	email = normalize(email)
+	if email == "" {
+		return errMissingEmail
+	}
+
+	if tok.RefreshToken == "" && tok.APIToken == "" {
+		return errMissingRefreshToken
+	}
+
+	normalizedClient, err := config.NormalizeClientNameOrDefault(client)
+	if err != nil {
+		return fmt.Errorf("normalize client: %w", err)
+	}
+
+	if tok.CreatedAt.IsZero() {
+		tok.CreatedAt = time.Now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	tokens[tokenKey(normalizedClient, email)] = storedToken{
+		RefreshToken: tok.RefreshToken,
+		APIToken:     tok.APIToken,
+		Scopes:       tok.Scopes,
+		CreatedAt:    tok.CreatedAt,
+	}
+
+	return s.save(tokens)
+}
+
+func (s *EncryptedFileStore) GetToken(client, email string) (Token, error) {
+	email = normalize(email)
+	if email == "" {
+		return Token{}, errMissingEmail
+	}
+
+	normalizedClient, err := config.NormalizeClientNameOrDefault(client)
+	if err != nil {
+		return Token{}, fmt.Errorf("normalize client: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return Token{}, err
+	}
+
+	st, ok := tokens[tokenKey(normalizedClient, email)]
+	if !ok {
+		return Token{}, fmt.Errorf("read token: %w", keyring.ErrKeyNotFound)
+	}
+
+	return Token{
+		Client:       normalizedClient,
+		Email:        email,
+		Scopes:       st.Scopes,
+		CreatedAt:    st.CreatedAt,
+		RefreshToken: st.RefreshToken,
+		APIToken:     st.APIToken,
+	}, nil
+}
+
+func (s *EncryptedFileStore) DeleteToken(client, email string) error {
+	email = normalize(email)
+	if email == "" {
+		return errMissingEmail
+	}
+
+	normalizedClient, err := config.NormalizeClientNameOrDefault(client)
+	if err != nil {
+		return fmt.Errorf("normalize client: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	key := tokenKey(normalizedClient, email)
+	if _, ok := tokens[key]; !ok {
+		return nil
+	}
+
+	delete(tokens, key)
+
+	return s.save(tokens)
+}
+
+func (s *EncryptedFileStore) ListTokens() ([]Token, error) {
+	keys, err := s.Keys()
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+
+	out := make([]Token, 0, len(keys))
+
+	for _, k := range keys {
+		client, email, ok := ParseTokenKey(k)
+		if !ok {
+			continue
+		}
+
+		tok, err := s.GetToken(client, email)
+		if err != nil {
+			return nil, fmt.Errorf("read token for %s: %w", email, err)
+		}
+
+		out = append(out, tok)
+	}
+
+	return out, nil
+}
+
+// load decrypts the token file on first use. A missing file is an empty
+// store.
+func (s *EncryptedFileStore) load() (map[string]storedToken, error) {
+	if s.tokens != nil {
+		return s.tokens, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]storedToken{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read token file: %w", err)
+	}
+
+	password, err := s.password("Password for " + s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, cipher, err := openSealed(password, data)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", s.path, err)
+	}
+
+	s.cipher = cipher
+
+	tokens := map[string]storedToken{}
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("decode token file: %w", err)
+	}
+
+	s.tokens = tokens
+
+	return tokens, nil
+}
+
+// save encrypts tokens and atomically replaces the token file.
+func (s *EncryptedFileStore) save(tokens map[string]storedToken) error {
+	if s.cipher == nil {
+		password, err := s.password("New password for " + s.path)
+		if err != nil {
+			return err
+		}
+
+		if password == "" {
+			return fmt.Errorf("token file password is empty; set %s", keyringPasswordEnv)
+		}
+
+		if s.cipher, err = newPasswordCipher(password); err != nil {
+			return err
+		}
+	}
+
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("encode tokens: %w", err)
+	}
+
+	sealed, err := s.cipher.seal(plaintext)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("ensure token dir: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+
+	if err := os.WriteFile(tmp, sealed, 0o600); err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("commit token file: %w", err)
+	}
+
+	s.tokens = tokens
+
+	return nil
+}
+
+// tokenStoreBackend returns the configured store: FRONT_TOKEN_STORE, then
+// token_store in the config file, then the system keyring.
+func tokenStoreBackend() (string, error) {
+	backend := normalize(os.Getenv(tokenStoreEnv))
+
+	if backend == "" {
+		cfg, err := config.ReadConfig()
+		if err != nil {
+			return "", err
+		}
+
+		backend = normalize(cfg.TokenStore)
+	}
+
+	switch backend {
+	case "", TokenStoreKeyring:
+		return TokenStoreKeyring, nil
+	case TokenStoreEncryptedFile:
+		return TokenStoreEncryptedFile, nil
+	default:
+		return "", fmt.Errorf("%w: %q (use %s or %s)", errInvalidTokenStore, backend, TokenStoreKeyring, TokenStoreEncryptedFile)
+	}
+}
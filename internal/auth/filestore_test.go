@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/99designs/keyring"
+)
+
+func TestEncryptedFileStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.enc")
+	store := NewEncryptedFileStore(path, keyring.FixedStringPrompt("secret"))
+
+	if err := store.SetToken("default", "Me@Example.com", Token{RefreshToken: "r1"}); err != nil {
+		t.Fatalf("SetToken: %v", err)
+	}
+
+	if err := store.SetToken("work", "api@example.com", Token{APIToken: "a1"}); err != nil {
+		t.Fatalf("SetToken: %v", err)
+	}
+
+	// A fresh store reads back what the first one wrote.
+	reopened := NewEncryptedFileStore(path, keyring.FixedStringPrompt("secret"))
+
+	tokens, err := reopened.ListTokens()
+	if err != nil || len(tokens) != 2 {
+		t.Fatalf("ListTokens = %+v, %v", tokens, err)
+	}
+
+	tok, err := reopened.GetToken("default", "me@example.com")
+	if err != nil || tok.RefreshToken != "r1" || tok.CreatedAt.IsZero() {
+		t.Fatalf("GetToken = %+v, %v", tok, err)
+	}
+
+	if err := reopened.DeleteToken("default", "me@example.com"); err != nil {
+		t.Fatalf("DeleteToken: %v", err)
+	}
+
+	if _, err := reopened.GetToken("default", "me@example.com"); !errors.Is(err, keyring.ErrKeyNotFound) {
+		t.Fatalf("GetToken after delete: %v", err)
+	}
+
+	wrong := NewEncryptedFileStore(path, keyring.FixedStringPrompt("guess"))
+	if _, err := wrong.ListTokens(); !errors.Is(err, errDecrypt) {
+		t.Fatalf("wrong password: %v", err)
+	}
+}
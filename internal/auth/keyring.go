@@ -34,6 +34,7 @@ type Token struct {
 	Scopes       []string  `json:"scopes,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 	RefreshToken string    `json:"-"`
+	APIToken     string    `json:"-"`
 }
 
 const (
@@ -43,7 +44,7 @@ const (
 
 var (
 	errMissingEmail        = errors.New("missing email")
-	errMissingRefreshToken = errors.New("missing refresh token")
+	errMissingRefreshToken = errors.New("missing refresh token or API token")
 	errNoTTY               = errors.New("no TTY available for keyring password prompt")
 	errInvalidBackend      = errors.New("invalid keyring backend")
 	errKeyringTimeout      = errors.New("keyring connection timed out")
@@ -166,6 +167,24 @@ func openKeyringWithTimeout(cfg keyring.Config, timeout time.Duration) (keyring.
 
 func OpenDefault() (Store, error) {
 	defaultStoreOnce.Do(func() {
+		backend, err := tokenStoreBackend()
+		if err != nil {
+			defaultStoreErr = err
+			return
+		}
+
+		if backend == TokenStoreEncryptedFile {
+			path, err := config.TokenFilePath()
+			if err != nil {
+				defaultStoreErr = err
+				return
+			}
+
+			defaultStore = NewEncryptedFileStore(path, fileKeyringPasswordFunc())
+
+			return
+		}
+
 		ring, err := openKeyringFunc()
 		if err != nil {
 			defaultStoreErr = err
@@ -185,7 +204,8 @@ func ResetDefaultStore() {
 }
 
 type storedToken struct {
-	RefreshToken string    `json:"refresh_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	APIToken     string    `json:"api_token,omitempty"`
 	Scopes       []string  `json:"scopes,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 }
@@ -205,7 +225,7 @@ func (s *KeyringStore) SetToken(client, email string, tok Token) error {
 		return errMissingEmail
 	}
 
-	if tok.RefreshToken == "" {
+	if tok.RefreshToken == "" && tok.APIToken == "" {
 		return errMissingRefreshToken
 	}
 
@@ -220,6 +240,7 @@ func (s *KeyringStore) SetToken(client, email string, tok Token) error {
 
 	payload, err := json.Marshal(storedToken{
 		RefreshToken: tok.RefreshToken,
+		APIToken:     tok.APIToken,
 		Scopes:       tok.Scopes,
 		CreatedAt:    tok.CreatedAt,
 	})
@@ -264,6 +285,7 @@ func (s *KeyringStore) GetToken(client, email string) (Token, error) {
 		Scopes:       st.Scopes,
 		CreatedAt:    st.CreatedAt,
 		RefreshToken: st.RefreshToken,
+		APIToken:     st.APIToken,
 	}, nil
 }
 
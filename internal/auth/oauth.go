@@ -35,6 +35,13 @@ type AuthorizeOptions struct {
 	ForceConsent bool
 	Timeout      time.Duration
 	Client       string
+	Scopes       []string
+}
+
+// Grant is the outcome of a successful authorization.
+type Grant struct {
+	RefreshToken string
+	Scopes       []string // granted scopes; the requested ones if Front doesn't say
 }
 
 var (
@@ -45,23 +52,24 @@ var (
 	errNoRefreshToken      = errors.New("no refresh token received; try with --force-consent")
 	errStateMismatch       = errors.New("state mismatch")
 	errUnsupportedPlatform = errors.New("unsupported platform")
-	openBrowserFn          = openBrowser
+	openBrowserFn          = OpenBrowser
 	randomStateFn          = randomState
+	pkceVerifierFn         = oauth2.GenerateVerifier
 )
 
-func Authorize(ctx context.Context, opts AuthorizeOptions) (string, error) {
+func Authorize(ctx context.Context, opts AuthorizeOptions) (Grant, error) {
 	if opts.Timeout <= 0 {
 		opts.Timeout = 2 * time.Minute
 	}
 
 	creds, err := config.ReadClientCredentials(opts.Client)
 	if err != nil {
-		return "", fmt.Errorf("read credentials: %w", err)
+		return Grant{}, fmt.Errorf("read credentials: %w", err)
 	}
 
 	state, err := randomStateFn()
 	if err != nil {
-		return "", err
+		return Grant{}, err
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
@@ -72,35 +80,53 @@ func Authorize(ctx context.Context, opts AuthorizeOptions) (string, error) {
 		redirectURI = fmt.Sprintf("https://localhost:%d/callback", defaultCallbackPort)
 	}
 
-	cfg := oauth2.Config{
-		ClientID:     creds.ClientID,
-		ClientSecret: creds.ClientSecret,
-		Endpoint:     frontEndpoint,
-		RedirectURL:  redirectURI,
-	}
+	cfg := oauthConfig(creds)
+	cfg.RedirectURL = redirectURI
+	cfg.Scopes = opts.Scopes
 
 	parsed, err := url.Parse(cfg.RedirectURL)
 	if err != nil {
-		return "", fmt.Errorf("parse redirect uri: %w", err)
+		return Grant{}, fmt.Errorf("parse redirect uri: %w", err)
 	}
 
 	if !strings.EqualFold(parsed.Scheme, "https") {
-		return "", errHTTPSRequired
+		return Grant{}, errHTTPSRequired
 	}
 
-	authOpts := []oauth2.AuthCodeOption{oauth2.AccessTypeOffline}
+	// PKCE (S256) binds the code to this login, so public clients without a
+	// secret can authorize safely.
+	verifier := pkceVerifierFn()
+
+	authOpts := []oauth2.AuthCodeOption{oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)}
 	if opts.ForceConsent {
 		authOpts = append(authOpts, oauth2.SetAuthURLParam("prompt", "consent"))
 	}
 
+	exchangeOpts := []oauth2.AuthCodeOption{oauth2.VerifierOption(verifier)}
+
 	if opts.Manual {
-		return authorizeManual(ctx, cfg, state, authOpts)
+		return authorizeManual(ctx, cfg, state, authOpts, exchangeOpts)
 	}
 
-	return authorizeWithServer(ctx, cfg, state, authOpts)
+	return authorizeWithServer(ctx, cfg, state, authOpts, exchangeOpts)
 }
 
-func authorizeManual(ctx context.Context, cfg oauth2.Config, state string, authOpts []oauth2.AuthCodeOption) (string, error) {
+// oauthConfig builds the OAuth client config for creds. Public (PKCE-only)
+// clients have no secret and send their client_id in the request body.
+func oauthConfig(creds config.OAuthCredentials) oauth2.Config {
+	endpoint := frontEndpoint
+	if creds.ClientSecret == "" {
+		endpoint.AuthStyle = oauth2.AuthStyleInParams
+	}
+
+	return oauth2.Config{
+		ClientID:     creds.ClientID,
+		ClientSecret: creds.ClientSecret,
+		Endpoint:     endpoint,
+	}
+}
+
+func authorizeManual(ctx context.Context, cfg oauth2.Config, state string, authOpts, exchangeOpts []oauth2.AuthCodeOption) (Grant, error) {
 	authURL := cfg.AuthCodeURL(state, authOpts...)
 
 	fmt.Fprintln(os.Stderr, "Visit this URL to authorize:")
@@ -113,37 +139,37 @@ func authorizeManual(ctx context.Context, cfg oauth2.Config, state string, authO
 
 	var line string
 	if _, err := fmt.Scanln(&line); err != nil {
-		return "", fmt.Errorf("read redirect url: %w", err)
+		return Grant{}, fmt.Errorf("read redirect url: %w", err)
 	}
 
 	line = strings.TrimSpace(line)
 
 	code, gotState, err := extractCodeAndState(line)
 	if err != nil {
-		return "", err
+		return Grant{}, err
 	}
 
 	if gotState != "" && gotState != state {
-		return "", errStateMismatch
+		return Grant{}, errStateMismatch
 	}
 
-	tok, err := cfg.Exchange(ctx, code)
+	tok, err := cfg.Exchange(ctx, code, exchangeOpts...)
 	if err != nil {
-		return "", fmt.Errorf("exchange code: %w", err)
+		return Grant{}, fmt.Errorf("exchange code: %w", err)
 	}
 
 	if tok.RefreshToken == "" {
-		return "", errNoRefreshToken
+		return Grant{}, errNoRefreshToken
 	}
 
-	return tok.RefreshToken, nil
+	return grantFromToken(tok, cfg.Scopes), nil
 }
 
-func authorizeWithServer(ctx context.Context, cfg oauth2.Config, state string, authOpts []oauth2.AuthCodeOption) (string, error) {
+func authorizeWithServer(ctx context.Context, cfg oauth2.Config, state string, authOpts, exchangeOpts []oauth2.AuthCodeOption) (Grant, error) {
 	// Parse port from redirect URI
 	parsed, err := url.Parse(cfg.RedirectURL)
 	if err != nil {
-		return "", fmt.Errorf("parse redirect uri: %w", err)
+		return Grant{}, fmt.Errorf("parse redirect uri: %w", err)
 	}
 
 	port := parsed.Port()
@@ -154,12 +180,12 @@ func authorizeWithServer(ctx context.Context, cfg oauth2.Config, state string, a
 	// Get TLS certificate for HTTPS
 	certPath, keyPath, err := EnsureCertificate()
 	if err != nil {
-		return "", fmt.Errorf("setup TLS: %w", err)
+		return Grant{}, fmt.Errorf("setup TLS: %w", err)
 	}
 
 	ln, err := (&net.ListenConfig{}).Listen(ctx, "tcp", "127.0.0.1:"+port)
 	if err != nil {
-		return "", fmt.Errorf("listen for callback: %w", err)
+		return Grant{}, fmt.Errorf("listen for callback: %w", err)
 	}
 
 	defer func() { _ = ln.Close() }()
@@ -253,37 +279,49 @@ func authorizeWithServer(ctx context.Context, cfg oauth2.Config, state string, a
 	case code := <-codeCh:
 		fmt.Fprintln(os.Stderr, "Authorization received. Finishing...")
 
-		tok, err := cfg.Exchange(ctx, code)
+		tok, err := cfg.Exchange(ctx, code, exchangeOpts...)
 		if err != nil {
 			_ = srv.Close()
 
-			return "", fmt.Errorf("exchange code: %w", err)
+			return Grant{}, fmt.Errorf("exchange code: %w", err)
 		}
 
 		if tok.RefreshToken == "" {
 			_ = srv.Close()
 
-			return "", errNoRefreshToken
+			return Grant{}, errNoRefreshToken
 		}
 
 		shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
 		defer cancel()
 		_ = srv.Shutdown(shutdownCtx)
 
-		return tok.RefreshToken, nil
+		return grantFromToken(tok, cfg.Scopes), nil
 
 	case err := <-errCh:
 		_ = srv.Close()
 
-		return "", err
+		return Grant{}, err
 
 	case <-ctx.Done():
 		_ = srv.Close()
 
-		return "", fmt.Errorf("authorization canceled: %w", ctx.Err())
+		return Grant{}, fmt.Errorf("authorization canceled: %w", ctx.Err())
 	}
 }
 
+// grantFromToken reads the granted scopes from the token response's scope
+// field (space or comma separated), falling back to the requested scopes.
+func grantFromToken(tok *oauth2.Token, requested []string) Grant {
+	scopes := requested
+
+	if raw, ok := tok.Extra("scope").(string); ok && strings.TrimSpace(raw) != "" {
+		scopes = strings.FieldsFunc(raw, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+
+	return Grant{RefreshToken: tok.RefreshToken, Scopes: scopes}
+}
+
 func randomState() (string, error) {
 	b := make([]byte, 32)
 	if _, err := rand.Read(b); err != nil {
@@ -307,7 +345,8 @@ func extractCodeAndState(rawURL string) (code, state string, err error) {
 	return code, parsed.Query().Get("state"), nil
 }
 
-func openBrowser(targetURL string) error {
+// OpenBrowser opens targetURL in the default browser without waiting for it.
+func OpenBrowser(targetURL string) error {
 	var cmd *exec.Cmd
 
 	switch runtime.GOOS {
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+func TestAuthorizeManualSendsPKCE(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	if err := config.WriteClientCredentials("default", config.OAuthCredentials{ClientID: "cid"}); err != nil {
+		t.Fatalf("WriteClientCredentials: %v", err)
+	}
+
+	const verifier = "test-verifier-0123456789-0123456789-0123456789"
+
+	var exchange url.Values
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			t.Error("public client sent basic auth")
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+		}
+
+		exchange = r.PostForm
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"access_token":"a1","refresh_token":"r1","token_type":"Bearer"}`)
+	}))
+	defer srv.Close()
+
+	oldEndpoint, oldState, oldVerifier := frontEndpoint, randomStateFn, pkceVerifierFn
+	frontEndpoint.TokenURL = srv.URL
+	randomStateFn = func() (string, error) { return "st", nil }
+	pkceVerifierFn = func() string { return verifier }
+
+	t.Cleanup(func() { frontEndpoint, randomStateFn, pkceVerifierFn = oldEndpoint, oldState, oldVerifier })
+
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+
+	stderrR, stderrW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+
+	oldStdin, oldStderr := os.Stdin, os.Stderr
+	os.Stdin, os.Stderr = stdinR, stderrW
+
+	_, _ = io.WriteString(stdinW, "https://localhost:8484/callback?code=c1&state=st\n")
+	_ = stdinW.Close()
+
+	grant, err := Authorize(context.Background(), AuthorizeOptions{Manual: true, Client: "default"})
+
+	os.Stdin, os.Stderr = oldStdin, oldStderr
+	_ = stderrW.Close()
+
+	stderr, _ := io.ReadAll(stderrR)
+
+	if err != nil || grant.RefreshToken != "r1" {
+		t.Fatalf("Authorize = %+v, %v", grant, err)
+	}
+
+	var authURL *url.URL
+
+	for line := range strings.Lines(string(stderr)) {
+		if strings.HasPrefix(line, frontEndpoint.AuthURL) {
+			authURL, _ = url.Parse(strings.TrimSpace(line))
+		}
+	}
+
+	if authURL == nil {
+		t.Fatalf("no authorization URL in %q", stderr)
+	}
+
+	q := authURL.Query()
+	if q.Get("code_challenge") != oauth2.S256ChallengeFromVerifier(verifier) || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("authorization URL %s lacks the S256 challenge", authURL)
+	}
+
+	if exchange.Get("code_verifier") != verifier || exchange.Get("code") != "c1" || exchange.Get("client_id") != "cid" {
+		t.Errorf("exchange form = %v", exchange)
+	}
+}
+
+func TestOAuthConfigAuthStyle(t *testing.T) {
+	if got := oauthConfig(config.OAuthCredentials{ClientID: "cid"}).Endpoint.AuthStyle; got != oauth2.AuthStyleInParams {
+		t.Errorf("public client AuthStyle = %v, want AuthStyleInParams", got)
+	}
+
+	if got := oauthConfig(config.OAuthCredentials{ClientID: "cid", ClientSecret: "s"}).Endpoint.AuthStyle; got != oauth2.AuthStyleAutoDetect {
+		t.Errorf("confidential client AuthStyle = %v, want AuthStyleAutoDetect", got)
+	}
+}
@@ -10,10 +10,19 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/log"
 )
 
 var ErrNotAuthenticated = errors.New("not authenticated")
 
+// APITokenEnv names the environment variable holding a static Front API
+// token. When set it is used instead of any stored credentials.
+const APITokenEnv = "FRONT_API_TOKEN" //nolint:gosec // env var name
+
+// apiTokenTTL is how long a stored API token is used before it is re-read
+// from the store. API tokens don't expire on their own.
+const apiTokenTTL = time.Hour
+
 // TokenSource provides OAuth2 tokens with lazy refresh on 401.
 // Access tokens are kept in memory only; refresh tokens are stored in keyring.
 type TokenSource struct {
@@ -48,6 +57,25 @@ func NewRefreshTokenSource(client, refreshToken string) *RefreshTokenSource {
 	}
 }
 
+// APITokenSource serves a static Front API token; there is nothing to refresh.
+type APITokenSource struct {
+	token string
+}
+
+// NewAPITokenSource creates a token source for a Front API token.
+func NewAPITokenSource(token string) *APITokenSource {
+	return &APITokenSource{token: token}
+}
+
+// Token returns the API token as a bearer token.
+func (ts *APITokenSource) Token() (*oauth2.Token, error) {
+	if ts.token == "" {
+		return nil, ErrNotAuthenticated
+	}
+
+	return &oauth2.Token{AccessToken: ts.token, TokenType: "Bearer"}, nil
+}
+
 // Token returns an access token by exchanging the refresh token.
 func (ts *RefreshTokenSource) Token() (*oauth2.Token, error) {
 	creds, err := config.ReadClientCredentials(ts.client)
@@ -55,11 +83,7 @@ func (ts *RefreshTokenSource) Token() (*oauth2.Token, error) {
 		return nil, fmt.Errorf("read credentials: %w", err)
 	}
 
-	cfg := oauth2.Config{
-		ClientID:     creds.ClientID,
-		ClientSecret: creds.ClientSecret,
-		Endpoint:     frontEndpoint,
-	}
+	cfg := oauthConfig(creds)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -113,6 +137,13 @@ func (ts *TokenSource) refresh() error {
 		return fmt.Errorf("%w: %w", ErrNotAuthenticated, err)
 	}
 
+	if tok.APIToken != "" {
+		ts.accessToken = tok.APIToken
+		ts.accessExpiry = time.Now().Add(apiTokenTTL)
+
+		return nil
+	}
+
 	if tok.RefreshToken == "" {
 		return ErrNotAuthenticated
 	}
@@ -123,11 +154,7 @@ func (ts *TokenSource) refresh() error {
 		return fmt.Errorf("read credentials: %w", err)
 	}
 
-	cfg := oauth2.Config{
-		ClientID:     creds.ClientID,
-		ClientSecret: creds.ClientSecret,
-		Endpoint:     frontEndpoint,
-	}
+	cfg := oauthConfig(creds)
 
 	// Use refresh token to get new access token
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -148,7 +175,7 @@ func (ts *TokenSource) refresh() error {
 		tok.RefreshToken = newTok.RefreshToken
 		if err := ts.store.SetToken(ts.client, ts.email, tok); err != nil {
 			// Log but don't fail - we still have a working access token
-			fmt.Printf("Warning: failed to store new refresh token: %v\n", err)
+			log.Warn("failed to store new refresh token", "email", ts.email, "err", err)
 		}
 	}
 
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type AccountCmd struct {
+	List     AccountListCmd    `cmd:"" help:"List accounts"`
+	Get      AccountGetCmd     `cmd:"" help:"Get an account"`
+	Create   AccountCreateCmd  `cmd:"" help:"Create an account"`
+	Update   AccountUpdateCmd  `cmd:"" help:"Update an account"`
+	Delete   AccountDeleteCmd  `cmd:"" help:"Delete an account"`
+	Contacts AccountContactCmd `cmd:"" help:"Manage contacts of an account"`
+}
+
+type AccountListCmd struct {
+	PaginationFlags `embed:""`
+
+	Limit int `help:"Maximum results" default:"25"`
+}
+
+func (c *AccountListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Account]{
+		Path:    fmt.Sprintf("/accounts?limit=%d", c.Limit),
+		Empty:   "No accounts found.",
+		Headers: []string{"ID", "NAME", "DOMAINS"},
+		Row:     output.FormatAccount,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type AccountGetCmd struct {
+	ID string `arg:"" help:"Account ID"`
+}
+
+func (c *AccountGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	account, err := client.GetAccount(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, account)
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:          %s\n", account.ID)
+	fmt.Fprintf(os.Stdout, "Name:        %s\n", account.Name)
+
+	if account.Description != "" {
+		fmt.Fprintf(os.Stdout, "Description: %s\n", account.Description)
+	}
+
+	if len(account.Domains) > 0 {
+		fmt.Fprintf(os.Stdout, "Domains:     %s\n", strings.Join(account.Domains, ", "))
+	}
+
+	if account.ExternalID != "" {
+		fmt.Fprintf(os.Stdout, "External ID: %s\n", account.ExternalID)
+	}
+
+	if account.CreatedAt > 0 {
+		fmt.Fprintf(os.Stdout, "Created:     %s\n", output.FormatTimestamp(account.CreatedAt))
+	}
+
+	return nil
+}
+
+type AccountCreateCmd struct {
+	Name        string   `required:"" help:"Account name"`
+	Description string   `help:"Account description"`
+	Domain      []string `help:"Domain belonging to the account; repeatable"`
+	ExternalID  string   `help:"External identifier (e.g. your CRM's ID)"`
+	Contact     []string `help:"Contact ID to add to the account; repeatable"`
+}
+
+func (c *AccountCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"name": c.Name,
+	}
+
+	if c.Description != "" {
+		req["description"] = c.Description
+	}
+
+	if len(c.Domain) > 0 {
+		req["domains"] = c.Domain
+	}
+
+	if c.ExternalID != "" {
+		req["external_id"] = c.ExternalID
+	}
+
+	if len(c.Contact) > 0 {
+		req["contact_ids"] = c.Contact
+	}
+
+	var result api.Account
+	if err := client.Post(ctx, "/accounts", req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Account created: %s\n", result.ID)
+
+	return nil
+}
+
+type AccountUpdateCmd struct {
+	ID          string   `arg:"" help:"Account ID"`
+	Name        string   `help:"New name"`
+	Description string   `help:"New description"`
+	Domain      []string `help:"Replace domains; repeatable"`
+	ExternalID  string   `help:"New external identifier"`
+}
+
+func (c *AccountUpdateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{}
+
+	if c.Name != "" {
+		req["name"] = c.Name
+	}
+
+	if c.Description != "" {
+		req["description"] = c.Description
+	}
+
+	if len(c.Domain) > 0 {
+		req["domains"] = c.Domain
+	}
+
+	if c.ExternalID != "" {
+		req["external_id"] = c.ExternalID
+	}
+
+	if len(req) == 0 {
+		return fmt.Errorf("no updates specified")
+	}
+
+	var result api.Account
+	if err := client.Patch(ctx, "/accounts/"+c.ID, req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Account updated: %s\n", c.ID)
+
+	return nil
+}
+
+type AccountDeleteCmd struct {
+	ID string `arg:"" help:"Account ID"`
+}
+
+func (c *AccountDeleteCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Delete(ctx, "/accounts/"+c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Account deleted: %s\n", c.ID)
+
+	return nil
+}
+
+type AccountContactCmd struct {
+	List   AccountContactListCmd   `cmd:"" help:"List contacts of an account"`
+	Add    AccountContactAddCmd    `cmd:"" help:"Add contacts to an account"`
+	Remove AccountContactRemoveCmd `cmd:"" help:"Remove contacts from an account"`
+}
+
+type AccountContactListCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Account ID"`
+}
+
+func (c *AccountContactListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Contact]{
+		Path:    fmt.Sprintf("/accounts/%s/contacts", c.ID),
+		Empty:   "No contacts found.",
+		Headers: []string{"ID", "NAME", "HANDLE"},
+		Row:     output.FormatContact,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type AccountContactAddCmd struct {
+	ID         string   `arg:"" help:"Account ID"`
+	ContactIDs []string `arg:"" help:"Contact IDs to add"`
+}
+
+func (c *AccountContactAddCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	req := map[string][]string{"contact_ids": c.ContactIDs}
+	if err := client.Post(ctx, fmt.Sprintf("/accounts/%s/contacts", c.ID), req, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Added %d contacts to %s\n", len(c.ContactIDs), c.ID)
+
+	return nil
+}
+
+type AccountContactRemoveCmd struct {
+	ID         string   `arg:"" help:"Account ID"`
+	ContactIDs []string `arg:"" help:"Contact IDs to remove"`
+}
+
+func (c *AccountContactRemoveCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	req := map[string][]string{"contact_ids": c.ContactIDs}
+	if err := client.DeleteWithBody(ctx, fmt.Sprintf("/accounts/%s/contacts", c.ID), req); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Removed %d contacts from %s\n", len(c.ContactIDs), c.ID)
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccountContactRemoveSendsBody(t *testing.T) {
+	var got struct {
+		ContactIDs []string `json:"contact_ids"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/accounts/acc_1/contacts" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := AccountContactRemoveCmd{ID: "acc_1", ContactIDs: []string{"ctc_1", "ctc_2"}}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(got.ContactIDs) != 2 || got.ContactIDs[0] != "ctc_1" || got.ContactIDs[1] != "ctc_2" {
+		t.Fatalf("unexpected contact_ids: %v", got.ContactIDs)
+	}
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+var (
+	errInvalidAlias  = errors.New("invalid alias")
+	errUnknownAlias  = errors.New("unknown alias")
+	errUnclosedQuote = errors.New("unclosed quote")
+)
+
+// placeholderPattern matches the $1, $2, ... placeholders of an expansion.
+var placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+type AliasCmd struct {
+	Set    AliasSetCmd    `cmd:"" help:"Create or replace a command alias"`
+	List   AliasListCmd   `cmd:"" help:"List command aliases"`
+	Delete AliasDeleteCmd `cmd:"" help:"Delete a command alias"`
+}
+
+type AliasSetCmd struct {
+	Name      string `arg:"" help:"Alias name (used as the first word: frontcli <name>)"`
+	Expansion string `arg:"" help:"Command line it expands to; $1, $2, ... take the alias's arguments"`
+}
+
+func (c *AliasSetCmd) Run(kctx *kong.Context) error {
+	name := strings.TrimSpace(c.Name)
+
+	switch {
+	case name == "" || strings.ContainsAny(name, " \t\n") || strings.HasPrefix(name, "-"):
+		return fmt.Errorf("%w %q: use a single word", errInvalidAlias, c.Name)
+	case findChild(kctx.Model.Node, name) != nil:
+		return fmt.Errorf("%w %q: it is a built-in command", errInvalidAlias, name)
+	}
+
+	words, err := splitCommandLine(c.Expansion)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", errInvalidAlias, name, err)
+	}
+
+	if len(words) == 0 {
+		return fmt.Errorf("%w %q: empty expansion", errInvalidAlias, name)
+	}
+
+	if first := firstCommandWord(kctx.Model.Node, words); first < 0 || findChild(kctx.Model.Node, words[first]) == nil {
+		return fmt.Errorf("%w %q: expansion must start with a frontcli command", errInvalidAlias, name)
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.CommandAliases == nil {
+		cfg.CommandAliases = map[string]string{}
+	}
+
+	cfg.CommandAliases[name] = c.Expansion
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Alias %s → frontcli %s\n", name, c.Expansion)
+
+	return nil
+}
+
+type AliasListCmd struct{}
+
+func (c *AliasListCmd) Run(flags *RootFlags) error {
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if mode.JSON {
+		aliases := cfg.CommandAliases
+		if aliases == nil {
+			aliases = map[string]string{}
+		}
+
+		return mode.Write(os.Stdout, aliases)
+	}
+
+	if len(cfg.CommandAliases) == 0 {
+		fmt.Fprintln(os.Stdout, "No aliases. Create one with 'frontcli alias set <name> <command>'.")
+
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.CommandAliases))
+	for name := range cfg.CommandAliases {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("ALIAS", "EXPANSION")
+
+	for _, name := range names {
+		tbl.AddRow(name, cfg.CommandAliases[name])
+	}
+
+	return tbl.Flush()
+}
+
+type AliasDeleteCmd struct {
+	Name string `arg:"" help:"Alias name"`
+}
+
+func (c *AliasDeleteCmd) Run() error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cfg.CommandAliases[c.Name]; !ok {
+		return fmt.Errorf("%w %q", errUnknownAlias, c.Name)
+	}
+
+	delete(cfg.CommandAliases, c.Name)
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Deleted alias %s\n", c.Name)
+
+	return nil
+}
+
+// expandAlias rewrites args when their first command word is a user alias:
+// the alias's words replace it, $N placeholders take the words after it,
+// and unused words are appended. Built-in commands always win, and flags
+// before the alias are kept.
+func expandAlias(root *kong.Node, args []string) ([]string, error) {
+	i := firstCommandWord(root, args)
+	if i < 0 || findChild(root, args[i]) != nil {
+		return args, nil
+	}
+
+	// A broken config is reported by the command that reads it (and must
+	// not block 'frontcli config edit').
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return args, nil //nolint:nilerr // see above
+	}
+
+	expansion, ok := cfg.CommandAliases[args[i]]
+	if !ok {
+		return args, nil
+	}
+
+	words, err := splitCommandLine(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("alias %s: %w", args[i], err)
+	}
+
+	rest := args[i+1:]
+	used := 0
+
+	for j, word := range words {
+		var missing int
+
+		words[j] = placeholderPattern.ReplaceAllStringFunc(word, func(m string) string {
+			n, _ := strconv.Atoi(m[1:])
+			if n < 1 || n > len(rest) {
+				missing = max(missing, n)
+
+				return m
+			}
+
+			used = max(used, n)
+
+			return rest[n-1]
+		})
+
+		if missing > 0 {
+			return nil, fmt.Errorf("alias %s (%s) needs at least %d argument(s)", args[i], expansion, missing)
+		}
+	}
+
+	return slices.Concat(args[:i], words, rest[used:]), nil
+}
+
+// firstCommandWord returns the index of the first word in args that is not
+// a root flag or a root flag's value, or -1.
+func firstCommandWord(root *kong.Node, args []string) int {
+	for i := 0; i < len(args); i++ {
+		word := args[i]
+		if !strings.HasPrefix(word, "-") {
+			return i
+		}
+
+		if word == "--" {
+			return -1
+		}
+
+		if f := findFlag(root, word); f != nil && !strings.Contains(word, "=") && takesValue(f) {
+			i++
+		}
+	}
+
+	return -1
+}
+
+// splitCommandLine splits s into words like a POSIX shell: whitespace
+// separates words, quotes group them, and a backslash escapes the next
+// character outside single quotes.
+func splitCommandLine(s string) ([]string, error) {
+	var (
+		words   []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+
+			inWord = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, errUnclosedQuote
+	}
+
+	if inWord {
+		words = append(words, current.String())
+	}
+
+	return words, nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpandAlias(t *testing.T) {
+	writeTestConfig(t, `command_aliases:
+  mine: conversations list --assignee me --status open
+  vip: conv search "tag:vip $1" --limit $2
+`)
+
+	parser, _, err := newParser()
+	if err != nil {
+		t.Fatalf("newParser: %v", err)
+	}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"mine", "--limit", "5"}, []string{"conversations", "list", "--assignee", "me", "--status", "open", "--limit", "5"}},
+		{[]string{"--account", "work", "mine"}, []string{"--account", "work", "conversations", "list", "--assignee", "me", "--status", "open"}},
+		{[]string{"vip", "is:open", "10", "--json"}, []string{"conv", "search", "tag:vip is:open", "--limit", "10", "--json"}},
+		{[]string{"tags", "list"}, []string{"tags", "list"}},
+	}
+
+	for _, tt := range tests {
+		got, err := expandAlias(parser.Model.Node, tt.args)
+		if err != nil {
+			t.Fatalf("expandAlias(%v): %v", tt.args, err)
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Fatalf("expandAlias(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	if _, err := expandAlias(parser.Model.Node, []string{"vip", "is:open"}); err == nil {
+		t.Fatal("expected an error for a missing placeholder argument")
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	got, err := splitCommandLine(`conv search 'tag:vip is:open' --format "{{.ID}} x" a\ b`)
+	if err != nil {
+		t.Fatalf("splitCommandLine: %v", err)
+	}
+
+	want := []string{"conv", "search", "tag:vip is:open", "--format", "{{.ID}} x", "a b"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("splitCommandLine = %q, want %q", got, want)
+	}
+
+	if _, err := splitCommandLine(`conv "open`); err == nil {
+		t.Fatal("expected an error for an unclosed quote")
+	}
+}
@@ -0,0 +1,566 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+const (
+	analyticsPollTimeout  = 5 * time.Minute
+	defaultAnalyticsRange = 7 * 24 * time.Hour
+)
+
+// analyticsPollInterval is a var so tests can poll without sleeping.
+var analyticsPollInterval = 2 * time.Second
+
+// defaultAnalyticsMetrics covers volume, response time and resolution time.
+var defaultAnalyticsMetrics = []string{
+	"num_messages_received",
+	"num_messages_sent",
+	"avg_first_response_time",
+	"avg_response_time",
+	"avg_resolution_time",
+}
+
+var errAnalyticsFailed = errors.New("analytics job failed")
+
+type AnalyticsCmd struct {
+	Report AnalyticsReportCmd `cmd:"" help:"Compute an analytics report"`
+	Get    AnalyticsGetCmd    `cmd:"" help:"Get an analytics report by ID"`
+	Export AnalyticsExportCmd `cmd:"" help:"Export raw analytics data (events or messages)"`
+}
+
+// AnalyticsScope holds the time range and filters shared by reports and exports.
+type AnalyticsScope struct {
+	Start    string   `help:"Range start (RFC3339, YYYY-MM-DD or Unix seconds; default: 7 days ago)"`
+	End      string   `help:"Range end (RFC3339, YYYY-MM-DD or Unix seconds; default: now)"`
+	Timezone string   `help:"IANA timezone for the range (default: config timezone)"`
+	Inbox    []string `help:"Filter by inbox (ID or name); repeatable"`
+	Teammate []string `help:"Filter by teammate (ID, email or username); repeatable"`
+	Tag      []string `help:"Filter by tag (ID or name); repeatable"`
+}
+
+// resolveNames replaces inbox, teammate and tag names in the filters with IDs.
+func (s *AnalyticsScope) resolveNames(ctx context.Context, client *api.Client) (err error) {
+	if s.Inbox, err = resolveAll(ctx, s.Inbox, client.ResolveInbox); err != nil {
+		return err
+	}
+
+	if s.Teammate, err = resolveAll(ctx, s.Teammate, client.ResolveTeammate); err != nil {
+		return err
+	}
+
+	s.Tag, err = resolveAll(ctx, s.Tag, client.ResolveTag)
+
+	return err
+}
+
+// resolve returns the Unix range, timezone and filters for the scope.
+func (s AnalyticsScope) resolve() (start, end float64, tz string, filters *api.AnalyticsFilters, err error) {
+	if end, err = parseTimeFlag(s.End); err != nil {
+		return 0, 0, "", nil, fmt.Errorf("invalid --end: %w", err)
+	}
+
+	if end == 0 {
+		end = float64(time.Now().Unix())
+	}
+
+	if start, err = parseTimeFlag(s.Start); err != nil {
+		return 0, 0, "", nil, fmt.Errorf("invalid --start: %w", err)
+	}
+
+	if start == 0 {
+		start = end - defaultAnalyticsRange.Seconds()
+	}
+
+	if start >= end {
+		return 0, 0, "", nil, fmt.Errorf("--start must be before --end")
+	}
+
+	tz = strings.TrimSpace(s.Timezone)
+	if tz == "" {
+		if cfg, cfgErr := config.ReadConfig(); cfgErr == nil {
+			tz = cfg.Timezone
+		}
+	}
+
+	if tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return 0, 0, "", nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+		}
+	}
+
+	if len(s.Inbox)+len(s.Teammate)+len(s.Tag) > 0 {
+		filters = &api.AnalyticsFilters{InboxIDs: s.Inbox, TeammateIDs: s.Teammate, TagIDs: s.Tag}
+	}
+
+	return start, end, tz, filters, nil
+}
+
+type AnalyticsReportCmd struct {
+	AnalyticsScope `embed:""`
+
+	Metric []string `help:"Metric ID to compute; repeatable (default: volume, response and resolution times)"`
+	By     string   `help:"Break the report down per inbox or teammate" enum:"inbox,teammate,-" default:"-"`
+	NoWait bool     `help:"Print the report ID without waiting for it to finish"`
+}
+
+func (c *AnalyticsReportCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := c.resolveNames(ctx, client); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	start, end, tz, filters, err := c.resolve()
+	if err != nil {
+		return err
+	}
+
+	req := api.AnalyticsReportRequest{
+		Start:    start,
+		End:      end,
+		Timezone: tz,
+		Metrics:  c.Metric,
+		Filters:  filters,
+	}
+	if len(req.Metrics) == 0 {
+		req.Metrics = defaultAnalyticsMetrics
+	}
+
+	if c.By != "-" {
+		if c.NoWait {
+			return fmt.Errorf("--no-wait cannot be combined with --by")
+		}
+
+		return c.runBreakdown(ctx, client, mode, req)
+	}
+
+	report, err := client.CreateAnalyticsReport(ctx, req)
+	if err == nil && !c.NoWait {
+		report, err = waitForReport(ctx, client, report)
+	}
+
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, report)
+	}
+
+	if c.NoWait {
+		fmt.Fprintf(os.Stdout, "Report %s is %s\n", report.UID(), report.Status)
+
+		return nil
+	}
+
+	return printAnalyticsMetrics(mode, report.Metrics)
+}
+
+// analyticsBreakdownRow is one per-inbox or per-teammate report.
+type analyticsBreakdownRow struct {
+	ID     string               `json:"id"`
+	Name   string               `json:"name"`
+	Report *api.AnalyticsReport `json:"report"`
+}
+
+func (c *AnalyticsReportCmd) runBreakdown(ctx context.Context, client *api.Client, mode output.Mode, req api.AnalyticsReportRequest) error {
+	rows, err := c.breakdownTargets(ctx, client)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	var mu sync.Mutex
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(4)
+
+	for i := range rows {
+		g.Go(func() error {
+			r := req
+
+			f := api.AnalyticsFilters{}
+			if req.Filters != nil {
+				f = *req.Filters
+			}
+
+			if c.By == "inbox" {
+				f.InboxIDs = []string{rows[i].ID}
+			} else {
+				f.TeammateIDs = []string{rows[i].ID}
+			}
+
+			r.Filters = &f
+
+			report, err := client.CreateAnalyticsReport(gctx, r)
+			if err != nil {
+				return err
+			}
+
+			report, err = waitForReport(gctx, client, report)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			rows[i].Report = report
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, rows)
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprintf(os.Stdout, "No %ses found.\n", c.By)
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow(append([]string{strings.ToUpper(c.By)}, upperAll(req.Metrics)...)...)
+
+	for _, row := range rows {
+		values := make(map[string]string, len(row.Report.Metrics))
+		for _, m := range row.Report.Metrics {
+			values[m.ID] = output.FormatAnalyticsMetric(m)[1]
+		}
+
+		cols := []string{row.Name}
+		for _, id := range req.Metrics {
+			cols = append(cols, values[id])
+		}
+
+		tbl.AddRow(cols...)
+	}
+
+	return tbl.Flush()
+}
+
+// breakdownTargets lists the inboxes or teammates to report on, restricted to
+// those passed via --inbox/--teammate when given.
+func (c *AnalyticsReportCmd) breakdownTargets(ctx context.Context, client *api.Client) ([]analyticsBreakdownRow, error) {
+	var rows []analyticsBreakdownRow
+
+	all := PaginationFlags{All: true}
+
+	if c.By == "inbox" {
+		_, err := listPages(ctx, client, "/inboxes", all, func(page *api.ListResponse[api.Inbox]) error {
+			for _, inbox := range page.Results {
+				if len(c.Inbox) == 0 || slices.Contains(c.Inbox, inbox.ID) {
+					rows = append(rows, analyticsBreakdownRow{ID: inbox.ID, Name: inbox.Name})
+				}
+			}
+
+			return nil
+		})
+
+		return rows, err
+	}
+
+	_, err := listPages(ctx, client, "/teammates", all, func(page *api.ListResponse[api.Teammate]) error {
+		for _, tm := range page.Results {
+			if len(c.Teammate) == 0 || slices.Contains(c.Teammate, tm.ID) {
+				rows = append(rows, analyticsBreakdownRow{ID: tm.ID, Name: tm.Email})
+			}
+		}
+
+		return nil
+	})
+
+	return rows, err
+}
+
+type AnalyticsGetCmd struct {
+	ID   string `arg:"" help:"Report ID"`
+	Wait bool   `help:"Wait for the report to finish"`
+}
+
+func (c *AnalyticsGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	report, err := client.GetAnalyticsReport(ctx, c.ID)
+	if err == nil && c.Wait {
+		report, err = waitForReport(ctx, client, report)
+	}
+
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, report)
+	}
+
+	if report.Status != "done" {
+		fmt.Fprintf(os.Stdout, "Report %s is %s (%d%%)\n", c.ID, report.Status, report.Progress)
+
+		return nil
+	}
+
+	return printAnalyticsMetrics(mode, report.Metrics)
+}
+
+type AnalyticsExportCmd struct {
+	AnalyticsScope `embed:""`
+
+	Type   string `help:"Export type" enum:"events,messages" default:"messages"`
+	Output string `name:"out" short:"o" help:"Download the finished export to this file"`
+	NoWait bool   `help:"Print the export ID without waiting for it to finish"`
+}
+
+func (c *AnalyticsExportCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := c.resolveNames(ctx, client); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	start, end, tz, filters, err := c.resolve()
+	if err != nil {
+		return err
+	}
+
+	export, err := client.CreateAnalyticsExport(ctx, api.AnalyticsExportRequest{
+		Start:    start,
+		End:      end,
+		Timezone: tz,
+		Type:     c.Type,
+		Filters:  filters,
+	})
+	if err == nil && !c.NoWait {
+		export, err = waitForExport(ctx, client, export)
+	}
+
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if strings.TrimSpace(c.Output) != "" && export.Status == "done" {
+		path, err := config.ExpandPath(c.Output)
+		if err != nil {
+			return err
+		}
+
+		if err := downloadURL(ctx, export.URL, path); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Export saved to %s\n", path)
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, export)
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:       %s\n", export.UID())
+	fmt.Fprintf(os.Stdout, "Status:   %s\n", export.Status)
+
+	if export.URL != "" {
+		fmt.Fprintf(os.Stdout, "URL:      %s\n", export.URL)
+	}
+
+	if export.Size > 0 {
+		fmt.Fprintf(os.Stdout, "Size:     %d bytes\n", export.Size)
+	}
+
+	return nil
+}
+
+// waitForReport polls a report until it is no longer running.
+func waitForReport(ctx context.Context, client *api.Client, report *api.AnalyticsReport) (*api.AnalyticsReport, error) {
+	uid := report.UID()
+
+	err := pollAnalytics(ctx, "report", func() (string, int, error) {
+		if report.Status != "running" {
+			return report.Status, report.Progress, nil
+		}
+
+		next, err := client.GetAnalyticsReport(ctx, uid)
+		if err != nil {
+			return "", 0, err
+		}
+
+		report = next
+
+		return report.Status, report.Progress, nil
+	})
+
+	return report, err
+}
+
+// waitForExport polls an export until it is no longer running.
+func waitForExport(ctx context.Context, client *api.Client, export *api.AnalyticsExport) (*api.AnalyticsExport, error) {
+	uid := export.UID()
+
+	err := pollAnalytics(ctx, "export", func() (string, int, error) {
+		if export.Status != "running" {
+			return export.Status, export.Progress, nil
+		}
+
+		next, err := client.GetAnalyticsExport(ctx, uid)
+		if err != nil {
+			return "", 0, err
+		}
+
+		export = next
+
+		return export.Status, export.Progress, nil
+	})
+
+	return export, err
+}
+
+// pollAnalytics calls check until the job leaves the running state, reporting
+// progress on stderr.
+func pollAnalytics(ctx context.Context, kind string, check func() (string, int, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, analyticsPollTimeout)
+	defer cancel()
+
+	for {
+		status, progress, err := check()
+		if err != nil {
+			return err
+		}
+
+		switch status {
+		case "running":
+			fmt.Fprintf(os.Stderr, "Waiting for %s (%d%%)...\n", kind, progress)
+		case "failed":
+			return fmt.Errorf("%w: %s", errAnalyticsFailed, kind)
+		default:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %s: %w", kind, ctx.Err())
+		case <-time.After(analyticsPollInterval):
+		}
+	}
+}
+
+func printAnalyticsMetrics(mode output.Mode, metrics []api.AnalyticsMetric) error {
+	if len(metrics) == 0 {
+		fmt.Fprintln(os.Stdout, "No metrics found.")
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("METRIC", "VALUE")
+
+	for _, m := range metrics {
+		tbl.AddRow(output.FormatAnalyticsMetric(m)...)
+	}
+
+	return tbl.Flush()
+}
+
+// downloadURL saves a pre-signed export URL to path. Export URLs are not
+// Front API URLs, so they are fetched without the API client's credentials.
+func downloadURL(ctx context.Context, rawURL, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("download export: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download export: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download export: unexpected status %s", resp.Status)
+	}
+
+	f, err := os.Create(path) //nolint:gosec // Path is cleaned by config.ExpandPath
+	if err != nil {
+		return fmt.Errorf("create output file: %w", err)
+	}
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return f.Close()
+}
+
+func upperAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ToUpper(v)
+	}
+
+	return out
+}
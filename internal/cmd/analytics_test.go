@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestAnalyticsReportPollsUntilDone(t *testing.T) {
+	var created api.AnalyticsReportRequest
+	polls := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/analytics/reports":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+
+			_, _ = io.WriteString(w, `{"status":"running","progress":10,"_links":{"self":"https://api2.frontapp.com/analytics/reports/rep_1"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/analytics/reports/rep_1":
+			polls++
+			_, _ = io.WriteString(w, `{"status":"done","progress":100,"metrics":[{"id":"num_messages_received","type":"number","value":42}]}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	oldInterval := analyticsPollInterval
+	analyticsPollInterval = 0
+	t.Cleanup(func() { analyticsPollInterval = oldInterval })
+
+	cmd := AnalyticsReportCmd{
+		AnalyticsScope: AnalyticsScope{Start: "1700000000", End: "1700086400", Timezone: "UTC", Inbox: []string{"inb_1"}},
+		By:             "-",
+	}
+	flags := &RootFlags{JSON: true, Account: "test@example.com"}
+
+	if err := cmd.Run(flags); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if polls != 1 {
+		t.Fatalf("expected 1 poll, got %d", polls)
+	}
+
+	if created.Start != 1700000000 || created.End != 1700086400 || created.Timezone != "UTC" {
+		t.Fatalf("unexpected range: %+v", created)
+	}
+
+	if created.Filters == nil || len(created.Filters.InboxIDs) != 1 || created.Filters.InboxIDs[0] != "inb_1" {
+		t.Fatalf("unexpected filters: %+v", created.Filters)
+	}
+
+	if len(created.Metrics) != len(defaultAnalyticsMetrics) {
+		t.Fatalf("expected default metrics, got %v", created.Metrics)
+	}
+}
@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+var apiMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+type APICmd struct {
+	Args     []string `arg:"" help:"[METHOD] PATH, e.g. GET /conversations/cnv_xxx"`
+	Method   string   `help:"HTTP method (default GET, or POST when a body is given)" short:"X"`
+	Field    []string `help:"Body field key=value; true, false, null, numbers and @file are converted" short:"F" sep:"none"`
+	RawField []string `help:"Body field key=value, always sent as a string" short:"f" sep:"none"`
+	Input    string   `help:"Read the JSON request body from a file (- for stdin)"`
+	Paginate bool     `help:"Follow _pagination.next and merge all pages (GET only)"`
+}
+
+func (c *APICmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	method, path, err := c.target()
+	if err != nil {
+		return err
+	}
+
+	params, err := c.params()
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+
+	switch {
+	case c.Input != "":
+		if body, err = readAPIInput(c.Input); err != nil {
+			return err
+		}
+
+		if !json.Valid(body) {
+			return fmt.Errorf("--input %s is not valid JSON", c.Input)
+		}
+
+		// With an explicit body, fields become query parameters.
+		path, err = withQueryParams(path, params)
+	case method == http.MethodGet || method == http.MethodDelete:
+		path, err = withQueryParams(path, params)
+	case len(params) > 0:
+		body, err = json.Marshal(params)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if c.Paginate && method != http.MethodGet {
+		return fmt.Errorf("--paginate only applies to GET requests")
+	}
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	var result json.RawMessage
+
+	if c.Paginate {
+		result, err = paginateRaw(ctx, client, mode, path)
+	} else {
+		result, err = client.Raw(ctx, method, path, body)
+	}
+
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// target splits the positional arguments into a method and path. --method
+// wins when no method is given positionally; the two must agree otherwise.
+func (c *APICmd) target() (string, string, error) {
+	var method, path string
+
+	switch len(c.Args) {
+	case 1:
+		path = c.Args[0]
+	case 2:
+		method, path = strings.ToUpper(c.Args[0]), c.Args[1]
+	default:
+		return "", "", fmt.Errorf("expected [METHOD] PATH, got %d arguments", len(c.Args))
+	}
+
+	if c.Method != "" {
+		flagMethod := strings.ToUpper(c.Method)
+		if method != "" && method != flagMethod {
+			return "", "", fmt.Errorf("method %s conflicts with --method %s", method, c.Method)
+		}
+
+		method = flagMethod
+	}
+
+	if method == "" {
+		method = http.MethodGet
+		if c.Input != "" || len(c.Field) > 0 || len(c.RawField) > 0 {
+			method = http.MethodPost
+		}
+	}
+
+	if !slices.Contains(apiMethods, method) {
+		return "", "", fmt.Errorf("unsupported method %q (use %s)", method, strings.Join(apiMethods, ", "))
+	}
+
+	if !strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "https://") {
+		path = "/" + path
+	}
+
+	return method, path, nil
+}
+
+// params builds the request fields from --field and --raw-field. Keys may
+// nest with brackets: options[archive]=true, to[]=a@example.com.
+func (c *APICmd) params() (map[string]any, error) {
+	params := map[string]any{}
+
+	for _, f := range c.Field {
+		key, raw, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --field %q (want key=value)", f)
+		}
+
+		value, err := typedFieldValue(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := setParam(params, key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range c.RawField {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --raw-field %q (want key=value)", f)
+		}
+
+		if err := setParam(params, key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return params, nil
+}
+
+// typedFieldValue converts a --field value the way gh api does: literals
+// become JSON scalars and @path reads the value from a file (- for stdin).
+func typedFieldValue(raw string) (any, error) {
+	switch raw {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return n, nil
+	}
+
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f, nil
+	}
+
+	if name, ok := strings.CutPrefix(raw, "@"); ok {
+		data, err := readAPIInput(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return strings.TrimSuffix(string(data), "\n"), nil
+	}
+
+	return raw, nil
+}
+
+// setParam stores value at a bracketed key path such as a[b][] in params.
+func setParam(params map[string]any, key string, value any) error {
+	name, rest, _ := strings.Cut(key, "[")
+	if name == "" {
+		return fmt.Errorf("invalid field key %q", key)
+	}
+
+	var parts []string
+
+	for rest != "" {
+		part, after, ok := strings.Cut(rest, "]")
+		if !ok || (after != "" && !strings.HasPrefix(after, "[")) {
+			return fmt.Errorf("invalid field key %q", key)
+		}
+
+		parts = append(parts, part)
+		rest = strings.TrimPrefix(after, "[")
+	}
+
+	container := params
+	path := append([]string{name}, parts...)
+
+	for i, part := range path {
+		last := i == len(path)-1
+		nextIsArray := !last && path[i+1] == ""
+
+		switch {
+		case last:
+			container[part] = value
+		case nextIsArray:
+			if i+1 != len(path)-1 {
+				return fmt.Errorf("invalid field key %q: [] must come last", key)
+			}
+
+			list, _ := container[part].([]any)
+			container[part] = append(list, value)
+
+			return nil
+		default:
+			child, ok := container[part].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				container[part] = child
+			}
+
+			container = child
+		}
+	}
+
+	return nil
+}
+
+// withQueryParams appends params to path's query string. Nested fields are
+// flattened back to bracketed keys (q[statuses][]=open).
+func withQueryParams(path string, params map[string]any) (string, error) {
+	if len(params) == 0 {
+		return path, nil
+	}
+
+	base, rawQuery, _ := strings.Cut(path, "?")
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid query in %q: %w", path, err)
+	}
+
+	for key, value := range params {
+		addQueryParam(query, key, value)
+	}
+
+	return base + "?" + query.Encode(), nil
+}
+
+func addQueryParam(query url.Values, key string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for sub, item := range v {
+			addQueryParam(query, key+"["+sub+"]", item)
+		}
+	case []any:
+		for _, item := range v {
+			query.Add(key+"[]", fmt.Sprint(item))
+		}
+	case nil:
+		query.Add(key, "")
+	default:
+		query.Add(key, fmt.Sprint(v))
+	}
+}
+
+func readAPIInput(name string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+
+	return data, nil
+}
+
+// paginateRaw follows _pagination.next from path and merges every page's
+// _results. In NDJSON mode each page is written as it arrives and nil is
+// returned.
+func paginateRaw(ctx context.Context, client *api.Client, mode output.Mode, path string) (json.RawMessage, error) {
+	merged := api.ListResponse[json.RawMessage]{Results: []json.RawMessage{}}
+
+	_, err := listPages(ctx, client, path, PaginationFlags{All: true}, func(page *api.ListResponse[json.RawMessage]) error {
+		if mode.Streaming() {
+			if len(page.Results) == 0 {
+				return nil
+			}
+
+			return mode.Write(os.Stdout, page.Results)
+		}
+
+		merged.Results = append(merged.Results, page.Results...)
+
+		return nil
+	})
+	if err != nil || mode.Streaming() {
+		return nil, err
+	}
+
+	return json.Marshal(merged)
+}
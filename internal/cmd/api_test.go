@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAPICmdTarget(t *testing.T) {
+	tests := []struct {
+		cmd        APICmd
+		method     string
+		path       string
+		wantErrors bool
+	}{
+		{cmd: APICmd{Args: []string{"/me"}}, method: "GET", path: "/me"},
+		{cmd: APICmd{Args: []string{"delete", "conversations/cnv_1"}}, method: "DELETE", path: "/conversations/cnv_1"},
+		{cmd: APICmd{Args: []string{"/tags"}, Field: []string{"name=vip"}}, method: "POST", path: "/tags"},
+		{cmd: APICmd{Args: []string{"/tags"}, Method: "patch"}, method: "PATCH", path: "/tags"},
+		{cmd: APICmd{Args: []string{"GET", "/tags"}, Method: "POST"}, wantErrors: true},
+		{cmd: APICmd{Args: []string{"HEAD", "/tags"}}, wantErrors: true},
+		{cmd: APICmd{Args: []string{"GET", "/a", "/b"}}, wantErrors: true},
+	}
+
+	for _, tt := range tests {
+		method, path, err := tt.cmd.target()
+		if (err != nil) != tt.wantErrors {
+			t.Fatalf("target(%v) error = %v", tt.cmd.Args, err)
+		}
+
+		if !tt.wantErrors && (method != tt.method || path != tt.path) {
+			t.Fatalf("target(%v) = %s %s, want %s %s", tt.cmd.Args, method, path, tt.method, tt.path)
+		}
+	}
+}
+
+func TestAPICmdParams(t *testing.T) {
+	cmd := APICmd{
+		Field:    []string{"options[archive]=true", "limit=10", "to[]=a@example.com", "to[]=b@example.com", "note=null"},
+		RawField: []string{"subject=42", "body=a=b"},
+	}
+
+	got, err := cmd.params()
+	if err != nil {
+		t.Fatalf("params: %v", err)
+	}
+
+	want := map[string]any{
+		"options": map[string]any{"archive": true},
+		"limit":   int64(10),
+		"to":      []any{"a@example.com", "b@example.com"},
+		"note":    nil,
+		"subject": "42",
+		"body":    "a=b",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("params = %#v", got)
+	}
+
+	for _, bad := range []string{"novalue", "=x", "a[b=x", "a[]x=1", "a[][b]=1"} {
+		if _, err := (&APICmd{Field: []string{bad}}).params(); err == nil {
+			t.Fatalf("params(%q) should fail", bad)
+		}
+	}
+}
+
+func TestWithQueryParams(t *testing.T) {
+	got, err := withQueryParams("/conversations?limit=5", map[string]any{
+		"q": map[string]any{"statuses": []any{"open"}},
+	})
+	if err != nil {
+		t.Fatalf("withQueryParams: %v", err)
+	}
+
+	if want := "/conversations?limit=5&q%5Bstatuses%5D%5B%5D=open"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestAPICmdPostsFieldsAndPaginates(t *testing.T) {
+	var posted map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/tags":
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &posted)
+			_, _ = w.Write([]byte(`{"id":"tag_1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/tags" && r.URL.Query().Get("page_token") == "":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tag_1"}],"_pagination":{"next":"/tags?page_token=p2"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/tags":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tag_2"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	flags := &RootFlags{JSON: true, Account: "test@example.com"}
+
+	post := APICmd{Args: []string{"/tags"}, Field: []string{"name=vip", "highlight=null"}}
+	if err := post.Run(flags); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+
+	if posted["name"] != "vip" || posted["highlight"] != nil {
+		t.Fatalf("posted = %v", posted)
+	}
+
+	list := APICmd{Args: []string{"/tags"}, Paginate: true}
+	if err := list.Run(flags); err != nil {
+		t.Fatalf("paginate: %v", err)
+	}
+
+	if err := (&APICmd{Args: []string{"POST", "/tags"}, Paginate: true}).Run(flags); err == nil {
+		t.Fatal("--paginate with POST should fail")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"golang.org/x/term"
@@ -11,6 +12,7 @@ import (
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/auth"
 	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/log"
 )
 
 type AuthCmd struct {
@@ -19,6 +21,11 @@ type AuthCmd struct {
 	Logout AuthLogoutCmd `cmd:"" help:"Remove stored tokens"`
 	Status AuthStatusCmd `cmd:"" help:"Show authentication status"`
 	List   AuthListCmd   `cmd:"" help:"List authenticated accounts"`
+	Switch AuthSwitchCmd `cmd:"" help:"Set the default account"`
+	Token  AuthTokenCmd  `cmd:"" help:"Manage Front API tokens"`
+	Export AuthExportCmd `cmd:"" help:"Print an account's credentials for another machine"`
+	Import AuthImportCmd `cmd:"" help:"Store credentials printed by auth export"`
+	Run    AuthRunCmd    `cmd:"" help:"Run a read-only command for every authenticated account and merge the results"`
 }
 
 type AuthSetupCmd struct {
@@ -26,12 +33,14 @@ type AuthSetupCmd struct {
 	ClientSecret string `name:"client-secret" help:"OAuth client secret (for non-interactive use)"`
 	ClientName   string `help:"Client name (default: default)" default:"default" name:"client-name"`
 	RedirectURI  string `help:"OAuth redirect URI" default:"https://localhost:8484/callback"`
+	PKCE         bool   `name:"pkce" help:"Public client: authorize with PKCE only, without a client secret"`
+	APIBaseURL   string `name:"api-base-url" help:"API host for this client, e.g. for another Front region or a staging server"`
 }
 
 func (c *AuthSetupCmd) Run() error {
 	secret := c.ClientSecret
 
-	if secret == "" {
+	if secret == "" && !c.PKCE {
 		if term.IsTerminal(int(os.Stdin.Fd())) {
 			fmt.Print("Client Secret: ")
 
@@ -44,7 +53,7 @@ func (c *AuthSetupCmd) Run() error {
 
 			secret = string(bytes)
 		} else {
-			return fmt.Errorf("client secret required: use --client-secret flag or run interactively")
+			return fmt.Errorf("client secret required: use --client-secret, --pkce for a public client, or run interactively")
 		}
 	}
 
@@ -52,6 +61,7 @@ func (c *AuthSetupCmd) Run() error {
 		ClientID:     c.ClientID,
 		ClientSecret: secret,
 		RedirectURI:  c.RedirectURI,
+		APIBaseURL:   c.APIBaseURL,
 	}
 
 	if err := config.WriteClientCredentials(c.ClientName, creds); err != nil {
@@ -66,20 +76,22 @@ func (c *AuthSetupCmd) Run() error {
 }
 
 type AuthLoginCmd struct {
-	Email        string `help:"Email/identifier to associate with this token" name:"email"`
-	ClientName   string `help:"Client name" default:"default" name:"client-name"`
-	ForceConsent bool   `help:"Force consent prompt even if already authorized"`
-	Manual       bool   `help:"Manual authorization (paste URL instead of callback server)"`
+	Email        string   `help:"Email/identifier to associate with this token" name:"email"`
+	ClientName   string   `help:"Client name" default:"default" name:"client-name"`
+	ForceConsent bool     `help:"Force consent prompt even if already authorized"`
+	Manual       bool     `help:"Manual authorization (paste URL instead of callback server)"`
+	Scopes       []string `help:"OAuth scopes to request (default: everything the app allows)"`
 }
 
 func (c *AuthLoginCmd) Run(flags *RootFlags) error {
 	ctx := context.Background()
 
-	refreshToken, err := auth.Authorize(ctx, auth.AuthorizeOptions{
+	grant, err := auth.Authorize(ctx, auth.AuthorizeOptions{
 		Client:       c.ClientName,
 		ForceConsent: c.ForceConsent,
 		Manual:       c.Manual,
 		Timeout:      3 * time.Minute,
+		Scopes:       c.Scopes,
 	})
 	if err != nil {
 		return fmt.Errorf("authorization failed: %w", err)
@@ -93,12 +105,15 @@ func (c *AuthLoginCmd) Run(flags *RootFlags) error {
 	// Use email from flag or try to fetch from /me
 	email := c.Email
 	if email == "" && flags != nil && flags.Account != "" {
-		email = flags.Account
+		if email, err = config.ResolveAccount(flags.Account); err != nil {
+			return err
+		}
 	}
 
 	if email == "" {
 		// Fetch real email from /me endpoint
-		email, err = c.fetchEmail(ctx, refreshToken)
+		// Create a temporary token source with the refresh token
+		email, err = fetchEmail(ctx, api.NewClientWithBaseURL(auth.NewRefreshTokenSource(c.ClientName, grant.RefreshToken), apiBaseURL(c.ClientName)))
 		if err != nil {
 			// Don't fall back - require user to specify email
 			return fmt.Errorf("could not determine your identity: %w\nUse --email flag to specify your email", err)
@@ -107,7 +122,8 @@ func (c *AuthLoginCmd) Run(flags *RootFlags) error {
 
 	tok := auth.Token{
 		Email:        email,
-		RefreshToken: refreshToken,
+		Scopes:       grant.Scopes,
+		RefreshToken: grant.RefreshToken,
 		CreatedAt:    time.Now().UTC(),
 	}
 
@@ -117,14 +133,15 @@ func (c *AuthLoginCmd) Run(flags *RootFlags) error {
 
 	fmt.Fprintf(os.Stdout, "Successfully authenticated as %s\n", email)
 
+	if len(grant.Scopes) > 0 {
+		fmt.Fprintf(os.Stdout, "Granted scopes: %s\n", strings.Join(grant.Scopes, ", "))
+	}
+
 	return nil
 }
 
-func (c *AuthLoginCmd) fetchEmail(ctx context.Context, refreshToken string) (string, error) {
-	// Create a temporary token source with the refresh token
-	ts := auth.NewRefreshTokenSource(c.ClientName, refreshToken)
-	client := api.NewClient(ts)
-
+// fetchEmail identifies the account a freshly obtained token belongs to.
+func fetchEmail(ctx context.Context, client *api.Client) (string, error) {
 	// Try to get account info from /me
 	me, err := client.Me(ctx)
 	if err != nil {
@@ -148,6 +165,10 @@ func (c *AuthLoginCmd) fetchEmail(ctx context.Context, refreshToken string) (str
 		return "", fmt.Errorf("could not determine account identity")
 	}
 
+	if t := findTeammate(teammates.Results, me, ""); t != nil && t.Email != "" {
+		return t.Email, nil
+	}
+
 	if len(teammates.Results) == 1 {
 		// Single teammate account - use that email
 		return teammates.Results[0].Email, nil
@@ -177,12 +198,18 @@ type AuthLogoutCmd struct {
 	All        bool   `help:"Log out all accounts for this client"`
 }
 
-func (c *AuthLogoutCmd) Run() error {
+func (c *AuthLogoutCmd) Run(flags *RootFlags) error {
 	store, err := auth.OpenDefault()
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
 	}
 
+	if c.Email == "" && flags != nil && flags.Account != "" {
+		if c.Email, err = config.ResolveAccount(flags.Account); err != nil {
+			return err
+		}
+	}
+
 	if c.All {
 		tokens, err := store.ListTokens()
 		if err != nil {
@@ -199,7 +226,7 @@ func (c *AuthLogoutCmd) Run() error {
 		for _, tok := range tokens {
 			if tok.Client == normalizedClient {
 				if err := store.DeleteToken(tok.Client, tok.Email); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to remove token for %s: %v\n", tok.Email, err)
+					log.Warn("failed to remove token", "email", tok.Email, "err", err)
 				} else {
 					count++
 				}
@@ -257,20 +284,21 @@ type AuthStatusCmd struct {
 	ClientName string `help:"Client name" default:"default" name:"client-name"`
 }
 
-func (c *AuthStatusCmd) Run() error {
+func (c *AuthStatusCmd) Run(flags *RootFlags) error {
+	for _, env := range []string{auth.APITokenEnv, auth.RefreshTokenEnv} {
+		if os.Getenv(env) != "" {
+			fmt.Fprintf(os.Stdout, "Authenticated with a token from %s\n", env)
+
+			return nil
+		}
+	}
+
 	// Check if credentials exist
 	exists, err := config.ClientCredentialsExists(c.ClientName)
 	if err != nil {
 		return err
 	}
 
-	if !exists {
-		fmt.Fprintln(os.Stdout, "Not configured")
-		fmt.Fprintln(os.Stdout, "Run 'frontcli auth setup <client_id>' to configure.")
-
-		return nil
-	}
-
 	store, err := auth.OpenDefault()
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
@@ -294,6 +322,13 @@ func (c *AuthStatusCmd) Run() error {
 		}
 	}
 
+	if count == 0 && !exists {
+		fmt.Fprintln(os.Stdout, "Not configured")
+		fmt.Fprintln(os.Stdout, "Run 'frontcli auth setup <client_id>' to configure, or 'frontcli auth token set' to use an API token.")
+
+		return nil
+	}
+
 	if count == 0 {
 		fmt.Fprintln(os.Stdout, "OAuth credentials configured but not authenticated.")
 		fmt.Fprintln(os.Stdout, "Run 'frontcli auth login' to authenticate.")
@@ -303,9 +338,16 @@ func (c *AuthStatusCmd) Run() error {
 
 	fmt.Fprintf(os.Stdout, "Authenticated: %d account(s)\n", count)
 
+	active := activeAccount(flags)
+
 	for _, tok := range tokens {
 		if tok.Client == normalizedClient {
-			fmt.Fprintf(os.Stdout, "  - %s (since %s)\n", tok.Email, tok.CreatedAt.Format("2006-01-02"))
+			fmt.Fprintf(os.Stdout, "  - %s (%s, since %s)%s\n",
+				tok.Email, tokenKind(tok), tok.CreatedAt.Format("2006-01-02"), activeMarker(tok, active))
+
+			if len(tok.Scopes) > 0 {
+				fmt.Fprintf(os.Stdout, "    scopes: %s\n", strings.Join(tok.Scopes, ", "))
+			}
 		}
 	}
 
@@ -314,7 +356,7 @@ func (c *AuthStatusCmd) Run() error {
 
 type AuthListCmd struct{}
 
-func (c *AuthListCmd) Run() error {
+func (c *AuthListCmd) Run(flags *RootFlags) error {
 	store, err := auth.OpenDefault()
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
@@ -333,10 +375,120 @@ func (c *AuthListCmd) Run() error {
 
 	fmt.Fprintln(os.Stdout, "Authenticated accounts:")
 
+	active := activeAccount(flags)
+
 	for _, tok := range tokens {
-		fmt.Fprintf(os.Stdout, "  %s (client: %s, since %s)\n",
-			tok.Email, tok.Client, tok.CreatedAt.Format("2006-01-02"))
+		fmt.Fprintf(os.Stdout, "  %s (client: %s, %s, since %s)%s\n",
+			tok.Email, tok.Client, tokenKind(tok), tok.CreatedAt.Format("2006-01-02"), activeMarker(tok, active))
 	}
 
 	return nil
 }
+
+type AuthSwitchCmd struct {
+	Account string `arg:"" optional:"" help:"Account email or alias (prompts when omitted)"`
+}
+
+func (c *AuthSwitchCmd) Run() error {
+	store, err := auth.OpenDefault()
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return fmt.Errorf("list tokens: %w", err)
+	}
+
+	if len(tokens) == 0 {
+		return fmt.Errorf("no authenticated accounts; run 'frontcli auth login' first")
+	}
+
+	email := c.Account
+	if email == "" {
+		if email, err = promptAccount(tokens); err != nil {
+			return err
+		}
+	}
+
+	if email, err = config.ResolveAccount(email); err != nil {
+		return err
+	}
+
+	found := false
+
+	for _, tok := range tokens {
+		if tok.Email == email {
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("account %s is not authenticated; run 'frontcli auth login --email %s'", email, email)
+	}
+
+	if err := config.SetDefaultAccount(email); err != nil {
+		return fmt.Errorf("save default account: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "Default account: %s\n", email)
+
+	if env := os.Getenv("FRONT_ACCOUNT"); env != "" {
+		fmt.Fprintf(os.Stderr, "Note: FRONT_ACCOUNT=%s still takes precedence in this shell\n", env)
+	}
+
+	return nil
+}
+
+// promptAccount lists the stored accounts and reads a choice from stdin.
+func promptAccount(tokens []auth.Token) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("account required: pass an email or alias, or run interactively")
+	}
+
+	active := activeAccount(nil)
+
+	for i, tok := range tokens {
+		fmt.Fprintf(os.Stdout, "  %d) %s (client: %s)%s\n", i+1, tok.Email, tok.Client, activeMarker(tok, active))
+	}
+
+	fmt.Fprint(os.Stdout, "Switch to: ")
+
+	var choice int
+	if _, err := fmt.Fscanln(os.Stdin, &choice); err != nil || choice < 1 || choice > len(tokens) {
+		return "", fmt.Errorf("invalid choice; enter a number between 1 and %d", len(tokens))
+	}
+
+	return tokens[choice-1].Email, nil
+}
+
+// activeAccount returns the account commands currently run as, or "" when
+// none is selected explicitly.
+func activeAccount(flags *RootFlags) string {
+	var flagAccount string
+	if flags != nil {
+		flagAccount = flags.Account
+	}
+
+	email, _ := config.ResolveAccount(flagAccount)
+
+	return email
+}
+
+func tokenKind(tok auth.Token) string {
+	if tok.APIToken != "" {
+		return "api token"
+	}
+
+	return "oauth"
+}
+
+func activeMarker(tok auth.Token, active string) string {
+	if active != "" && tok.Email == active {
+		return " [active]"
+	}
+
+	return ""
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/log"
+)
+
+// exportPasswordEnv supplies the auth export/import password without a
+// prompt, for scripts.
+const exportPasswordEnv = "FRONT_EXPORT_PASSWORD" //nolint:gosec // env var name
+
+type AuthExportCmd struct {
+	Email      string `help:"Email/account to export (default: the active account)" name:"email"`
+	ClientName string `help:"Client name" default:"default" name:"client-name"`
+	Encrypt    bool   `help:"Encrypt the export with a password (prompted, or FRONT_EXPORT_PASSWORD)"`
+}
+
+func (c *AuthExportCmd) Run(flags *RootFlags) error {
+	email := c.Email
+	if email == "" {
+		email = activeAccount(flags)
+	}
+
+	if email == "" {
+		return fmt.Errorf("no account selected; specify --email")
+	}
+
+	store, err := auth.OpenDefault()
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+
+	tok, err := store.GetToken(c.ClientName, email)
+	if err != nil {
+		return fmt.Errorf("%s is not authenticated for client %s: %w", email, c.ClientName, err)
+	}
+
+	export := auth.Export{
+		Client:       tok.Client,
+		Email:        tok.Email,
+		RefreshToken: tok.RefreshToken,
+		APIToken:     tok.APIToken,
+		Scopes:       tok.Scopes,
+		CreatedAt:    tok.CreatedAt,
+	}
+
+	// A refresh token is useless without the OAuth client it was issued to.
+	if tok.RefreshToken != "" {
+		creds, err := config.ReadClientCredentials(c.ClientName)
+		if err != nil {
+			return err
+		}
+
+		export.Credentials = &creds
+	}
+
+	var password string
+
+	if c.Encrypt {
+		if password, err = readExportPassword(true); err != nil {
+			return err
+		}
+	} else {
+		log.Warn("the export is not encrypted; treat it like a password (use --encrypt to protect it)")
+	}
+
+	blob, err := auth.EncodeExport(export, password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, blob)
+
+	return nil
+}
+
+type AuthImportCmd struct {
+	File       string `arg:"" optional:"" help:"File holding the export (default: stdin)"`
+	ClientName string `help:"Store under this client name instead of the exported one" name:"client-name"`
+	Force      bool   `help:"Overwrite existing OAuth client credentials"`
+}
+
+func (c *AuthImportCmd) Run() error {
+	var (
+		data []byte
+		err  error
+	)
+
+	if c.File == "" || c.File == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(c.File)
+	}
+
+	if err != nil {
+		return fmt.Errorf("read export: %w", err)
+	}
+
+	blob := strings.TrimSpace(string(data))
+
+	var password string
+
+	if auth.IsEncryptedExport(blob) {
+		if password, err = readExportPassword(false); err != nil {
+			return err
+		}
+	}
+
+	export, err := auth.DecodeExport(blob, password)
+	if err != nil {
+		return err
+	}
+
+	clientName := export.Client
+	if c.ClientName != "" {
+		clientName = c.ClientName
+	}
+
+	if export.Credentials != nil {
+		exists, err := config.ClientCredentialsExists(clientName)
+		if err != nil {
+			return err
+		}
+
+		if !exists || c.Force {
+			if err := config.WriteClientCredentials(clientName, *export.Credentials); err != nil {
+				return fmt.Errorf("save credentials: %w", err)
+			}
+		}
+	}
+
+	store, err := auth.OpenDefault()
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+
+	if err := store.SetToken(clientName, export.Email, export.Token()); err != nil {
+		return fmt.Errorf("store token: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "Imported %s (client: %s)\n", export.Email, clientName)
+
+	return nil
+}
+
+// readExportPassword reads the export password from FRONT_EXPORT_PASSWORD or
+// a prompt. New passwords are asked for twice.
+func readExportPassword(confirm bool) (string, error) {
+	if password := os.Getenv(exportPasswordEnv); password != "" {
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("password required: set %s or run interactively", exportPasswordEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr) // newline after hidden input
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Confirm password: ")
+
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+
+		if string(again) != string(password) {
+			return "", fmt.Errorf("passwords do not match")
+		}
+	}
+
+	if len(password) == 0 {
+		return "", fmt.Errorf("password is empty")
+	}
+
+	return string(password), nil
+}
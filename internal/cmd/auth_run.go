@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type AuthRunCmd struct {
+	Parallel int      `help:"Accounts queried at once" default:"1"`
+	Args     []string `arg:"" passthrough:"" help:"Command to run for every account, after --"`
+}
+
+func (c *AuthRunCmd) Run() error {
+	args := c.Args
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		return errors.New("no command given; usage: frontcli auth run -- <command>")
+	}
+
+	return runAllAccounts(context.Background(), args, c.Parallel)
+}
+
+// allAccountsDenied are the commands that are not per-account reads: they
+// manage local state, prompt, or run until interrupted.
+var allAccountsDenied = []string{"auth", "config", "alias", "cache", "sync", "listen", "ui", "version"}
+
+// accountRun is one account's run of the command.
+type accountRun struct {
+	Account string
+	Stdout  []byte
+	Stderr  []byte
+	Err     error
+}
+
+// runAccount runs frontcli with args as one stored account, with env added
+// to the environment. Tests replace it.
+var runAccount = func(ctx context.Context, tok auth.Token, args, env []string) accountRun {
+	run := accountRun{Account: tok.Email}
+
+	exe, err := os.Executable()
+	if err != nil {
+		run.Err = err
+
+		return run
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, exe, append([]string{"--account", tok.Email, "--client", tok.Client}, args...)...) //nolint:gosec // re-runs this binary
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	run.Err = cmd.Run()
+	run.Stdout, run.Stderr = stdout.Bytes(), stderr.Bytes()
+
+	return run
+}
+
+// runAllAccounts runs a read-only command once per authenticated account and
+// merges the results, marking each with its account. Requests that would
+// change data are refused in every run.
+func runAllAccounts(ctx context.Context, args []string, parallel int) error {
+	parser, cli, err := newParser()
+	if err != nil {
+		return err
+	}
+
+	kctx, err := parser.Parse(args)
+	if err != nil {
+		return wrapParseError(err)
+	}
+
+	if command := strings.Fields(kctx.Command()); len(command) > 0 && slices.Contains(allAccountsDenied, command[0]) {
+		return fmt.Errorf("%s cannot run across accounts", strings.Join(command, " "))
+	}
+
+	if cli.RootFlags.Account != "" {
+		return errors.New("--account cannot be combined with running across all accounts")
+	}
+
+	mode, err := resolveOutputMode(&cli.RootFlags)
+	if err != nil {
+		return err
+	}
+
+	store, err := auth.OpenDefault()
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return fmt.Errorf("list tokens: %w", err)
+	}
+
+	if len(tokens) == 0 {
+		return errors.New("no authenticated accounts; run 'frontcli auth login' first")
+	}
+
+	env := []string{readOnlyEnv + "=1"}
+
+	tabular := mode.Template == "" && (mode.Format == output.FormatTable || mode.Format == output.FormatTSV)
+	if tabular {
+		// Tab-separated rows are split and realigned with an account column.
+		env = append(env, "FRONT_OUTPUT="+output.FormatTSV)
+	}
+
+	runs := make([]accountRun, len(tokens))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(parallel, 1))
+
+	for i, tok := range tokens {
+		g.Go(func() error {
+			runs[i] = runAccount(gctx, tok, args, env)
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	failed := 0
+
+	for _, run := range runs {
+		for line := range strings.Lines(string(run.Stderr)) {
+			fmt.Fprintf(os.Stderr, "[%s] %s\n", run.Account, strings.TrimRight(line, "\n"))
+		}
+
+		if run.Err != nil {
+			failed++
+
+			fmt.Fprintf(os.Stderr, "[%s] failed: %v\n", run.Account, run.Err)
+		}
+	}
+
+	switch {
+	case mode.Streaming():
+		err = mergeNDJSON(runs)
+	case mode.Format == output.FormatJSON:
+		err = mergeJSON(runs)
+	case tabular:
+		err = mergeTables(runs, mode.Plain || mode.Format == output.FormatTSV)
+	default:
+		err = writeSections(runs)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("command failed for %d of %d accounts", failed, len(runs))
+	}
+
+	return nil
+}
+
+// mergeNDJSON adds an account field to every object line.
+func mergeNDJSON(runs []accountRun) error {
+	w := bufio.NewWriter(os.Stdout)
+
+	for _, run := range runs {
+		for line := range strings.Lines(string(run.Stdout)) {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			var record map[string]any
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				record = map[string]any{"result": json.RawMessage(line)}
+			}
+
+			record["account"] = run.Account
+
+			data, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+
+			_, _ = w.Write(append(data, '\n'))
+		}
+	}
+
+	return w.Flush()
+}
+
+// mergeJSON writes one entry per account with its result or error.
+func mergeJSON(runs []accountRun) error {
+	results := make([]map[string]any, 0, len(runs))
+
+	for _, run := range runs {
+		entry := map[string]any{"account": run.Account}
+
+		switch out := bytes.TrimSpace(run.Stdout); {
+		case run.Err != nil:
+			entry["error"] = run.Err.Error()
+		case json.Valid(out):
+			entry["result"] = json.RawMessage(out)
+		default:
+			entry["result"] = string(out)
+		}
+
+		results = append(results, entry)
+	}
+
+	return output.WriteJSON(os.Stdout, results)
+}
+
+// mergeTables joins the tab-separated tables of every account under one
+// header with a leading ACCOUNT column. Output that is not a table, such as
+// "No conversations found.", is shown per account instead.
+func mergeTables(runs []accountRun, plain bool) error {
+	var (
+		header []string
+		rows   [][]string
+		others []accountRun
+	)
+
+	for _, run := range runs {
+		lines := strings.Split(strings.TrimRight(string(run.Stdout), "\n"), "\n")
+		if len(lines) == 0 || !strings.Contains(lines[0], "\t") {
+			if strings.TrimSpace(string(run.Stdout)) != "" {
+				others = append(others, run)
+			}
+
+			continue
+		}
+
+		if header == nil {
+			header = append([]string{"ACCOUNT"}, strings.Split(lines[0], "\t")...)
+		}
+
+		for _, line := range lines[1:] {
+			rows = append(rows, append([]string{run.Account}, strings.Split(line, "\t")...))
+		}
+	}
+
+	if header == nil {
+		return writeSections(others)
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, plain)
+	tbl.AddRow(header...)
+
+	for _, row := range rows {
+		tbl.AddRow(row...)
+	}
+
+	if err := tbl.Flush(); err != nil {
+		return err
+	}
+
+	for _, run := range others {
+		fmt.Fprintf(os.Stderr, "[%s] %s\n", run.Account, strings.TrimSpace(string(run.Stdout)))
+	}
+
+	return nil
+}
+
+// writeSections prints each account's output under its own heading.
+func writeSections(runs []accountRun) error {
+	for i, run := range runs {
+		if i > 0 {
+			fmt.Fprintln(os.Stdout)
+		}
+
+		fmt.Fprintf(os.Stdout, "== %s ==\n%s", run.Account, run.Stdout)
+
+		if len(run.Stdout) > 0 && !bytes.HasSuffix(run.Stdout, []byte("\n")) {
+			fmt.Fprintln(os.Stdout)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/auth"
+)
+
+// stubAccountRuns answers each account's run from outputs and records the
+// arguments and environment the runs got.
+func stubAccountRuns(t *testing.T, outputs map[string]string) (args, env *[]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	args, env = new([]string), new([]string)
+
+	old := runAccount
+	runAccount = func(_ context.Context, tok auth.Token, a, e []string) accountRun {
+		mu.Lock()
+		*args, *env = a, e
+		mu.Unlock()
+
+		out, ok := outputs[tok.Email]
+		if !ok {
+			return accountRun{Account: tok.Email, Stderr: []byte("not authenticated\n"), Err: errors.New("exit status 3")}
+		}
+
+		return accountRun{Account: tok.Email, Stdout: []byte(out)}
+	}
+
+	t.Cleanup(func() { runAccount = old })
+
+	return args, env
+}
+
+func TestAllAccountsMergesTables(t *testing.T) {
+	useTestKeyring(t, "a@example.com", "b@example.com")
+
+	args, env := stubAccountRuns(t, map[string]string{
+		"a@example.com": "ID\tSUBJECT\ncnv_1\tHello\n",
+		"b@example.com": "ID\tSUBJECT\ncnv_2\tRefund\ncnv_3\tBug\n",
+	})
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--plain", "--all-accounts", "conv", "list"})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	want := "ACCOUNT\tID\tSUBJECT\na@example.com\tcnv_1\tHello\nb@example.com\tcnv_2\tRefund\nb@example.com\tcnv_3\tBug\n"
+	if out != want {
+		t.Fatalf("output:\n%s\nwant:\n%s", out, want)
+	}
+
+	if strings.Join(*args, " ") != "--plain conv list" || !slices.Contains(*env, readOnlyEnv+"=1") {
+		t.Fatalf("args %v, env %v", *args, *env)
+	}
+}
+
+func TestAllAccountsDropsFlagWithValue(t *testing.T) {
+	useTestKeyring(t, "a@example.com")
+
+	args, _ := stubAccountRuns(t, map[string]string{"a@example.com": "ID\ncnv_1\n"})
+
+	if _, err := captureStdout(t, func() error {
+		return Execute([]string{"--all-accounts=true", "--plain", "conv", "list"})
+	}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if strings.Join(*args, " ") != "--plain conv list" {
+		t.Fatalf("args %v", *args)
+	}
+}
+
+func TestAllAccountsPrefixesRateLimit(t *testing.T) {
+	useTestKeyring(t, "a@example.com", "b@example.com")
+
+	old := runAccount
+	runAccount = func(_ context.Context, tok auth.Token, args, _ []string) accountRun {
+		run := accountRun{Account: tok.Email, Stdout: []byte("ID\n")}
+		if slices.Contains(args, "--show-rate-limit") {
+			run.Stderr = []byte("Rate limit: 97/100 remaining\n")
+		}
+
+		return run
+	}
+
+	t.Cleanup(func() { runAccount = old })
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+
+	oldStderr := os.Stderr
+	os.Stderr = w
+
+	_, err = captureStdout(t, func() error {
+		return Execute([]string{"--all-accounts", "--show-rate-limit", "--plain", "conv", "list"})
+	})
+
+	os.Stderr = oldStderr
+	_ = w.Close()
+
+	stderr, _ := io.ReadAll(r)
+	_ = r.Close()
+
+	want := "[a@example.com] Rate limit: 97/100 remaining\n[b@example.com] Rate limit: 97/100 remaining\n"
+	if err != nil || string(stderr) != want {
+		t.Fatalf("err %v, stderr %q", err, stderr)
+	}
+}
+
+func TestAuthRunMergesJSONAndReportsFailures(t *testing.T) {
+	useTestKeyring(t, "a@example.com", "b@example.com")
+
+	stubAccountRuns(t, map[string]string{"a@example.com": `{"_results":[{"id":"cnv_1"}]}`})
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"auth", "run", "--parallel", "2", "--", "--json", "conv", "list"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 accounts") {
+		t.Fatalf("err = %v, want one failed account", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("decode %s: %v", out, err)
+	}
+
+	if len(got) != 2 || got[0]["account"] != "a@example.com" || got[0]["result"] == nil || got[1]["error"] == nil {
+		t.Fatalf("merged = %v", got)
+	}
+}
+
+func TestAuthRunRefusesLocalCommands(t *testing.T) {
+	useTestKeyring(t, "a@example.com")
+
+	stubAccountRuns(t, nil)
+
+	err := Execute([]string{"auth", "run", "--", "config", "path"})
+	if err == nil || !strings.Contains(err.Error(), "cannot run across accounts") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+func useTestKeyring(t *testing.T, emails ...string) {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("FRONT_ACCOUNT", "")
+	t.Setenv(auth.APITokenEnv, "")
+	t.Setenv(auth.RefreshTokenEnv, "")
+	t.Setenv("FRONT_TOKEN_STORE", "")
+	t.Setenv("FRONT_KEYRING_BACKEND", "file")
+	t.Setenv("FRONT_KEYRING_PASSWORD", "test")
+
+	auth.ResetDefaultStore()
+	t.Cleanup(auth.ResetDefaultStore)
+
+	store, err := auth.OpenDefault()
+	if err != nil {
+		t.Fatalf("open keyring: %v", err)
+	}
+
+	for _, email := range emails {
+		if err := store.SetToken("default", email, auth.Token{Email: email, RefreshToken: "refresh"}); err != nil {
+			t.Fatalf("store token: %v", err)
+		}
+	}
+}
+
+func TestAuthSwitchSetsDefaultAccount(t *testing.T) {
+	useTestKeyring(t, "me@work.com", "me@home.com")
+
+	if err := config.SetAccountAlias("home", "me@home.com"); err != nil {
+		t.Fatalf("set alias: %v", err)
+	}
+
+	if err := (&AuthSwitchCmd{Account: "home"}).Run(); err != nil {
+		t.Fatalf("switch: %v", err)
+	}
+
+	email, clientName, err := resolveAccount(&RootFlags{})
+	if err != nil || email != "me@home.com" || clientName != "default" {
+		t.Fatalf("resolveAccount = %q, %q, %v", email, clientName, err)
+	}
+
+	// --account still overrides the default for a single command.
+	if email, _, _ := resolveAccount(&RootFlags{Account: "me@work.com"}); email != "me@work.com" {
+		t.Fatalf("--account resolved to %q", email)
+	}
+
+	if err := (&AuthSwitchCmd{Account: "other@example.com"}).Run(); err == nil {
+		t.Fatal("switching to an account without a token should fail")
+	}
+}
+
+func TestAuthTokenSetStoresAPIToken(t *testing.T) {
+	useTestKeyring(t)
+
+	cmd := AuthTokenSetCmd{Token: " secret-token\n", Email: "bot@example.com", ClientName: "default"}
+	if err := cmd.Run(&RootFlags{}); err != nil {
+		t.Fatalf("token set: %v", err)
+	}
+
+	store, _ := auth.OpenDefault()
+
+	tok, err := store.GetToken("default", "bot@example.com")
+	if err != nil || tok.APIToken != "secret-token" || tok.RefreshToken != "" {
+		t.Fatalf("stored token = %+v, %v", tok, err)
+	}
+
+	if email, _, err := resolveAccount(&RootFlags{}); err != nil || email != "bot@example.com" {
+		t.Fatalf("resolveAccount = %q, %v", email, err)
+	}
+}
+
+func TestResolveAccountWithAPITokenEnv(t *testing.T) {
+	useTestKeyring(t)
+	t.Setenv(auth.APITokenEnv, "env-token")
+
+	email, clientName, err := resolveAccount(&RootFlags{})
+	if err != nil || email != envTokenAccount || clientName != "default" {
+		t.Fatalf("resolveAccount = %q, %q, %v", email, clientName, err)
+	}
+}
+
+func TestAuthImportStoresEncryptedExport(t *testing.T) {
+	useTestKeyring(t)
+	t.Setenv(exportPasswordEnv, "hunter2")
+
+	blob, err := auth.EncodeExport(auth.Export{
+		Client:       "default",
+		Email:        "ci@example.com",
+		RefreshToken: "refresh-ci",
+		Credentials:  &config.OAuthCredentials{ClientID: "cid", ClientSecret: "secret"},
+	}, "hunter2")
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "export.txt")
+	if err := os.WriteFile(path, []byte(blob+"\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if err := (&AuthImportCmd{File: path}).Run(); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	store, _ := auth.OpenDefault()
+
+	tok, err := store.GetToken("default", "ci@example.com")
+	if err != nil || tok.RefreshToken != "refresh-ci" {
+		t.Fatalf("stored token = %+v, %v", tok, err)
+	}
+
+	creds, err := config.ReadClientCredentials("default")
+	if err != nil || creds.ClientID != "cid" || creds.ClientSecret != "secret" {
+		t.Fatalf("credentials = %+v, %v", creds, err)
+	}
+
+	t.Setenv(exportPasswordEnv, "wrong")
+
+	if err := (&AuthImportCmd{File: path}).Run(); err == nil {
+		t.Fatal("import with the wrong password should fail")
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+type AuthTokenCmd struct {
+	Set AuthTokenSetCmd `cmd:"" help:"Store a Front API token in the keyring"`
+}
+
+type AuthTokenSetCmd struct {
+	Token      string `arg:"" optional:"" help:"API token (prompted, or read from stdin, when omitted)"`
+	Email      string `help:"Email/identifier to associate with this token" name:"email"`
+	ClientName string `help:"Client name" default:"default" name:"client-name"`
+}
+
+func (c *AuthTokenSetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	token, err := c.readToken()
+	if err != nil {
+		return err
+	}
+
+	email := c.Email
+	if email == "" && flags != nil && flags.Account != "" {
+		if email, err = config.ResolveAccount(flags.Account); err != nil {
+			return err
+		}
+	}
+
+	if email == "" {
+		// Identifying the account also checks that the token works.
+		email, err = fetchEmail(ctx, api.NewClientWithBaseURL(auth.NewAPITokenSource(token), apiBaseURL(c.ClientName)))
+		if err != nil {
+			return fmt.Errorf("could not determine your identity: %w\nUse --email flag to specify your email", err)
+		}
+	}
+
+	store, err := auth.OpenDefault()
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+
+	tok := auth.Token{
+		Email:     email,
+		APIToken:  token,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := store.SetToken(c.ClientName, email, tok); err != nil {
+		return fmt.Errorf("store token: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "API token stored for %s\n", email)
+
+	return nil
+}
+
+func (c *AuthTokenSetCmd) readToken() (string, error) {
+	token := c.Token
+
+	if token == "" {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			fmt.Print("API token: ")
+
+			bytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println() // newline after hidden input
+
+			if err != nil {
+				return "", fmt.Errorf("failed to read token: %w", err)
+			}
+
+			token = string(bytes)
+		} else {
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && line == "" {
+				return "", fmt.Errorf("read token from stdin: %w", err)
+			}
+
+			token = line
+		}
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("API token is empty")
+	}
+
+	return token, nil
+}
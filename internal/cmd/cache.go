@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+type CacheCmd struct {
+	Clear CacheClearCmd `cmd:"" help:"Delete all cached API responses"`
+}
+
+type CacheClearCmd struct{}
+
+func (c *CacheClearCmd) Run() error {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("clear cache: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "Cache cleared: %s\n", dir)
+
+	return nil
+}
@@ -4,17 +4,30 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
 	"github.com/dedene/frontapp-cli/internal/output"
 )
 
 type ChannelCmd struct {
-	List ChannelListCmd `cmd:"" help:"List channels"`
-	Get  ChannelGetCmd  `cmd:"" help:"Get a channel"`
+	List     ChannelListCmd     `cmd:"" help:"List channels"`
+	Get      ChannelGetCmd      `cmd:"" help:"Get a channel"`
+	Create   ChannelCreateCmd   `cmd:"" help:"Create a channel in an inbox"`
+	Update   ChannelUpdateCmd   `cmd:"" help:"Update a channel"`
+	Validate ChannelValidateCmd `cmd:"" help:"Validate a channel and report whether it works"`
+	Receive  ChannelReceiveCmd  `cmd:"" help:"Post an inbound message to a custom channel"`
 }
 
-type ChannelListCmd struct{}
+// channelValidatePollInterval is how often channels validate re-reads the
+// channel while Front validates it; a var so tests can poll without sleeping.
+var channelValidatePollInterval = 2 * time.Second
+
+type ChannelListCmd struct {
+	PaginationFlags `embed:""`
+}
 
 func (c *ChannelListCmd) Run(flags *RootFlags) error {
 	ctx := context.Background()
@@ -29,31 +42,19 @@ func (c *ChannelListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListChannels(ctx)
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Channel]{
+		Path:    "/channels",
+		Empty:   "No channels found.",
+		Headers: []string{"ID", "TYPE", "NAME", "ADDRESS"},
+		Row:     output.FormatChannel,
+	})
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No channels found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "TYPE", "NAME", "ADDRESS")
-
-	for _, ch := range resp.Results {
-		tbl.AddRow(output.FormatChannel(ch)...)
-	}
-
-	return tbl.Flush()
+	return nil
 }
 
 type ChannelGetCmd struct {
@@ -81,7 +82,7 @@ func (c *ChannelGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, ch)
+		return mode.Write(os.Stdout, ch)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:      %s\n", ch.ID)
@@ -92,3 +93,198 @@ func (c *ChannelGetCmd) Run(flags *RootFlags) error {
 
 	return nil
 }
+
+type ChannelCreateCmd struct {
+	Inbox      string   `required:"" help:"Inbox to create the channel in (ID or name)"`
+	Type       string   `help:"Channel type" enum:"custom,smtp,imap,twilio,twitter,facebook,smooch,intercom,truly,front_chat,front_mail" default:"custom"`
+	Name       string   `help:"Channel name"`
+	SendAs     string   `help:"Address messages are sent as"`
+	WebhookURL string   `help:"URL Front posts outbound messages of a custom channel to" name:"webhook-url"`
+	Setting    []string `help:"Channel setting as key=value; repeatable" sep:"none"`
+}
+
+func (c *ChannelCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	settings, err := channelSettings(c.Setting, c.WebhookURL)
+	if err != nil {
+		return err
+	}
+
+	inboxID, err := client.ResolveInbox(ctx, c.Inbox)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	req := map[string]any{"type": c.Type}
+
+	if c.Name != "" {
+		req["name"] = c.Name
+	}
+
+	if c.SendAs != "" {
+		req["send_as"] = c.SendAs
+	}
+
+	if len(settings) > 0 {
+		req["settings"] = settings
+	}
+
+	var result api.Channel
+	if err := client.Post(ctx, fmt.Sprintf("/inboxes/%s/channels", inboxID), req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Channel created: %s\n", result.ID)
+
+	return nil
+}
+
+type ChannelUpdateCmd struct {
+	ID         string   `arg:"" help:"Channel ID"`
+	Name       string   `help:"New name"`
+	WebhookURL string   `help:"New webhook URL of a custom channel" name:"webhook-url"`
+	Setting    []string `help:"Channel setting to change as key=value; repeatable" sep:"none"`
+}
+
+func (c *ChannelUpdateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	settings, err := channelSettings(c.Setting, c.WebhookURL)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{}
+
+	if c.Name != "" {
+		req["name"] = c.Name
+	}
+
+	if len(settings) > 0 {
+		req["settings"] = settings
+	}
+
+	if len(req) == 0 {
+		return fmt.Errorf("no updates specified")
+	}
+
+	if err := client.Patch(ctx, "/channels/"+c.ID, req, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Channel updated: %s\n", c.ID)
+
+	return nil
+}
+
+// channelSettings builds a channel's settings object from key=value flags,
+// with --webhook-url as a shorthand for webhook_url.
+func channelSettings(raw []string, webhookURL string) (map[string]string, error) {
+	settings := map[string]string{}
+
+	for _, kv := range raw {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid setting %q (want key=value)", kv)
+		}
+
+		settings[strings.TrimSpace(key)] = value
+	}
+
+	if webhookURL != "" {
+		settings["webhook_url"] = webhookURL
+	}
+
+	return settings, nil
+}
+
+type ChannelValidateCmd struct {
+	ID   string        `arg:"" help:"Channel ID"`
+	Wait time.Duration `help:"How long to wait for the channel to become valid" default:"30s"`
+}
+
+func (c *ChannelValidateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Post(ctx, fmt.Sprintf("/channels/%s/validate", c.ID), nil, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	// Front validates asynchronously, so re-read the channel until it reports
+	// valid or the wait runs out.
+	deadline := time.Now().Add(c.Wait)
+
+	var ch *api.Channel
+
+	for {
+		if ch, err = client.GetChannel(ctx, c.ID); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		if ch.IsValid || !time.Now().Before(deadline) {
+			break
+		}
+
+		select {
+		case <-interruptCtx.Done():
+			return context.Cause(interruptCtx)
+		case <-time.After(min(channelValidatePollInterval, time.Until(deadline))):
+		}
+	}
+
+	if mode.JSON {
+		if err := mode.Write(os.Stdout, ch); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(os.Stdout, "ID:    %s\n", ch.ID)
+		fmt.Fprintf(os.Stdout, "Type:  %s\n", ch.Type)
+		fmt.Fprintf(os.Stdout, "Valid: %v\n", ch.IsValid)
+	}
+
+	if !ch.IsValid {
+		return fmt.Errorf("channel %s is not valid", c.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+)
+
+type ChannelReceiveCmd struct {
+	ID         string   `arg:"" help:"Custom channel ID"`
+	Sender     string   `required:"" help:"Sender handle"`
+	SenderName string   `help:"Sender display name"`
+	Subject    string   `help:"Message subject"`
+	Body       string   `help:"Message body"`
+	BodyFile   string   `help:"Read body from file (- for stdin)" type:"existingfile"`
+	Markdown   bool     `help:"Body is Markdown rather than HTML"`
+	Metadata   []string `help:"Message metadata as key=value (e.g. thread_ref=order-42); repeatable" sep:"none"`
+}
+
+func (c *ChannelReceiveCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	if body == "" {
+		return fmt.Errorf("body is required (use --body or --body-file)")
+	}
+
+	id, err := api.SanitizeID(c.ID)
+	if err != nil {
+		return fmt.Errorf("invalid channel ID %q: %w", c.ID, err)
+	}
+
+	metadata := map[string]string{}
+
+	for _, raw := range c.Metadata {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid metadata %q (want key=value)", raw)
+		}
+
+		metadata[strings.TrimSpace(key)] = value
+	}
+
+	sender := map[string]any{"handle": c.Sender}
+	if c.SenderName != "" {
+		sender["name"] = c.SenderName
+	}
+
+	req := map[string]any{
+		"sender": sender,
+		"body":   body,
+	}
+
+	if c.Subject != "" {
+		req["subject"] = c.Subject
+	}
+
+	if c.Markdown {
+		req["body_format"] = "markdown"
+	}
+
+	if len(metadata) > 0 {
+		req["metadata"] = metadata
+	}
+
+	var accepted api.MessageAccepted
+	if err := client.Post(ctx, fmt.Sprintf("/channels/%s/incoming_messages", id), req, &accepted); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, accepted)
+	}
+
+	fmt.Fprintf(os.Stdout, "Message received: %s\n", accepted.MessageUID)
+
+	return nil
+}
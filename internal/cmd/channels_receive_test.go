@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChannelReceivePostsIncomingMessage(t *testing.T) {
+	var sent map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/channels/cha_1/incoming_messages" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &sent)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted","message_uid":"uid_1"}`))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	flags := &RootFlags{JSON: true, Account: "test@example.com"}
+
+	cmd := ChannelReceiveCmd{
+		ID:       "cha_1",
+		Sender:   "user-42",
+		Body:     "Where is my order?",
+		Metadata: []string{"thread_ref=order-42", "note=a=b"},
+	}
+	if err := cmd.Run(flags); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	sender, _ := sent["sender"].(map[string]any)
+	metadata, _ := sent["metadata"].(map[string]any)
+
+	if sender["handle"] != "user-42" || metadata["thread_ref"] != "order-42" || metadata["note"] != "a=b" {
+		t.Fatalf("sent = %v", sent)
+	}
+
+	bad := ChannelReceiveCmd{ID: "cha_1", Sender: "x", Body: "y", Metadata: []string{"novalue"}}
+	if err := bad.Run(flags); err == nil {
+		t.Fatal("metadata without = should fail")
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChannelCreateSendsTypeAndSettings(t *testing.T) {
+	var gotPath string
+	var sent map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"_results":[{"id":"inb_1","name":"Billing"}]}`)
+
+			return
+		}
+
+		gotPath = r.URL.Path
+
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		_, _ = io.WriteString(w, `{"id":"cha_1","type":"custom"}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{
+			"--account", "test@example.com", "channels", "create", "--inbox", "Billing", "--name", "Orders",
+			"--webhook-url", "https://example.com/hook", "--setting", "note=a,b",
+		})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if gotPath != "/inboxes/inb_1/channels" || !strings.Contains(out, "cha_1") {
+		t.Fatalf("path %s, output %q", gotPath, out)
+	}
+
+	settings, _ := sent["settings"].(map[string]any)
+	if sent["type"] != "custom" || sent["name"] != "Orders" || settings["webhook_url"] != "https://example.com/hook" || settings["note"] != "a,b" {
+		t.Fatalf("body = %v", sent)
+	}
+}
+
+func TestChannelValidateWaitsUntilValid(t *testing.T) {
+	old := channelValidatePollInterval
+	channelValidatePollInterval = 0
+
+	t.Cleanup(func() { channelValidatePollInterval = old })
+
+	var requests []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+
+			return
+		}
+
+		// Front validates asynchronously: the first read is still invalid.
+		if len(requests) == 2 {
+			_, _ = io.WriteString(w, `{"id":"cha_1","type":"smtp"}`)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"id":"cha_1","type":"smtp","is_valid":true}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "channels", "validate", "cha_1"})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	want := "POST /channels/cha_1/validate,GET /channels/cha_1,GET /channels/cha_1"
+	if strings.Join(requests, ",") != want || !strings.Contains(out, "Valid: true") {
+		t.Fatalf("requests %v, output %q", requests, out)
+	}
+}
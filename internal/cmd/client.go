@@ -1,6 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/auth"
 	"github.com/dedene/frontapp-cli/internal/config"
@@ -8,30 +17,351 @@ import (
 
 var newClientFromAuth = api.NewClientFromAuth
 
+// envTokenAccount stands in for the account when FRONT_API_TOKEN or
+// FRONT_REFRESH_TOKEN is used without --account; it keys the response cache.
+const envTokenAccount = "env-token"
+
+// baseURLEnv points the client at another API host, such as frontcli-mock.
+const baseURLEnv = "FRONT_API_BASE_URL"
+
+// readOnlyEnv makes every client refuse requests that modify data; auth run
+// sets it for the commands it runs per account.
+const readOnlyEnv = "FRONT_READ_ONLY"
+
+// vcrEnv selects record or replay mode like --record and --replay, as
+// record:<file> or replay:<file>.
+const vcrEnv = "FRONT_VCR"
+
 // getClient creates an API client using stored auth credentials.
 func getClient(flags *RootFlags) (*api.Client, error) {
-	email, err := config.ResolveAccount(flags.Account)
+	vcrPath, vcrMode, vcr, err := resolveVCR(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		client *api.Client
+		email  string
+	)
+
+	replay := vcr && vcrMode == api.VCRReplay
+
+	if replay {
+		// Replayed responses need no credentials.
+		client = api.NewClient(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "replay"}))
+	} else {
+		var clientName string
+
+		email, clientName, err = resolveAccount(flags)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err = newClientFromAuth(clientName, email)
+		if err != nil {
+			return nil, err
+		}
+
+		client.SetBaseURL(apiBaseURL(clientName))
+	}
+
+	client.SetReadOnly(os.Getenv(readOnlyEnv) != "")
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := resolveRetryPolicy(flags, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetRetryPolicy(policy)
+
+	throttle, err := resolveThrottle(flags, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetThrottle(throttle)
+
+	breaker, err := resolveCircuitBreaker(flags, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetCircuitBreaker(breaker)
+
+	timeout, err := resolveTimeout(flags, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	clientName := flags.Client
-	if clientName == "" {
-		clientName = "default"
+	client.SetTimeout(timeout)
+	client.SetContext(interruptCtx)
+
+	if vcr {
+		client.EnableVCR(vcrPath, vcrMode)
+	}
+
+	if flags.Verbose > 0 {
+		client.EnableTracing(os.Stderr, flags.Verbose > 1)
+	}
+
+	lastClient = client
+
+	// Replayed calls change nothing, so there is nothing to audit, and no
+	// account to cache for.
+	if replay {
+		return client, nil
+	}
+
+	historyPath, err := config.HistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetAuditLog(&api.AuditLog{Path: historyPath, Account: email, Command: runningCommand})
+
+	// A cache hit would record a 304 instead of the response.
+	if !flags.NoCache && !vcr {
+		dir, err := config.CacheDir()
+		if err != nil {
+			return nil, err
+		}
+
+		// Cached responses are per account: the same URL returns different
+		// data for different users.
+		client.SetCache(api.NewResponseCache(filepath.Join(dir, email)))
+	}
+
+	return client, nil
+}
+
+// apiBaseURL is the API host for an OAuth client: FRONT_API_BASE_URL, else
+// the api_base_url saved by auth setup. Empty means the public API.
+func apiBaseURL(clientName string) string {
+	if baseURL := os.Getenv(baseURLEnv); baseURL != "" {
+		return baseURL
+	}
+
+	creds, err := config.ReadClientCredentials(clientName)
+	if err != nil {
+		// API tokens need no saved client.
+		return ""
+	}
+
+	return creds.APIBaseURL
+}
+
+// resolveAccount picks the account and OAuth client a command runs as:
+// --account (or FRONT_ACCOUNT, then the configured default, with aliases
+// expanded), falling back to the first stored token for --client.
+func resolveAccount(flags *RootFlags) (string, string, error) {
+	email, err := config.ResolveAccount(flags.Account)
+	if err != nil {
+		return "", "", err
+	}
+
+	if email == "" && (os.Getenv(auth.APITokenEnv) != "" || os.Getenv(auth.RefreshTokenEnv) != "") {
+		// Tokens from the environment need no stored account.
+		clientName, err := config.NormalizeClientNameOrDefault(flags.Client)
+
+		return envTokenAccount, clientName, err
 	}
 
 	if email == "" {
 		// Try to get email from stored tokens
-		email, err = auth.GetAuthenticatedEmail(clientName)
+		email, err = auth.GetAuthenticatedEmail(flags.Client)
 		if err != nil {
-			return nil, &api.AuthError{Err: err}
+			return "", "", &api.AuthError{Err: err}
 		}
 	}
 
-	clientName, err = config.ResolveClientForAccount(email, flags.Client)
+	clientName, err := config.ResolveClientForAccount(email, flags.Client)
 	if err != nil {
-		return nil, err
+		return "", "", err
+	}
+
+	return email, clientName, nil
+}
+
+// resolveVCR returns the cassette and mode from --record, --replay or
+// FRONT_VCR; ok is false when neither is set.
+func resolveVCR(flags *RootFlags) (path string, mode api.VCRMode, ok bool, err error) {
+	switch {
+	case flags.Record != "":
+		return flags.Record, api.VCRRecord, true, nil
+	case flags.Replay != "":
+		return flags.Replay, api.VCRReplay, true, nil
+	}
+
+	value := os.Getenv(vcrEnv)
+	if value == "" {
+		return "", 0, false, nil
+	}
+
+	name, path, found := strings.Cut(value, ":")
+	if !found || path == "" {
+		return "", 0, false, fmt.Errorf("invalid %s %q (use record:<file> or replay:<file>)", vcrEnv, value)
+	}
+
+	switch name {
+	case "record":
+		return path, api.VCRRecord, true, nil
+	case "replay":
+		return path, api.VCRReplay, true, nil
+	default:
+		return "", 0, false, fmt.Errorf("invalid %s mode %q (use record or replay)", vcrEnv, name)
+	}
+}
+
+// resolveRetryPolicy applies max_retries, retry_base_delay and retry_budget
+// from cfg, then the --max-retries, --retry-base-delay, --retry-budget and
+// --no-retry flags.
+func resolveRetryPolicy(flags *RootFlags, cfg config.File) (api.RetryPolicy, error) {
+	var err error
+
+	policy := api.DefaultRetryPolicy()
+
+	maxRetries := cfg.MaxRetries
+	if flags.MaxRetries != nil {
+		maxRetries = flags.MaxRetries
+	}
+
+	if maxRetries != nil {
+		if *maxRetries < 0 {
+			return policy, fmt.Errorf("max retries must not be negative")
+		}
+
+		policy.MaxRateLimitRetries = *maxRetries
+		policy.MaxServerErrorRetries = *maxRetries
+		policy.MaxNetworkRetries = *maxRetries
+	}
+
+	if cfg.RetryBaseDelay != "" {
+		if policy.BaseDelay, err = time.ParseDuration(cfg.RetryBaseDelay); err != nil {
+			return policy, fmt.Errorf("invalid retry_base_delay in config: %w", err)
+		}
+	}
+
+	if flags.RetryBaseDelay != nil {
+		policy.BaseDelay = *flags.RetryBaseDelay
+	}
+
+	if policy.BaseDelay < 0 {
+		return policy, fmt.Errorf("retry base delay must not be negative")
+	}
+
+	if cfg.RetryBudget != "" {
+		if policy.Budget, err = time.ParseDuration(cfg.RetryBudget); err != nil {
+			return policy, fmt.Errorf("invalid retry_budget in config: %w", err)
+		}
+	}
+
+	if flags.RetryBudget != nil {
+		policy.Budget = *flags.RetryBudget
+	}
+
+	if policy.Budget < 0 {
+		return policy, fmt.Errorf("retry budget must not be negative")
+	}
+
+	if flags.NoRetry {
+		policy.MaxRateLimitRetries = 0
+		policy.MaxServerErrorRetries = 0
+		policy.MaxNetworkRetries = 0
+	}
+
+	return policy, nil
+}
+
+// resolveAll maps each name in values to an ID with resolve (one of the
+// client's Resolve* methods).
+func resolveAll(ctx context.Context, values []string, resolve func(context.Context, string) (string, error)) ([]string, error) {
+	ids := make([]string, len(values))
+
+	for i, v := range values {
+		id, err := resolve(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// resolveThrottle returns the throttle strategy from --throttle, else the
+// config file, else balanced.
+func resolveThrottle(flags *RootFlags, cfg config.File) (api.ThrottleStrategy, error) {
+	name := flags.Throttle
+	if name == "" {
+		name = cfg.Throttle
+	}
+
+	if name == "" {
+		return api.ThrottleBalanced, nil
+	}
+
+	return api.ParseThrottleStrategy(strings.ToLower(name))
+}
+
+// resolveCircuitBreaker returns the breaker tuned by the config file, or nil
+// with --no-circuit-breaker.
+func resolveCircuitBreaker(flags *RootFlags, cfg config.File) (*api.CircuitBreaker, error) {
+	if flags.NoCircuitBreaker {
+		return nil, nil
+	}
+
+	breaker := api.NewCircuitBreaker()
+
+	if cfg.CircuitBreakerThreshold != nil {
+		if *cfg.CircuitBreakerThreshold < 1 {
+			return nil, fmt.Errorf("circuit_breaker_threshold must be at least 1")
+		}
+
+		breaker.Threshold = *cfg.CircuitBreakerThreshold
+	}
+
+	if cfg.CircuitBreakerCooldown != "" {
+		cooldown, err := time.ParseDuration(cfg.CircuitBreakerCooldown)
+		if err != nil {
+			return nil, fmt.Errorf("invalid circuit_breaker_cooldown in config: %w", err)
+		}
+
+		// An open breaker would close again at once.
+		if cooldown <= 0 {
+			return nil, fmt.Errorf("circuit_breaker_cooldown must be positive")
+		}
+
+		breaker.Cooldown = cooldown
+	}
+
+	return breaker, nil
+}
+
+// resolveTimeout is --timeout, else request_timeout from the config file;
+// zero means no limit.
+func resolveTimeout(flags *RootFlags, cfg config.File) (time.Duration, error) {
+	if flags.Timeout != nil {
+		if *flags.Timeout < 0 {
+			return 0, fmt.Errorf("timeout must not be negative")
+		}
+
+		return *flags.Timeout, nil
+	}
+
+	if cfg.RequestTimeout == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(cfg.RequestTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid request_timeout in config: %w", err)
 	}
 
-	return newClientFromAuth(clientName, email)
+	return timeout, nil
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+func TestResolveRetryPolicy(t *testing.T) {
+	five := 5
+	cfg := config.File{MaxRetries: &five, RetryBaseDelay: "250ms"}
+
+	policy, err := resolveRetryPolicy(&RootFlags{}, cfg)
+	if err != nil || policy.MaxRateLimitRetries != 5 || policy.MaxServerErrorRetries != 5 || policy.BaseDelay != 250*time.Millisecond {
+		t.Fatalf("from config: %+v, %v", policy, err)
+	}
+
+	two, delay := 2, time.Second
+
+	policy, err = resolveRetryPolicy(&RootFlags{RetryFlags: RetryFlags{MaxRetries: &two, RetryBaseDelay: &delay}}, cfg)
+	if err != nil || policy.MaxRateLimitRetries != 2 || policy.BaseDelay != time.Second {
+		t.Fatalf("flags over config: %+v, %v", policy, err)
+	}
+
+	policy, err = resolveRetryPolicy(&RootFlags{RetryFlags: RetryFlags{NoRetry: true}}, cfg)
+	if err != nil || policy.MaxRateLimitRetries != 0 || policy.MaxServerErrorRetries != 0 || policy.MaxNetworkRetries != 0 {
+		t.Fatalf("--no-retry: %+v, %v", policy, err)
+	}
+}
+
+func TestResolveCircuitBreakerRejectsZeroCooldown(t *testing.T) {
+	breaker, err := resolveCircuitBreaker(&RootFlags{}, config.File{CircuitBreakerCooldown: "2m"})
+	if err != nil || breaker.Cooldown != 2*time.Minute {
+		t.Fatalf("from config: %+v, %v", breaker, err)
+	}
+
+	for _, cooldown := range []string{"0s", "-5s"} {
+		if _, err := resolveCircuitBreaker(&RootFlags{}, config.File{CircuitBreakerCooldown: cooldown}); err == nil {
+			t.Errorf("cooldown %s accepted", cooldown)
+		}
+	}
+}
+
+func TestAPIBaseURLFromClientCredentials(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv(baseURLEnv, "")
+
+	creds := config.OAuthCredentials{ClientID: "id", ClientSecret: "secret", APIBaseURL: "https://eu.example.com"}
+	if err := config.WriteClientCredentials("eu", creds); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := apiBaseURL("eu"); got != "https://eu.example.com" {
+		t.Fatalf("saved client: %q", got)
+	}
+
+	if got := apiBaseURL("missing"); got != "" {
+		t.Fatalf("unknown client: %q", got)
+	}
+
+	t.Setenv(baseURLEnv, "http://127.0.0.1:8089")
+
+	if got := apiBaseURL("eu"); got != "http://127.0.0.1:8089" {
+		t.Fatalf("environment over saved client: %q", got)
+	}
+}
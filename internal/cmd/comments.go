@@ -4,19 +4,25 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/log"
 	"github.com/dedene/frontapp-cli/internal/output"
 )
 
 type CommentCmd struct {
-	List   CommentListCmd   `cmd:"" help:"List comments in a conversation"`
-	Get    CommentGetCmd    `cmd:"" help:"Get a comment"`
-	Create CommentCreateCmd `cmd:"" help:"Create a comment"`
+	List     CommentListCmd     `cmd:"" help:"List comments in a conversation"`
+	Get      CommentGetCmd      `cmd:"" help:"Get a comment"`
+	Create   CommentCreateCmd   `cmd:"" help:"Create a comment"`
+	Mentions CommentMentionsCmd `cmd:"" help:"List the teammates a comment mentions"`
 }
 
 type CommentListCmd struct {
+	PaginationFlags `embed:""`
+
 	ConvID string `arg:"" help:"Conversation ID"`
 }
 
@@ -33,54 +39,27 @@ func (c *CommentListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.Comment]
-	if err := client.Get(ctx, fmt.Sprintf("/conversations/%s/comments", c.ConvID), &resp); err != nil {
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Comment]{
+		Path:    fmt.Sprintf("/conversations/%s/comments", c.ConvID),
+		Empty:   "No comments found.",
+		Headers: []string{"ID", "AUTHOR", "BODY", "DATE"},
+		Row:     output.FormatComment,
+	})
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No comments found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "AUTHOR", "BODY", "DATE")
-
-	for _, comment := range resp.Results {
-		author := "-"
-		if comment.Author != nil {
-			author = comment.Author.Email
-			if author == "" {
-				author = comment.Author.Username
-			}
-		}
-
-		body := comment.Body
-		if len(body) > 50 {
-			body = body[:47] + "..."
-		}
-
-		tbl.AddRow(
-			comment.ID,
-			author,
-			body,
-			output.FormatTimestamp(comment.PostedAt),
-		)
-	}
-
-	return tbl.Flush()
+	return nil
 }
 
 type CommentCreateCmd struct {
-	ConvID string `arg:"" help:"Conversation ID"`
-	Body   string `required:"" help:"Comment body (@mentions supported)"`
+	ConvID   string   `arg:"" help:"Conversation ID"`
+	Body     string   `help:"Comment body (@mentions supported)"`
+	BodyFile string   `help:"Read body from file (- for stdin)" type:"existingfile"`
+	Edit     bool     `help:"Write the comment in $EDITOR (starting from --body or --body-file, if given)"`
+	Attach   []string `help:"Attach a file; repeatable" type:"existingfile" sep:"none"`
 }
 
 func (c *CommentCreateCmd) Run(flags *RootFlags) error {
@@ -96,19 +75,55 @@ func (c *CommentCreateCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	if c.Edit {
+		if body, err = editText("frontcli-comment-*.txt", body); err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(body) == "" {
+			fmt.Fprintln(os.Stderr, "Empty comment; nothing posted.")
+
+			return nil
+		}
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("body is required (use --body, --body-file or --edit)")
+	}
+
+	body, err = expandMentions(ctx, client, strings.TrimRight(body, "\n"))
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
 	req := map[string]string{
-		"body": c.Body,
+		"body": body,
 	}
 
+	path := fmt.Sprintf("/conversations/%s/comments", c.ConvID)
+
 	var result api.Comment
-	if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/comments", c.ConvID), req, &result); err != nil {
+	if len(c.Attach) > 0 {
+		err = client.PostMultipart(ctx, path, req, c.Attach, &result)
+	} else {
+		err = client.Post(ctx, path, req, &result)
+	}
+
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Comment created: %s\n", result.ID)
@@ -116,6 +131,70 @@ func (c *CommentCreateCmd) Run(flags *RootFlags) error {
 	return nil
 }
 
+// mentionPattern matches @mentions that are not part of an email address.
+var mentionPattern = regexp.MustCompile(`(^|[^\w@.])@(\w[\w.+-]*(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+
+// expandMentions rewrites @mentions of a teammate's email, email local part
+// or unique first name to the @username Front recognizes, and warns about
+// mentions that match no teammate. Teammates are only fetched when the body
+// mentions someone.
+func expandMentions(ctx context.Context, client *api.Client, body string) (string, error) {
+	if !mentionPattern.MatchString(body) {
+		return body, nil
+	}
+
+	teammates, err := client.Teammates(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return mentionPattern.ReplaceAllStringFunc(body, func(match string) string {
+		m := mentionPattern.FindStringSubmatch(match)
+		prefix, word := m[1], strings.TrimRight(m[2], ".-")
+		rest := m[2][len(word):]
+
+		username := mentionUsername(word, teammates)
+		if username == "" {
+			log.Warn("mention does not match any teammate", "mention", "@"+word)
+
+			return match
+		}
+
+		return prefix + "@" + username + rest
+	}), nil
+}
+
+func mentionUsername(word string, teammates []api.Teammate) string {
+	for _, tm := range teammates {
+		if tm.Username != "" && strings.EqualFold(tm.Username, word) {
+			return tm.Username
+		}
+	}
+
+	for _, tm := range teammates {
+		local, _, _ := strings.Cut(tm.Email, "@")
+		if tm.Username != "" && (strings.EqualFold(tm.Email, word) || strings.EqualFold(local, word)) {
+			return tm.Username
+		}
+	}
+
+	var match string
+
+	for _, tm := range teammates {
+		if tm.Username == "" || !strings.EqualFold(tm.FirstName, word) {
+			continue
+		}
+
+		if match != "" {
+			return "" // ambiguous
+		}
+
+		match = tm.Username
+	}
+
+	return match
+}
+
 type CommentGetCmd struct {
 	ID string `arg:"" help:"Comment ID"`
 }
@@ -141,7 +220,7 @@ func (c *CommentGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, comment)
+		return mode.Write(os.Stdout, comment)
 	}
 
 	author := "-"
@@ -160,3 +239,37 @@ func (c *CommentGetCmd) Run(flags *RootFlags) error {
 
 	return nil
 }
+
+type CommentMentionsCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Comment ID"`
+}
+
+func (c *CommentMentionsCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Teammate]{
+		Path:    fmt.Sprintf("/comments/%s/mentions", c.ID),
+		Empty:   "No mentions.",
+		Headers: []string{"ID", "EMAIL", "NAME"},
+		Row:     output.FormatTeammate,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newCommentServer accepts comments on cnv_1 and stores the posted body and
+// attachment names; it knows two teammates for mention lookups.
+func newCommentServer(t *testing.T, body *string, attachments *[]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/teammates":
+			_, _ = w.Write([]byte(`{"_results":[
+				{"id":"tea_1","email":"alice.archer@example.com","username":"alice","first_name":"Alice"},
+				{"id":"tea_2","email":"bob@example.com","username":"bbaker","first_name":"Bob"}
+			]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/conversations/cnv_1/comments":
+			if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+				if err := r.ParseMultipartForm(1 << 20); err != nil {
+					t.Errorf("parse form: %v", err)
+				}
+
+				*body = r.FormValue("body")
+
+				for name, files := range r.MultipartForm.File {
+					*attachments = append(*attachments, name+"="+files[0].Filename)
+				}
+			} else {
+				var req map[string]string
+
+				data, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(data, &req)
+				*body = req["body"]
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"com_1"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCommentCreateEdit(t *testing.T) {
+	var sent string
+
+	srv := newCommentServer(t, &sent, nil)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	var initial string
+
+	oldEditor := runEditor
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		initial = string(data)
+
+		return os.WriteFile(path, []byte(initial+"\n\nSecond paragraph.\n"), 0o600)
+	}
+
+	t.Cleanup(func() { runEditor = oldEditor })
+
+	cmd := CommentCreateCmd{ConvID: "cnv_1", Body: "@alice can you check?", Edit: true}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if initial != "@alice can you check?" {
+		t.Fatalf("editor started with %q", initial)
+	}
+
+	if sent != "@alice can you check?\n\nSecond paragraph." {
+		t.Fatalf("comment body = %q", sent)
+	}
+}
+
+func TestCommentCreateResolvesMentionsAndAttaches(t *testing.T) {
+	var (
+		sent        string
+		attachments []string
+	)
+
+	srv := newCommentServer(t, &sent, &attachments)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	file := filepath.Join(t.TempDir(), "trace.log")
+	if err := os.WriteFile(file, []byte("stack trace"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := CommentCreateCmd{
+		ConvID: "cnv_1",
+		Body:   "@Bob and @alice.archer, see the log. Ping @bob@example.com or @nobody. Mail ops@example.com.",
+		Attach: []string{file},
+	}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "@bbaker and @alice, see the log. Ping @bbaker or @nobody. Mail ops@example.com."
+	if sent != want {
+		t.Fatalf("comment body = %q, want %q", sent, want)
+	}
+
+	if len(attachments) != 1 || attachments[0] != "attachments[0]=trace.log" {
+		t.Fatalf("attachments = %v", attachments)
+	}
+}
+
+func TestConvGetFullNestsCommentReplies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/conversations/cnv_1":
+			_, _ = w.Write([]byte(`{"id":"cnv_1","subject":"Crash"}`))
+		case "/conversations/cnv_1/messages":
+			_, _ = w.Write([]byte(`{"_results":[]}`))
+		case "/conversations/cnv_1/comments":
+			_, _ = w.Write([]byte(`{"_results":[
+				{"id":"com_reply","body":"On it","posted_at":30,"_links":{"related":{"parent":"https://api2.frontapp.com/comments/com_root"}}},
+				{"id":"com_other","body":"Unrelated","posted_at":20},
+				{"id":"com_root","body":"Who takes this?","posted_at":10}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	os.Stdout = w
+
+	cmd := ConvGetCmd{ID: "cnv_1", Full: true}
+	runErr := cmd.Run(&RootFlags{Account: "test@example.com"})
+
+	os.Stdout = oldStdout
+	_ = w.Close()
+
+	out, _ := io.ReadAll(r)
+	_ = r.Close()
+
+	if runErr != nil {
+		t.Fatalf("Run: %v", runErr)
+	}
+
+	root := strings.Index(string(out), "[comment:com_root]")
+	reply := strings.Index(string(out), "    ↳ -")
+	other := strings.Index(string(out), "[comment:com_other]")
+
+	if root < 0 || reply < root || other < reply || !strings.Contains(string(out), "    On it") {
+		t.Fatalf("reply not nested under its parent:\n%s", out)
+	}
+}
@@ -1,10 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/auth"
 )
 
+// completionTimeout bounds the API lookups behind dynamic completions so a
+// slow network never stalls the shell.
+const completionTimeout = 3 * time.Second
+
 type CompletionCmd struct {
 	Bash CompletionBashCmd `cmd:"" help:"Generate bash completions"`
 	Zsh  CompletionZshCmd  `cmd:"" help:"Generate zsh completions"`
@@ -15,15 +28,15 @@ type CompletionBashCmd struct{}
 
 func (c *CompletionBashCmd) Run() error {
 	script := `_frontcli_completions() {
+    local IFS=$'\n'
     local cur="${COMP_WORDS[COMP_CWORD]}"
-    local commands="version config auth conversations messages drafts tags inboxes teammates contacts channels comments templates completion whoami"
+    local candidates
+    candidates=($(frontcli __complete -- "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null | cut -f1))
 
-    if [ $COMP_CWORD -eq 1 ]; then
-        COMPREPLY=($(compgen -W "$commands" -- "$cur"))
-    fi
+    COMPREPLY=($(compgen -W "${candidates[*]}" -- "$cur"))
 }
 
-complete -F _frontcli_completions frontcli
+complete -o default -F _frontcli_completions frontcli
 `
 	fmt.Fprint(os.Stdout, script)
 
@@ -36,34 +49,14 @@ func (c *CompletionZshCmd) Run() error {
 	script := `#compdef frontcli
 
 _frontcli() {
-    local -a commands
-    commands=(
-        'version:Print version'
-        'config:Manage configuration'
-        'auth:Authentication and credentials'
-        'conversations:Conversations'
-        'messages:Messages'
-        'drafts:Drafts'
-        'tags:Tags'
-        'inboxes:Inboxes'
-        'teammates:Teammates'
-        'contacts:Contacts'
-        'channels:Channels'
-        'comments:Comments'
-        'templates:Templates'
-        'completion:Generate shell completions'
-        'whoami:Show authenticated user info'
-    )
-
-    _arguments \
-        '1: :->command' \
-        '*::arg:->args'
-
-    case $state in
-        command)
-            _describe 'command' commands
-            ;;
-    esac
+    local -a candidates
+    local line
+    for line in "${(@f)$(frontcli __complete -- "${words[@]:1:$((CURRENT-1))}" 2>/dev/null)}"; do
+        [[ -z "$line" ]] && continue
+        candidates+=("${${line%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+    done
+
+    _describe 'frontcli' candidates
 }
 
 compdef _frontcli frontcli
@@ -76,25 +69,300 @@ compdef _frontcli frontcli
 type CompletionFishCmd struct{}
 
 func (c *CompletionFishCmd) Run() error {
-	script := `complete -c frontcli -f
-
-complete -c frontcli -n '__fish_use_subcommand' -a 'version' -d 'Print version'
-complete -c frontcli -n '__fish_use_subcommand' -a 'config' -d 'Manage configuration'
-complete -c frontcli -n '__fish_use_subcommand' -a 'auth' -d 'Authentication and credentials'
-complete -c frontcli -n '__fish_use_subcommand' -a 'conversations' -d 'Conversations'
-complete -c frontcli -n '__fish_use_subcommand' -a 'messages' -d 'Messages'
-complete -c frontcli -n '__fish_use_subcommand' -a 'drafts' -d 'Drafts'
-complete -c frontcli -n '__fish_use_subcommand' -a 'tags' -d 'Tags'
-complete -c frontcli -n '__fish_use_subcommand' -a 'inboxes' -d 'Inboxes'
-complete -c frontcli -n '__fish_use_subcommand' -a 'teammates' -d 'Teammates'
-complete -c frontcli -n '__fish_use_subcommand' -a 'contacts' -d 'Contacts'
-complete -c frontcli -n '__fish_use_subcommand' -a 'channels' -d 'Channels'
-complete -c frontcli -n '__fish_use_subcommand' -a 'comments' -d 'Comments'
-complete -c frontcli -n '__fish_use_subcommand' -a 'templates' -d 'Templates'
-complete -c frontcli -n '__fish_use_subcommand' -a 'completion' -d 'Generate shell completions'
-complete -c frontcli -n '__fish_use_subcommand' -a 'whoami' -d 'Show authenticated user info'
+	script := `complete -c frontcli -f -a '(frontcli __complete -- (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)'
 `
 	fmt.Fprint(os.Stdout, script)
 
 	return nil
 }
+
+// CompleteCmd backs the shell completion scripts: given the words typed so
+// far (the last one possibly empty), it prints one candidate per line as
+// "value<TAB>description".
+type CompleteCmd struct {
+	Words []string `arg:"" optional:"" passthrough:"" help:"Command line words after frontcli"`
+}
+
+// completion is a candidate value and its description.
+type completion struct {
+	Value string
+	Help  string
+}
+
+func (c *CompleteCmd) Run(kctx *kong.Context, flags *RootFlags) error {
+	// The scripts pass "--" first so kong leaves words like "--inbox" alone.
+	words := c.Words
+	if len(words) > 0 && words[0] == "--" {
+		words = words[1:]
+	}
+
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	lookup := func(kind string) []completion {
+		return completeResource(flags, kind, words)
+	}
+
+	for _, cand := range completeWords(kctx.Model.Node, words, lookup) {
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", cand.Value, cand.Help)
+	}
+
+	return nil
+}
+
+// completeWords completes the last of words against the command tree:
+// subcommands, flags, enum values, and (through lookup) live resource
+// names for flags such as --inbox and --tag.
+func completeWords(root *kong.Node, words []string, lookup func(kind string) []completion) []completion {
+	node := root
+	cur := words[len(words)-1]
+
+	var pending *kong.Flag // flag still waiting for its value
+
+	for _, word := range words[:len(words)-1] {
+		if pending != nil {
+			pending = nil
+
+			continue
+		}
+
+		if word == "--" {
+			break
+		}
+
+		if strings.HasPrefix(word, "-") {
+			if f := findFlag(node, word); f != nil && !strings.Contains(word, "=") && takesValue(f) {
+				pending = f
+			}
+
+			continue
+		}
+
+		if child := findChild(node, word); child != nil {
+			node = child
+		}
+	}
+
+	if pending != nil {
+		return filterPrefix(flagValues(pending, lookup), cur)
+	}
+
+	if name, value, ok := strings.Cut(cur, "="); ok && strings.HasPrefix(name, "--") {
+		if f := findFlag(node, name); f != nil {
+			var out []completion
+			for _, cand := range filterPrefix(flagValues(f, lookup), value) {
+				out = append(out, completion{Value: name + "=" + cand.Value, Help: cand.Help})
+			}
+
+			return out
+		}
+	}
+
+	if strings.HasPrefix(cur, "-") {
+		return filterPrefix(nodeFlags(node), cur)
+	}
+
+	var out []completion
+
+	for _, child := range node.Children {
+		if child.Hidden || child.Type != kong.CommandNode {
+			continue
+		}
+
+		out = append(out, completion{Value: child.Name, Help: child.Help})
+	}
+
+	return filterPrefix(out, cur)
+}
+
+func findChild(node *kong.Node, name string) *kong.Node {
+	for _, child := range node.Children {
+		if child.Type == kong.CommandNode && (child.Name == name || slices.Contains(child.Aliases, name)) {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// findFlag finds the flag named by word ("--inbox", "--inbox=x" or "-v") on
+// node or any of its parents.
+func findFlag(node *kong.Node, word string) *kong.Flag {
+	name, _, _ := strings.Cut(word, "=")
+
+	for n := node; n != nil; n = n.Parent {
+		for _, f := range n.Flags {
+			switch {
+			case strings.HasPrefix(name, "--"):
+				long := strings.TrimPrefix(name, "--")
+				if f.Name == long || slices.Contains(f.Aliases, long) {
+					return f
+				}
+			case len(name) == 2 && f.Short != 0 && rune(name[1]) == f.Short:
+				return f
+			}
+		}
+	}
+
+	return nil
+}
+
+func takesValue(f *kong.Flag) bool {
+	return !f.IsBool() && !f.IsCounter()
+}
+
+// nodeFlags lists the flags usable at node, its own first.
+func nodeFlags(node *kong.Node) []completion {
+	var out []completion
+
+	for n := node; n != nil; n = n.Parent {
+		for _, f := range n.Flags {
+			if f.Hidden {
+				continue
+			}
+
+			out = append(out, completion{Value: "--" + f.Name, Help: f.Help})
+		}
+	}
+
+	return out
+}
+
+// flagValues lists the values a flag accepts: its enum, or live resource
+// names for flags that take one.
+func flagValues(f *kong.Flag, lookup func(kind string) []completion) []completion {
+	if f.Enum != "" {
+		var out []completion
+		for _, v := range strings.Split(f.Enum, ",") {
+			out = append(out, completion{Value: strings.TrimSpace(v)})
+		}
+
+		return out
+	}
+
+	switch f.Name {
+	case "inbox":
+		return lookup("inbox")
+	case "tag":
+		return lookup("tag")
+	case "assignee", "teammate":
+		return append([]completion{{Value: "me", Help: "the authenticated teammate"}}, lookup("teammate")...)
+	case "team":
+		return lookup("team")
+	case "account":
+		return lookup("account")
+	}
+
+	return nil
+}
+
+func filterPrefix(cands []completion, prefix string) []completion {
+	var out []completion
+
+	for _, cand := range cands {
+		if strings.HasPrefix(cand.Value, prefix) {
+			out = append(out, cand)
+		}
+	}
+
+	return out
+}
+
+// completeResource fetches completion candidates of kind for the account
+// named in words, if any. Failures (not logged in, offline) yield nothing.
+func completeResource(flags *RootFlags, kind string, words []string) []completion {
+	if kind == "account" {
+		return storedAccounts()
+	}
+
+	accountFlags := *flags
+	if account := accountFromWords(words); account != "" {
+		accountFlags.Account = account
+	}
+
+	client, err := getClient(&accountFlags)
+	if err != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	var out []completion
+
+	switch kind {
+	case "inbox":
+		resp, err := client.ListInboxes(ctx)
+		if err != nil {
+			return nil
+		}
+
+		for _, inbox := range resp.Results {
+			out = append(out, completion{Value: inbox.Name, Help: inbox.ID})
+		}
+	case "tag":
+		resp, err := client.ListTags(ctx)
+		if err != nil {
+			return nil
+		}
+
+		for _, tag := range resp.Results {
+			out = append(out, completion{Value: tag.Name, Help: tag.ID})
+		}
+	case "teammate":
+		resp, err := client.ListTeammates(ctx)
+		if err != nil {
+			return nil
+		}
+
+		for _, tm := range resp.Results {
+			out = append(out, completion{Value: tm.Email, Help: strings.TrimSpace(tm.FirstName + " " + tm.LastName)})
+		}
+	case "team":
+		var resp api.ListResponse[api.Team]
+		if err := client.Get(ctx, "/teams", &resp); err != nil {
+			return nil
+		}
+
+		for _, team := range resp.Results {
+			out = append(out, completion{Value: team.Name, Help: team.ID})
+		}
+	}
+
+	return out
+}
+
+// accountFromWords returns the --account value typed on the command line.
+func accountFromWords(words []string) string {
+	for i, word := range words {
+		if value, ok := strings.CutPrefix(word, "--account="); ok {
+			return value
+		}
+
+		if word == "--account" && i+1 < len(words)-1 {
+			return words[i+1]
+		}
+	}
+
+	return ""
+}
+
+func storedAccounts() []completion {
+	store, err := auth.OpenDefault()
+	if err != nil {
+		return nil
+	}
+
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return nil
+	}
+
+	var out []completion
+	for _, tok := range tokens {
+		out = append(out, completion{Value: tok.Email, Help: tok.Client})
+	}
+
+	return out
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func completionValues(t *testing.T, words ...string) []string {
+	t.Helper()
+
+	parser, _, err := newParser()
+	if err != nil {
+		t.Fatalf("newParser: %v", err)
+	}
+
+	lookup := func(kind string) []completion {
+		return completeResource(&RootFlags{Account: "test@example.com"}, kind, words)
+	}
+
+	var values []string
+	for _, cand := range completeWords(parser.Model.Node, words, lookup) {
+		values = append(values, cand.Value)
+	}
+
+	return values
+}
+
+func TestCompleteWordsCommandsAndFlags(t *testing.T) {
+	if got := completionValues(t, "conv", "li"); !slices.Equal(got, []string{"list"}) {
+		t.Fatalf("subcommands = %v, want [list]", got)
+	}
+
+	if got := completionValues(t, "conv", "list", "--inb"); !slices.Equal(got, []string{"--inbox"}) {
+		t.Fatalf("flags = %v, want [--inbox]", got)
+	}
+
+	if got := completionValues(t, "--log-level", "w"); !slices.Equal(got, []string{"warn"}) {
+		t.Fatalf("enum values = %v, want [warn]", got)
+	}
+
+	if got := completionValues(t, "--log-format=j"); !slices.Equal(got, []string{"--log-format=json"}) {
+		t.Fatalf("inline enum values = %v, want [--log-format=json]", got)
+	}
+
+	if got := completionValues(t, "__"); len(got) != 0 {
+		t.Fatalf("hidden commands completed: %v", got)
+	}
+}
+
+func TestCompleteWordsLiveTagNames(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tags" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"_results":[{"id":"tag_1","name":"urgent"},{"id":"tag_2","name":"vip"}]}`))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	got := completionValues(t, "conv", "list", "--tag", "u")
+	if !slices.Equal(got, []string{"urgent"}) {
+		t.Fatalf("tag completions = %v, want [urgent]", got)
+	}
+}
@@ -5,10 +5,15 @@ import (
 	"os"
 
 	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/output"
 )
 
 type ConfigCmd struct {
 	Path ConfigPathCmd `cmd:"" help:"Show configuration paths"`
+	Get  ConfigGetCmd  `cmd:"" help:"Print a config value"`
+	Set  ConfigSetCmd  `cmd:"" help:"Set a config value (an empty value removes it)"`
+	List ConfigListCmd `cmd:"" help:"List all config values"`
+	Edit ConfigEditCmd `cmd:"" help:"Open config.yaml in $EDITOR"`
 }
 
 type ConfigPathCmd struct{}
@@ -41,3 +46,116 @@ func (c *ConfigPathCmd) Run() error {
 
 	return nil
 }
+
+type ConfigGetCmd struct {
+	Key string `arg:"" help:"Key: default_account, default_output, timezone, token_store, max_retries, retry_base_delay, retry_budget, request_timeout, throttle, circuit_breaker_threshold, circuit_breaker_cooldown, aliases[.<alias>] or domains[.<domain>]"`
+}
+
+func (c *ConfigGetCmd) Run() error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	value, err := cfg.Get(c.Key)
+	if err != nil {
+		return err
+	}
+
+	if value != "" {
+		fmt.Fprintln(os.Stdout, value)
+	}
+
+	return nil
+}
+
+type ConfigSetCmd struct {
+	Key   string `arg:"" help:"Key: default_account, default_output, timezone, token_store, max_retries, retry_base_delay, retry_budget, request_timeout, throttle, circuit_breaker_threshold, circuit_breaker_cooldown, aliases.<alias> or domains.<domain>"`
+	Value string `arg:"" help:"New value; empty to remove the setting"`
+}
+
+func (c *ConfigSetCmd) Run() error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Set(c.Key, c.Value); err != nil {
+		return err
+	}
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return err
+	}
+
+	if c.Value == "" {
+		fmt.Fprintf(os.Stdout, "Removed %s\n", c.Key)
+	} else {
+		fmt.Fprintf(os.Stdout, "Set %s\n", c.Key)
+	}
+
+	return nil
+}
+
+type ConfigListCmd struct{}
+
+func (c *ConfigListCmd) Run(flags *RootFlags) error {
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	entries := cfg.Entries()
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stdout, "No configuration set.")
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("KEY", "VALUE")
+
+	for _, e := range entries {
+		tbl.AddRow(e.Key, e.Value)
+	}
+
+	return tbl.Flush()
+}
+
+type ConfigEditCmd struct{}
+
+func (c *ConfigEditCmd) Run() error {
+	if _, err := config.EnsureDir(); err != nil {
+		return fmt.Errorf("ensure config dir: %w", err)
+	}
+
+	path, err := config.ConfigPath()
+	if err != nil {
+		return fmt.Errorf("resolve config path: %w", err)
+	}
+
+	if err := runEditor(path); err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("%w (run 'frontcli config edit' again to fix it)", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%s: %w (run 'frontcli config edit' again to fix it)", path, err)
+	}
+
+	return nil
+}
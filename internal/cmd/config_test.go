@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+func TestConfigEditRejectsInvalidValues(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	old := runEditor
+	runEditor = func(path string) error {
+		return os.WriteFile(path, []byte("default_output: xml\n"), 0o600)
+	}
+
+	t.Cleanup(func() { runEditor = old })
+
+	err := (&ConfigEditCmd{}).Run()
+	if err == nil || !strings.Contains(err.Error(), "default_output") {
+		t.Fatalf("Run error = %v, want invalid default_output", err)
+	}
+}
+
+func TestConfigSetWritesConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := (&ConfigSetCmd{Key: "timezone", Value: "Europe/Brussels"}).Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+
+	if cfg.Timezone != "Europe/Brussels" {
+		t.Fatalf("timezone = %q", cfg.Timezone)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
@@ -13,7 +12,7 @@ import (
 
 type ContactCmd struct {
 	List    ContactListCmd    `cmd:"" help:"List contacts"`
-	Search  ContactSearchCmd  `cmd:"" help:"Search contacts"`
+	Search  ContactSearchCmd  `cmd:"" help:"Search contacts by name or handle (Front's filter, or a client-side scan when unsupported)"`
 	Get     ContactGetCmd     `cmd:"" help:"Get a contact"`
 	Handles ContactHandlesCmd `cmd:"" help:"List contact handles"`
 	Handle  ContactHandleCmd  `cmd:"" help:"Manage contact handles"`
@@ -27,7 +26,13 @@ type ContactCmd struct {
 }
 
 type ContactListCmd struct {
-	Limit int `help:"Maximum results" default:"25"`
+	PaginationFlags `embed:""`
+
+	Limit         int    `help:"Maximum results" default:"25"`
+	UpdatedAfter  string `help:"Only contacts updated after this time (RFC3339, YYYY-MM-DD, 3d or Unix seconds)" name:"updated-after"`
+	UpdatedBefore string `help:"Only contacts updated before this time (RFC3339, YYYY-MM-DD, 3d or Unix seconds)" name:"updated-before"`
+	SortBy        string `help:"Order contacts by created or updated time (server-side)" name:"sort-by" enum:"created,updated," default:""`
+	SortOrder     string `help:"Order direction with --sort-by (asc, desc)" name:"sort-order" enum:"asc,desc," default:""`
 }
 
 func (c *ContactListCmd) Run(flags *RootFlags) error {
@@ -43,37 +48,52 @@ func (c *ContactListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListContacts(ctx, c.Limit)
+	updatedAfter, err := parseTimeFlag(c.UpdatedAfter)
 	if err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
+		return fmt.Errorf("--updated-after: %w", err)
+	}
 
-		return err
+	updatedBefore, err := parseTimeFlag(c.UpdatedBefore)
+	if err != nil {
+		return fmt.Errorf("--updated-before: %w", err)
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+	if c.SortOrder != "" && c.SortBy == "" {
+		return fmt.Errorf("--sort-order requires --sort-by")
 	}
 
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No contacts found.")
+	opts := api.ListContactsOptions{
+		Limit:         c.Limit,
+		UpdatedAfter:  updatedAfter,
+		UpdatedBefore: updatedBefore,
+		SortOrder:     c.SortOrder,
+	}
 
-		return nil
+	if c.SortBy != "" {
+		opts.SortBy = c.SortBy + "_at"
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "HANDLE")
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Contact]{
+		Path:    opts.Path(),
+		Empty:   "No contacts found.",
+		Headers: []string{"ID", "NAME", "HANDLE"},
+		Row:     output.FormatContact,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
-	for _, contact := range resp.Results {
-		tbl.AddRow(output.FormatContact(contact)...)
+		return err
 	}
 
-	return tbl.Flush()
+	return nil
 }
 
 type ContactSearchCmd struct {
 	Query    string `arg:"" help:"Search query (matches name or handle)"`
 	Limit    int    `help:"Maximum results" default:"25"`
 	MaxPages int    `help:"Maximum pages to search (100 contacts/page)" default:"25"`
+	Workers  int    `help:"Pages fetched concurrently when scanning (0 = one per date range)" default:"0"`
+	Method   string `help:"Where to search: auto tries Front's ?q= filter and scans the address book client-side if the API ignores or rejects it; server never scans; scan skips the filter" enum:"auto,server,scan" default:"auto"`
 }
 
 func (c *ContactSearchCmd) Run(flags *RootFlags) error {
@@ -89,43 +109,20 @@ func (c *ContactSearchCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	// Front API doesn't have a contact search endpoint, so we paginate
-	// through contacts and filter client-side until we have enough matches.
-	query := strings.ToLower(c.Query)
-	var matches []api.Contact
-
-	// First page: fetch max 100 contacts
-	resp, err := client.ListContacts(ctx, 100)
+	matches, err := client.SearchContacts(ctx, c.Query, api.ContactSearchOptions{
+		Limit:    c.Limit,
+		MaxPages: c.MaxPages,
+		Workers:  c.Workers,
+		Method:   api.ContactSearchMethod(c.Method),
+	})
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
-		return err
-	}
-
-	for page := 1; page <= c.MaxPages; page++ {
-		for _, contact := range resp.Results {
-			if contactMatches(contact, query) {
-				matches = append(matches, contact)
-				if len(matches) >= c.Limit {
-					break
-				}
-			}
-		}
-
-		// Stop if we have enough matches or no more pages
-		if len(matches) >= c.Limit || resp.Pagination.Next == "" {
-			break
-		}
 
-		// Fetch next page
-		resp, err = client.ListContactsPage(ctx, resp.Pagination.Next)
-		if err != nil {
-			fmt.Fprint(os.Stderr, errfmt.Format(err))
-			return err
-		}
+		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, matches)
+		return mode.Write(os.Stdout, matches)
 	}
 
 	if len(matches) == 0 {
@@ -143,20 +140,6 @@ func (c *ContactSearchCmd) Run(flags *RootFlags) error {
 	return tbl.Flush()
 }
 
-func contactMatches(contact api.Contact, query string) bool {
-	if strings.Contains(strings.ToLower(contact.Name), query) {
-		return true
-	}
-
-	for _, h := range contact.Handles {
-		if strings.Contains(strings.ToLower(h.Handle), query) {
-			return true
-		}
-	}
-
-	return false
-}
-
 type ContactGetCmd struct {
 	ID string `arg:"" help:"Contact ID"`
 }
@@ -182,7 +165,7 @@ func (c *ContactGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, contact)
+		return mode.Write(os.Stdout, contact)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:   %s\n", contact.ID)
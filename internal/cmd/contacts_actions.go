@@ -8,7 +8,6 @@ import (
 
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
-	"github.com/dedene/frontapp-cli/internal/output"
 )
 
 type ContactCreateCmd struct {
@@ -65,7 +64,7 @@ func (c *ContactCreateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Contact created: %s\n", result.ID)
@@ -114,7 +113,7 @@ func (c *ContactUpdateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Contact updated: %s\n", result.Name)
@@ -11,6 +11,8 @@ import (
 )
 
 type ContactConvosCmd struct {
+	PaginationFlags `embed:""`
+
 	ID    string `arg:"" help:"Contact ID"`
 	Limit int    `help:"Maximum results" default:"25"`
 }
@@ -28,30 +30,17 @@ func (c *ContactConvosCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	path := fmt.Sprintf("/contacts/%s/conversations?limit=%d", c.ID, c.Limit)
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Conversation]{
+		Path:    fmt.Sprintf("/contacts/%s/conversations?limit=%d", c.ID, c.Limit),
+		Empty:   "No conversations found.",
+		Headers: []string{"ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED"},
+		Row:     output.FormatConversation,
+	})
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No conversations found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED")
-
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversation(conv)...)
-	}
-
-	return tbl.Flush()
+	return nil
 }
@@ -35,7 +35,7 @@ func (c *ContactHandlesCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, map[string]any{"handles": contact.Handles})
+		return mode.Write(os.Stdout, map[string]any{"handles": contact.Handles})
 	}
 
 	if len(contact.Handles) == 0 {
@@ -91,7 +91,7 @@ func (c *ContactHandleAddCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Handle added: %s\n", result.Handle)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
@@ -11,6 +12,8 @@ import (
 )
 
 type ContactNotesCmd struct {
+	PaginationFlags `embed:""`
+
 	ID string `arg:"" help:"Contact ID"`
 }
 
@@ -27,44 +30,36 @@ func (c *ContactNotesCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.ContactNote]
-	if err := client.Get(ctx, fmt.Sprintf("/contacts/%s/notes", c.ID), &resp); err != nil {
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.ContactNote]{
+		Path:    fmt.Sprintf("/contacts/%s/notes", c.ID),
+		Empty:   "No notes found.",
+		Headers: []string{"ID", "AUTHOR", "NOTE", "DATE"},
+		Row:     formatContactNote,
+	})
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No notes found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "AUTHOR", "NOTE", "DATE")
-
-	for _, note := range resp.Results {
-		author := "-"
-		if note.Author != nil {
-			author = note.Author.Email
-			if author == "" {
-				author = note.Author.Username
-			}
-		}
+	return nil
+}
 
-		body := note.Body
-		if len(body) > 50 {
-			body = body[:47] + "..."
+func formatContactNote(note api.ContactNote) []string {
+	author := "-"
+	if note.Author != nil {
+		author = note.Author.Email
+		if author == "" {
+			author = note.Author.Username
 		}
+	}
 
-		tbl.AddRow(note.ID, author, body, output.FormatTimestamp(note.CreatedAt))
+	body := note.Body
+	if len(body) > 50 {
+		body = body[:47] + "..."
 	}
 
-	return tbl.Flush()
+	return []string{note.ID, author, body, output.FormatTimestamp(note.CreatedAt)}
 }
 
 type ContactNoteCmd struct {
@@ -73,7 +68,8 @@ type ContactNoteCmd struct {
 
 type ContactNoteAddCmd struct {
 	ContactID string `arg:"" help:"Contact ID"`
-	Body      string `required:"" help:"Note body"`
+	Body      string `help:"Note body"`
+	BodyFile  string `help:"Read body from file (- for stdin)" type:"existingfile"`
 }
 
 func (c *ContactNoteAddCmd) Run(flags *RootFlags) error {
@@ -89,7 +85,16 @@ func (c *ContactNoteAddCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	req := map[string]string{"body": c.Body}
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("body is required (use --body or --body-file)")
+	}
+
+	req := map[string]string{"body": body}
 
 	var result api.ContactNote
 	if err := client.Post(ctx, fmt.Sprintf("/contacts/%s/notes", c.ContactID), req, &result); err != nil {
@@ -99,7 +104,7 @@ func (c *ContactNoteAddCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Note added: %s\n", result.ID)
@@ -1,22 +1,32 @@
 package cmd
 
 type ConvCmd struct {
-	List      ConvListCmd      `cmd:"" help:"List conversations"`
-	Get       ConvGetCmd       `cmd:"" help:"Get a conversation"`
-	Search    ConvSearchCmd    `cmd:"" help:"Search conversations"`
-	Messages  ConvMessagesCmd  `cmd:"" help:"List messages in a conversation"`
-	Comments  ConvCommentsCmd  `cmd:"" help:"List comments in a conversation"`
-	Archive   ConvArchiveCmd   `cmd:"" help:"Archive conversations"`
-	Open      ConvOpenCmd      `cmd:"" help:"Open (unarchive) conversations"`
-	Trash     ConvTrashCmd     `cmd:"" help:"Move conversations to trash"`
-	Assign    ConvAssignCmd    `cmd:"" help:"Assign a conversation"`
-	Unassign  ConvUnassignCmd  `cmd:"" help:"Unassign a conversation"`
-	Snooze    ConvSnoozeCmd    `cmd:"" help:"Snooze a conversation"`
-	Unsnooze  ConvUnsnoozeCmd  `cmd:"" help:"Unsnooze a conversation"`
-	Followers ConvFollowersCmd `cmd:"" help:"List followers of a conversation"`
-	Follow    ConvFollowCmd    `cmd:"" help:"Follow a conversation"`
-	Unfollow  ConvUnfollowCmd  `cmd:"" help:"Unfollow a conversation"`
-	Tag       ConvTagCmd       `cmd:"" help:"Add tag to conversation"`
-	Untag     ConvUntagCmd     `cmd:"" help:"Remove tag from conversation"`
-	Update    ConvUpdateCmd    `cmd:"" help:"Update conversation custom fields"`
+	List           ConvListCmd           `cmd:"" help:"List conversations"`
+	Get            ConvGetCmd            `cmd:"" help:"Get a conversation"`
+	OpenWeb        ConvOpenWebCmd        `cmd:"" name:"open-web" help:"Open a conversation in the Front web app"`
+	Search         ConvSearchCmd         `cmd:"" help:"Search conversations"`
+	Overdue        ConvOverdueCmd        `cmd:"" help:"List open conversations waiting for a reply longer than a threshold"`
+	Create         ConvCreateCmd         `cmd:"" help:"Start a new outbound conversation"`
+	Messages       ConvMessagesCmd       `cmd:"" help:"List messages in a conversation"`
+	Rcpts          ConvRecipientCmd      `cmd:"" name:"recipients" help:"List everyone involved in a conversation"`
+	Watch          ConvWatchCmd          `cmd:"" aliases:"tail" help:"Follow a conversation, printing new messages and comments"`
+	Comments       ConvCommentsCmd       `cmd:"" help:"List comments in a conversation"`
+	Archive        ConvArchiveCmd        `cmd:"" help:"Archive conversations"`
+	Open           ConvOpenCmd           `cmd:"" help:"Open (unarchive) conversations"`
+	Trash          ConvTrashCmd          `cmd:"" help:"Move conversations to trash"`
+	Assign         ConvAssignCmd         `cmd:"" help:"Assign a conversation"`
+	AssignBalanced ConvAssignBalancedCmd `cmd:"" help:"Spread an inbox's unassigned conversations evenly across teammates"`
+	Unassign       ConvUnassignCmd       `cmd:"" help:"Unassign a conversation"`
+	Snooze         ConvSnoozeCmd         `cmd:"" help:"Snooze a conversation"`
+	Unsnooze       ConvUnsnoozeCmd       `cmd:"" help:"Unsnooze a conversation"`
+	Remind         ConvRemindCmd         `cmd:"" help:"Set or cancel a follow-up reminder"`
+	Reminders      ConvRemindersCmd      `cmd:"" help:"List reminders on a conversation"`
+	Followers      ConvFollowersCmd      `cmd:"" help:"List followers of a conversation"`
+	Follow         ConvFollowCmd         `cmd:"" help:"Follow a conversation"`
+	Unfollow       ConvUnfollowCmd       `cmd:"" help:"Unfollow a conversation"`
+	Tag            ConvTagCmd            `cmd:"" help:"Add tag to conversation"`
+	Untag          ConvUntagCmd          `cmd:"" help:"Remove tag from conversation"`
+	Update         ConvUpdateCmd         `cmd:"" help:"Update conversation custom fields"`
+	Export         ConvExportCmd         `cmd:"" help:"Export a conversation to EML, mbox or JSON"`
+	Bulk           ConvBulkCmd           `cmd:"" help:"Apply an action to every conversation matching a search"`
 }
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
 	"github.com/dedene/frontapp-cli/internal/output"
@@ -18,8 +22,9 @@ import (
 const maxBulkIDs = 50
 
 type ConvArchiveCmd struct {
-	IDs     []string `arg:"" help:"Conversation IDs to archive"`
-	IDsFrom string   `help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	IDs        []string `arg:"" optional:"" help:"Conversation IDs to archive"`
+	IDsFrom    string   `name:"ids-from" help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	BatchFlags `embed:""`
 }
 
 func (c *ConvArchiveCmd) Run(flags *RootFlags) error {
@@ -30,29 +35,30 @@ func (c *ConvArchiveCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	ids, err := collectIDs(c.IDs, c.IDsFrom)
+	ids, err := collectConversationIDs(c.IDs, c.IDsFrom)
 	if err != nil {
 		return err
 	}
 
-	if len(ids) == 0 {
-		return fmt.Errorf("no conversation IDs provided")
-	}
+	rec := newUndoRecorder("archive", "")
 
-	for _, id := range ids {
-		if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"status": "archived"}, nil); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to archive %s: %v\n", id, err)
-		} else {
-			fmt.Fprintf(os.Stdout, "Archived %s\n", id)
+	err = forEachID(ctx, ids, "archive", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		if err := changeStatus(ctx, client, rec, id, "archived"); err != nil {
+			return "", err
 		}
-	}
 
-	return nil
+		return "Archived " + id, nil
+	})
+
+	rec.save(flags)
+
+	return err
 }
 
 type ConvOpenCmd struct {
-	IDs     []string `arg:"" help:"Conversation IDs to open"`
-	IDsFrom string   `help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	IDs        []string `arg:"" optional:"" help:"Conversation IDs to open"`
+	IDsFrom    string   `name:"ids-from" help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	BatchFlags `embed:""`
 }
 
 func (c *ConvOpenCmd) Run(flags *RootFlags) error {
@@ -63,29 +69,30 @@ func (c *ConvOpenCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	ids, err := collectIDs(c.IDs, c.IDsFrom)
+	ids, err := collectConversationIDs(c.IDs, c.IDsFrom)
 	if err != nil {
 		return err
 	}
 
-	if len(ids) == 0 {
-		return fmt.Errorf("no conversation IDs provided")
-	}
+	rec := newUndoRecorder("open", "")
 
-	for _, id := range ids {
-		if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"status": "open"}, nil); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to open %s: %v\n", id, err)
-		} else {
-			fmt.Fprintf(os.Stdout, "Opened %s\n", id)
+	err = forEachID(ctx, ids, "open", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		if err := changeStatus(ctx, client, rec, id, "open"); err != nil {
+			return "", err
 		}
-	}
 
-	return nil
+		return "Opened " + id, nil
+	})
+
+	rec.save(flags)
+
+	return err
 }
 
 type ConvTrashCmd struct {
-	IDs     []string `arg:"" help:"Conversation IDs to trash"`
-	IDsFrom string   `help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	IDs        []string `arg:"" optional:"" help:"Conversation IDs to trash"`
+	IDsFrom    string   `name:"ids-from" help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	BatchFlags `embed:""`
 }
 
 func (c *ConvTrashCmd) Run(flags *RootFlags) error {
@@ -96,29 +103,31 @@ func (c *ConvTrashCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	ids, err := collectIDs(c.IDs, c.IDsFrom)
+	ids, err := collectConversationIDs(c.IDs, c.IDsFrom)
 	if err != nil {
 		return err
 	}
 
-	if len(ids) == 0 {
-		return fmt.Errorf("no conversation IDs provided")
-	}
+	rec := newUndoRecorder("trash", "")
 
-	for _, id := range ids {
-		if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"status": "trashed"}, nil); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to trash %s: %v\n", id, err)
-		} else {
-			fmt.Fprintf(os.Stdout, "Trashed %s\n", id)
+	err = forEachID(ctx, ids, "trash", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		if err := changeStatus(ctx, client, rec, id, "trashed"); err != nil {
+			return "", err
 		}
-	}
 
-	return nil
+		return "Trashed " + id, nil
+	})
+
+	rec.save(flags)
+
+	return err
 }
 
 type ConvAssignCmd struct {
-	ID string `arg:"" help:"Conversation ID"`
-	To string `required:"" help:"Teammate ID to assign to"`
+	IDs        []string `arg:"" optional:"" help:"Conversation IDs to assign"`
+	IDsFrom    string   `name:"ids-from" help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	To         string   `required:"" help:"Teammate to assign to (ID, email, username or me)"`
+	BatchFlags `embed:""`
 }
 
 func (c *ConvAssignCmd) Run(flags *RootFlags) error {
@@ -129,19 +138,31 @@ func (c *ConvAssignCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	if err := client.Patch(ctx, "/conversations/"+c.ID, map[string]string{"assignee_id": c.To}, nil); err != nil {
+	ids, err := collectConversationIDs(c.IDs, c.IDsFrom)
+	if err != nil {
+		return err
+	}
+
+	assigneeID, err := client.ResolveTeammate(ctx, c.To)
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "Assigned %s to %s\n", c.ID, c.To)
+	return forEachID(ctx, ids, "assign", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"assignee_id": assigneeID}, nil); err != nil {
+			return "", err
+		}
 
-	return nil
+		return fmt.Sprintf("Assigned %s to %s", id, c.To), nil
+	})
 }
 
 type ConvUnassignCmd struct {
-	ID string `arg:"" help:"Conversation ID"`
+	IDs        []string `arg:"" optional:"" help:"Conversation IDs to unassign"`
+	IDsFrom    string   `name:"ids-from" help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	BatchFlags `embed:""`
 }
 
 func (c *ConvUnassignCmd) Run(flags *RootFlags) error {
@@ -152,21 +173,26 @@ func (c *ConvUnassignCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	if err := client.Patch(ctx, "/conversations/"+c.ID, map[string]any{"assignee_id": nil}, nil); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
-
+	ids, err := collectConversationIDs(c.IDs, c.IDsFrom)
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "Unassigned %s\n", c.ID)
+	return forEachID(ctx, ids, "unassign", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		if err := client.Patch(ctx, "/conversations/"+id, map[string]any{"assignee_id": nil}, nil); err != nil {
+			return "", err
+		}
 
-	return nil
+		return "Unassigned " + id, nil
+	})
 }
 
 type ConvSnoozeCmd struct {
-	ID       string `arg:"" help:"Conversation ID"`
-	Until    string `help:"Snooze until (RFC3339 timestamp)"`
-	Duration string `help:"Snooze duration (e.g. 2h, 30m)"`
+	IDs        []string `arg:"" optional:"" help:"Conversation IDs to snooze"`
+	IDsFrom    string   `name:"ids-from" help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	Until      string   `help:"Snooze until, e.g. tomorrow 9:00, monday, 3d, 2024-01-15 14:00"`
+	Duration   string   `help:"Snooze duration (e.g. 2h, 30m)"`
+	BatchFlags `embed:""`
 }
 
 func (c *ConvSnoozeCmd) Run(flags *RootFlags) error {
@@ -177,6 +203,11 @@ func (c *ConvSnoozeCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	ids, err := collectConversationIDs(c.IDs, c.IDsFrom)
+	if err != nil {
+		return err
+	}
+
 	until := strings.TrimSpace(c.Until)
 	if strings.TrimSpace(c.Duration) != "" {
 		if until != "" {
@@ -195,17 +226,22 @@ func (c *ConvSnoozeCmd) Run(flags *RootFlags) error {
 		return fmt.Errorf("either --until or --duration is required")
 	}
 
-	req := map[string]string{"scheduled_at": until}
+	ts, err := parseFutureTimeFlag(until)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
 
-	if err := client.Patch(ctx, fmt.Sprintf("/conversations/%s/reminders", c.ID), req, nil); err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
+	until = time.Unix(int64(ts), 0).UTC().Format(time.RFC3339)
 
-		return err
-	}
+	req := map[string]string{"scheduled_at": until}
 
-	fmt.Fprintf(os.Stdout, "Snoozed %s until %s\n", c.ID, until)
+	return forEachID(ctx, ids, "snooze", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		if err := client.Patch(ctx, fmt.Sprintf("/conversations/%s/reminders", id), req, nil); err != nil {
+			return "", err
+		}
 
-	return nil
+		return fmt.Sprintf("Snoozed %s until %s", id, until), nil
+	})
 }
 
 type ConvUnsnoozeCmd struct {
@@ -258,7 +294,7 @@ func (c *ConvFollowersCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return mode.Write(os.Stdout, resp)
 	}
 
 	if len(resp.Results) == 0 {
@@ -279,7 +315,7 @@ func (c *ConvFollowersCmd) Run(flags *RootFlags) error {
 
 type ConvFollowCmd struct {
 	ID   string `arg:"" help:"Conversation ID"`
-	User string `help:"Teammate ID to follow as"`
+	User string `help:"Teammate to add as follower (ID, email or username)"`
 }
 
 func (c *ConvFollowCmd) Run(flags *RootFlags) error {
@@ -292,7 +328,14 @@ func (c *ConvFollowCmd) Run(flags *RootFlags) error {
 
 	var body map[string]string
 	if strings.TrimSpace(c.User) != "" {
-		body = map[string]string{"teammate_id": c.User}
+		teammateID, err := client.ResolveTeammate(ctx, c.User)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		body = map[string]string{"teammate_id": teammateID}
 	}
 
 	if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/followers", c.ID), body, nil); err != nil {
@@ -312,7 +355,7 @@ func (c *ConvFollowCmd) Run(flags *RootFlags) error {
 
 type ConvUnfollowCmd struct {
 	ID   string `arg:"" help:"Conversation ID"`
-	User string `help:"Teammate ID to unfollow"`
+	User string `help:"Teammate to remove as follower (ID, email or username)"`
 }
 
 func (c *ConvUnfollowCmd) Run(flags *RootFlags) error {
@@ -325,7 +368,14 @@ func (c *ConvUnfollowCmd) Run(flags *RootFlags) error {
 
 	path := fmt.Sprintf("/conversations/%s/followers", c.ID)
 	if strings.TrimSpace(c.User) != "" {
-		path = fmt.Sprintf("/conversations/%s/followers/%s", c.ID, c.User)
+		teammateID, err := client.ResolveTeammate(ctx, c.User)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		path = fmt.Sprintf("/conversations/%s/followers/%s", c.ID, teammateID)
 	}
 
 	if err := client.Delete(ctx, path); err != nil {
@@ -344,8 +394,9 @@ func (c *ConvUnfollowCmd) Run(flags *RootFlags) error {
 }
 
 type ConvTagCmd struct {
-	ID    string `arg:"" help:"Conversation ID"`
-	TagID string `arg:"" help:"Tag ID to add"`
+	ID     string `arg:"" help:"Conversation ID"`
+	TagID  string `arg:"" help:"Tag to add (ID or name)"`
+	Create bool   `help:"Create the tag if no tag has this name"`
 }
 
 func (c *ConvTagCmd) Run(flags *RootFlags) error {
@@ -356,7 +407,14 @@ func (c *ConvTagCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	payload := map[string][]string{"tag_ids": {c.TagID}}
+	tagID, err := resolveTagOrCreate(ctx, client, c.TagID, c.Create)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	payload := map[string][]string{"tag_ids": {tagID}}
 	if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/tags", c.ID), payload, nil); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
@@ -368,9 +426,29 @@ func (c *ConvTagCmd) Run(flags *RootFlags) error {
 	return nil
 }
 
+// resolveTagOrCreate resolves a tag name or ID. With create, a name that
+// matches no tag becomes a new top-level tag; ambiguous names still fail.
+func resolveTagOrCreate(ctx context.Context, client *api.Client, nameOrID string, create bool) (string, error) {
+	id, err := client.ResolveTag(ctx, nameOrID)
+
+	var nameErr *api.NameResolutionError
+	if !create || !errors.As(err, &nameErr) || len(nameErr.Matches) > 0 {
+		return id, err
+	}
+
+	tag, err := client.CreateTag(ctx, strings.TrimSpace(nameOrID))
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(os.Stderr, "Created tag %s (%s)\n", tag.Name, tag.ID)
+
+	return tag.ID, nil
+}
+
 type ConvUntagCmd struct {
 	ID    string `arg:"" help:"Conversation ID"`
-	TagID string `arg:"" help:"Tag ID to remove"`
+	TagID string `arg:"" help:"Tag to remove (ID or name)"`
 }
 
 func (c *ConvUntagCmd) Run(flags *RootFlags) error {
@@ -381,7 +459,14 @@ func (c *ConvUntagCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	if err := client.Delete(ctx, fmt.Sprintf("/conversations/%s/tags/%s", c.ID, c.TagID)); err != nil {
+	tagID, err := client.ResolveTag(ctx, c.TagID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if err := client.Delete(ctx, fmt.Sprintf("/conversations/%s/tags/%s", c.ID, tagID)); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
@@ -440,6 +525,114 @@ func (c *ConvUpdateCmd) Run(flags *RootFlags) error {
 	return nil
 }
 
+// collectConversationIDs is collectIDs for commands that need at least one
+// conversation.
+func collectConversationIDs(ids []string, idsFrom string) ([]string, error) {
+	out, err := collectIDs(ids, idsFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no conversation IDs provided")
+	}
+
+	return out, nil
+}
+
+// BatchFlags control how commands acting on several conversations run and
+// handle failures.
+type BatchFlags struct {
+	ContinueOnError bool `help:"Keep going after a conversation fails (default)" xor:"on-error"`
+	FailFast        bool `help:"Stop at the first conversation that fails" xor:"on-error"`
+	Concurrency     int  `help:"Number of conversations updated at once" default:"4"`
+}
+
+// forEachID applies fn to the conversation IDs, up to --concurrency at a time
+// with the client's rate limiter pacing the requests. It prints the success
+// message fn returns or the failure as each ID completes, and a summary when
+// there is more than one ID. It fails if any ID failed. On Ctrl-C it starts
+// no more IDs, counts the aborted ones as skipped and returns
+// api.ErrInterrupted after the summary.
+func forEachID(
+	ctx context.Context,
+	ids []string,
+	action string,
+	batch BatchFlags,
+	fn func(ctx context.Context, id string) (string, error),
+) error {
+	var (
+		mu                sync.Mutex
+		succeeded, failed int
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(batch.Concurrency, 1))
+
+	for _, id := range ids {
+		g.Go(func() error {
+			if gctx.Err() != nil || interrupted() {
+				return nil
+			}
+
+			msg, err := fn(gctx, id)
+			if errors.Is(err, api.ErrInterrupted) {
+				return nil
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				failed++
+
+				fmt.Fprintf(os.Stderr, "Failed to %s %s: %v\n", action, id, err)
+
+				if batch.FailFast {
+					return err
+				}
+
+				return nil
+			}
+
+			succeeded++
+
+			fmt.Fprintln(os.Stdout, msg)
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	stopped := interrupted()
+
+	if len(ids) > 1 || stopped {
+		label := "Done"
+		if stopped {
+			label = "Interrupted"
+		}
+
+		summary := fmt.Sprintf("%s: %d succeeded, %d failed", label, succeeded, failed)
+
+		if skipped := len(ids) - succeeded - failed; skipped > 0 {
+			summary += fmt.Sprintf(", %d skipped", skipped)
+		}
+
+		fmt.Fprintln(os.Stderr, summary)
+	}
+
+	if stopped {
+		return fmt.Errorf("%s stopped after %d of %d conversations: %w", action, succeeded+failed, len(ids), api.ErrInterrupted)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("could not %s %d of %d conversations", action, failed, len(ids))
+	}
+
+	return nil
+}
+
 func collectIDs(ids []string, idsFrom string) ([]string, error) {
 	fromIDs, err := readIDsFromInput(idsFrom)
 	if err != nil {
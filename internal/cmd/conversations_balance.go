@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type ConvAssignBalancedCmd struct {
+	Inbox      string   `required:"" help:"Inbox whose unassigned conversations are dispatched (ID or name)"`
+	Teammates  []string `required:"" help:"Teammates to share the conversations (IDs, emails, usernames or me); comma-separated or repeatable"`
+	ByLoad     bool     `help:"Count each teammate's open conversations first and favor the least loaded"`
+	Max        int      `help:"Assign at most this many conversations, oldest first (0 = all)"`
+	DryRun     bool     `help:"Show who would get which conversation without assigning"`
+	BatchFlags `embed:""`
+}
+
+// balancedAssignment is one conversation and the teammate it goes to.
+type balancedAssignment struct {
+	ConversationID string `json:"conversation_id"`
+	Subject        string `json:"subject"`
+	AssigneeID     string `json:"assignee_id"`
+	Assignee       string `json:"assignee"`
+}
+
+func (c *ConvAssignBalancedCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	plan, err := c.plan(ctx, client)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if c.DryRun && mode.JSON {
+		return mode.Write(os.Stdout, plan)
+	}
+
+	if len(plan) == 0 {
+		fmt.Fprintln(os.Stdout, "No unassigned conversations found.")
+
+		return nil
+	}
+
+	if c.DryRun {
+		tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+		tbl.AddRow("ID", "SUBJECT", "ASSIGNEE")
+
+		for _, a := range plan {
+			subject := a.Subject
+			if len(subject) > 50 {
+				subject = subject[:47] + "..."
+			}
+
+			tbl.AddRow(a.ConversationID, subject, a.Assignee)
+		}
+
+		return tbl.Flush()
+	}
+
+	assignees := make(map[string]balancedAssignment, len(plan))
+	ids := make([]string, 0, len(plan))
+
+	for _, a := range plan {
+		assignees[a.ConversationID] = a
+		ids = append(ids, a.ConversationID)
+	}
+
+	return forEachID(ctx, ids, "assign", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		a := assignees[id]
+		if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"assignee_id": a.AssigneeID}, nil); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Assigned %s to %s", id, a.Assignee), nil
+	})
+}
+
+// plan deals the inbox's unassigned conversations, oldest first, to the
+// teammate with the fewest conversations so far. Without --by-load everyone
+// starts at zero, which makes it a round-robin in the order given.
+func (c *ConvAssignBalancedCmd) plan(ctx context.Context, client *api.Client) ([]balancedAssignment, error) {
+	inboxID, err := client.ResolveInbox(ctx, c.Inbox)
+	if err != nil {
+		return nil, err
+	}
+
+	teammateIDs, err := resolveAll(ctx, c.Teammates, client.ResolveTeammate)
+	if err != nil {
+		return nil, err
+	}
+
+	loads := make([]int, len(teammateIDs))
+
+	if c.ByLoad {
+		for i, id := range teammateIDs {
+			assigned, err := listConversations(ctx, client, api.ListConversationsOptions{AssigneeID: id, Statuses: []string{"assigned"}})
+			if err != nil {
+				return nil, err
+			}
+
+			loads[i] = len(assigned)
+		}
+	}
+
+	convs, err := listConversations(ctx, client, api.ListConversationsOptions{InboxIDs: []string{inboxID}, Statuses: []string{"unassigned"}})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(convs, func(i, j int) bool { return convs[i].CreatedAt < convs[j].CreatedAt })
+
+	if c.Max > 0 && len(convs) > c.Max {
+		convs = convs[:c.Max]
+	}
+
+	plan := make([]balancedAssignment, 0, len(convs))
+
+	for _, conv := range convs {
+		next := 0
+		for i := range loads {
+			if loads[i] < loads[next] {
+				next = i
+			}
+		}
+
+		loads[next]++
+
+		plan = append(plan, balancedAssignment{
+			ConversationID: conv.ID,
+			Subject:        conv.Subject,
+			AssigneeID:     teammateIDs[next],
+			Assignee:       c.Teammates[next],
+		})
+	}
+
+	return plan, nil
+}
+
+// listConversations fetches every conversation matching opts.
+func listConversations(ctx context.Context, client *api.Client, opts api.ListConversationsOptions) ([]api.Conversation, error) {
+	opts.Limit = 100
+
+	path, err := opts.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	var convs []api.Conversation
+
+	_, err = listPages(ctx, client, path, PaginationFlags{All: true}, func(resp *api.ListResponse[api.Conversation]) error {
+		convs = append(convs, resp.Results...)
+
+		return nil
+	})
+
+	return convs, err
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConvAssignBalancedFavorsLeastLoaded(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		assigned []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch:
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+
+			mu.Lock()
+			assigned = append(assigned, strings.TrimPrefix(r.URL.Path, "/conversations/")+"="+body["assignee_id"])
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/teammates/tea_1/conversations":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"cnv_a"},{"id":"cnv_b"}]}`)
+		case r.URL.Path == "/teammates/tea_2/conversations":
+			_, _ = io.WriteString(w, `{"_results":[]}`)
+		case r.URL.Path == "/conversations" && r.URL.Query().Get("q[inbox_id]") == "inb_1" && r.URL.Query().Get("q[statuses][]") == "unassigned":
+			_, _ = io.WriteString(w, `{"_results":[
+				{"id":"cnv_3","created_at":300},
+				{"id":"cnv_1","created_at":100},
+				{"id":"cnv_2","created_at":200},
+				{"id":"cnv_4","created_at":400}
+			]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvAssignBalancedCmd{Inbox: "inb_1", Teammates: []string{"tea_1", "tea_2"}, ByLoad: true, Max: 3}
+	cmd.Concurrency = 1
+
+	if _, err := captureStdout(t, func() error { return cmd.Run(&RootFlags{Account: "test@example.com"}) }); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	// tea_2 starts two behind, so it takes the two oldest before tea_1 gets one.
+	sort.Strings(assigned)
+
+	if got, want := strings.Join(assigned, ","), "cnv_1=tea_2,cnv_2=tea_2,cnv_3=tea_1"; got != want {
+		t.Fatalf("assigned %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type ConvBulkCmd struct {
+	Action      string `arg:"" help:"Action to apply (archive, open, trash, tag, untag, assign, unassign, snooze)" enum:"archive,open,trash,tag,untag,assign,unassign,snooze"`
+	Query       string `arg:"" help:"Search query selecting the conversations (same syntax as conv search)"`
+	TagID       string `help:"Tag (ID or name) for tag/untag" name:"tag-id"`
+	To          string `help:"Teammate (ID, email, username or me) for assign"`
+	Duration    string `help:"Snooze duration for snooze (e.g. 2h, 30m)"`
+	Max         int    `help:"Refuse to act when the query matches more conversations than this" default:"50"`
+	Concurrency int    `help:"Number of concurrent requests" default:"4"`
+	DryRun      bool   `help:"Show matching conversations without changing them"`
+}
+
+// bulkResult is the outcome of applying the action to one conversation.
+type bulkResult struct {
+	ID      string `json:"id"`
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"`
+}
+
+func (c *ConvBulkCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	apply, rec, err := c.action(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if c.Max <= 0 {
+		return fmt.Errorf("--max must be positive")
+	}
+
+	convs, err := searchAllConversations(ctx, client, c.Query, c.Max+1)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if len(convs) > c.Max {
+		return fmt.Errorf("query matches more than %d conversations; narrow the query or raise --max", c.Max)
+	}
+
+	if c.DryRun {
+		return c.printDryRun(mode, convs)
+	}
+
+	if len(convs) == 0 {
+		fmt.Fprintln(os.Stdout, "No conversations found.")
+
+		return nil
+	}
+
+	results := make([]bulkResult, len(convs))
+
+	var (
+		mu   sync.Mutex
+		done int
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(c.Concurrency, 1))
+
+	for i, conv := range convs {
+		results[i] = bulkResult{ID: conv.ID, Skipped: true}
+
+		g.Go(func() error {
+			// After Ctrl-C nothing new starts; aborted requests stay skipped.
+			if interrupted() {
+				return nil
+			}
+
+			err := apply(gctx, conv.ID)
+			if errors.Is(err, api.ErrInterrupted) {
+				return nil
+			}
+
+			res := bulkResult{ID: conv.ID, OK: err == nil}
+			if err != nil {
+				res.Error = err.Error()
+			} else if rec != nil {
+				rec.note(&conv)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			results[i] = res
+			done++
+
+			if !mode.JSON {
+				if res.OK {
+					fmt.Fprintf(os.Stdout, "[%d/%d] %s %s\n", done, len(convs), c.Action, conv.ID)
+				} else {
+					fmt.Fprintf(os.Stderr, "[%d/%d] Failed to %s %s: %s\n", done, len(convs), c.Action, conv.ID, res.Error)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	if rec != nil {
+		rec.save(flags)
+	}
+
+	failed, skipped := 0, 0
+
+	for _, res := range results {
+		switch {
+		case res.Skipped:
+			skipped++
+		case !res.OK:
+			failed++
+		}
+	}
+
+	if mode.JSON {
+		if err := mode.Write(os.Stdout, results); err != nil {
+			return err
+		}
+	} else if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Interrupted: %d succeeded, %d failed, %d skipped\n", len(results)-failed-skipped, failed, skipped)
+	} else {
+		fmt.Fprintf(os.Stderr, "Done: %d succeeded, %d failed\n", len(results)-failed, failed)
+	}
+
+	if interrupted() {
+		return fmt.Errorf("%s stopped after %d of %d conversations: %w", c.Action, len(results)-skipped, len(results), api.ErrInterrupted)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d conversations failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// action validates the flags for c.Action and returns the function that
+// applies it to a single conversation, and the recorder of its undo journal
+// when 'frontcli undo' can revert it.
+func (c *ConvBulkCmd) action(ctx context.Context, client *api.Client) (func(context.Context, string) error, *undoRecorder, error) {
+	patch := func(body any) func(context.Context, string) error {
+		return func(ctx context.Context, id string) error {
+			return client.Patch(ctx, "/conversations/"+id, body, nil)
+		}
+	}
+
+	switch c.Action {
+	case "archive":
+		return patch(map[string]string{"status": "archived"}), newUndoRecorder(c.Action, ""), nil
+	case "open":
+		return patch(map[string]string{"status": "open"}), newUndoRecorder(c.Action, ""), nil
+	case "trash":
+		return patch(map[string]string{"status": "trashed"}), newUndoRecorder(c.Action, ""), nil
+	case "unassign":
+		return patch(map[string]any{"assignee_id": nil}), nil, nil
+	case "assign":
+		if strings.TrimSpace(c.To) == "" {
+			return nil, nil, fmt.Errorf("--to is required for assign")
+		}
+
+		assigneeID, err := client.ResolveTeammate(ctx, c.To)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return patch(map[string]string{"assignee_id": assigneeID}), nil, nil
+	case "tag", "untag":
+		if strings.TrimSpace(c.TagID) == "" {
+			return nil, nil, fmt.Errorf("--tag-id is required for %s", c.Action)
+		}
+
+		resolved, err := client.ResolveTag(ctx, c.TagID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tagID, err := api.SanitizeID(resolved)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid tag ID %q: %w", resolved, err)
+		}
+
+		rec := newUndoRecorder(c.Action, tagID)
+
+		if c.Action == "untag" {
+			return func(ctx context.Context, id string) error {
+				return client.Delete(ctx, fmt.Sprintf("/conversations/%s/tags/%s", id, tagID))
+			}, rec, nil
+		}
+
+		return func(ctx context.Context, id string) error {
+			return client.Post(ctx, fmt.Sprintf("/conversations/%s/tags", id), map[string][]string{"tag_ids": {tagID}}, nil)
+		}, rec, nil
+	case "snooze":
+		d, err := time.ParseDuration(strings.TrimSpace(c.Duration))
+		if err != nil {
+			return nil, nil, fmt.Errorf("--duration is required for snooze: %w", err)
+		}
+
+		req := map[string]string{"scheduled_at": time.Now().Add(d).UTC().Format(time.RFC3339)}
+
+		return func(ctx context.Context, id string) error {
+			return client.Patch(ctx, fmt.Sprintf("/conversations/%s/reminders", id), req, nil)
+		}, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown action: %s", c.Action)
+}
+
+func (c *ConvBulkCmd) printDryRun(mode output.Mode, convs []api.Conversation) error {
+	if mode.JSON {
+		return mode.Write(os.Stdout, convs)
+	}
+
+	if len(convs) == 0 {
+		fmt.Fprintln(os.Stdout, "No conversations found.")
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED", "UPDATED")
+
+	for _, conv := range convs {
+		tbl.AddRow(output.FormatConversationWithUpdated(conv)...)
+	}
+
+	if err := tbl.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Dry run: would %s %d conversations\n", c.Action, len(convs))
+
+	return nil
+}
+
+// searchAllConversations follows search pagination until limit results have
+// been collected or the results are exhausted.
+func searchAllConversations(ctx context.Context, client *api.Client, query string, limit int) ([]api.Conversation, error) {
+	var convs []api.Conversation
+
+	opts := convSearchOptions{PageSize: 100, MaxResults: limit, All: true}
+
+	_, err := searchConversations(ctx, client, query, opts, func(page []api.Conversation) error {
+		convs = append(convs, page...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return convs, nil
+}
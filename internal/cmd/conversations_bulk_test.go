@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func newBulkServer(t *testing.T, patched *[]string) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/conversations/search/"):
+			_, _ = io.WriteString(w, `{"_results":[{"id":"cnv_1"},{"id":"cnv_2"},{"id":"cnv_3"}]}`)
+		case r.Method == http.MethodPatch:
+			mu.Lock()
+			*patched = append(*patched, strings.TrimPrefix(r.URL.Path, "/conversations/"))
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func useTestServer(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+
+	// Batch commands write their undo journal to the config dir.
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	old := newClientFromAuth
+	newClientFromAuth = func(_, _ string) (*api.Client, error) {
+		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
+	}
+	t.Cleanup(func() { newClientFromAuth = old })
+}
+
+func TestConvBulkArchivesMatches(t *testing.T) {
+	var patched []string
+
+	srv := newBulkServer(t, &patched)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvBulkCmd{Action: "archive", Query: "tag:spam", Max: 10, Concurrency: 2}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	sort.Strings(patched)
+
+	if strings.Join(patched, ",") != "cnv_1,cnv_2,cnv_3" {
+		t.Fatalf("unexpected PATCH requests: %v", patched)
+	}
+}
+
+func TestConvBulkDryRunMakesNoChanges(t *testing.T) {
+	var patched []string
+
+	srv := newBulkServer(t, &patched)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvBulkCmd{Action: "archive", Query: "tag:spam", Max: 10, Concurrency: 2, DryRun: true}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(patched) != 0 {
+		t.Fatalf("expected no changes, got %v", patched)
+	}
+}
+
+func TestConvBulkRefusesTooManyMatches(t *testing.T) {
+	var patched []string
+
+	srv := newBulkServer(t, &patched)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvBulkCmd{Action: "archive", Query: "tag:spam", Max: 2, Concurrency: 2}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err == nil {
+		t.Fatal("expected error when matches exceed --max")
+	}
+
+	if len(patched) != 0 {
+		t.Fatalf("expected no changes, got %v", patched)
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+)
+
+// Front queues outbound messages, so the new conversation only exists once
+// the message has been processed. These are vars so tests can poll without
+// sleeping.
+var (
+	convCreatePollInterval = time.Second
+	convCreatePollTimeout  = 30 * time.Second
+)
+
+type ConvCreateCmd struct {
+	Channel  string   `required:"" help:"Channel ID to send from"`
+	To       []string `required:"" help:"Recipient handle; repeatable"`
+	Subject  string   `help:"Conversation subject"`
+	Body     string   `help:"Message body"`
+	BodyFile string   `help:"Read body from file (- for stdin)" type:"existingfile"`
+	Tag      []string `help:"Tag to add (ID or name); repeatable"`
+	Assignee string   `help:"Teammate to assign (ID, email, username or me)"`
+	Archive  bool     `help:"Archive the conversation once sent"`
+}
+
+// convCreateResult is the JSON output of conv create.
+type convCreateResult struct {
+	MessageUID     string `json:"message_uid"`
+	MessageID      string `json:"message_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	AssigneeID     string `json:"assignee_id,omitempty"`
+}
+
+func (c *ConvCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	if body == "" {
+		return fmt.Errorf("body is required (use --body or --body-file)")
+	}
+
+	channelID, err := api.SanitizeID(c.Channel)
+	if err != nil {
+		return fmt.Errorf("invalid channel ID %q: %w", c.Channel, err)
+	}
+
+	// Resolve names before sending so a typo doesn't leave a half-done
+	// conversation behind.
+	tagIDs, err := resolveAll(ctx, c.Tag, client.ResolveTag)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	assigneeID, err := client.ResolveTeammate(ctx, c.Assignee)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	req := map[string]any{
+		"to":   c.To,
+		"body": body,
+	}
+
+	if c.Subject != "" {
+		req["subject"] = c.Subject
+	}
+
+	options := map[string]any{}
+
+	if len(tagIDs) > 0 {
+		options["tag_ids"] = tagIDs
+	}
+
+	if c.Archive {
+		options["archive"] = true
+	}
+
+	if len(options) > 0 {
+		req["options"] = options
+	}
+
+	var accepted api.MessageAccepted
+	if err := client.Post(ctx, fmt.Sprintf("/channels/%s/messages", channelID), req, &accepted); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	result := convCreateResult{MessageUID: accepted.MessageUID}
+
+	if accepted.MessageUID != "" {
+		msg, err := waitForMessage(ctx, client, accepted.MessageUID)
+		if err != nil && assigneeID != "" {
+			return fmt.Errorf("message %s sent but not assigned: %w", accepted.MessageUID, err)
+		}
+
+		if msg != nil {
+			result.MessageID = msg.ID
+			result.ConversationID = msg.ConversationID()
+		}
+	}
+
+	if assigneeID != "" {
+		if result.ConversationID == "" {
+			return fmt.Errorf("message %s sent but not assigned: conversation ID unknown", accepted.MessageUID)
+		}
+
+		patch := map[string]string{"assignee_id": assigneeID}
+		if err := client.Patch(ctx, "/conversations/"+result.ConversationID, patch, nil); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		result.AssigneeID = assigneeID
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	if result.ConversationID == "" {
+		fmt.Fprintf(os.Stdout, "Message queued: %s\n", accepted.MessageUID)
+
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "Conversation created: %s\n", result.ConversationID)
+
+	if result.AssigneeID != "" {
+		fmt.Fprintf(os.Stdout, "Assigned to %s\n", c.Assignee)
+	}
+
+	return nil
+}
+
+// waitForMessage polls for a queued message until Front has processed it.
+func waitForMessage(ctx context.Context, client *api.Client, uid string) (*api.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, convCreatePollTimeout)
+	defer cancel()
+
+	for {
+		msg, err := client.GetMessage(ctx, "alt:uid:"+uid)
+		if err == nil {
+			return msg, nil
+		}
+
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for message %s: %w", uid, ctx.Err())
+		case <-time.After(convCreatePollInterval):
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestConvCreateTagsAndAssigns(t *testing.T) {
+	oldInterval := convCreatePollInterval
+	convCreatePollInterval = 0
+
+	t.Cleanup(func() { convCreatePollInterval = oldInterval })
+
+	var (
+		mu       sync.Mutex
+		sent     map[string]any
+		assigned map[string]any
+		lookups  int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tags":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tag_vip","name":"VIP"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/teammates":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tea_1","email":"alice@example.com"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/channels/cha_1/messages":
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &sent)
+
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"status":"accepted","message_uid":"abc"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/messages/alt:uid:abc":
+			lookups++
+			if lookups == 1 {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"id":"msg_1","_links":{"related":{"conversation":"https://api2.frontapp.com/conversations/cnv_9"}}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/conversations/cnv_9":
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &assigned)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvCreateCmd{
+		Channel:  "cha_1",
+		To:       []string{"bob@example.com"},
+		Subject:  "Hello",
+		Body:     "Hi Bob",
+		Tag:      []string{"vip"},
+		Assignee: "alice@example.com",
+	}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	options, _ := sent["options"].(map[string]any)
+	if tags, _ := options["tag_ids"].([]any); len(tags) != 1 || tags[0] != "tag_vip" {
+		t.Fatalf("sent options = %v", sent["options"])
+	}
+
+	if sent["subject"] != "Hello" {
+		t.Fatalf("sent subject = %v", sent["subject"])
+	}
+
+	if assigned["assignee_id"] != "tea_1" {
+		t.Fatalf("assigned = %v", assigned)
+	}
+
+	if lookups != 2 {
+		t.Fatalf("message looked up %d times, want 2", lookups)
+	}
+}
+
+func TestConvCreateRequiresBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvCreateCmd{Channel: "cha_1", To: []string{"bob@example.com"}}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err == nil {
+		t.Fatal("expected error without a body")
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/export"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type ConvExportCmd struct {
+	ID            string `arg:"" help:"Conversation ID"`
+	Type          string `help:"Export format" enum:"eml,mbox,json" default:"eml"`
+	Output        string `name:"out" short:"o" help:"Output directory" default:"."`
+	NoAttachments bool   `help:"Skip downloading attachments"`
+}
+
+// conversationExport is the document written by --type json.
+type conversationExport struct {
+	Conversation *api.Conversation `json:"conversation"`
+	Messages     []api.Message     `json:"messages"`
+}
+
+func (c *ConvExportCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	id, err := api.SanitizeID(c.ID)
+	if err != nil {
+		return fmt.Errorf("invalid conversation ID %q: %w", c.ID, err)
+	}
+
+	dir, err := config.ExpandPath(c.Output)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec // user-visible export directory
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	conv, err := client.GetConversation(ctx, id)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	msgs, err := fetchAllMessages(ctx, client, id)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	export.SortByDate(msgs)
+
+	files := make([][]export.File, len(msgs))
+	if !c.NoAttachments {
+		if files, err = downloadAttachments(ctx, client, msgs); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
+	var written []string
+
+	switch c.Type {
+	case "json":
+		written, err = writeJSONExport(dir, conv, msgs, files)
+	case "mbox":
+		written, err = writeMboxExport(dir, id, msgs, files)
+	default:
+		written, err = writeEMLExport(dir, id, msgs, files)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, path := range written {
+		fmt.Fprintln(os.Stdout, path)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d messages from %s\n", len(msgs), id)
+
+	return nil
+}
+
+// fetchAllMessages lists every message in a conversation and fetches each in
+// full, since list results may omit bodies.
+func fetchAllMessages(ctx context.Context, client *api.Client, convID string) ([]api.Message, error) {
+	var ids []string
+
+	path := fmt.Sprintf("/conversations/%s/messages?limit=100", convID)
+
+	_, err := listPages(ctx, client, path, PaginationFlags{All: true}, func(page *api.ListResponse[api.Message]) error {
+		for _, msg := range page.Results {
+			ids = append(ids, msg.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]api.Message, len(ids))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(5) // Max 5 concurrent requests
+
+	for i, msgID := range ids {
+		g.Go(func() error {
+			msg, err := client.GetMessage(ctx, msgID)
+			if err != nil {
+				return err
+			}
+
+			messages[i] = *msg
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// downloadAttachments fetches the attachments of each message, indexed like msgs.
+func downloadAttachments(ctx context.Context, client *api.Client, msgs []api.Message) ([][]export.File, error) {
+	files := make([][]export.File, len(msgs))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(5)
+
+	for i, msg := range msgs {
+		files[i] = make([]export.File, len(msg.Attachments))
+
+		for j, att := range msg.Attachments {
+			g.Go(func() error {
+				var buf bytes.Buffer
+				if err := client.Download(ctx, attachmentPath(att), &buf); err != nil {
+					return err
+				}
+
+				files[i][j] = export.File{
+					Filename:    att.Filename,
+					ContentType: att.ContentType,
+					Data:        buf.Bytes(),
+				}
+
+				return nil
+			})
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// attachmentPath returns the download path for an attachment, falling back to
+// the ID embedded in its URL.
+func attachmentPath(att api.Attachment) string {
+	id := att.ID
+	if id == "" {
+		id = att.URL[strings.LastIndex(att.URL, "/")+1:]
+	}
+
+	return "/download/" + id
+}
+
+func writeEMLExport(dir, convID string, msgs []api.Message, files [][]export.File) ([]string, error) {
+	convDir := filepath.Join(dir, convID)
+	if err := os.MkdirAll(convDir, 0o755); err != nil { //nolint:gosec // user-visible export directory
+		return nil, fmt.Errorf("create output directory: %w", err)
+	}
+
+	paths := make([]string, 0, len(msgs))
+
+	for i, msg := range msgs {
+		var buf bytes.Buffer
+		if err := export.WriteEML(&buf, msg, files[i]); err != nil {
+			return nil, err
+		}
+
+		path := filepath.Join(convDir, fmt.Sprintf("%03d-%s.eml", i+1, msg.ID))
+		if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+			return nil, fmt.Errorf("write %s: %w", path, err)
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+func writeMboxExport(dir, convID string, msgs []api.Message, files [][]export.File) ([]string, error) {
+	var buf bytes.Buffer
+
+	for i, msg := range msgs {
+		if err := export.WriteMbox(&buf, msg, files[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(dir, convID+".mbox")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return nil, fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return []string{path}, nil
+}
+
+func writeJSONExport(dir string, conv *api.Conversation, msgs []api.Message, files [][]export.File) ([]string, error) {
+	var buf bytes.Buffer
+	if err := output.WriteJSON(&buf, conversationExport{Conversation: conv, Messages: msgs}); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, conv.ID+".json")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return nil, fmt.Errorf("write %s: %w", path, err)
+	}
+
+	paths := []string{path}
+
+	for i, msg := range msgs {
+		for _, f := range files[i] {
+			attDir := filepath.Join(dir, conv.ID+"_attachments")
+			if err := os.MkdirAll(attDir, 0o755); err != nil { //nolint:gosec // user-visible export directory
+				return nil, fmt.Errorf("create attachments directory: %w", err)
+			}
+
+			attPath := filepath.Join(attDir, msg.ID+"-"+filepath.Base(f.Filename))
+			if err := os.WriteFile(attPath, f.Data, 0o600); err != nil {
+				return nil, fmt.Errorf("write %s: %w", attPath, err)
+			}
+
+			paths = append(paths, attPath)
+		}
+	}
+
+	return paths, nil
+}
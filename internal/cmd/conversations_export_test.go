@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvExportWritesEMLWithAttachments(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations/cnv_1":
+			_, _ = io.WriteString(w, `{"id":"cnv_1","subject":"Hi"}`)
+		case "/conversations/cnv_1/messages":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"msg_2"},{"id":"msg_1"}]}`)
+		case "/messages/msg_1":
+			_, _ = io.WriteString(w, `{"id":"msg_1","created_at":1,"text":"first","subject":"Hi",`+
+				`"attachments":[{"id":"fil_1","filename":"a.txt","content_type":"text/plain"}]}`)
+		case "/messages/msg_2":
+			_, _ = io.WriteString(w, `{"id":"msg_2","created_at":2,"text":"second","subject":"Re: Hi"}`)
+		case "/download/fil_1":
+			_, _ = io.WriteString(w, "attachment body")
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	dir := t.TempDir()
+
+	cmd := ConvExportCmd{ID: "cnv_1", Type: "eml", Output: dir}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	first, err := os.ReadFile(filepath.Join(dir, "cnv_1", "001-msg_1.eml"))
+	if err != nil {
+		t.Fatalf("read first message: %v", err)
+	}
+
+	if !strings.Contains(string(first), `filename="a.txt"`) {
+		t.Fatalf("expected attachment in first message:\n%s", first)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "cnv_1", "002-msg_2.eml")); err != nil {
+		t.Fatalf("expected second message file: %v", err)
+	}
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
 )
 
 func buildConvSearchQuery(c *ConvSearchCmd) (string, error) {
@@ -49,8 +54,17 @@ func buildConvSearchQuery(c *ConvSearchCmd) (string, error) {
 		parts = append(parts, "is:unassigned")
 	}
 
-	add("before", c.Before)
-	add("after", c.After)
+	// Search takes Unix timestamps; accept the same dates as other flags.
+	for _, bound := range []struct{ prefix, value string }{{"before", c.Before}, {"after", c.After}} {
+		ts, err := parseTimeFlag(bound.value)
+		if err != nil {
+			return "", fmt.Errorf("--%s: %w", bound.prefix, err)
+		}
+
+		if ts != 0 {
+			add(bound.prefix, strconv.FormatInt(int64(ts), 10))
+		}
+	}
 
 	if strings.TrimSpace(c.Query) != "" {
 		parts = append(parts, strings.TrimSpace(c.Query))
@@ -63,6 +77,30 @@ func buildConvSearchQuery(c *ConvSearchCmd) (string, error) {
 	return strings.Join(parts, " "), nil
 }
 
+// meFilterPattern matches search filters that take a teammate, given as "me":
+// assignee:me, -participant:me and so on.
+var meFilterPattern = regexp.MustCompile(`(?i)(^|\s)(-?(?:assignee|participant|author|mention|commenter)):me(\s|$)`)
+
+// resolveMeFilters replaces "me" in the teammate filters of a search query
+// with the authenticated teammate's ID, fetching /me only when one is used.
+func resolveMeFilters(ctx context.Context, client *api.Client, query string) (string, error) {
+	if !meFilterPattern.MatchString(query) {
+		return query, nil
+	}
+
+	me, err := client.ResolveTeammate(ctx, "me")
+	if err != nil {
+		return "", err
+	}
+
+	// Matches share the whitespace between them, so replace until none is left.
+	for meFilterPattern.MatchString(query) {
+		query = meFilterPattern.ReplaceAllString(query, "${1}${2}:"+me+"${3}")
+	}
+
+	return query, nil
+}
+
 // maxStdinBytes limits stdin reads to 1 MiB to prevent unbounded memory use.
 const maxStdinBytes = 1 << 20
 
@@ -83,3 +121,26 @@ func readIDsFromInput(source string) ([]string, error) {
 	fields := strings.Fields(string(data))
 	return fields, nil
 }
+
+// readFileOrStdin reads a --*-file flag value; "-" reads stdin.
+func readFileOrStdin(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(io.LimitReader(os.Stdin, maxStdinBytes))
+	}
+
+	return os.ReadFile(path) //nolint:gosec // user-provided input file
+}
+
+// readBodyFlag returns the contents of --body-file when set, or else --body.
+func readBodyFlag(value, file string) (string, error) {
+	if file == "" {
+		return value, nil
+	}
+
+	data, err := readFileOrStdin(file)
+	if err != nil {
+		return "", fmt.Errorf("read body file: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -3,8 +3,10 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"net/url"
+	"maps"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 
@@ -17,11 +19,20 @@ import (
 )
 
 type ConvListCmd struct {
-	Inbox     string `help:"Filter by inbox ID"`
-	Tag       string `help:"Filter by tag ID"`
-	Status    string `help:"Filter by status (open, assigned, unassigned, archived, snoozed, trashed)"`
-	Limit     int    `help:"Maximum number of results" default:"25"`
-	SortOrder string `help:"Sort order (asc, desc)" short:"s" enum:"asc,desc,-" default:"-"`
+	PaginationFlags `embed:""`
+
+	Inbox         []string `help:"Filter by inbox (ID or name); repeatable, matches any"`
+	Tag           []string `help:"Filter by tag (ID or name); repeatable"`
+	Match         string   `help:"With several --tag values, list conversations with any or all of them" enum:"any,all" default:"any"`
+	Status        string   `help:"Filter by status (open, assigned, unassigned, archived, snoozed, trashed)"`
+	Assignee      string   `help:"Filter by assignee (ID, email, username, name or me)"`
+	Contact       string   `help:"Filter by contact (ID or email)"`
+	UpdatedAfter  string   `help:"Only conversations updated after this time (RFC3339, YYYY-MM-DD or Unix seconds)" name:"updated-after"`
+	UpdatedBefore string   `help:"Only conversations updated before this time (RFC3339, YYYY-MM-DD or Unix seconds)" name:"updated-before"`
+	Limit         int      `help:"Maximum number of results" default:"25"`
+	SortOrder     string   `help:"Sort order (asc, desc)" short:"s" enum:"asc,desc,-" default:"-"`
+	WaitingLonger string   `help:"Only conversations waiting for a reply longer than this (e.g. 4h, 2d)" name:"waiting-longer-than"`
+	Seen          bool     `help:"Add an UNSEEN column: yes when the latest reply has not been seen by its recipients (two extra requests per conversation)"`
 }
 
 func (c *ConvListCmd) Run(flags *RootFlags) error {
@@ -37,37 +48,182 @@ func (c *ConvListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListConversations(ctx, api.ListConversationsOptions{
-		InboxID:   c.Inbox,
-		TagID:     c.Tag,
-		Statuses:  api.ParseStatus(c.Status),
-		Limit:     c.Limit,
-		SortOrder: c.SortOrder,
-	})
+	inboxIDs, err := resolveAll(ctx, c.Inbox, client.ResolveInbox)
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+	tagIDs, err := resolveAll(ctx, c.Tag, client.ResolveTag)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
 	}
 
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No conversations found.")
+	assigneeID, err := client.ResolveTeammate(ctx, c.Assignee)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	updatedAfter, err := parseTimeFlag(c.UpdatedAfter)
+	if err != nil {
+		return fmt.Errorf("--updated-after: %w", err)
+	}
+
+	updatedBefore, err := parseTimeFlag(c.UpdatedBefore)
+	if err != nil {
+		return fmt.Errorf("--updated-before: %w", err)
+	}
+
+	waitingBefore, err := parseTimeFlag(c.WaitingLonger)
+	if err != nil {
+		return fmt.Errorf("--waiting-longer-than: %w", err)
+	}
+
+	opts := api.ListConversationsOptions{
+		InboxIDs:      inboxIDs,
+		TagIDs:        tagIDs,
+		AssigneeID:    assigneeID,
+		ContactID:     api.ContactRef(c.Contact),
+		Statuses:      api.ParseStatus(c.Status),
+		UpdatedAfter:  updatedAfter,
+		UpdatedBefore: updatedBefore,
+		Limit:         c.Limit,
+		SortOrder:     c.SortOrder,
+	}
+
+	path, err := opts.Path()
+	if err != nil {
+		return err
+	}
+
+	list := pagedList[api.Conversation]{
+		Path:    path,
+		Empty:   "No conversations found.",
+		Headers: []string{"ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED", "UPDATED", "WAITING"},
+		Row:     output.FormatConversationWithWaiting,
+		Keep:    keepAll(waitingSince(waitingBefore), c.tagMatch(tagIDs)),
+	}
+
+	if c.Seen {
+		if len(mode.Columns) > 0 {
+			return fmt.Errorf("--seen adds a column to the default table and cannot be combined with --columns")
+		}
 
+		unseen := make(map[string]string)
+
+		list.Headers = append(list.Headers, "UNSEEN")
+		list.Row = func(conv api.Conversation) []string {
+			return append(output.FormatConversationWithWaiting(conv), unseen[conv.ID])
+		}
+		list.Prepare = func(convs []api.Conversation) error {
+			return fetchUnseen(ctx, client, convs, unseen)
+		}
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, list)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// fetchUnseen records in unseen, for each conversation, whether the latest
+// outbound message among its recent ones has been seen: "yes" when nobody
+// has seen it, "no" when someone has, and "-" without a reply to check.
+func fetchUnseen(ctx context.Context, client *api.Client, convs []api.Conversation, unseen map[string]string) error {
+	var mu sync.Mutex
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(4)
+
+	for _, conv := range convs {
+		g.Go(func() error {
+			// Messages come newest first.
+			msgs, err := client.ListConversationMessages(gctx, conv.ID, 10)
+			if err != nil {
+				return err
+			}
+
+			state := "-"
+
+			if i := slices.IndexFunc(msgs.Results, func(m api.Message) bool { return !m.IsInbound }); i >= 0 {
+				receipts, err := client.MessageSeenReceipts(gctx, msgs.Results[i].ID)
+				if err != nil {
+					return err
+				}
+
+				state = "no"
+				if len(receipts) == 0 {
+					state = "yes"
+				}
+			}
+
+			mu.Lock()
+			unseen[conv.ID] = state
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// tagMatch keeps conversations carrying every tag in tagIDs with --match
+// all; the API itself matches any of them.
+func (c *ConvListCmd) tagMatch(tagIDs []string) func(api.Conversation) bool {
+	if c.Match != "all" || len(tagIDs) < 2 {
+		return nil
+	}
+
+	return func(conv api.Conversation) bool {
+		for _, id := range tagIDs {
+			if !slices.ContainsFunc(conv.Tags, func(tag api.Tag) bool { return tag.ID == id }) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// keepAll combines filters; nil filters are skipped, and with none left it
+// returns nil.
+func keepAll[T any](filters ...func(T) bool) func(T) bool {
+	filters = slices.DeleteFunc(filters, func(f func(T) bool) bool { return f == nil })
+	if len(filters) == 0 {
 		return nil
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED", "UPDATED")
+	return func(item T) bool {
+		for _, keep := range filters {
+			if !keep(item) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
 
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversationWithUpdated(conv)...)
+// waitingSince keeps conversations that have waited for a reply since before
+// the given time; with 0 it keeps everything.
+func waitingSince(before float64) func(api.Conversation) bool {
+	if before == 0 {
+		return nil
 	}
 
-	return tbl.Flush()
+	return func(conv api.Conversation) bool {
+		return conv.WaitingSince > 0 && conv.WaitingSince < before
+	}
 }
 
 type ConvGetCmd struct {
@@ -77,11 +233,24 @@ type ConvGetCmd struct {
 	Full     bool   `help:"Include full content with comments inline (implies -m -c)"`
 	HTML     bool   `help:"Show message body as HTML (with --full)"`
 	Text     bool   `help:"Show message body as plain text (with --full)"`
+	Web      bool   `help:"Open the conversation in the Front web app instead"`
+
+	Links   bool     `help:"Show the conversation's related API links (events, followers, drafts, ...)"`
+	Related []string `help:"Fetch related resources and show them inline: events"`
 }
 
+// convGetRelated are the related resources --related can inline.
+var convGetRelated = []string{"events"}
+
 func (c *ConvGetCmd) Run(flags *RootFlags) error {
 	ctx := context.Background()
 
+	for _, name := range c.Related {
+		if !slices.Contains(convGetRelated, name) {
+			return fmt.Errorf("unsupported --related %q (use %s)", name, strings.Join(convGetRelated, ", "))
+		}
+	}
+
 	client, err := getClient(flags)
 	if err != nil {
 		return err
@@ -99,13 +268,31 @@ func (c *ConvGetCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if c.Web {
+		return openWeb(conv.WebURL())
+	}
+
 	// --full implies -m -c
 	showMessages := c.Messages || c.Full
 	showComments := c.Comments || c.Full
 
+	var events []api.Event
+	if slices.Contains(c.Related, "events") {
+		if events, err = c.fetchEvents(ctx, client); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
 	if mode.JSON {
+		// The conversation's _links are always part of its JSON.
 		result := map[string]any{"conversation": conv}
 
+		if events != nil {
+			result["events"] = events
+		}
+
 		if showMessages {
 			msgs, err := c.fetchMessages(ctx, client)
 			if err != nil {
@@ -124,12 +311,12 @@ func (c *ConvGetCmd) Run(flags *RootFlags) error {
 			result["comments"] = comments
 		}
 
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:       %s\n", conv.ID)
 	fmt.Fprintf(os.Stdout, "Subject:  %s\n", conv.Subject)
-	fmt.Fprintf(os.Stdout, "Status:   %s\n", conv.Status)
+	fmt.Fprintf(os.Stdout, "Status:   %s\n", output.StyleStatus(conv.Status))
 
 	if conv.Assignee != nil {
 		fmt.Fprintf(os.Stdout, "Assignee: %s\n", conv.Assignee.Email)
@@ -146,6 +333,39 @@ func (c *ConvGetCmd) Run(flags *RootFlags) error {
 
 	fmt.Fprintf(os.Stdout, "Created:  %s\n", output.FormatTimestamp(conv.CreatedAt))
 
+	if c.Links {
+		fmt.Fprintln(os.Stdout, "\nLinks:")
+
+		tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+		tbl.AddRow("NAME", "URL")
+		tbl.AddRow("self", conv.Links.Self)
+
+		for _, name := range slices.Sorted(maps.Keys(conv.Links.Related)) {
+			tbl.AddRow(name, conv.Links.Related[name])
+		}
+
+		if err := tbl.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if events != nil {
+		fmt.Fprintln(os.Stdout, "\nEvents:")
+
+		tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+		tbl.AddRow("ID", "TYPE", "SOURCE", "TARGET", "DATE")
+
+		for _, event := range events {
+			row := output.FormatEvent(event)
+			// Every event is on this conversation; drop that column.
+			tbl.AddRow(append(row[:4:4], row[5])...)
+		}
+
+		if err := tbl.Flush(); err != nil {
+			return err
+		}
+	}
+
 	if c.Full {
 		return c.printFullTimeline(ctx, client)
 	}
@@ -185,25 +405,7 @@ func (c *ConvGetCmd) Run(flags *RootFlags) error {
 			tbl.AddRow("ID", "AUTHOR", "BODY", "DATE")
 
 			for _, comment := range comments {
-				author := "-"
-				if comment.Author != nil {
-					author = comment.Author.Email
-					if author == "" {
-						author = comment.Author.Username
-					}
-				}
-
-				body := comment.Body
-				if len(body) > 50 {
-					body = body[:47] + "..."
-				}
-
-				tbl.AddRow(
-					comment.ID,
-					author,
-					body,
-					output.FormatTimestamp(comment.PostedAt),
-				)
+				tbl.AddRow(output.FormatComment(comment)...)
 			}
 
 			if err := tbl.Flush(); err != nil {
@@ -230,6 +432,31 @@ func (c *ConvGetCmd) fetchMessages(ctx context.Context, client *api.Client) ([]a
 	return c.fetchFullMessages(ctx, client)
 }
 
+// fetchEvents returns the conversation's whole event history, oldest first.
+func (c *ConvGetCmd) fetchEvents(ctx context.Context, client *api.Client) ([]api.Event, error) {
+	convID, err := api.SanitizeID(c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid conversation ID %q: %w", c.ID, err)
+	}
+
+	events := []api.Event{}
+
+	path := fmt.Sprintf("/conversations/%s/events?limit=100", convID)
+
+	_, err = listPages(ctx, client, path, PaginationFlags{All: true}, func(page *api.ListResponse[api.Event]) error {
+		events = append(events, page.Results...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].EmittedAt < events[j].EmittedAt })
+
+	return events, nil
+}
+
 func (c *ConvGetCmd) fetchComments(ctx context.Context, client *api.Client) ([]api.Comment, error) {
 	var resp api.ListResponse[api.Comment]
 	if err := client.Get(ctx, fmt.Sprintf("/conversations/%s/comments?limit=50", c.ID), &resp); err != nil {
@@ -326,7 +553,14 @@ func (c *ConvGetCmd) printFullTimeline(ctx context.Context, client *api.Client)
 		})
 	}
 
+	// Replies are printed under the comment they answer, not on their own.
+	replies := threadComments(comments)
+
 	for i := range comments {
+		if replies.byID[comments[i].ParentID()] {
+			continue
+		}
+
 		timeline = append(timeline, timelineItem{
 			timestamp: comments[i].PostedAt,
 			comment:   &comments[i],
@@ -342,7 +576,7 @@ func (c *ConvGetCmd) printFullTimeline(ctx context.Context, client *api.Client)
 		if item.message != nil {
 			c.printMessage(*item.message)
 		} else {
-			c.printComment(*item.comment)
+			c.printComment(*item.comment, replies, 0)
 		}
 
 		if i < len(timeline)-1 {
@@ -370,9 +604,9 @@ func sortTimeline(items []timelineItem) {
 
 func (c *ConvGetCmd) printMessage(msg api.Message) {
 	// Direction
-	dir := "→"
+	dir := output.StyleDirection("→", false)
 	if msg.IsInbound {
-		dir = "←"
+		dir = output.StyleDirection("←", true)
 	}
 
 	// From
@@ -394,7 +628,33 @@ func (c *ConvGetCmd) printMessage(msg api.Message) {
 	fmt.Fprintln(os.Stdout)
 }
 
-func (c *ConvGetCmd) printComment(comment api.Comment) {
+// commentThreads indexes comments by ID and lists the replies to each.
+type commentThreads struct {
+	byID    map[string]bool
+	replies map[string][]api.Comment
+}
+
+func threadComments(comments []api.Comment) commentThreads {
+	t := commentThreads{byID: map[string]bool{}, replies: map[string][]api.Comment{}}
+
+	for _, comment := range comments {
+		t.byID[comment.ID] = true
+	}
+
+	for _, comment := range comments {
+		if parent := comment.ParentID(); t.byID[parent] {
+			t.replies[parent] = append(t.replies[parent], comment)
+		}
+	}
+
+	for _, r := range t.replies {
+		sort.SliceStable(r, func(i, j int) bool { return r[i].PostedAt < r[j].PostedAt })
+	}
+
+	return t
+}
+
+func (c *ConvGetCmd) printComment(comment api.Comment, threads commentThreads, depth int) {
 	// From
 	from := "-"
 	if comment.Author != nil {
@@ -404,13 +664,27 @@ func (c *ConvGetCmd) printComment(comment api.Comment) {
 		}
 	}
 
-	// Header with comment ID (# indicates internal comment)
-	fmt.Fprintf(os.Stdout, "# %s  %s  [comment:%s]\n", from, output.FormatTimestamp(comment.PostedAt), comment.ID)
+	indent := strings.Repeat("    ", depth)
+
+	marker := "#"
+	if depth > 0 {
+		marker = "↳"
+	}
+
+	// Header with comment ID (# indicates internal comment, ↳ a reply)
+	fmt.Fprintf(os.Stdout, "%s%s %s  %s  [comment:%s]\n", indent, marker, from, output.FormatTimestamp(comment.PostedAt), comment.ID)
 	fmt.Fprintln(os.Stdout)
 
 	// Body (comments are plain text)
-	fmt.Fprintln(os.Stdout, comment.Body)
+	for _, line := range strings.Split(comment.Body, "\n") {
+		fmt.Fprintln(os.Stdout, strings.TrimRight(indent+line, " "))
+	}
+
 	fmt.Fprintln(os.Stdout)
+
+	for _, reply := range threads.replies[comment.ID] {
+		c.printComment(reply, threads, depth+1)
+	}
 }
 
 func (c *ConvGetCmd) formatMessageBody(msg api.Message) string {
@@ -433,24 +707,33 @@ func (c *ConvGetCmd) formatMessageBody(msg api.Message) string {
 }
 
 type ConvSearchCmd struct {
+	PaginationFlags `embed:""`
+
 	Query      string   `arg:"" optional:"" help:"Search query"`
 	RawQuery   string   `help:"Raw query override" short:"q" name:"query"`
 	From       string   `help:"Filter by sender (from:)"`
 	To         string   `help:"Filter by recipient (to:)"`
 	Recipient  string   `help:"Filter by recipient (recipient:)"`
-	Inbox      string   `help:"Filter by inbox (inbox:)"`
-	Tag        []string `help:"Filter by tag (tag:)"`
+	Inbox      string   `help:"Filter by inbox ID or name (inbox:)"`
+	Tag        []string `help:"Filter by tag ID or name (tag:)"`
 	Status     string   `help:"Filter by status (open, archived, snoozed, trashed)"`
-	Assignee   string   `help:"Filter by assignee (assignee: or me)"`
+	Assignee   string   `help:"Filter by assignee ID, email, username or me (assignee:)"`
 	Unassigned bool     `help:"Filter unassigned conversations"`
-	Before     string   `help:"Filter before date/time (before:)"`
-	After      string   `help:"Filter after date/time (after:)"`
+	Before     string   `help:"Filter before date/time, e.g. 2024-01-15, yesterday, 3d (before:)"`
+	After      string   `help:"Filter after date/time, e.g. 2024-01-15 14:00, last monday, 2w (after:)"`
 	Limit      int      `help:"Maximum results" default:"25"`
+	MaxResults int      `help:"Stop after this many results when using --all (0 = no limit)" name:"max-results"`
+	Save       string   `help:"Save these filters as a named search, then run it"`
+	Saved      string   `help:"Run a saved search; filters given here are added to it"`
 }
 
 func (c *ConvSearchCmd) Run(flags *RootFlags) error {
 	ctx := context.Background()
 
+	if err := c.applySavedSearch(); err != nil {
+		return err
+	}
+
 	client, err := getClient(flags)
 	if err != nil {
 		return err
@@ -461,48 +744,127 @@ func (c *ConvSearchCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if err := c.resolveNames(ctx, client); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
 	query, err := buildConvSearchQuery(c)
 	if err != nil {
 		return err
 	}
 
-	// The query is a path parameter, not a query param
-	encodedQuery := url.PathEscape(query)
-	path := "/conversations/search/" + encodedQuery
+	if query, err = resolveMeFilters(ctx, client, query); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
 
-	// Add limit as a query parameter
-	if c.Limit > 0 {
-		path += fmt.Sprintf("?limit=%d", c.Limit)
+	opts := convSearchOptions{
+		PageSize:   c.Limit,
+		MaxResults: c.MaxResults,
+		All:        c.All,
+		PageToken:  c.PageToken,
 	}
 
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	table, err := pagedList[api.Conversation]{
+		Headers: []string{"ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED", "UPDATED"},
+		Row:     output.FormatConversationWithUpdated,
+	}.withColumns(mode)
+	if err != nil {
+		return err
+	}
+
+	var (
+		results []api.Conversation
+		tbl     output.TableWriter
+	)
+
+	nextToken, err := searchConversations(ctx, client, query, opts, func(page []api.Conversation) error {
+		if mode.Streaming() {
+			if len(page) == 0 {
+				return nil
+			}
+
+			return mode.Write(os.Stdout, page)
+		}
+
+		if mode.JSON {
+			results = append(results, page...)
+
+			return nil
+		}
+
+		if len(page) == 0 {
+			return nil
+		}
+
+		results = append(results, page...)
+
+		if mode.SortBy != "" {
+			return nil
+		}
+
+		return table.writeRows(&tbl, mode, page)
+	})
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+	if mode.SortBy != "" && !mode.JSON && len(results) > 0 {
+		output.SortByColumn(results, mode.SortBy, mode.SortDesc)
+
+		if err := table.writeRows(&tbl, mode, results); err != nil {
+			return err
+		}
 	}
 
-	if len(resp.Results) == 0 {
+	if mode.JSON && !mode.Streaming() {
+		resp := api.ListResponse[api.Conversation]{Results: results}
+		if resp.Results == nil {
+			resp.Results = []api.Conversation{}
+		}
+
+		if nextToken != "" {
+			resp.Pagination.Next = api.WithPageToken(convSearchPath(query, c.Limit), nextToken)
+		}
+
+		return mode.Write(os.Stdout, resp)
+	}
+
+	if len(results) == 0 && !mode.Streaming() {
 		fmt.Fprintln(os.Stdout, "No conversations found.")
+	}
 
-		return nil
+	if nextToken != "" {
+		fmt.Fprintf(os.Stderr, "More results available; use --all or --page-token %s\n", nextToken)
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED", "UPDATED")
+	return nil
+}
+
+// resolveNames replaces inbox, tag and assignee names with IDs, and "me"
+// with the authenticated teammate's ID.
+func (c *ConvSearchCmd) resolveNames(ctx context.Context, client *api.Client) (err error) {
+	if c.Inbox, err = client.ResolveInbox(ctx, c.Inbox); err != nil {
+		return err
+	}
 
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversationWithUpdated(conv)...)
+	if c.Tag, err = resolveAll(ctx, c.Tag, client.ResolveTag); err != nil {
+		return err
 	}
 
-	return tbl.Flush()
+	c.Assignee, err = client.ResolveTeammate(ctx, c.Assignee)
+
+	return err
 }
 
 type ConvMessagesCmd struct {
+	PaginationFlags `embed:""`
+
 	ID    string `arg:"" help:"Conversation ID"`
 	Limit int    `help:"Maximum number of messages" default:"25"`
 }
@@ -520,34 +882,29 @@ func (c *ConvMessagesCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListConversationMessages(ctx, c.ID, c.Limit)
+	id, err := api.SanitizeID(c.ID)
 	if err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
-
-		return err
-	}
-
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return fmt.Errorf("invalid conversation ID %q: %w", c.ID, err)
 	}
 
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No messages found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "DIR", "FROM", "PREVIEW", "DATE")
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Message]{
+		Path:    fmt.Sprintf("/conversations/%s/messages?limit=%d", id, c.Limit),
+		Empty:   "No messages found.",
+		Headers: []string{"ID", "DIR", "FROM", "PREVIEW", "DATE"},
+		Row:     output.FormatMessage,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
-	for _, msg := range resp.Results {
-		tbl.AddRow(output.FormatMessage(msg)...)
+		return err
 	}
 
-	return tbl.Flush()
+	return nil
 }
 
 type ConvCommentsCmd struct {
+	PaginationFlags `embed:""`
+
 	ID    string `arg:"" help:"Conversation ID"`
 	Limit int    `help:"Maximum number of comments" default:"25"`
 }
@@ -565,47 +922,17 @@ func (c *ConvCommentsCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.Comment]
-	if err := client.Get(ctx, fmt.Sprintf("/conversations/%s/comments?limit=%d", c.ID, c.Limit), &resp); err != nil {
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Comment]{
+		Path:    fmt.Sprintf("/conversations/%s/comments?limit=%d", c.ID, c.Limit),
+		Empty:   "No comments found.",
+		Headers: []string{"ID", "AUTHOR", "BODY", "DATE"},
+		Row:     output.FormatComment,
+	})
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No comments found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "AUTHOR", "BODY", "DATE")
-
-	for _, comment := range resp.Results {
-		author := "-"
-		if comment.Author != nil {
-			author = comment.Author.Email
-			if author == "" {
-				author = comment.Author.Username
-			}
-		}
-
-		body := comment.Body
-		if len(body) > 50 {
-			body = body[:47] + "..."
-		}
-
-		tbl.AddRow(
-			comment.ID,
-			author,
-			body,
-			output.FormatTimestamp(comment.PostedAt),
-		)
-	}
-
-	return tbl.Flush()
+	return nil
 }
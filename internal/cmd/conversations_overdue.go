@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type ConvOverdueCmd struct {
+	Threshold string   `help:"How long a conversation may wait for a reply (e.g. 4h, 2d)" default:"24h"`
+	Inbox     []string `help:"Only these inboxes (ID or name); repeatable"`
+	Tag       []string `help:"Only conversations with any of these tags (ID or name); repeatable"`
+	Assignee  string   `help:"Only this assignee (ID, email, username, name or me)"`
+	Fail      bool     `help:"Exit with status 1 when any conversation is overdue"`
+}
+
+func (c *ConvOverdueCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	before, err := parseTimeFlag(c.Threshold)
+	if err != nil {
+		return fmt.Errorf("--threshold: %w", err)
+	}
+
+	if before == 0 {
+		return fmt.Errorf("--threshold is required")
+	}
+
+	overdue, err := c.find(ctx, client, waitingSince(before))
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		if err := mode.Write(os.Stdout, map[string]any{"conversations": overdue}); err != nil {
+			return err
+		}
+	} else if len(overdue) == 0 {
+		fmt.Fprintln(os.Stdout, "No overdue conversations.")
+	} else {
+		tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+		tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED", "UPDATED", "WAITING")
+
+		for _, conv := range overdue {
+			tbl.AddRow(output.FormatConversationWithWaiting(conv)...)
+		}
+
+		if err := tbl.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if c.Fail && len(overdue) > 0 {
+		return &ExitError{Code: api.ExitError, Err: fmt.Errorf("%d conversation(s) waiting longer than %s", len(overdue), c.Threshold)}
+	}
+
+	return nil
+}
+
+// find lists the open conversations keep accepts, longest waiting first.
+func (c *ConvOverdueCmd) find(ctx context.Context, client *api.Client, keep func(api.Conversation) bool) ([]api.Conversation, error) {
+	inboxIDs, err := resolveAll(ctx, c.Inbox, client.ResolveInbox)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs, err := resolveAll(ctx, c.Tag, client.ResolveTag)
+	if err != nil {
+		return nil, err
+	}
+
+	assigneeID, err := client.ResolveTeammate(ctx, c.Assignee)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := api.ListConversationsOptions{
+		InboxIDs:   inboxIDs,
+		TagIDs:     tagIDs,
+		AssigneeID: assigneeID,
+		Statuses:   api.ParseStatus("open"),
+		Limit:      100,
+	}
+
+	path, err := opts.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	overdue := []api.Conversation{}
+
+	_, err = listPages(ctx, client, path, PaginationFlags{All: true}, func(resp *api.ListResponse[api.Conversation]) error {
+		for _, conv := range resp.Results {
+			if keep(conv) {
+				overdue = append(overdue, conv)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(overdue, func(i, j int) bool { return overdue[i].WaitingSince < overdue[j].WaitingSince })
+
+	return overdue, nil
+}
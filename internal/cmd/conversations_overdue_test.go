@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// newWaitingServer lists three open conversations: one waiting 3 days, one
+// waiting 2 hours and one waiting on the customer.
+func newWaitingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	now := time.Now().Unix()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conversations" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"_results":[
+			{"id":"cnv_recent","subject":"Recent","status":"assigned","waiting_since":%d},
+			{"id":"cnv_none","subject":"Replied","status":"unassigned"},
+			{"id":"cnv_old","subject":"Old","status":"unassigned","waiting_since":%d}
+		]}`, now-2*3600, now-3*86400-3600)
+	}))
+}
+
+func captureStdout(t *testing.T, run func() error) (string, error) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	os.Stdout = w
+
+	runErr := run()
+
+	os.Stdout = oldStdout
+	_ = w.Close()
+
+	out, _ := io.ReadAll(r)
+	_ = r.Close()
+
+	return string(out), runErr
+}
+
+func TestConvListWaitingLongerThan(t *testing.T) {
+	srv := newWaitingServer(t)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		cmd := ConvListCmd{WaitingLonger: "1d", Limit: 25, SortOrder: "-"}
+
+		return cmd.Run(&RootFlags{Plain: true, Account: "test@example.com"})
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if !strings.Contains(out, "WAITING") || !strings.Contains(out, "cnv_old") || !strings.Contains(out, "3d 1h") {
+		t.Fatalf("missing old conversation or its waiting time:\n%s", out)
+	}
+
+	if strings.Contains(out, "cnv_recent") || strings.Contains(out, "cnv_none") {
+		t.Fatalf("conversations waiting less than a day were listed:\n%s", out)
+	}
+}
+
+func TestConvOverdueFailsWhenOverdue(t *testing.T) {
+	srv := newWaitingServer(t)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		cmd := ConvOverdueCmd{Threshold: "1h", Fail: true}
+
+		return cmd.Run(&RootFlags{Plain: true, Account: "test@example.com"})
+	})
+
+	var ee *ExitError
+	if !errors.As(err, &ee) || ee.Code != api.ExitError {
+		t.Fatalf("err = %v, want exit code 1", err)
+	}
+
+	old, recent := strings.Index(out, "cnv_old"), strings.Index(out, "cnv_recent")
+	if old < 0 || recent < old || strings.Contains(out, "cnv_none") {
+		t.Fatalf("want cnv_old then cnv_recent:\n%s", out)
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// recipientRoles orders roles the way a message header reads.
+var recipientRoles = []string{"from", "to", "cc", "bcc"}
+
+type ConvRecipientCmd struct {
+	ID string `arg:"" help:"Conversation ID"`
+}
+
+// conversationRecipient is one handle seen across a conversation's messages.
+type conversationRecipient struct {
+	Handle    string   `json:"handle"`
+	Name      string   `json:"name,omitempty"`
+	Roles     []string `json:"roles"`
+	Messages  int      `json:"messages"`
+	ContactID string   `json:"contact_id,omitempty"`
+}
+
+func (c *ConvRecipientCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	id, err := api.SanitizeID(c.ID)
+	if err != nil {
+		return fmt.Errorf("invalid conversation ID %q: %w", c.ID, err)
+	}
+
+	var messages []api.Message
+
+	path := fmt.Sprintf("/conversations/%s/messages?limit=100", id)
+
+	_, err = listPages(ctx, client, path, PaginationFlags{All: true}, func(page *api.ListResponse[api.Message]) error {
+		messages = append(messages, page.Results...)
+
+		return nil
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	recipients := aggregateRecipients(messages)
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, api.ListResponse[conversationRecipient]{Results: recipients})
+	}
+
+	if len(recipients) == 0 {
+		fmt.Fprintln(os.Stdout, "No recipients found.")
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("HANDLE", "NAME", "ROLES", "MESSAGES")
+
+	for _, r := range recipients {
+		tbl.AddRow(r.Handle, r.Name, strings.Join(r.Roles, ","), strconv.Itoa(r.Messages))
+	}
+
+	return tbl.Flush()
+}
+
+// aggregateRecipients merges the recipients of every message by handle
+// (case-insensitive), in order of first appearance. Roles are collected
+// across messages; Messages counts the messages a handle appears on.
+func aggregateRecipients(messages []api.Message) []conversationRecipient {
+	recipients := []conversationRecipient{}
+	index := map[string]int{}
+
+	for _, msg := range messages {
+		seen := map[string]bool{}
+
+		for _, rcpt := range msg.Recipients {
+			key := strings.ToLower(strings.TrimSpace(rcpt.Handle))
+			if key == "" {
+				continue
+			}
+
+			i, ok := index[key]
+			if !ok {
+				i = len(recipients)
+				index[key] = i
+				recipients = append(recipients, conversationRecipient{Handle: rcpt.Handle, Roles: []string{}})
+			}
+
+			r := &recipients[i]
+
+			if r.Name == "" {
+				r.Name = rcpt.Name
+			}
+
+			if r.ContactID == "" {
+				r.ContactID = rcpt.ContactID()
+			}
+
+			if role := strings.ToLower(rcpt.Role); role != "" && !slices.Contains(r.Roles, role) {
+				r.Roles = append(r.Roles, role)
+			}
+
+			if !seen[key] {
+				seen[key] = true
+				r.Messages++
+			}
+		}
+	}
+
+	for i := range recipients {
+		slices.SortFunc(recipients[i].Roles, func(a, b string) int {
+			return roleRank(a) - roleRank(b)
+		})
+	}
+
+	return recipients
+}
+
+func roleRank(role string) int {
+	if i := slices.Index(recipientRoles, role); i >= 0 {
+		return i
+	}
+
+	return len(recipientRoles)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestAggregateRecipients(t *testing.T) {
+	contact := api.Links{Related: map[string]string{"contact": "https://api2.frontapp.com/contacts/crd_1"}}
+
+	messages := []api.Message{
+		{Recipients: []api.Recipient{
+			{Handle: "alice@example.com", Role: "from", Name: "Alice", Links: contact},
+			{Handle: "support@acme.com", Role: "to"},
+			{Handle: "bob@example.com", Role: "cc"},
+		}},
+		{Recipients: []api.Recipient{
+			{Handle: "support@acme.com", Role: "from"},
+			{Handle: "Alice@Example.com", Role: "to"},
+			{Handle: "bob@example.com", Role: "cc"},
+			{Handle: "bob@example.com", Role: "bcc"},
+		}},
+	}
+
+	want := []conversationRecipient{
+		{Handle: "alice@example.com", Name: "Alice", Roles: []string{"from", "to"}, Messages: 2, ContactID: "crd_1"},
+		{Handle: "support@acme.com", Roles: []string{"from", "to"}, Messages: 2},
+		{Handle: "bob@example.com", Roles: []string{"cc", "bcc"}, Messages: 2},
+	}
+
+	if got := aggregateRecipients(messages); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v\nwant %+v", got, want)
+	}
+
+	if got := aggregateRecipients(nil); got == nil || len(got) != 0 {
+		t.Fatalf("empty conversation = %#v", got)
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type ConvRemindCmd struct {
+	ID       string `arg:"" help:"Conversation ID"`
+	At       string `help:"Remind at, e.g. tomorrow 9:00, friday, 3d, 2024-01-15 14:00 or Unix seconds"`
+	Duration string `help:"Remind after duration (e.g. 2h, 30m)"`
+	Teammate string `help:"Teammate to remind: ID, email or username (default: yourself)"`
+	Cancel   bool   `help:"Cancel the reminder"`
+}
+
+func (c *ConvRemindCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	scheduledAt, err := c.scheduledAt(time.Now())
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{"scheduled_at": nil}
+	if scheduledAt != "" {
+		req["scheduled_at"] = scheduledAt
+	}
+
+	if c.Teammate != "" {
+		teammateID, err := client.ResolveTeammate(ctx, c.Teammate)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		req["teammate_id"] = teammateID
+	}
+
+	if err := client.Patch(ctx, fmt.Sprintf("/conversations/%s/reminders", c.ID), req, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, req)
+	}
+
+	if c.Cancel {
+		fmt.Fprintf(os.Stdout, "Reminder cancelled on %s\n", c.ID)
+
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "Reminder set on %s for %s\n", c.ID, scheduledAt)
+
+	return nil
+}
+
+// scheduledAt resolves --at/--duration/--cancel into an RFC3339 timestamp,
+// or "" when cancelling.
+func (c *ConvRemindCmd) scheduledAt(now time.Time) (string, error) {
+	at := strings.TrimSpace(c.At)
+	duration := strings.TrimSpace(c.Duration)
+
+	if c.Cancel {
+		if at != "" || duration != "" {
+			return "", fmt.Errorf("--cancel cannot be combined with --at or --duration")
+		}
+
+		return "", nil
+	}
+
+	switch {
+	case at != "" && duration != "":
+		return "", fmt.Errorf("use either --at or --duration, not both")
+	case duration != "":
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return "", fmt.Errorf("invalid duration: %w", err)
+		}
+
+		return now.Add(d).UTC().Format(time.RFC3339), nil
+	case at != "":
+		ts, err := parseFutureTimeFlag(at)
+		if err != nil {
+			return "", err
+		}
+
+		return time.Unix(int64(ts), 0).UTC().Format(time.RFC3339), nil
+	default:
+		return "", fmt.Errorf("one of --at, --duration or --cancel is required")
+	}
+}
+
+type ConvRemindersCmd struct {
+	ID string `arg:"" help:"Conversation ID"`
+}
+
+func (c *ConvRemindersCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	conv, err := client.GetConversation(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, conv.Reminders)
+	}
+
+	if len(conv.Reminders) == 0 {
+		fmt.Fprintln(os.Stdout, "No reminders found.")
+
+		return nil
+	}
+
+	tw := output.NewTableWriter(os.Stdout, mode.Plain)
+	tw.AddRow("OWNER", "SCHEDULED", "CREATED")
+
+	for _, r := range conv.Reminders {
+		owner := r.Owner()
+		if owner == "" {
+			owner = "-"
+		}
+
+		tw.AddRow(owner, output.FormatTimestamp(r.ScheduledAt), output.FormatTimestamp(r.CreatedAt))
+	}
+
+	return tw.Flush()
+}
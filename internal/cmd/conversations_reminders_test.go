@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConvRemindScheduledAt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		cmd     ConvRemindCmd
+		want    string
+		wantErr bool
+	}{
+		{cmd: ConvRemindCmd{Duration: "2h"}, want: "2026-03-01T14:00:00Z"},
+		{cmd: ConvRemindCmd{At: "2026-03-02T09:00:00Z"}, want: "2026-03-02T09:00:00Z"},
+		{cmd: ConvRemindCmd{At: "1772442000"}, want: "2026-03-02T09:00:00Z"},
+		{cmd: ConvRemindCmd{Cancel: true}, want: ""},
+		{cmd: ConvRemindCmd{}, wantErr: true},
+		{cmd: ConvRemindCmd{At: "2026-03-02T09:00:00Z", Duration: "1h"}, wantErr: true},
+		{cmd: ConvRemindCmd{Cancel: true, Duration: "1h"}, wantErr: true},
+		{cmd: ConvRemindCmd{Duration: "soon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.cmd.scheduledAt(now)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("scheduledAt(%+v) error = %v, wantErr %v", tt.cmd, err, tt.wantErr)
+		}
+
+		if got != tt.want {
+			t.Fatalf("scheduledAt(%+v) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// convSearchOptions controls how searchConversations walks results.
+type convSearchOptions struct {
+	PageSize   int
+	MaxResults int // 0 means no limit
+	All        bool
+	PageToken  string
+}
+
+func convSearchPath(query string, pageSize int) string {
+	path := "/conversations/search/" + url.PathEscape(query)
+	if pageSize > 0 {
+		path += fmt.Sprintf("?limit=%d", min(pageSize, 100))
+	}
+
+	return path
+}
+
+// searchConversations runs a conversation search, handing each page of new
+// results to fn. Without All only the first page is fetched and the token for
+// the next page is returned.
+//
+// Front stops paginating search results before _total is reached on large
+// result sets. With All, once pagination runs out while the window reports
+// more matches than were returned, the search is repeated with a before:
+// bound at the oldest conversation seen so far. Results are de-duplicated
+// across windows.
+func searchConversations(
+	ctx context.Context,
+	client *api.Client,
+	query string,
+	opts convSearchOptions,
+	fn func([]api.Conversation) error,
+) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", fmt.Errorf("no query provided")
+	}
+
+	seen := make(map[string]bool)
+	pageURL := api.WithPageToken(convSearchPath(query, opts.PageSize), opts.PageToken)
+
+	var (
+		oldest      float64
+		windowTotal int
+		windowSeen  int
+		windowNew   int
+	)
+
+	for {
+		resp, err := api.GetPage[api.Conversation](ctx, client, pageURL)
+		if err != nil {
+			return "", err
+		}
+
+		if resp.Total > 0 {
+			windowTotal = resp.Total
+		}
+
+		fresh := make([]api.Conversation, 0, len(resp.Results))
+
+		for _, conv := range resp.Results {
+			windowSeen++
+
+			if oldest == 0 || (conv.CreatedAt > 0 && conv.CreatedAt < oldest) {
+				oldest = conv.CreatedAt
+			}
+
+			if seen[conv.ID] {
+				continue
+			}
+
+			seen[conv.ID] = true
+			fresh = append(fresh, conv)
+		}
+
+		windowNew += len(fresh)
+
+		limitReached := false
+		if opts.MaxResults > 0 && len(seen) >= opts.MaxResults {
+			fresh = fresh[:len(fresh)-(len(seen)-opts.MaxResults)]
+			limitReached = true
+		}
+
+		if err := fn(fresh); err != nil {
+			return "", err
+		}
+
+		if limitReached {
+			return "", nil
+		}
+
+		if next := resp.Pagination.Next; next != "" {
+			if !opts.All {
+				return api.PageToken(next), nil
+			}
+
+			pageURL = next
+
+			continue
+		}
+
+		// Pagination is exhausted. Move the window back in time when the API
+		// capped this window and the last window made progress.
+		if !opts.All || windowTotal <= windowSeen || windowNew == 0 || oldest == 0 {
+			return "", nil
+		}
+
+		// before: is exclusive; step one second past the oldest result so
+		// conversations sharing its timestamp are not skipped.
+		windowQuery := fmt.Sprintf("%s before:%d", query, int64(oldest)+1)
+		pageURL = convSearchPath(windowQuery, opts.PageSize)
+		windowTotal, windowSeen, windowNew = 0, 0, 0
+	}
+}
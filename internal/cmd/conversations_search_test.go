@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestSearchConversationsWindowsCappedResults(t *testing.T) {
+	var queries []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := strings.TrimPrefix(r.URL.Path, "/conversations/search/")
+		queries = append(queries, query)
+
+		switch query {
+		case "tag:x":
+			_, _ = io.WriteString(w, `{"_total":4,"_results":[{"id":"cnv_1","created_at":300},{"id":"cnv_2","created_at":200}]}`)
+		case "tag:x before:201":
+			_, _ = io.WriteString(w, `{"_total":3,"_results":[{"id":"cnv_2","created_at":200},{"id":"cnv_3","created_at":100}]}`)
+		case "tag:x before:101":
+			_, _ = io.WriteString(w, `{"_total":1,"_results":[{"id":"cnv_4","created_at":50}]}`)
+		default:
+			t.Errorf("unexpected query %q", query)
+		}
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+
+	var ids []string
+
+	_, err := searchConversations(context.Background(), client, "tag:x", convSearchOptions{PageSize: 2, All: true}, func(page []api.Conversation) error {
+		for _, conv := range page {
+			ids = append(ids, conv.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("searchConversations: %v", err)
+	}
+
+	if got := strings.Join(ids, ","); got != "cnv_1,cnv_2,cnv_3,cnv_4" {
+		t.Fatalf("unexpected results %s (queries %v)", got, queries)
+	}
+}
+
+func TestSearchConversationsStopsAtMaxResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"_results":[{"id":"cnv_1"},{"id":"cnv_2"},{"id":"cnv_3"}],"_pagination":{"next":"/conversations/search/x?page_token=abc"}}`)
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+
+	var count int
+
+	next, err := searchConversations(context.Background(), client, "x", convSearchOptions{All: true, MaxResults: 2}, func(page []api.Conversation) error {
+		count += len(page)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("searchConversations: %v", err)
+	}
+
+	if count != 2 || next != "" {
+		t.Fatalf("count = %d, next = %q; want 2 results and no token", count, next)
+	}
+}
+
+func TestBuildConvSearchQueryConvertsDates(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	got, err := buildConvSearchQuery(&ConvSearchCmd{Tag: []string{"x"}, After: "2023-11-14T22:13:20Z", Before: "1700086400"})
+	if err != nil {
+		t.Fatalf("buildConvSearchQuery: %v", err)
+	}
+
+	if want := "tag:x before:1700086400 after:1700000000"; got != want {
+		t.Fatalf("query = %q, want %q", got, want)
+	}
+
+	if _, err := buildConvSearchQuery(&ConvSearchCmd{After: "someday"}); err == nil {
+		t.Fatal("expected an error for an unparseable date")
+	}
+}
+
+func TestConvSearchResolvesMeOnce(t *testing.T) {
+	var (
+		meCalls int
+		query   string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/me" {
+			meCalls++
+
+			_, _ = io.WriteString(w, `{"id":"tea_1"}`)
+
+			return
+		}
+
+		query = strings.TrimPrefix(r.URL.Path, "/conversations/search/")
+
+		_, _ = io.WriteString(w, `{"_results":[]}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvSearchCmd{Query: "participant:me -mention:me commenter:meg", Assignee: "me", Limit: 25}
+	if _, err := captureStdout(t, func() error { return cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}) }); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := "assignee:tea_1 participant:tea_1 -mention:tea_1 commenter:meg"; query != want || meCalls != 1 {
+		t.Fatalf("query = %q (want %q), /me calls = %d", query, want, meCalls)
+	}
+}
@@ -1,15 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
-
-	"golang.org/x/oauth2"
+	"time"
 
 	"github.com/dedene/frontapp-cli/internal/api"
 )
@@ -19,17 +22,12 @@ func TestConvSearchEncodesQuery(t *testing.T) {
 	var gotQuery string
 
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		gotPath = r.URL.Path
-		gotQuery = r.URL.Query().Get("q")
+		gotPath, gotQuery, _ = strings.Cut(strings.TrimPrefix(r.URL.Path, "/conversations/"), "/")
 		_, _ = io.WriteString(w, `{"_results":[]}`)
 	}))
 	defer srv.Close()
 
-	old := newClientFromAuth
-	newClientFromAuth = func(_, _ string) (*api.Client, error) {
-		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
-	}
-	t.Cleanup(func() { newClientFromAuth = old })
+	useTestServer(t, srv)
 
 	cmd := ConvSearchCmd{Query: "from:me project update", Limit: 10}
 	flags := &RootFlags{JSON: true, Account: "test@example.com"}
@@ -38,8 +36,8 @@ func TestConvSearchEncodesQuery(t *testing.T) {
 		t.Fatalf("Run: %v", err)
 	}
 
-	if gotPath != "/conversations/search" {
-		t.Fatalf("expected path /conversations/search, got %s", gotPath)
+	if gotPath != "search" {
+		t.Fatalf("expected path /conversations/search/{query}, got %s", gotPath)
 	}
 
 	if gotQuery != "from:me project update" {
@@ -47,6 +45,46 @@ func TestConvSearchEncodesQuery(t *testing.T) {
 	}
 }
 
+func TestConvListRepeatedTagsMatchAll(t *testing.T) {
+	var gotTags []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/tags":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"tag_vip","name":"VIP"},{"id":"tag_bug","name":"Bug"}]}`)
+		case "/conversations":
+			gotTags = r.URL.Query()["q[tag_id]"]
+			_, _ = io.WriteString(w, `{"_results":[
+				{"id":"cnv_both","tags":[{"id":"tag_vip"},{"id":"tag_bug"}]},
+				{"id":"cnv_vip","tags":[{"id":"tag_vip"}]}
+			]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--json", "--account", "test@example.com", "conv", "list", "--tag", "VIP", "--tag", "bug", "--match", "all"})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if strings.Join(gotTags, ",") != "tag_vip,tag_bug" {
+		t.Fatalf("q[tag_id] = %v", gotTags)
+	}
+
+	if !strings.Contains(out, "cnv_both") || strings.Contains(out, "cnv_vip") {
+		t.Fatalf("want only cnv_both:\n%s", out)
+	}
+}
+
 func TestConvTagSendsTagIDs(t *testing.T) {
 	var gotBody map[string][]string
 
@@ -67,11 +105,7 @@ func TestConvTagSendsTagIDs(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	old := newClientFromAuth
-	newClientFromAuth = func(_, _ string) (*api.Client, error) {
-		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
-	}
-	t.Cleanup(func() { newClientFromAuth = old })
+	useTestServer(t, srv)
 
 	cmd := ConvTagCmd{ID: "cnv_123", TagID: "tag_abc"}
 	flags := &RootFlags{Account: "test@example.com"}
@@ -85,10 +119,61 @@ func TestConvTagSendsTagIDs(t *testing.T) {
 	}
 }
 
+func TestConvTagCreatesMissingTag(t *testing.T) {
+	var created, tagged string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		data, _ := io.ReadAll(r.Body)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tags":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"tag_vip","name":"VIP"}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/tags":
+			created = string(data)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"tag_new","name":"Refund pending"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/conversations/cnv_1/tags":
+			tagged = string(data)
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvTagCmd{ID: "cnv_1", TagID: "Refund pending"}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err == nil {
+		t.Fatal("want an error for an unknown tag without --create")
+	}
+
+	cmd.Create = true
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if created != `{"name":"Refund pending"}` || tagged != `{"tag_ids":["tag_new"]}` {
+		t.Fatalf("created %s, tagged %s", created, tagged)
+	}
+}
+
 func TestConvArchiveIDsFromStdin(t *testing.T) {
 	var seen []string
 
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Read for the undo journal before each change.
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"status":"assigned"}`)
+
+			return
+		}
+
 		if r.Method != http.MethodPatch {
 			t.Fatalf("expected PATCH, got %s", r.Method)
 		}
@@ -98,11 +183,7 @@ func TestConvArchiveIDsFromStdin(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	old := newClientFromAuth
-	newClientFromAuth = func(_, _ string) (*api.Client, error) {
-		return api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL), nil
-	}
-	t.Cleanup(func() { newClientFromAuth = old })
+	useTestServer(t, srv)
 
 	r, w, err := os.Pipe()
 	if err != nil {
@@ -128,3 +209,290 @@ func TestConvArchiveIDsFromStdin(t *testing.T) {
 		t.Fatalf("expected 2 requests, got %d", len(seen))
 	}
 }
+
+func TestConvAssignReportsPartialFailure(t *testing.T) {
+	var patched []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Fatalf("expected PATCH, got %s", r.Method)
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/conversations/")
+		if id == "cnv_missing" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		patched = append(patched, id)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvAssignCmd{IDs: []string{"cnv_1", "cnv_missing", "cnv_2"}, To: "tea_1"}
+
+	err := cmd.Run(&RootFlags{Account: "test@example.com"})
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("Run error = %v, want partial failure", err)
+	}
+
+	if strings.Join(patched, ",") != "cnv_1,cnv_2" {
+		t.Fatalf("unexpected PATCH requests: %v", patched)
+	}
+}
+
+func TestConvArchiveFailFastStopsAtFirstFailure(t *testing.T) {
+	var seen []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Read for the undo journal before each change.
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"status":"assigned"}`)
+
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/conversations/")
+		seen = append(seen, id)
+
+		if id == "cnv_bad" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	flags := &RootFlags{Account: "test@example.com"}
+	ids := []string{"cnv_1", "cnv_bad", "cnv_2"}
+
+	cmd := ConvArchiveCmd{IDs: ids}
+	if err := cmd.Run(flags); err == nil {
+		t.Fatal("expected an error when a conversation fails")
+	}
+
+	if len(seen) != 3 {
+		t.Fatalf("continue-on-error: got %d requests, want 3", len(seen))
+	}
+
+	seen = nil
+
+	cmd = ConvArchiveCmd{IDs: ids, BatchFlags: BatchFlags{FailFast: true, Concurrency: 1}}
+	if err := cmd.Run(flags); err == nil {
+		t.Fatal("expected an error when a conversation fails")
+	}
+
+	if strings.Join(seen, ",") != "cnv_1,cnv_bad" {
+		t.Fatalf("fail-fast: unexpected requests %v", seen)
+	}
+}
+
+func TestConvArchiveStopsOnInterrupt(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	old := interruptCtx
+	interruptCtx = ctx
+	t.Cleanup(func() { interruptCtx = old })
+
+	var (
+		mu   sync.Mutex
+		seen []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Read for the undo journal before each change.
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"status":"assigned"}`)
+
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/conversations/")
+
+		mu.Lock()
+		seen = append(seen, id)
+		mu.Unlock()
+
+		// Ctrl-C while the second conversation is being archived. The
+		// body is read so the server notices the client hanging up.
+		if id == "cnv_2" {
+			_, _ = io.ReadAll(r.Body)
+
+			cancel(api.ErrInterrupted)
+			<-r.Context().Done()
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvArchiveCmd{IDs: []string{"cnv_1", "cnv_2", "cnv_3"}, BatchFlags: BatchFlags{Concurrency: 1}}
+
+	err := cmd.Run(&RootFlags{Account: "test@example.com"})
+	if !errors.Is(err, api.ErrInterrupted) || ExitCode(err) != api.ExitInterrupted {
+		t.Fatalf("Run error = %v, want interrupted", err)
+	}
+
+	if !strings.Contains(err.Error(), "after 1 of 3") || strings.Join(seen, ",") != "cnv_1,cnv_2" {
+		t.Fatalf("err %v, requests %v", err, seen)
+	}
+}
+
+func TestConvTrashRunsConcurrently(t *testing.T) {
+	var (
+		mu                sync.Mutex
+		inFlight, maxSeen int
+		seen              []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Read for the undo journal before each change.
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"status":"assigned"}`)
+
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		maxSeen = max(maxSeen, inFlight)
+		seen = append(seen, strings.TrimPrefix(r.URL.Path, "/conversations/"))
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := ConvTrashCmd{
+		IDs:        []string{"cnv_1", "cnv_2", "cnv_3", "cnv_4"},
+		BatchFlags: BatchFlags{Concurrency: 2},
+	}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	sort.Strings(seen)
+
+	if strings.Join(seen, ",") != "cnv_1,cnv_2,cnv_3,cnv_4" {
+		t.Fatalf("unexpected PATCH requests: %v", seen)
+	}
+
+	if maxSeen != 2 {
+		t.Fatalf("max concurrent requests = %d, want 2", maxSeen)
+	}
+}
+
+func TestConvGetShowsLinksAndEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/conversations/cnv_1":
+			_, _ = io.WriteString(w, `{"id":"cnv_1","subject":"Refund","_links":{
+				"self":"https://api2.frontapp.com/conversations/cnv_1",
+				"related":{"events":"https://api2.frontapp.com/conversations/cnv_1/events","followers":"https://api2.frontapp.com/conversations/cnv_1/followers"}}}`)
+		case "/conversations/cnv_1/events":
+			_, _ = io.WriteString(w, `{"_results":[
+				{"id":"evt_2","type":"archive","emitted_at":200},
+				{"id":"evt_1","type":"inbound","emitted_at":100}
+			]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		cmd := ConvGetCmd{ID: "cnv_1", Links: true, Related: []string{"events"}}
+
+		return cmd.Run(&RootFlags{Account: "test@example.com", Plain: true})
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for _, want := range []string{
+		"followers\thttps://api2.frontapp.com/conversations/cnv_1/followers\n",
+		"ID\tTYPE\tSOURCE\tTARGET\tDATE\nevt_1\tinbound\t",
+		"\nevt_2\tarchive\t",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output misses %q:\n%s", want, out)
+		}
+	}
+
+	if err := (&ConvGetCmd{ID: "cnv_1", Related: []string{"drafts"}}).Run(&RootFlags{}); err == nil {
+		t.Fatal("--related drafts succeeded")
+	}
+}
+
+func TestConvListSeenAddsUnseenColumn(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/conversations":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"cnv_1","status":"assigned"},{"id":"cnv_2","status":"assigned"},{"id":"cnv_3","status":"unassigned"}]}`)
+		case "/conversations/cnv_1/messages":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"msg_in","is_inbound":true},{"id":"msg_1","is_inbound":false}]}`)
+		case "/conversations/cnv_2/messages":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"msg_2","is_inbound":false}]}`)
+		case "/conversations/cnv_3/messages":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"msg_3","is_inbound":true}]}`)
+		case "/messages/msg_1/seen":
+			_, _ = io.WriteString(w, `{"_results":[]}`)
+		case "/messages/msg_2/seen":
+			_, _ = io.WriteString(w, `{"_results":[{"first_seen_at":"2024-01-02T12:00:00Z","seen_by":{"handle":"jane@customer.com"}}]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--plain", "--account", "test@example.com", "conv", "list", "--seen"})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	var unseen []string
+
+	for i, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		cells := strings.Split(line, "\t")
+		if i == 0 && cells[len(cells)-1] != "UNSEEN" {
+			t.Fatalf("header = %q", line)
+		}
+
+		unseen = append(unseen, cells[0]+"="+cells[len(cells)-1])
+	}
+
+	if got := strings.Join(unseen[1:], ","); got != "cnv_1=yes,cnv_2=no,cnv_3=-" {
+		t.Fatalf("unseen = %s", got)
+	}
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/log"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// watchPageSize is how many recent messages and comments each poll fetches.
+// Anything older than that between two polls is assumed already seen.
+const watchPageSize = 25
+
+// notifyDesktop shows a desktop notification. It is a var so tests can
+// capture notifications instead of popping them up.
+var notifyDesktop = func(title, body string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+
+		return exec.Command("osascript", "-e", script).Run()
+	case "linux", "freebsd", "openbsd":
+		return exec.Command("notify-send", title, body).Run()
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+}
+
+type ConvWatchCmd struct {
+	ID       string        `arg:"" help:"Conversation ID"`
+	Interval time.Duration `help:"Polling interval" default:"15s"`
+	Last     int           `help:"Show this many recent items before following" default:"3"`
+	Notify   bool          `help:"Show a desktop notification for each new item"`
+}
+
+// watchEvent is one timeline item in JSON output, written as a JSON line.
+type watchEvent struct {
+	Type    string       `json:"type"` // message or comment
+	Message *api.Message `json:"message,omitempty"`
+	Comment *api.Comment `json:"comment,omitempty"`
+}
+
+func (c *ConvWatchCmd) Run(flags *RootFlags) error {
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	id, err := api.SanitizeID(c.ID)
+	if err != nil {
+		return fmt.Errorf("invalid conversation ID %q: %w", c.ID, err)
+	}
+
+	if c.Interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	seen := map[string]bool{}
+
+	// The first poll establishes what has already been said; only the last
+	// few items are shown.
+	items, err := c.poll(ctx, client, id, seen)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if len(items) > c.Last {
+		items = items[len(items)-c.Last:]
+	}
+
+	if err := c.print(mode, items, false); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Watching %s every %s (Ctrl+C to stop)\n", id, c.Interval)
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		items, err := c.poll(ctx, client, id, seen)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+
+			// Keep watching through transient failures.
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			continue
+		}
+
+		if err := c.print(mode, items, c.Notify); err != nil {
+			return err
+		}
+	}
+}
+
+// poll fetches the latest messages and comments and returns those not seen
+// before, oldest first.
+func (c *ConvWatchCmd) poll(ctx context.Context, client *api.Client, id string, seen map[string]bool) ([]timelineItem, error) {
+	messages, err := client.ListConversationMessages(ctx, id, watchPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments api.ListResponse[api.Comment]
+	if err := client.Get(ctx, fmt.Sprintf("/conversations/%s/comments?limit=%d", id, watchPageSize), &comments); err != nil {
+		return nil, err
+	}
+
+	var items []timelineItem
+
+	for i := range messages.Results {
+		if msg := &messages.Results[i]; !seen[msg.ID] {
+			seen[msg.ID] = true
+			items = append(items, timelineItem{timestamp: msg.CreatedAt, message: msg})
+		}
+	}
+
+	for i := range comments.Results {
+		if comment := &comments.Results[i]; !seen[comment.ID] {
+			seen[comment.ID] = true
+			items = append(items, timelineItem{timestamp: comment.PostedAt, comment: comment})
+		}
+	}
+
+	sortTimeline(items)
+
+	return items, nil
+}
+
+func (c *ConvWatchCmd) print(mode output.Mode, items []timelineItem, notify bool) error {
+	view := ConvGetCmd{ID: c.ID}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+
+	for _, item := range items {
+		switch {
+		case mode.JSON && item.message != nil:
+			if err := enc.Encode(watchEvent{Type: "message", Message: item.message}); err != nil {
+				return fmt.Errorf("encode json: %w", err)
+			}
+		case mode.JSON:
+			if err := enc.Encode(watchEvent{Type: "comment", Comment: item.comment}); err != nil {
+				return fmt.Errorf("encode json: %w", err)
+			}
+		case item.message != nil:
+			fmt.Fprintln(os.Stdout, strings.Repeat("─", 60))
+			view.printMessage(*item.message)
+		default:
+			fmt.Fprintln(os.Stdout, strings.Repeat("─", 60))
+			view.printComment(*item.comment, commentThreads{}, 0)
+		}
+
+		if notify {
+			title, body := watchNotification(item)
+			if err := notifyDesktop(title, body); err != nil {
+				log.Warn("desktop notification failed", "err", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// watchNotification summarizes a timeline item for a desktop notification.
+func watchNotification(item timelineItem) (string, string) {
+	if item.message != nil {
+		return "Message from " + messageSender(item.message), item.message.Blurb
+	}
+
+	from := "New comment"
+	if a := item.comment.Author; a != nil && a.Email != "" {
+		from = "Comment from " + a.Email
+	}
+
+	return from, item.comment.Body
+}
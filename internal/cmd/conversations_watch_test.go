@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+func TestConvWatchPollReturnsOnlyNewItems(t *testing.T) {
+	var polls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/conversations/cnv_1/messages":
+			if polls.Add(1) == 1 {
+				_, _ = w.Write([]byte(`{"_results":[{"id":"msg_2","created_at":20},{"id":"msg_1","created_at":10}]}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"_results":[{"id":"msg_3","created_at":40},{"id":"msg_2","created_at":20},{"id":"msg_1","created_at":10}]}`))
+		case "/conversations/cnv_1/comments":
+			if polls.Load() == 1 {
+				_, _ = w.Write([]byte(`{"_results":[{"id":"com_1","posted_at":15}]}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"_results":[{"id":"com_2","posted_at":30},{"id":"com_1","posted_at":15}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), srv.URL)
+	cmd := ConvWatchCmd{ID: "cnv_1"}
+	seen := map[string]bool{}
+
+	first, err := cmd.poll(context.Background(), client, "cnv_1", seen)
+	if err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+
+	if got := timelineIDs(first); len(got) != 3 || got[0] != "msg_1" || got[1] != "com_1" || got[2] != "msg_2" {
+		t.Fatalf("first poll = %v", got)
+	}
+
+	second, err := cmd.poll(context.Background(), client, "cnv_1", seen)
+	if err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+
+	if got := timelineIDs(second); len(got) != 2 || got[0] != "com_2" || got[1] != "msg_3" {
+		t.Fatalf("second poll = %v", got)
+	}
+}
+
+func TestConvWatchNotifies(t *testing.T) {
+	var titles []string
+
+	old := notifyDesktop
+	notifyDesktop = func(title, _ string) error {
+		titles = append(titles, title)
+
+		return nil
+	}
+
+	t.Cleanup(func() { notifyDesktop = old })
+
+	items := []timelineItem{
+		{message: &api.Message{ID: "msg_1", Recipients: []api.Recipient{{Handle: "bob@example.com", Role: "from"}}}},
+		{comment: &api.Comment{ID: "com_1", Author: &api.Author{Email: "alice@example.com"}}},
+	}
+
+	if err := (&ConvWatchCmd{ID: "cnv_1"}).print(output.Mode{JSON: true}, items, true); err != nil {
+		t.Fatalf("print: %v", err)
+	}
+
+	if len(titles) != 2 || titles[0] != "Message from bob@example.com" || titles[1] != "Comment from alice@example.com" {
+		t.Fatalf("titles = %v", titles)
+	}
+}
+
+func timelineIDs(items []timelineItem) []string {
+	ids := make([]string, len(items))
+
+	for i, item := range items {
+		if item.message != nil {
+			ids[i] = item.message.ID
+		} else {
+			ids[i] = item.comment.ID
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+)
+
+// customFieldResources maps resource names to their API collection.
+var customFieldResources = map[string]string{
+	"conversation": "conversations",
+	"contact":      "contacts",
+	"account":      "accounts",
+	"inbox":        "inboxes",
+	"link":         "links",
+	"teammate":     "teammates",
+}
+
+type CustomFieldCmd struct {
+	List CustomFieldListCmd `cmd:"" help:"List custom field definitions"`
+	Set  CustomFieldSetCmd  `cmd:"" help:"Set custom field values on a resource"`
+}
+
+type CustomFieldListCmd struct {
+	PaginationFlags `embed:""`
+
+	Resource string `arg:"" optional:"" help:"Resource type" enum:"conversation,contact,account,inbox,link,teammate" default:"conversation"`
+}
+
+func (c *CustomFieldListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.CustomField]{
+		Path:    fmt.Sprintf("/%s/custom_fields", customFieldResources[c.Resource]),
+		Empty:   "No custom fields found.",
+		Headers: []string{"ID", "NAME", "TYPE", "VALUES"},
+		Row:     formatCustomField,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func formatCustomField(field api.CustomField) []string {
+	values := make([]string, 0, len(field.Values))
+	for _, v := range field.Values {
+		values = append(values, v.Value)
+	}
+
+	allowed := "-"
+	if len(values) > 0 {
+		allowed = strings.Join(values, ", ")
+	}
+
+	return []string{
+		field.ID,
+		field.Name,
+		field.Type,
+		allowed,
+	}
+}
+
+type CustomFieldSetCmd struct {
+	Resource string   `arg:"" help:"Resource type" enum:"conversation,contact,account,inbox,link,teammate"`
+	ID       string   `arg:"" help:"Resource ID"`
+	Fields   []string `arg:"" help:"Field assignments (name=value); an empty value clears the field"`
+}
+
+func (c *CustomFieldSetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	id, err := api.SanitizeID(c.ID)
+	if err != nil {
+		return fmt.Errorf("invalid %s ID %q: %w", c.Resource, c.ID, err)
+	}
+
+	collection := customFieldResources[c.Resource]
+
+	var defs []api.CustomField
+
+	_, err = listPages(ctx, client, fmt.Sprintf("/%s/custom_fields", collection), PaginationFlags{All: true}, func(page *api.ListResponse[api.CustomField]) error {
+		defs = append(defs, page.Results...)
+
+		return nil
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	updates, err := buildCustomFieldUpdates(defs, c.Fields)
+	if err != nil {
+		return err
+	}
+
+	// Front replaces the whole custom_fields object on PATCH, so merge the
+	// new values into the current ones.
+	var current struct {
+		CustomFields map[string]any `json:"custom_fields"`
+	}
+
+	if err := client.Get(ctx, fmt.Sprintf("/%s/%s", collection, id), &current); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	merged := current.CustomFields
+	if merged == nil {
+		merged = map[string]any{}
+	}
+
+	for name, value := range updates {
+		if value == nil {
+			delete(merged, name)
+
+			continue
+		}
+
+		merged[name] = value
+	}
+
+	req := map[string]any{
+		"custom_fields": merged,
+	}
+
+	if err := client.Patch(ctx, fmt.Sprintf("/%s/%s", collection, id), req, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Updated %d custom fields on %s\n", len(updates), id)
+
+	return nil
+}
+
+// buildCustomFieldUpdates validates name=value assignments against the field
+// definitions and converts each value to the field's type. A nil value means
+// the field should be cleared.
+func buildCustomFieldUpdates(defs []api.CustomField, assignments []string) (map[string]any, error) {
+	byName := make(map[string]api.CustomField, len(defs))
+	for _, def := range defs {
+		byName[strings.ToLower(def.Name)] = def
+	}
+
+	updates := make(map[string]any, len(assignments))
+
+	for _, raw := range assignments {
+		name, value, ok := strings.Cut(raw, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid field format: %s (expected name=value)", raw)
+		}
+
+		def, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field %q (see 'frontcli custom-fields list')", name)
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			updates[def.Name] = nil
+
+			continue
+		}
+
+		converted, err := convertCustomFieldValue(def, value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", def.Name, err)
+		}
+
+		updates[def.Name] = converted
+	}
+
+	return updates, nil
+}
+
+func convertCustomFieldValue(def api.CustomField, value string) (any, error) {
+	switch def.Type {
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false, got %q", value)
+		}
+
+		return b, nil
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", value)
+		}
+
+		return n, nil
+	case "datetime":
+		ts, err := parseTimeFlag(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return ts, nil
+	case "enum":
+		for _, v := range def.Values {
+			if strings.EqualFold(v.Value, value) {
+				return v.Value, nil
+			}
+		}
+
+		allowed := make([]string, 0, len(def.Values))
+		for _, v := range def.Values {
+			allowed = append(allowed, v.Value)
+		}
+
+		return nil, fmt.Errorf("%q is not one of: %s", value, strings.Join(allowed, ", "))
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestBuildCustomFieldUpdates(t *testing.T) {
+	defs := []api.CustomField{
+		{Name: "Priority", Type: "enum", Values: []api.CustomFieldValue{{Value: "High"}, {Value: "Low"}}},
+		{Name: "Seats", Type: "number"},
+		{Name: "VIP", Type: "boolean"},
+		{Name: "Notes", Type: "string"},
+	}
+
+	got, err := buildCustomFieldUpdates(defs, []string{"priority=high", "Seats=12", "vip=true", "Notes="})
+	if err != nil {
+		t.Fatalf("buildCustomFieldUpdates: %v", err)
+	}
+
+	if got["Priority"] != "High" || got["Seats"] != 12.0 || got["VIP"] != true {
+		t.Fatalf("unexpected updates: %v", got)
+	}
+
+	if v, ok := got["Notes"]; !ok || v != nil {
+		t.Fatalf("expected Notes to be cleared, got %v", got)
+	}
+
+	for _, bad := range []string{"Unknown=1", "Priority=Medium", "Seats=many", "VIP=perhaps", "Seats"} {
+		if _, err := buildCustomFieldUpdates(defs, []string{bad}); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type DashboardCmd struct {
+	Since string `help:"How far back to look for mentions (e.g. 3d, yesterday, RFC3339)" default:"7d"`
+	Top   int    `help:"Conversations to list per section" default:"5"`
+}
+
+// dashboard is the "my work" summary for the authenticated teammate.
+type dashboard struct {
+	Teammate          string             `json:"teammate"`
+	OpenAssigned      int                `json:"open_assigned"`
+	AwaitingReply     int                `json:"awaiting_reply"`
+	WaitingOnCustomer int                `json:"waiting_on_customer"`
+	OverdueSnoozed    int                `json:"overdue_snoozed"`
+	RecentMentions    int                `json:"recent_mentions"`
+	OldestAwaiting    []api.Conversation `json:"oldest_awaiting"`
+	OverdueSnoozes    []api.Conversation `json:"overdue_snoozes"`
+	Mentions          []notification     `json:"mentions"`
+}
+
+func (c *DashboardCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	since, err := parseTimeFlag(c.Since)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	me, err := client.Me(ctx)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	d, err := c.build(ctx, client, me, since)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, d)
+	}
+
+	fmt.Fprintf(os.Stdout, "Teammate:             %s\n", d.Teammate)
+	fmt.Fprintf(os.Stdout, "Open assigned:        %d\n", d.OpenAssigned)
+	fmt.Fprintf(os.Stdout, "  Awaiting your reply: %d\n", d.AwaitingReply)
+	fmt.Fprintf(os.Stdout, "  Waiting on customer: %d\n", d.WaitingOnCustomer)
+	fmt.Fprintf(os.Stdout, "Overdue snoozes:      %d\n", d.OverdueSnoozed)
+	fmt.Fprintf(os.Stdout, "Recent mentions:      %d\n", d.RecentMentions)
+
+	sections := []struct {
+		title string
+		rows  [][]string
+	}{
+		{"Awaiting your reply (oldest first)", conversationRows(d.OldestAwaiting, func(conv api.Conversation) float64 { return conv.WaitingSince })},
+		{"Overdue snoozes", conversationRows(d.OverdueSnoozes, snoozeDue)},
+		{"Recent mentions", mentionRows(d.Mentions)},
+	}
+
+	for _, s := range sections {
+		if len(s.rows) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(os.Stdout, "\n%s:\n", s.title)
+
+		tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+		tbl.AddRow("ID", "SUBJECT", "SINCE")
+
+		for _, row := range s.rows {
+			tbl.AddRow(row...)
+		}
+
+		if err := tbl.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *DashboardCmd) build(ctx context.Context, client *api.Client, me *api.Me, since float64) (*dashboard, error) {
+	d := &dashboard{Teammate: me.Email}
+	if name := strings.TrimSpace(me.FirstName + " " + me.LastName); name != "" {
+		d.Teammate = fmt.Sprintf("%s <%s>", name, me.Email)
+	}
+
+	var awaiting []api.Conversation
+
+	// waiting_since is the oldest unreplied message, so a conversation
+	// without one is waiting on the customer.
+	_, err := searchConversations(ctx, client, fmt.Sprintf("assignee:%s is:open", me.ID), convSearchOptions{PageSize: 100, All: true}, func(convs []api.Conversation) error {
+		for _, conv := range convs {
+			d.OpenAssigned++
+
+			if conv.WaitingSince > 0 {
+				awaiting = append(awaiting, conv)
+			} else {
+				d.WaitingOnCustomer++
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.AwaitingReply = len(awaiting)
+
+	sort.SliceStable(awaiting, func(i, j int) bool { return awaiting[i].WaitingSince < awaiting[j].WaitingSince })
+	d.OldestAwaiting = firstN(awaiting, c.Top)
+
+	now := float64(time.Now().Unix())
+
+	_, err = searchConversations(ctx, client, fmt.Sprintf("assignee:%s is:snoozed", me.ID), convSearchOptions{PageSize: 100, All: true}, func(convs []api.Conversation) error {
+		for _, conv := range convs {
+			if due := snoozeDue(conv); due > 0 && due < now {
+				d.OverdueSnoozes = append(d.OverdueSnoozes, conv)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(d.OverdueSnoozes, func(i, j int) bool { return snoozeDue(d.OverdueSnoozes[i]) < snoozeDue(d.OverdueSnoozes[j]) })
+
+	mentions := map[string]*notification{}
+
+	n := NotificationsCmd{Reason: []string{reasonMentioned}}
+
+	err = n.collectEvents(ctx, client, me, since, func(conv api.Conversation, reason string, at float64) {
+		if isClosedStatus(conv.Status) {
+			return
+		}
+
+		if m, ok := mentions[conv.ID]; ok {
+			m.LastAt = max(m.LastAt, at)
+
+			return
+		}
+
+		mentions[conv.ID] = &notification{Conversation: conv, Reasons: []string{reason}, LastAt: at}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.Mentions = []notification{}
+	for _, m := range mentions {
+		d.Mentions = append(d.Mentions, *m)
+	}
+
+	sort.Slice(d.Mentions, func(i, j int) bool { return d.Mentions[i].LastAt > d.Mentions[j].LastAt })
+
+	d.OverdueSnoozed, d.RecentMentions = len(d.OverdueSnoozes), len(d.Mentions)
+	d.OverdueSnoozes = firstN(d.OverdueSnoozes, c.Top)
+	d.Mentions = firstN(d.Mentions, c.Top)
+
+	return d, nil
+}
+
+// firstN returns at most n items (all of them when n is 0), never nil so
+// JSON output shows an empty list.
+func firstN[T any](items []T, n int) []T {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
+
+// snoozeDue is when the earliest reminder on a snoozed conversation fires.
+func snoozeDue(conv api.Conversation) float64 {
+	var due float64
+
+	for _, r := range conv.Reminders {
+		if due == 0 || (r.ScheduledAt > 0 && r.ScheduledAt < due) {
+			due = r.ScheduledAt
+		}
+	}
+
+	return due
+}
+
+func conversationRows(convs []api.Conversation, at func(api.Conversation) float64) [][]string {
+	rows := make([][]string, 0, len(convs))
+	for _, conv := range convs {
+		rows = append(rows, []string{conv.ID, conv.Subject, output.FormatTimestamp(at(conv))})
+	}
+
+	return rows
+}
+
+func mentionRows(mentions []notification) [][]string {
+	rows := make([][]string, 0, len(mentions))
+	for _, m := range mentions {
+		rows = append(rows, []string{m.Conversation.ID, m.Conversation.Subject, output.FormatTimestamp(m.LastAt)})
+	}
+
+	return rows
+}
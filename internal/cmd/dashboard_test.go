@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestDashboardSummarizesMyWork(t *testing.T) {
+	past := time.Now().Add(-time.Hour).Unix()
+	future := time.Now().Add(time.Hour).Unix()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/me":
+			_, _ = w.Write([]byte(`{"id":"tea_me","email":"me@example.com","username":"me"}`))
+		case "/conversations/search/assignee:tea_me is:open":
+			_, _ = w.Write([]byte(`{"_results":[
+				{"id":"cnv_new","status":"assigned","waiting_since":300},
+				{"id":"cnv_old","status":"assigned","waiting_since":100},
+				{"id":"cnv_replied","status":"assigned"}
+			],"_total":3}`))
+		case "/conversations/search/assignee:tea_me is:snoozed":
+			fmt.Fprintf(w, `{"_results":[
+				{"id":"cnv_late","status":"snoozed","scheduled_reminders":[{"scheduled_at":%d}]},
+				{"id":"cnv_later","status":"snoozed","scheduled_reminders":[{"scheduled_at":%d}]}
+			],"_total":2}`, past, future)
+		case "/events":
+			_, _ = w.Write([]byte(`{"_results":[
+				{"id":"evt_1","type":"mention","emitted_at":200,
+				 "conversation":{"id":"cnv_old","status":"assigned"},
+				 "target":{"_meta":{"type":"teammate"},"data":{"id":"tea_me"}}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	client, err := newClientFromAuth("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := DashboardCmd{Since: "7d", Top: 5}
+
+	d, err := cmd.build(context.Background(), client, &api.Me{ID: "tea_me", Email: "me@example.com"}, 0)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	if d.OpenAssigned != 3 || d.AwaitingReply != 2 || d.WaitingOnCustomer != 1 {
+		t.Fatalf("counts = %d open, %d awaiting, %d waiting on customer", d.OpenAssigned, d.AwaitingReply, d.WaitingOnCustomer)
+	}
+
+	if len(d.OldestAwaiting) != 2 || d.OldestAwaiting[0].ID != "cnv_old" {
+		t.Fatalf("oldest awaiting = %+v", d.OldestAwaiting)
+	}
+
+	if d.OverdueSnoozed != 1 || d.OverdueSnoozes[0].ID != "cnv_late" {
+		t.Fatalf("overdue snoozes = %+v", d.OverdueSnoozes)
+	}
+
+	var mentions []string
+	for _, m := range d.Mentions {
+		mentions = append(mentions, m.Conversation.ID)
+	}
+
+	if strings.Join(mentions, ",") != "cnv_old" {
+		t.Fatalf("mentions = %v", mentions)
+	}
+}
@@ -11,20 +11,22 @@ import (
 )
 
 type DraftCmd struct {
-	Create DraftCreateCmd `cmd:"" help:"Create a draft"`
-	List   DraftListCmd   `cmd:"" help:"List drafts in a conversation"`
-	Get    DraftGetCmd    `cmd:"" help:"Get a draft"`
-	Update DraftUpdateCmd `cmd:"" help:"Update a draft"`
-	Delete DraftDeleteCmd `cmd:"" help:"Delete a draft"`
+	Create  DraftCreateCmd  `cmd:"" help:"Create a draft"`
+	Compose DraftComposeCmd `cmd:"" help:"Write a reply draft in $EDITOR"`
+	List    DraftListCmd    `cmd:"" help:"List drafts in a conversation"`
+	Get     DraftGetCmd     `cmd:"" help:"Get a draft"`
+	Update  DraftUpdateCmd  `cmd:"" help:"Update a draft"`
+	Delete  DraftDeleteCmd  `cmd:"" help:"Delete a draft"`
 }
 
 type DraftCreateCmd struct {
-	ConvID   string `arg:"" help:"Conversation ID (for reply drafts)" optional:""`
-	Channel  string `help:"Channel ID (for new message drafts)"`
-	To       string `help:"Recipient (for new message drafts)"`
-	Subject  string `help:"Draft subject"`
-	Body     string `help:"Draft body"`
-	BodyFile string `help:"Read body from file" type:"existingfile"`
+	ConvID     string `arg:"" help:"Conversation ID (for reply drafts)" optional:""`
+	Channel    string `help:"Channel ID (for new message drafts)"`
+	To         string `help:"Recipient (for new message drafts)"`
+	Subject    string `help:"Draft subject"`
+	Body       string `help:"Draft body"`
+	BodyFile   string `help:"Read body from file (- for stdin)" type:"existingfile"`
+	BodyFormat string `help:"Format of --body/--body-file: html, or markdown to convert it to HTML" enum:"html,markdown" default:"html"`
 }
 
 func (c *DraftCreateCmd) Run(flags *RootFlags) error {
@@ -40,14 +42,14 @@ func (c *DraftCreateCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	body := c.Body
-	if c.BodyFile != "" {
-		data, err := os.ReadFile(c.BodyFile)
-		if err != nil {
-			return fmt.Errorf("read body file: %w", err)
-		}
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
 
-		body = string(data)
+	body, err = renderBody(body, c.BodyFormat)
+	if err != nil {
+		return err
 	}
 
 	req := map[string]any{
@@ -80,7 +82,7 @@ func (c *DraftCreateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Draft created: %s\n", result.ID)
@@ -89,6 +91,8 @@ func (c *DraftCreateCmd) Run(flags *RootFlags) error {
 }
 
 type DraftListCmd struct {
+	PaginationFlags `embed:""`
+
 	ConvID string `arg:"" help:"Conversation ID"`
 }
 
@@ -105,36 +109,28 @@ func (c *DraftListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.Draft]
-	if err := client.Get(ctx, fmt.Sprintf("/conversations/%s/drafts", c.ConvID), &resp); err != nil {
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Draft]{
+		Path:    fmt.Sprintf("/conversations/%s/drafts", c.ConvID),
+		Empty:   "No drafts found.",
+		Headers: []string{"ID", "VERSION", "SUBJECT", "CREATED"},
+		Row:     formatDraft,
+	})
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No drafts found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "VERSION", "SUBJECT", "CREATED")
+	return nil
+}
 
-	for _, draft := range resp.Results {
-		tbl.AddRow(
-			draft.ID,
-			fmt.Sprintf("%d", draft.Version),
-			draft.Subject,
-			output.FormatTimestamp(draft.CreatedAt),
-		)
+func formatDraft(draft api.Draft) []string {
+	return []string{
+		draft.ID,
+		fmt.Sprintf("%d", draft.Version),
+		draft.Subject,
+		output.FormatTimestamp(draft.CreatedAt),
 	}
-
-	return tbl.Flush()
 }
 
 type DraftGetCmd struct {
@@ -162,7 +158,7 @@ func (c *DraftGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, draft)
+		return mode.Write(os.Stdout, draft)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:      %s\n", draft.ID)
@@ -182,7 +178,8 @@ func (c *DraftGetCmd) Run(flags *RootFlags) error {
 type DraftUpdateCmd struct {
 	ID           string `arg:"" help:"Draft ID"`
 	Body         string `help:"New body"`
-	BodyFile     string `help:"Read body from file" type:"existingfile"`
+	BodyFile     string `help:"Read body from file (- for stdin)" type:"existingfile"`
+	BodyFormat   string `help:"Format of --body/--body-file: html, or markdown to convert it to HTML" enum:"html,markdown" default:"html"`
 	Subject      string `help:"New subject"`
 	DraftVersion int    `required:"" name:"draft-version" help:"Current version number (for optimistic locking)"`
 }
@@ -200,14 +197,14 @@ func (c *DraftUpdateCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	body := c.Body
-	if c.BodyFile != "" {
-		data, err := os.ReadFile(c.BodyFile)
-		if err != nil {
-			return fmt.Errorf("read body file: %w", err)
-		}
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
 
-		body = string(data)
+	body, err = renderBody(body, c.BodyFormat)
+	if err != nil {
+		return err
 	}
 
 	req := map[string]any{
@@ -230,7 +227,7 @@ func (c *DraftUpdateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Draft updated (new version: %d)\n", result.Version)
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// runEditor opens path in the user's editor. It is a var so tests can fill in
+// the file without launching one.
+var runEditor = func(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// Allow editors with arguments, e.g. EDITOR="code --wait".
+	args := strings.Fields(editor)
+
+	cmd := exec.Command(args[0], append(args[1:], path)...) //nolint:gosec // the user's own editor
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run editor %q: %w", editor, err)
+	}
+
+	return nil
+}
+
+// editText opens a temporary file holding initial in the user's editor and
+// returns what it contains once the editor exits.
+func editText(pattern, initial string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("create compose file: %w", err)
+	}
+
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(initial); err != nil {
+		_ = file.Close()
+
+		return "", fmt.Errorf("write compose file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("write compose file: %w", err)
+	}
+
+	if err := runEditor(file.Name()); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("read compose file: %w", err)
+	}
+
+	return string(data), nil
+}
+
+type DraftComposeCmd struct {
+	ConvID     string `arg:"" help:"Conversation ID to reply to"`
+	Channel    string `help:"Channel ID to send from (default: the conversation's)"`
+	Send       bool   `help:"Send the reply immediately instead of saving a draft"`
+	SendAt     string `help:"Schedule the reply instead of saving a draft, e.g. tomorrow 9:00, 2h"`
+	BodyFormat string `help:"Format of the text you write: text keeps line breaks as typed, markdown is converted to HTML" enum:"text,markdown" default:"text"`
+}
+
+// composedMessage is the result of editing a compose template.
+type composedMessage struct {
+	To      []string
+	CC      []string
+	Subject string
+	Body    string
+}
+
+func (c *DraftComposeCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	scheduled, err := parseSendAt(c.SendAt)
+	if err != nil {
+		return err
+	}
+
+	conv, err := client.GetConversation(ctx, c.ConvID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	msgs, err := client.ListConversationMessages(ctx, c.ConvID, 0)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	text, err := editText("frontcli-compose-*.txt", composeTemplate(conv, latestMessage(msgs.Results)))
+	if err != nil {
+		return err
+	}
+
+	composed := parseComposed(text)
+	if !hasOwnText(composed.Body) {
+		fmt.Fprintln(os.Stderr, "Empty message; nothing saved.")
+
+		return nil
+	}
+
+	body, err := renderBody(composed.Body, c.BodyFormat)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"body": body,
+	}
+
+	if len(composed.To) > 0 {
+		req["to"] = composed.To
+	}
+
+	if len(composed.CC) > 0 {
+		req["cc"] = composed.CC
+	}
+
+	if composed.Subject != "" {
+		req["subject"] = composed.Subject
+	}
+
+	if c.Channel != "" {
+		req["channel_id"] = c.Channel
+	}
+
+	if c.Send || scheduled != 0 {
+		req["type"] = "reply"
+
+		scheduleSend(req, scheduled)
+
+		var result map[string]any
+		if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/messages", c.ConvID), req, &result); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		if mode.JSON {
+			return mode.Write(os.Stdout, result)
+		}
+
+		if scheduled != 0 {
+			fmt.Fprintf(os.Stdout, "Reply scheduled for %s\n", output.FormatTimestamp(scheduled))
+
+			return nil
+		}
+
+		fmt.Fprintln(os.Stdout, "Reply sent successfully")
+
+		return nil
+	}
+
+	var draft api.Draft
+	if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/drafts", c.ConvID), req, &draft); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, draft)
+	}
+
+	fmt.Fprintf(os.Stdout, "Draft created: %s\n", draft.ID)
+
+	return nil
+}
+
+func latestMessage(msgs []api.Message) *api.Message {
+	var latest *api.Message
+
+	for i := range msgs {
+		if latest == nil || msgs[i].CreatedAt > latest.CreatedAt {
+			latest = &msgs[i]
+		}
+	}
+
+	return latest
+}
+
+// composeTemplate renders the editor template: comment lines, headers, an
+// empty body and the quoted last message.
+func composeTemplate(conv *api.Conversation, last *api.Message) string {
+	var to, cc []string
+
+	if last != nil {
+		for _, r := range last.Recipients {
+			switch {
+			case last.IsInbound && r.Role == "from", !last.IsInbound && r.Role == "to":
+				to = append(to, r.Handle)
+			case r.Role == "cc":
+				cc = append(cc, r.Handle)
+			}
+		}
+	}
+
+	subject := conv.Subject
+	if subject != "" && !strings.HasPrefix(strings.ToLower(subject), "re:") {
+		subject = "Re: " + subject
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# Reply to %s. Lines starting with # are ignored.\n", conv.ID)
+	sb.WriteString("# Write your reply below the headers; leave it empty to cancel.\n")
+	fmt.Fprintf(&sb, "To: %s\n", strings.Join(to, ", "))
+	fmt.Fprintf(&sb, "Cc: %s\n", strings.Join(cc, ", "))
+	fmt.Fprintf(&sb, "Subject: %s\n\n\n", subject)
+
+	if last != nil && strings.TrimSpace(last.Text) != "" {
+		sb.WriteString("\n")
+		fmt.Fprintf(&sb, "On %s, %s wrote:\n", output.FormatTimestamp(last.CreatedAt), messageSender(last))
+
+		for _, line := range strings.Split(strings.TrimRight(last.Text, "\n"), "\n") {
+			sb.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func messageSender(msg *api.Message) string {
+	for _, r := range msg.Recipients {
+		if r.Role == "from" {
+			return r.Handle
+		}
+	}
+
+	if msg.Author != nil && msg.Author.Email != "" {
+		return msg.Author.Email
+	}
+
+	return "someone"
+}
+
+// parseComposed reads an edited template: "#" lines are dropped, headers run
+// until the first blank line and everything after is the body.
+func parseComposed(text string) composedMessage {
+	var (
+		msg       composedMessage
+		body      []string
+		inHeaders = true
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if inHeaders {
+			if strings.TrimSpace(line) == "" {
+				inHeaders = false
+
+				continue
+			}
+
+			key, value, ok := strings.Cut(line, ":")
+			if !ok {
+				// Not a header: the body starts here.
+				inHeaders = false
+				body = append(body, line)
+
+				continue
+			}
+
+			value = strings.TrimSpace(value)
+
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "to":
+				msg.To = splitAddresses(value)
+			case "cc":
+				msg.CC = splitAddresses(value)
+			case "subject":
+				msg.Subject = value
+			}
+
+			continue
+		}
+
+		body = append(body, line)
+	}
+
+	msg.Body = strings.Trim(strings.Join(body, "\n"), "\n")
+
+	return msg
+}
+
+func splitAddresses(value string) []string {
+	var addrs []string
+
+	for _, a := range strings.Split(value, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addrs = append(addrs, a)
+		}
+	}
+
+	return addrs
+}
+
+// hasOwnText reports whether body contains anything besides the quoted
+// message and its attribution line.
+func hasOwnText(body string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ">") || (strings.HasPrefix(line, "On ") && strings.HasSuffix(line, "wrote:")) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// textToHTML converts a plain-text body into HTML that keeps its line breaks.
+func textToHTML(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>\n")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseComposed(t *testing.T) {
+	text := "# comment\nTo: a@example.com, b@example.com\nCc:\nSubject: Re: Hi\n\nThanks!\n\nOn 2025-01-15 10:30, a@example.com wrote:\n> Hi\n"
+
+	msg := parseComposed(text)
+
+	if strings.Join(msg.To, ",") != "a@example.com,b@example.com" || len(msg.CC) != 0 || msg.Subject != "Re: Hi" {
+		t.Fatalf("headers = %+v", msg)
+	}
+
+	if !strings.HasPrefix(msg.Body, "Thanks!\n") || !hasOwnText(msg.Body) {
+		t.Fatalf("body = %q", msg.Body)
+	}
+
+	if hasOwnText("On 2025-01-15 10:30, a@example.com wrote:\n> Hi") {
+		t.Fatal("quoted text alone counted as a reply")
+	}
+}
+
+func TestDraftComposeCreatesDraft(t *testing.T) {
+	var created map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/conversations/cnv_1":
+			_, _ = w.Write([]byte(`{"id":"cnv_1","subject":"Order question"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/conversations/cnv_1/messages":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"msg_1","is_inbound":true,"created_at":1700000000,` +
+				`"text":"Where is my order?","recipients":[{"handle":"client@example.com","role":"from"}]}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/conversations/cnv_1/drafts":
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &created)
+			_, _ = w.Write([]byte(`{"id":"drf_1","version":1}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	var template string
+
+	oldEditor := runEditor
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		template = string(data)
+
+		return os.WriteFile(path, []byte(strings.Replace(template, "\n\n\n", "\n\nOn its way <today>.\n\n", 1)), 0o600)
+	}
+
+	t.Cleanup(func() { runEditor = oldEditor })
+
+	cmd := DraftComposeCmd{ConvID: "cnv_1", BodyFormat: "text"}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for _, want := range []string{"To: client@example.com\n", "Subject: Re: Order question\n", "> Where is my order?\n"} {
+		if !strings.Contains(template, want) {
+			t.Fatalf("template missing %q:\n%s", want, template)
+		}
+	}
+
+	if body, _ := created["body"].(string); !strings.HasPrefix(body, "On its way &lt;today&gt;.<br>") {
+		t.Fatalf("draft body = %q", created["body"])
+	}
+
+	if to, _ := created["to"].([]any); len(to) != 1 || to[0] != "client@example.com" {
+		t.Fatalf("draft to = %v", created["to"])
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type EventCmd struct {
+	List EventListCmd `cmd:"" help:"List activity events"`
+	Get  EventGetCmd  `cmd:"" help:"Get an event"`
+}
+
+type EventListCmd struct {
+	PaginationFlags `embed:""`
+
+	Type         []string `help:"Filter by event type (assign, archive, tag, inbound, ...); repeatable"`
+	Before       string   `help:"Only events before this time (RFC3339, YYYY-MM-DD or Unix seconds)"`
+	After        string   `help:"Only events after this time (RFC3339, YYYY-MM-DD or Unix seconds)"`
+	Conversation string   `help:"Only events for this conversation ID"`
+	Limit        int      `help:"Maximum number of results" default:"25"`
+}
+
+func (c *EventListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	opts := api.ListEventsOptions{
+		Types: c.Type,
+		Limit: c.Limit,
+	}
+
+	if opts.Before, err = parseTimeFlag(c.Before); err != nil {
+		return fmt.Errorf("invalid --before: %w", err)
+	}
+
+	if opts.After, err = parseTimeFlag(c.After); err != nil {
+		return fmt.Errorf("invalid --after: %w", err)
+	}
+
+	path := "/events?" + opts.Query()
+
+	if strings.TrimSpace(c.Conversation) != "" {
+		convID, err := api.SanitizeID(c.Conversation)
+		if err != nil {
+			return fmt.Errorf("invalid conversation ID %q: %w", c.Conversation, err)
+		}
+
+		path = fmt.Sprintf("/conversations/%s/events?%s", convID, opts.Query())
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Event]{
+		Path:    path,
+		Empty:   "No events found.",
+		Headers: []string{"ID", "TYPE", "SOURCE", "TARGET", "CONVERSATION", "DATE"},
+		Row:     output.FormatEvent,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type EventGetCmd struct {
+	ID string `arg:"" help:"Event ID"`
+}
+
+func (c *EventGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	event, err := client.GetEvent(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, event)
+	}
+
+	row := output.FormatEvent(*event)
+
+	fmt.Fprintf(os.Stdout, "ID:           %s\n", event.ID)
+	fmt.Fprintf(os.Stdout, "Type:         %s\n", event.Type)
+	fmt.Fprintf(os.Stdout, "Date:         %s\n", output.FormatTimestamp(event.EmittedAt))
+
+	if event.Source != nil {
+		fmt.Fprintf(os.Stdout, "Source:       %s (%s)\n", row[2], event.Source.Meta.Type)
+	}
+
+	if event.Target != nil {
+		fmt.Fprintf(os.Stdout, "Target:       %s (%s)\n", row[3], event.Target.Meta.Type)
+	}
+
+	if event.Conversation != nil {
+		fmt.Fprintf(os.Stdout, "Conversation: %s\n", event.Conversation.ID)
+
+		if event.Conversation.Subject != "" {
+			fmt.Fprintf(os.Stdout, "Subject:      %s\n", event.Conversation.Subject)
+		}
+	}
+
+	return nil
+}
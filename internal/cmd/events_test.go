@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEventListFiltersByConversation(t *testing.T) {
+	var gotPath string
+	var gotQuery map[string][]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query()
+		_, _ = io.WriteString(w, `{"_results":[]}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := EventListCmd{Conversation: "cnv_123", Type: []string{"archive"}, After: "1700000000", Limit: 10}
+	flags := &RootFlags{JSON: true, Account: "test@example.com"}
+
+	if err := cmd.Run(flags); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if gotPath != "/conversations/cnv_123/events" {
+		t.Fatalf("unexpected path: %s", gotPath)
+	}
+
+	if got := gotQuery["q[types][]"]; len(got) != 1 || got[0] != "archive" {
+		t.Fatalf("unexpected types filter: %v", got)
+	}
+
+	if got := gotQuery["q[after]"]; len(got) != 1 || got[0] != "1700000000" {
+		t.Fatalf("unexpected after filter: %v", got)
+	}
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "1700000000", want: 1700000000},
+		{in: "2023-11-14T22:13:20Z", want: 1700000000},
+		{in: "yesterday-ish", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTimeFlag(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseTimeFlag(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+
+		if got != tt.want {
+			t.Fatalf("parseTimeFlag(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,13 @@
 package cmd
 
-import "errors"
+import (
+	"errors"
 
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// ExitError carries the process exit code for an error. Execute returns
+// one for every failure; see ExitCode for the codes.
 type ExitError struct {
 	Code int
 	Err  error
@@ -23,19 +29,48 @@ func (e *ExitError) Unwrap() error {
 	return e.Err
 }
 
+// ExitCode maps err to the process exit code, so scripts can branch on the
+// kind of failure: 2 usage, 3 authentication, 4 not found, 5 rate limited,
+// 130 interrupted, and 1 for anything else.
 func ExitCode(err error) int {
 	if err == nil {
-		return 0
+		return api.ExitSuccess
 	}
 
 	var ee *ExitError
 	if errors.As(err, &ee) && ee != nil {
 		if ee.Code < 0 {
-			return 1
+			return api.ExitError
 		}
 
 		return ee.Code
 	}
 
-	return 1
+	var coded interface{ ExitCode() int }
+	if errors.As(err, &coded) {
+		return coded.ExitCode()
+	}
+
+	switch {
+	case errors.Is(err, api.ErrNotAuthenticated):
+		return api.ExitAuth
+	case errors.Is(err, api.ErrNotFound):
+		return api.ExitNotFound
+	case errors.Is(err, api.ErrRateLimited):
+		return api.ExitRateLimit
+	case errors.Is(err, api.ErrInterrupted):
+		return api.ExitInterrupted
+	default:
+		return api.ExitError
+	}
+}
+
+// withExitCode wraps err in an ExitError carrying its exit code.
+func withExitCode(err error) error {
+	var ee *ExitError
+	if err == nil || errors.As(err, &ee) {
+		return err
+	}
+
+	return &ExitError{Code: ExitCode(err), Err: err}
 }
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"generic", errors.New("boom"), 1},
+		{"explicit", &ExitError{Code: 7, Err: errors.New("x")}, 7},
+		{"unauthorized", &api.APIError{StatusCode: http.StatusUnauthorized}, 3},
+		{"auth", fmt.Errorf("get client: %w", &api.AuthError{Err: errors.New("no token")}), 3},
+		{"not found", &api.APIError{StatusCode: http.StatusNotFound}, 4},
+		{"unknown name", &api.NameResolutionError{Resource: "tag", Name: "vip"}, 4},
+		{"ambiguous name", &api.NameResolutionError{Resource: "tag", Name: "vip", Matches: []string{"a", "b"}}, 2},
+		{"wrong resource", &api.WrongResourceTypeError{ExpectedType: "conversation", ActualType: "message", ID: "msg_1"}, 2},
+		{"rate limited", &api.RateLimitError{RetryAfter: 10}, 5},
+		{"sentinel", fmt.Errorf("page 2: %w", api.ErrRateLimited), 5},
+		{"interrupted", fmt.Errorf("GET /conversations: %w", api.ErrInterrupted), 130},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Fatalf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteReturnsExitCodes(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	err := Execute([]string{"--account", "test@example.com", "tags", "get", "tag_missing"})
+
+	var ee *ExitError
+	if !errors.As(err, &ee) || ee.Code != api.ExitNotFound {
+		t.Fatalf("Execute error = %#v, want exit code %d", err, api.ExitNotFound)
+	}
+
+	if code := ExitCode(Execute([]string{"--no-such-flag"})); code != api.ExitUsage {
+		t.Fatalf("usage exit code = %d, want %d", code, api.ExitUsage)
+	}
+}
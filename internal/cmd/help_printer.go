@@ -50,7 +50,7 @@ func helpPrinter(options kong.HelpOptions, ctx *kong.Context) error {
 
 	// Post-process: inject build info and colorize
 	out := injectBuildLine(buf.String())
-	out = colorizeHelp(out, helpProfile(origStdout, helpColorMode(ctx.Args)))
+	out = colorizeHelp(out, terminalProfile(origStdout, colorMode(ctx.Args)))
 	_, err := io.WriteString(origStdout, out)
 
 	return err
@@ -84,7 +84,9 @@ func injectBuildLine(out string) string {
 	return out
 }
 
-func helpColorMode(args []string) string {
+// colorMode is FRONT_COLOR (auto, always or never), or never when the
+// arguments ask for plain or JSON output.
+func colorMode(args []string) string {
 	if v := os.Getenv("FRONT_COLOR"); v != "" {
 		return strings.ToLower(strings.TrimSpace(v))
 	}
@@ -98,7 +100,9 @@ func helpColorMode(args []string) string {
 	return colorAuto
 }
 
-func helpProfile(stdout io.Writer, mode string) termenv.Profile {
+// terminalProfile is the color profile for stdout under mode; NO_COLOR always
+// wins.
+func terminalProfile(stdout io.Writer, mode string) termenv.Profile {
 	if termenv.EnvNoColor() {
 		return termenv.Ascii
 	}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type HistoryCmd struct {
+	Since  string `help:"Only calls at or after this time (e.g. 2h, 7d, yesterday, 2024-01-15)"`
+	Path   string `help:"Only calls whose API path contains this (e.g. a conversation ID)"`
+	Method string `help:"Only calls with this HTTP method (POST, PATCH, PUT, DELETE)"`
+	Limit  int    `help:"Show at most this many of the most recent calls (0 = all)" default:"50"`
+}
+
+func (c *HistoryCmd) Run(flags *RootFlags) error {
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	since, err := parseTimeFlag(c.Since)
+	if err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+
+	path, err := config.HistoryPath()
+	if err != nil {
+		return err
+	}
+
+	entries, err := api.ReadAuditLog(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	account := ""
+	if flags.Account != "" {
+		if account, err = config.ResolveAccount(flags.Account); err != nil {
+			return err
+		}
+	}
+
+	matches := make([]api.AuditEntry, 0, len(entries))
+
+	for _, e := range entries {
+		switch {
+		case account != "" && e.Account != account,
+			since != 0 && float64(e.Time.Unix()) < since,
+			c.Path != "" && !strings.Contains(e.Path, c.Path),
+			c.Method != "" && !strings.EqualFold(e.Method, c.Method):
+			continue
+		}
+
+		matches = append(matches, e)
+	}
+
+	if c.Limit > 0 && len(matches) > c.Limit {
+		matches = matches[len(matches)-c.Limit:]
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, matches)
+	}
+
+	if len(matches) == 0 {
+		fmt.Fprintln(os.Stdout, "No changes recorded.")
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("TIME", "ACCOUNT", "COMMAND", "METHOD", "PATH", "RESULT", "SUMMARY")
+
+	for _, e := range matches {
+		result := "ok"
+		if e.Error != "" {
+			result = "failed: " + e.Error
+		}
+
+		summary := e.Summary
+		if len(summary) > 60 {
+			summary = summary[:57] + "..."
+		}
+
+		tbl.AddRow(output.FormatTimestamp(float64(e.Time.Unix())), e.Account, e.Command, e.Method, e.Path, result, summary)
+	}
+
+	return tbl.Flush()
+}
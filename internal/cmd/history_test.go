@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+func TestHistoryRecordsMutatingCalls(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"id":"tag_1","name":"VIP"}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	historyPath, err := config.HistoryPath()
+	if err != nil {
+		t.Fatalf("HistoryPath: %v", err)
+	}
+
+	// Reads leave no trace: the config dir is only created for a change.
+	_, _ = captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "tags", "get", "tag_1"})
+	})
+
+	if _, err := os.Stat(filepath.Dir(historyPath)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("config dir after a read: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"tags", "get", "tag_1"},
+		{"tags", "create", "--name", "VIP"},
+		{"tags", "delete", "tag_2"},
+	} {
+		_, _ = captureStdout(t, func() error {
+			return Execute(append([]string{"--account", "test@example.com"}, args...))
+		})
+	}
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--json", "history", "--limit", "5"})
+	})
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+
+	var entries []api.AuditEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+
+	// The GET is not a change and is left out.
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	created, deleted := entries[0], entries[1]
+
+	if created.Method != "POST" || created.Path != "/tags" || created.Account != "test@example.com" ||
+		created.Command != "tags create" || created.Summary != `{"name":"VIP"}` || created.Error != "" {
+		t.Errorf("create entry = %+v", created)
+	}
+
+	if deleted.Method != "DELETE" || deleted.Path != "/tags/tag_2" || deleted.Error == "" {
+		t.Errorf("delete entry = %+v", deleted)
+	}
+}
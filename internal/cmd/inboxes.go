@@ -11,13 +11,22 @@ import (
 )
 
 type InboxCmd struct {
-	List     InboxListCmd     `cmd:"" help:"List inboxes"`
-	Get      InboxGetCmd      `cmd:"" help:"Get an inbox"`
-	Convos   InboxConvosCmd   `cmd:"" help:"List conversations in an inbox"`
-	Channels InboxChannelsCmd `cmd:"" help:"List channels in an inbox"`
+	List          InboxListCmd          `cmd:"" help:"List inboxes"`
+	Get           InboxGetCmd           `cmd:"" help:"Get an inbox"`
+	Create        InboxCreateCmd        `cmd:"" help:"Create an inbox"`
+	Update        InboxUpdateCmd        `cmd:"" help:"Update an inbox"`
+	Delete        InboxDeleteCmd        `cmd:"" help:"Delete an inbox"`
+	Convos        InboxConvosCmd        `cmd:"" help:"List conversations in an inbox"`
+	Channels      InboxChannelsCmd      `cmd:"" help:"List channels in an inbox"`
+	AddChannel    InboxAddChannelCmd    `cmd:"" help:"Add channels to an inbox"`
+	RemoveChannel InboxRemoveChannelCmd `cmd:"" help:"Remove channels from an inbox"`
 }
 
-type InboxListCmd struct{}
+type InboxListCmd struct {
+	PaginationFlags `embed:""`
+
+	Team string `help:"Only list inboxes of this team (ID or name)"`
+}
 
 func (c *InboxListCmd) Run(flags *RootFlags) error {
 	ctx := context.Background()
@@ -32,35 +41,28 @@ func (c *InboxListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListInboxes(ctx)
+	if c.Team != "" {
+		err = runTeamInboxes(ctx, client, mode, c.PaginationFlags, c.Team)
+	} else {
+		err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Inbox]{
+			Path:    "/inboxes",
+			Empty:   "No inboxes found.",
+			Headers: []string{"ID", "NAME"},
+			Row:     output.FormatInbox,
+		})
+	}
+
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No inboxes found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME")
-
-	for _, inbox := range resp.Results {
-		tbl.AddRow(output.FormatInbox(inbox)...)
-	}
-
-	return tbl.Flush()
+	return nil
 }
 
 type InboxGetCmd struct {
-	ID string `arg:"" help:"Inbox ID"`
+	ID string `arg:"" help:"Inbox ID or name"`
 }
 
 func (c *InboxGetCmd) Run(flags *RootFlags) error {
@@ -76,6 +78,12 @@ func (c *InboxGetCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if c.ID, err = client.ResolveInbox(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
 	inbox, err := client.GetInbox(ctx, c.ID)
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
@@ -84,7 +92,7 @@ func (c *InboxGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, inbox)
+		return mode.Write(os.Stdout, inbox)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:      %s\n", inbox.ID)
@@ -95,7 +103,9 @@ func (c *InboxGetCmd) Run(flags *RootFlags) error {
 }
 
 type InboxConvosCmd struct {
-	ID    string `arg:"" help:"Inbox ID"`
+	PaginationFlags `embed:""`
+
+	ID    string `arg:"" help:"Inbox ID or name"`
 	Limit int    `help:"Maximum number of results" default:"25"`
 }
 
@@ -112,39 +122,74 @@ func (c *InboxConvosCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	path := fmt.Sprintf("/inboxes/%s/conversations?limit=%d", c.ID, c.Limit)
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	if c.ID, err = client.ResolveInbox(ctx, c.ID); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Conversation]{
+		Path:    fmt.Sprintf("/inboxes/%s/conversations?limit=%d", c.ID, c.Limit),
+		Empty:   "No conversations found.",
+		Headers: []string{"ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED"},
+		Row:     output.FormatConversation,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type InboxChannelsCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Inbox ID or name"`
+}
+
+func (c *InboxChannelsCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
 	}
 
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No conversations found.")
+	if c.ID, err = client.ResolveInbox(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
-		return nil
+		return err
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED")
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Channel]{
+		Path:    fmt.Sprintf("/inboxes/%s/channels", c.ID),
+		Empty:   "No channels found.",
+		Headers: []string{"ID", "TYPE", "NAME", "ADDRESS"},
+		Row:     output.FormatChannel,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversation(conv)...)
+		return err
 	}
 
-	return tbl.Flush()
+	return nil
 }
 
-type InboxChannelsCmd struct {
-	ID string `arg:"" help:"Inbox ID"`
+type InboxCreateCmd struct {
+	Name     string   `required:"" help:"Inbox name"`
+	Team     string   `help:"Create the inbox in this team (ID or name) instead of company-wide"`
+	Teammate []string `help:"Give these teammates access (IDs, emails or usernames); repeatable"`
 }
 
-func (c *InboxChannelsCmd) Run(flags *RootFlags) error {
+func (c *InboxCreateCmd) Run(flags *RootFlags) error {
 	ctx := context.Background()
 
 	client, err := getClient(flags)
@@ -157,29 +202,184 @@ func (c *InboxChannelsCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.Channel]
-	if err := client.Get(ctx, fmt.Sprintf("/inboxes/%s/channels", c.ID), &resp); err != nil {
+	req := map[string]any{"name": c.Name}
+
+	if len(c.Teammate) > 0 {
+		teammateIDs, err := resolveAll(ctx, c.Teammate, client.ResolveTeammate)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		req["teammate_ids"] = teammateIDs
+	}
+
+	path := "/inboxes"
+	if c.Team != "" {
+		teamID, err := client.ResolveTeam(ctx, c.Team)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		path = fmt.Sprintf("/teams/%s/inboxes", teamID)
+	}
+
+	var result api.Inbox
+	if err := client.Post(ctx, path, req, &result); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return mode.Write(os.Stdout, result)
 	}
 
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No channels found.")
+	fmt.Fprintf(os.Stdout, "Inbox created: %s\n", result.ID)
 
-		return nil
+	return nil
+}
+
+type InboxUpdateCmd struct {
+	ID   string `arg:"" help:"Inbox ID or name"`
+	Name string `help:"New name"`
+}
+
+func (c *InboxUpdateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "TYPE", "NAME", "ADDRESS")
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{}
 
-	for _, ch := range resp.Results {
-		tbl.AddRow(output.FormatChannel(ch)...)
+	if c.Name != "" {
+		req["name"] = c.Name
 	}
 
-	return tbl.Flush()
+	if len(req) == 0 {
+		return fmt.Errorf("no updates specified")
+	}
+
+	if c.ID, err = client.ResolveInbox(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	var result api.Inbox
+	if err := client.Patch(ctx, "/inboxes/"+c.ID, req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Inbox updated: %s\n", c.ID)
+
+	return nil
+}
+
+type InboxDeleteCmd struct {
+	ID string `arg:"" help:"Inbox ID or name"`
+}
+
+func (c *InboxDeleteCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if c.ID, err = client.ResolveInbox(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if err := client.Delete(ctx, "/inboxes/"+c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Inbox deleted")
+
+	return nil
+}
+
+type InboxAddChannelCmd struct {
+	ID         string   `arg:"" help:"Inbox ID or name"`
+	ChannelIDs []string `arg:"" help:"Channel IDs to add"`
+}
+
+func (c *InboxAddChannelCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if c.ID, err = client.ResolveInbox(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	req := map[string][]string{"channel_ids": c.ChannelIDs}
+	if err := client.Post(ctx, fmt.Sprintf("/inboxes/%s/channels", c.ID), req, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Added %d channels to %s\n", len(c.ChannelIDs), c.ID)
+
+	return nil
+}
+
+type InboxRemoveChannelCmd struct {
+	ID         string   `arg:"" help:"Inbox ID or name"`
+	ChannelIDs []string `arg:"" help:"Channel IDs to remove"`
+}
+
+func (c *InboxRemoveChannelCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if c.ID, err = client.ResolveInbox(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	req := map[string][]string{"channel_ids": c.ChannelIDs}
+	if err := client.DeleteWithBody(ctx, fmt.Sprintf("/inboxes/%s/channels", c.ID), req); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Removed %d channels from %s\n", len(c.ChannelIDs), c.ID)
+
+	return nil
 }
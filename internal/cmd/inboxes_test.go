@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInboxCreateInTeamAndManageChannels(t *testing.T) {
+	var requests []string
+	var bodies []map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /teams":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"tim_1","name":"Support"}]}`)
+
+			return
+		case "GET /inboxes":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"inb_1","name":"Billing"}]}`)
+
+			return
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		bodies = append(bodies, body)
+
+		_, _ = io.WriteString(w, `{"id":"inb_1","name":"Billing"}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	for _, args := range [][]string{
+		{"inboxes", "create", "--name", "Billing", "--team", "Support", "--teammate", "tea_1"},
+		{"inboxes", "add-channel", "Billing", "cha_1", "cha_2"},
+		{"inboxes", "remove-channel", "inb_1", "cha_2"},
+	} {
+		if _, err := captureStdout(t, func() error {
+			return Execute(append([]string{"--account", "test@example.com"}, args...))
+		}); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+
+	want := []string{
+		"GET /teams", "POST /teams/tim_1/inboxes",
+		"GET /inboxes", "POST /inboxes/inb_1/channels",
+		"DELETE /inboxes/inb_1/channels",
+	}
+	if strings.Join(requests, ",") != strings.Join(want, ",") {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+
+	if bodies[0]["name"] != "Billing" || len(bodies[0]["teammate_ids"].([]any)) != 1 {
+		t.Errorf("create body = %v", bodies[0])
+	}
+
+	if ids := bodies[1]["channel_ids"].([]any); len(ids) != 2 || ids[1] != "cha_2" {
+		t.Errorf("add-channel body = %v", bodies[1])
+	}
+
+	if ids := bodies[2]["channel_ids"].([]any); len(ids) != 1 || ids[0] != "cha_2" {
+		t.Errorf("remove-channel body = %v", bodies[2])
+	}
+}
@@ -0,0 +1,603 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+)
+
+type KBCmd struct {
+	List       KBListCmd     `cmd:"" help:"List knowledge bases"`
+	Get        KBGetCmd      `cmd:"" help:"Get a knowledge base"`
+	Categories KBCategoryCmd `cmd:"" help:"Manage knowledge base categories"`
+	Articles   KBArticleCmd  `cmd:"" help:"Manage knowledge base articles"`
+}
+
+type KBListCmd struct {
+	PaginationFlags `embed:""`
+}
+
+func (c *KBListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.KnowledgeBase]{
+		Path:    "/knowledge_bases",
+		Empty:   "No knowledge bases found.",
+		Headers: []string{"ID", "NAME", "STATUS", "LOCALES"},
+		Row: func(kb api.KnowledgeBase) []string {
+			return []string{kb.ID, kb.Name, kb.Status, strings.Join(kb.Locales, ",")}
+		},
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type KBGetCmd struct {
+	ID string `arg:"" help:"Knowledge base ID"`
+}
+
+func (c *KBGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	var kb api.KnowledgeBase
+	if err := client.Get(ctx, "/knowledge_bases/"+c.ID, &kb); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, kb)
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:      %s\n", kb.ID)
+	fmt.Fprintf(os.Stdout, "Name:    %s\n", kb.Name)
+	fmt.Fprintf(os.Stdout, "Status:  %s\n", kb.Status)
+
+	if len(kb.Locales) > 0 {
+		fmt.Fprintf(os.Stdout, "Locales: %s\n", strings.Join(kb.Locales, ", "))
+	}
+
+	return nil
+}
+
+type KBCategoryCmd struct {
+	List   KBCategoryListCmd   `cmd:"" help:"List categories of a knowledge base"`
+	Get    KBCategoryGetCmd    `cmd:"" help:"Get a category"`
+	Create KBCategoryCreateCmd `cmd:"" help:"Create a category"`
+	Update KBCategoryUpdateCmd `cmd:"" help:"Update a category"`
+}
+
+type KBCategoryListCmd struct {
+	PaginationFlags `embed:""`
+
+	KBID string `arg:"" name:"kb-id" help:"Knowledge base ID"`
+}
+
+func (c *KBCategoryListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.KBCategory]{
+		Path:    fmt.Sprintf("/knowledge_bases/%s/categories", c.KBID),
+		Empty:   "No categories found.",
+		Headers: []string{"ID", "NAME", "PARENT"},
+		Row:     formatKBCategory,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func formatKBCategory(cat api.KBCategory) []string {
+	parent := cat.ParentCategoryID
+	if parent == "" {
+		parent = "-"
+	}
+
+	return []string{
+		cat.ID,
+		cat.Name,
+		parent,
+	}
+}
+
+type KBCategoryGetCmd struct {
+	ID string `arg:"" help:"Category ID"`
+}
+
+func (c *KBCategoryGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	var cat api.KBCategory
+	if err := client.Get(ctx, "/knowledge_base_categories/"+c.ID, &cat); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, cat)
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:     %s\n", cat.ID)
+	fmt.Fprintf(os.Stdout, "Name:   %s\n", cat.Name)
+
+	if cat.ParentCategoryID != "" {
+		fmt.Fprintf(os.Stdout, "Parent: %s\n", cat.ParentCategoryID)
+	}
+
+	if cat.Description != "" {
+		fmt.Fprintf(os.Stdout, "\n%s\n", cat.Description)
+	}
+
+	return nil
+}
+
+type KBCategoryCreateCmd struct {
+	KBID        string `arg:"" name:"kb-id" help:"Knowledge base ID"`
+	Name        string `required:"" help:"Category name"`
+	Description string `help:"Category description"`
+	Parent      string `help:"Parent category ID"`
+}
+
+func (c *KBCategoryCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"name": c.Name,
+	}
+
+	if c.Description != "" {
+		req["description"] = c.Description
+	}
+
+	if c.Parent != "" {
+		req["parent_category_id"] = c.Parent
+	}
+
+	var result api.KBCategory
+	if err := client.Post(ctx, fmt.Sprintf("/knowledge_bases/%s/categories", c.KBID), req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Category created: %s\n", result.ID)
+
+	return nil
+}
+
+type KBCategoryUpdateCmd struct {
+	ID          string `arg:"" help:"Category ID"`
+	Name        string `help:"New name"`
+	Description string `help:"New description"`
+	Parent      string `help:"New parent category ID"`
+}
+
+func (c *KBCategoryUpdateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{}
+
+	if c.Name != "" {
+		req["name"] = c.Name
+	}
+
+	if c.Description != "" {
+		req["description"] = c.Description
+	}
+
+	if c.Parent != "" {
+		req["parent_category_id"] = c.Parent
+	}
+
+	if len(req) == 0 {
+		return fmt.Errorf("no updates specified")
+	}
+
+	var result api.KBCategory
+	if err := client.Patch(ctx, "/knowledge_base_categories/"+c.ID, req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Category updated: %s\n", c.ID)
+
+	return nil
+}
+
+type KBArticleCmd struct {
+	List    KBArticleListCmd    `cmd:"" help:"List articles of a knowledge base"`
+	Get     KBArticleGetCmd     `cmd:"" help:"Get an article"`
+	Create  KBArticleCreateCmd  `cmd:"" help:"Create an article"`
+	Update  KBArticleUpdateCmd  `cmd:"" help:"Update an article"`
+	Publish KBArticlePublishCmd `cmd:"" help:"Publish an article"`
+}
+
+type KBArticleListCmd struct {
+	PaginationFlags `embed:""`
+
+	KBID     string `arg:"" name:"kb-id" help:"Knowledge base ID"`
+	Category string `help:"Only list articles in this category ID"`
+}
+
+func (c *KBArticleListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/knowledge_bases/%s/articles", c.KBID)
+	if c.Category != "" {
+		path = fmt.Sprintf("/knowledge_base_categories/%s/articles", c.Category)
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.KBArticle]{
+		Path:    path,
+		Empty:   "No articles found.",
+		Headers: []string{"ID", "STATUS", "LOCALE", "SUBJECT"},
+		Row:     formatKBArticle,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func formatKBArticle(article api.KBArticle) []string {
+	subject := article.Subject
+	if len(subject) > 60 {
+		subject = subject[:57] + "..."
+	}
+
+	return []string{
+		article.ID,
+		article.Status,
+		article.Locale,
+		subject,
+	}
+}
+
+type KBArticleGetCmd struct {
+	ID     string `arg:"" help:"Article ID"`
+	Locale string `help:"Fetch a specific locale of the article"`
+	Raw    bool   `help:"Print only the article content"`
+	Output string `name:"out" short:"o" help:"Write the article content to a file"`
+}
+
+func (c *KBArticleGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	path := "/knowledge_base_articles/" + c.ID
+	if c.Locale != "" {
+		path += "/locales/" + c.Locale
+	}
+
+	var article api.KBArticle
+	if err := client.Get(ctx, path, &article); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if c.Output != "" {
+		outPath, err := config.ExpandPath(c.Output)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(outPath, []byte(article.Content), 0o600); err != nil {
+			return fmt.Errorf("write %s: %w", outPath, err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Saved %s to %s\n", article.ID, outPath)
+
+		return nil
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, article)
+	}
+
+	if c.Raw {
+		fmt.Fprintln(os.Stdout, article.Content)
+
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:       %s\n", article.ID)
+	fmt.Fprintf(os.Stdout, "Subject:  %s\n", article.Subject)
+	fmt.Fprintf(os.Stdout, "Status:   %s\n", article.Status)
+	fmt.Fprintf(os.Stdout, "Locale:   %s\n", article.Locale)
+
+	if article.CategoryID != "" {
+		fmt.Fprintf(os.Stdout, "Category: %s\n", article.CategoryID)
+	}
+
+	if article.Content != "" {
+		fmt.Fprintf(os.Stdout, "\n%s\n", article.Content)
+	}
+
+	return nil
+}
+
+type KBArticleCreateCmd struct {
+	KBID        string `arg:"" name:"kb-id" help:"Knowledge base ID"`
+	Subject     string `required:"" help:"Article subject"`
+	Content     string `help:"Article content (HTML)"`
+	ContentFile string `help:"Read content from file (- for stdin)" type:"existingfile"`
+	Category    string `help:"Category ID"`
+	Locale      string `help:"Article locale (defaults to the knowledge base's default)"`
+	Publish     bool   `help:"Publish immediately instead of saving as draft"`
+}
+
+func (c *KBArticleCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	content, err := readContentFlag(c.Content, c.ContentFile)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"subject": c.Subject,
+		"content": content,
+		"status":  "draft",
+	}
+
+	if c.Publish {
+		req["status"] = "published"
+	}
+
+	if c.Category != "" {
+		req["category_id"] = c.Category
+	}
+
+	if c.Locale != "" {
+		req["locale"] = c.Locale
+	}
+
+	var result api.KBArticle
+	if err := client.Post(ctx, fmt.Sprintf("/knowledge_bases/%s/articles", c.KBID), req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Article created: %s\n", result.ID)
+
+	return nil
+}
+
+type KBArticleUpdateCmd struct {
+	ID          string `arg:"" help:"Article ID"`
+	Subject     string `help:"New subject"`
+	Content     string `help:"New content (HTML)"`
+	ContentFile string `help:"Read content from file (- for stdin)" type:"existingfile"`
+	Category    string `help:"New category ID"`
+	Locale      string `help:"Update a specific locale of the article"`
+}
+
+func (c *KBArticleUpdateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	content, err := readContentFlag(c.Content, c.ContentFile)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{}
+
+	if c.Subject != "" {
+		req["subject"] = c.Subject
+	}
+
+	if content != "" {
+		req["content"] = content
+	}
+
+	if c.Category != "" {
+		req["category_id"] = c.Category
+	}
+
+	if len(req) == 0 {
+		return fmt.Errorf("no updates specified")
+	}
+
+	path := "/knowledge_base_articles/" + c.ID
+	if c.Locale != "" {
+		path += "/locales/" + c.Locale
+	}
+
+	var result api.KBArticle
+	if err := client.Patch(ctx, path, req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Article updated: %s\n", c.ID)
+
+	return nil
+}
+
+type KBArticlePublishCmd struct {
+	ID        string `arg:"" help:"Article ID"`
+	Locale    string `help:"Publish a specific locale of the article"`
+	Unpublish bool   `help:"Revert the article to draft"`
+}
+
+func (c *KBArticlePublishCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	status := "published"
+	if c.Unpublish {
+		status = "draft"
+	}
+
+	path := "/knowledge_base_articles/" + c.ID
+	if c.Locale != "" {
+		path += "/locales/" + c.Locale
+	}
+
+	if err := client.Patch(ctx, path, map[string]string{"status": status}, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Article %s: %s\n", status, c.ID)
+
+	return nil
+}
+
+// readContentFlag returns the inline value, or the file contents when a file
+// was given.
+func readContentFlag(value, file string) (string, error) {
+	if file == "" {
+		return value, nil
+	}
+
+	data, err := readFileOrStdin(file)
+	if err != nil {
+		return "", fmt.Errorf("read content file: %w", err)
+	}
+
+	return string(data), nil
+}
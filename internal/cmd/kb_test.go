@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKBArticlePublishPatchesStatus(t *testing.T) {
+	tests := []struct {
+		cmd      KBArticlePublishCmd
+		wantPath string
+		want     string
+	}{
+		{cmd: KBArticlePublishCmd{ID: "kba_1"}, wantPath: "/knowledge_base_articles/kba_1", want: "published"},
+		{cmd: KBArticlePublishCmd{ID: "kba_1", Locale: "fr", Unpublish: true}, wantPath: "/knowledge_base_articles/kba_1/locales/fr", want: "draft"},
+	}
+
+	for _, tt := range tests {
+		var got map[string]string
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPatch || r.URL.Path != tt.wantPath {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		useTestServer(t, srv)
+
+		if err := tt.cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+
+		srv.Close()
+
+		if got["status"] != tt.want {
+			t.Fatalf("status = %q, want %q", got["status"], tt.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type LimitsCmd struct{}
+
+func (c *LimitsCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	// Any response carries the headers; /me is about the cheapest.
+	if _, err := client.Me(ctx); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	status := client.RateLimit()
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, status)
+	}
+
+	if status.Limit == 0 {
+		fmt.Fprintln(os.Stdout, "Front sent no rate-limit headers.")
+
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "Remaining: %d of %d\n", status.Remaining, status.Limit)
+
+	if status.BurstLimit > 0 {
+		fmt.Fprintf(os.Stdout, "Burst:     %d of %d\n", status.BurstRemaining, status.BurstLimit)
+	}
+
+	if !status.ResetAt.IsZero() {
+		fmt.Fprintf(os.Stdout, "Resets:    %s (in %s)\n",
+			output.FormatTimestamp(float64(status.ResetAt.Unix())), resetIn(status.ResetAt))
+	}
+
+	return nil
+}
+
+// lastClient is the client the command last created, for --show-rate-limit.
+var lastClient *api.Client
+
+// printRateLimit writes the quota left after the command to stderr, if the
+// command talked to Front at all.
+func printRateLimit() {
+	if lastClient == nil {
+		return
+	}
+
+	status := lastClient.RateLimit()
+	if status.Limit == 0 {
+		return
+	}
+
+	line := fmt.Sprintf("Rate limit: %d/%d remaining", status.Remaining, status.Limit)
+
+	if status.BurstLimit > 0 {
+		line += fmt.Sprintf(", burst %d/%d", status.BurstRemaining, status.BurstLimit)
+	}
+
+	if !status.ResetAt.IsZero() {
+		line += ", resets in " + resetIn(status.ResetAt)
+	}
+
+	fmt.Fprintln(os.Stderr, line)
+}
+
+// resetIn is the time left until t, in seconds: Front's windows are short.
+func resetIn(t time.Time) string {
+	return max(time.Until(t), 0).Round(time.Second).String()
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestLimitsReportsRateLimitHeaders(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second).Unix()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("x-ratelimit-limit", "100")
+		w.Header().Set("x-ratelimit-remaining", "97")
+		w.Header().Set("x-ratelimit-reset", strconv.FormatInt(reset, 10))
+
+		_, _ = io.WriteString(w, `{"id":"cmp_1","name":"Acme","_results":[]}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "--json", "limits"})
+	})
+	if err != nil {
+		t.Fatalf("limits: %v", err)
+	}
+
+	var status api.RateLimitStatus
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+
+	if status.Limit != 100 || status.Remaining != 97 || status.ResetAt.Unix() != reset {
+		t.Fatalf("status = %+v", status)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+
+	oldStderr := os.Stderr
+	os.Stderr = w
+
+	_, err = captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "--show-rate-limit", "tags", "list"})
+	})
+
+	os.Stderr = oldStderr
+	_ = w.Close()
+
+	stderr, _ := io.ReadAll(r)
+	_ = r.Close()
+
+	if err != nil || !strings.Contains(string(stderr), "Rate limit: 97/100 remaining, resets in ") {
+		t.Fatalf("err %v, stderr %q", err, stderr)
+	}
+}
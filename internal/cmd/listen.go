@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	stdlog "log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/log"
+	"github.com/dedene/frontapp-cli/internal/webhook"
+)
+
+const (
+	webhookSecretEnv   = "FRONT_WEBHOOK_SECRET"
+	maxWebhookBodySize = 1 << 20
+)
+
+type ListenCmd struct {
+	Port     int      `help:"Port to listen on" default:"8585"`
+	Host     string   `help:"Address to bind to" default:"127.0.0.1"`
+	Path     string   `help:"Path to accept webhook deliveries on" default:"/"`
+	Secret   string   `help:"Webhook signing secret (or FRONT_WEBHOOK_SECRET)"`
+	Type     []string `help:"Only print events of this type (inbound, outbound, assign, ...); repeatable"`
+	Insecure bool     `help:"Accept unsigned deliveries when no secret is set"`
+}
+
+func (c *ListenCmd) Run(_ *RootFlags) error {
+	secret := c.Secret
+	if secret == "" {
+		secret = os.Getenv(webhookSecretEnv)
+	}
+
+	if secret == "" && !c.Insecure {
+		return fmt.Errorf("webhook secret required: use --secret, set %s, or pass --insecure", webhookSecretEnv)
+	}
+
+	certPath, keyPath, err := auth.EnsureCertificate()
+	if err != nil {
+		return fmt.Errorf("setup TLS: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+
+	ln, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	srv := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		ErrorLog:          stdlog.New(io.Discard, "", 0), //nolint:forbidigo // Suppress TLS handshake errors from self-signed cert
+		Handler:           c.handler(secret, os.Stdout),
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Listening for Front webhooks on https://%s%s (Ctrl+C to stop)\n", addr, c.Path)
+
+	if err := srv.ServeTLS(ln, certPath, keyPath); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve webhooks: %w", err)
+	}
+
+	return nil
+}
+
+// handler returns the HTTP handler that validates deliveries and writes each
+// accepted event to out as a single JSON line.
+func (c *ListenCmd) handler(secret string, out io.Writer) http.Handler {
+	types := make(map[string]bool, len(c.Type))
+	for _, t := range c.Type {
+		types[t] = true
+	}
+
+	var mu sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != c.Path {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if secret != "" {
+			if err := webhook.VerifySignature(secret, r.Header, body); err != nil {
+				log.Warn("rejected webhook delivery", "err", err)
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+		}
+
+		// Front sends a challenge when an application webhook is registered.
+		if challenge := r.Header.Get(webhook.ChallengeHeader); challenge != "" {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, challenge)
+
+			return
+		}
+
+		eventType, err := webhook.EventType(body)
+		if err != nil {
+			log.Warn("rejected webhook delivery", "err", err)
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		if len(types) > 0 && !types[eventType] {
+			return
+		}
+
+		var line bytes.Buffer
+		if err := json.Compact(&line, body); err != nil {
+			return
+		}
+
+		line.WriteByte('\n')
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		_, _ = out.Write(line.Bytes())
+	})
+}
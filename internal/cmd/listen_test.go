@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Front signs rule webhooks with HMAC-SHA1
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/webhook"
+)
+
+func signedRequest(t *testing.T, secret, body string) *http.Request {
+	t.Helper()
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	_, _ = mac.Write([]byte(body))
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(webhook.SignatureHeader, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	return req
+}
+
+func TestListenHandlerFiltersAndPrintsJSONLines(t *testing.T) {
+	var out bytes.Buffer
+
+	cmd := ListenCmd{Path: "/", Type: []string{"inbound"}}
+	h := cmd.handler("s3cret", &out)
+
+	for _, body := range []string{
+		`{"type": "inbound", "id": "evt_1"}`,
+		`{"type": "assign", "id": "evt_2"}`,
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, signedRequest(t, "s3cret", body))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d for %s", rec.Code, body)
+		}
+	}
+
+	if got := out.String(); got != "{\"type\":\"inbound\",\"id\":\"evt_1\"}\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestListenHandlerRejectsBadSignature(t *testing.T) {
+	var out bytes.Buffer
+
+	cmd := ListenCmd{Path: "/"}
+	h := cmd.handler("s3cret", &out)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(t, "wrong", `{"type":"inbound"}`))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+
+	if out.Len() != 0 {
+		t.Fatalf("expected no output, got %q", out.String())
+	}
+}
+
+func TestListenHandlerAnswersChallenge(t *testing.T) {
+	cmd := ListenCmd{Path: "/"}
+	h := cmd.handler("s3cret", &bytes.Buffer{})
+
+	req := signedRequest(t, "s3cret", `{"type":"sync"}`)
+	req.Header.Set(webhook.ChallengeHeader, "abc123")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Body.String() != "abc123" {
+		t.Fatalf("expected challenge echo, got %q", rec.Body.String())
+	}
+}
@@ -3,26 +3,37 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/config"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/log"
 	"github.com/dedene/frontapp-cli/internal/markdown"
 	"github.com/dedene/frontapp-cli/internal/output"
 )
 
 type MsgCmd struct {
 	Get         MsgGetCmd         `cmd:"" help:"Get a message"`
+	Seen        MsgSeenCmd        `cmd:"" help:"Mark an outbound message as seen by its recipient"`
+	Source      MsgSourceCmd      `cmd:"" help:"Print the raw RFC 822 source of a message"`
 	Send        MsgSendCmd        `cmd:"" help:"Send a new message"`
 	Reply       MsgReplyCmd       `cmd:"" help:"Reply to a conversation"`
+	Import      MsgImportCmd      `cmd:"" help:"Import a historical message into an inbox"`
+	Scheduled   MsgScheduledCmd   `cmd:"" help:"List or cancel scheduled messages"`
 	Attachments MsgAttachmentsCmd `cmd:"" help:"List message attachments"`
 	Attachment  MsgAttachmentCmd  `cmd:"" help:"Attachment operations"`
 }
 
 type MsgGetCmd struct {
-	ID  string `arg:"" help:"Message ID"`
-	Raw bool   `help:"Show raw body (no HTML conversion)"`
+	ID      string `arg:"" help:"Message ID"`
+	Raw     bool   `help:"Show raw body (no HTML conversion)"`
+	Web     bool   `help:"Open the message in the Front web app instead"`
+	Details bool   `help:"Show recipients, delivery status and email headers (Message-ID, bounce info)"`
 }
 
 func (c *MsgGetCmd) Run(flags *RootFlags) error {
@@ -45,8 +56,12 @@ func (c *MsgGetCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if c.Web {
+		return openWeb(msg.WebURL())
+	}
+
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, msg)
+		return mode.Write(os.Stdout, msg)
 	}
 
 	direction := "Outbound"
@@ -80,6 +95,20 @@ func (c *MsgGetCmd) Run(flags *RootFlags) error {
 	}
 
 	fmt.Fprintf(os.Stdout, "Date:      %s\n", output.FormatTimestamp(msg.CreatedAt))
+
+	if c.Details {
+		var seen []api.SeenReceipt
+
+		// Only outbound messages have seen receipts, and not on every channel.
+		if !msg.IsInbound {
+			if seen, err = client.MessageSeenReceipts(ctx, msg.ID); err != nil {
+				log.Debug("no seen receipts", "message", msg.ID, "err", err)
+			}
+		}
+
+		printMessageDetails(os.Stdout, msg, seen)
+	}
+
 	fmt.Fprintln(os.Stdout)
 
 	switch {
@@ -99,12 +128,149 @@ func (c *MsgGetCmd) Run(flags *RootFlags) error {
 	return nil
 }
 
+// deliveryHeaders are shown first in msg get --details; they are the ones
+// that explain why a reply did not arrive or which thread it landed in.
+var deliveryHeaders = []string{
+	"Message-ID",
+	"In-Reply-To",
+	"References",
+	"Return-Path",
+	"X-Failed-Recipients",
+}
+
+// printMessageDetails prints the recipients, delivery status and headers of
+// msg. seen lists its seen receipts; nil leaves them out.
+func printMessageDetails(w io.Writer, msg *api.Message, seen []api.SeenReceipt) {
+	if len(msg.Recipients) > 0 {
+		fmt.Fprintln(w, "Recipients:")
+
+		for _, r := range msg.Recipients {
+			handle := r.Handle
+			if r.Name != "" {
+				handle = fmt.Sprintf("%s <%s>", r.Name, r.Handle)
+			}
+
+			fmt.Fprintf(w, "  %-4s %s\n", r.Role, handle)
+		}
+	}
+
+	switch {
+	case msg.DeliveryFailed():
+		fmt.Fprintf(w, "Delivery:  failed (%s)\n", strings.ReplaceAll(msg.ErrorType, "_", " "))
+	case !msg.IsInbound:
+		fmt.Fprintln(w, "Delivery:  sent")
+	}
+
+	switch {
+	case seen == nil:
+	case len(seen) == 0:
+		fmt.Fprintln(w, "Seen:      not yet")
+	default:
+		fmt.Fprintln(w, "Seen:")
+
+		for _, r := range seen {
+			handle := "-"
+			if r.SeenBy != nil {
+				handle = r.SeenBy.Handle
+			}
+
+			fmt.Fprintf(w, "  %s at %s\n", handle, output.FormatTimestamp(r.SeenAt()))
+		}
+	}
+
+	for _, name := range deliveryHeaders {
+		if v := msg.Header(name); v != "" {
+			fmt.Fprintf(w, "%s: %s\n", name, v)
+		}
+	}
+
+	if msg.Metadata == nil || len(msg.Metadata.Headers) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(msg.Metadata.Headers))
+	for name := range msg.Metadata.Headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Headers:")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s: %s\n", name, msg.Header(name))
+	}
+}
+
+// EnvelopeFlags are the optional recipients and sender name shared by msg
+// send and msg reply.
+type EnvelopeFlags struct {
+	CC         []string `name:"cc" help:"Cc address; repeatable or comma-separated"`
+	BCC        []string `name:"bcc" help:"Bcc address; repeatable or comma-separated"`
+	SenderName string   `help:"Display name to send as instead of the channel's default"`
+}
+
+func (e EnvelopeFlags) apply(req map[string]any) {
+	if len(e.CC) > 0 {
+		req["cc"] = e.CC
+	}
+
+	if len(e.BCC) > 0 {
+		req["bcc"] = e.BCC
+	}
+
+	if e.SenderName != "" {
+		req["sender_name"] = e.SenderName
+	}
+}
+
+// renderBody converts a body written in the given --body-format to the HTML
+// Front expects.
+func renderBody(body, format string) (string, error) {
+	switch format {
+	case "markdown":
+		return markdown.ToHTML(body)
+	case "text":
+		return textToHTML(body), nil
+	default:
+		return body, nil
+	}
+}
+
+// parseSendAt parses --send-at into a Unix time, or 0 to send now.
+func parseSendAt(value string) (float64, error) {
+	ts, err := parseFutureTimeFlag(value)
+	if err != nil {
+		return 0, fmt.Errorf("--send-at: %w", err)
+	}
+
+	if ts != 0 && ts <= float64(time.Now().Unix()) {
+		return 0, fmt.Errorf("--send-at %q is in the past", value)
+	}
+
+	return ts, nil
+}
+
+// scheduleSend asks Front to hold a message until ts instead of sending it
+// now; ts 0 leaves the request unchanged.
+func scheduleSend(req map[string]any, ts float64) {
+	if ts != 0 {
+		req["scheduled_at"] = time.Unix(int64(ts), 0).UTC().Format(time.RFC3339)
+	}
+}
+
 type MsgSendCmd struct {
-	Channel  string `required:"" help:"Channel ID to send from"`
-	To       string `required:"" help:"Recipient address"`
-	Subject  string `help:"Message subject"`
-	Body     string `help:"Message body"`
-	BodyFile string `help:"Read body from file" type:"existingfile"`
+	Channel    string   `required:"" help:"Channel ID to send from"`
+	To         []string `required:"" help:"Recipient address; repeatable or comma-separated"`
+	Subject    string   `help:"Message subject"`
+	Body       string   `help:"Message body"`
+	BodyFile   string   `help:"Read body from file (- for stdin)" type:"existingfile"`
+	BodyFormat string   `help:"Format of --body/--body-file: html, or markdown to convert it to HTML" enum:"html,markdown" default:"html"`
+	Template   string   `help:"Message template ID to use as subject and body; fills in {{contact.name}}-style variables"`
+	Var        []string `help:"Template variable as name=value; repeatable" sep:"none"`
+	SendAt     string   `help:"Schedule the message instead of sending it now, e.g. tomorrow 9:00, 2h, 2024-01-15 14:00"`
+
+	EnvelopeFlags `embed:""`
 }
 
 func (c *MsgSendCmd) Run(flags *RootFlags) error {
@@ -120,29 +286,62 @@ func (c *MsgSendCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	body := c.Body
-	if c.BodyFile != "" {
-		data, err := os.ReadFile(c.BodyFile)
+	scheduled, err := parseSendAt(c.SendAt)
+	if err != nil {
+		return err
+	}
+
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	body, err = renderBody(body, c.BodyFormat)
+	if err != nil {
+		return err
+	}
+
+	subject := c.Subject
+
+	if c.Template != "" {
+		if body != "" {
+			return fmt.Errorf("use either --template or --body/--body-file")
+		}
+
+		// contact.* variables describe the first recipient.
+		src := messageTemplateSource{To: c.To[0], Vars: c.Var}
+
+		tmplSubject, tmplBody, err := renderMessageTemplate(ctx, client, c.Template, src)
 		if err != nil {
-			return fmt.Errorf("read body file: %w", err)
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
 		}
 
-		body = string(data)
+		body = tmplBody
+
+		if subject == "" {
+			subject = tmplSubject
+		}
 	}
 
 	if body == "" {
-		return fmt.Errorf("body is required (use --body or --body-file)")
+		return fmt.Errorf("body is required (use --body, --body-file or --template)")
 	}
 
 	req := map[string]any{
-		"to":   []string{c.To},
+		"to":   c.To,
 		"body": body,
 	}
 
-	if c.Subject != "" {
-		req["subject"] = c.Subject
+	if subject != "" {
+		req["subject"] = subject
 	}
 
+	c.EnvelopeFlags.apply(req)
+
+	scheduleSend(req, scheduled)
+
 	var result map[string]any
 	if err := client.Post(ctx, fmt.Sprintf("/channels/%s/messages", c.Channel), req, &result); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
@@ -151,7 +350,13 @@ func (c *MsgSendCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
+	}
+
+	if scheduled != 0 {
+		fmt.Fprintf(os.Stdout, "Message scheduled for %s\n", output.FormatTimestamp(scheduled))
+
+		return nil
 	}
 
 	fmt.Fprintln(os.Stdout, "Message sent successfully")
@@ -160,10 +365,20 @@ func (c *MsgSendCmd) Run(flags *RootFlags) error {
 }
 
 type MsgReplyCmd struct {
-	ConvID    string `arg:"" help:"Conversation ID to reply to"`
-	Body      string `help:"Reply body"`
-	BodyFile  string `help:"Read body from file" type:"existingfile"`
-	InReplyTo string `help:"Message ID to reply to (for threading)"`
+	ConvID     string   `arg:"" help:"Conversation ID to reply to"`
+	Body       string   `help:"Reply body"`
+	BodyFile   string   `help:"Read body from file (- for stdin)" type:"existingfile"`
+	BodyFormat string   `help:"Format of --body/--body-file: html, or markdown to convert it to HTML" enum:"html,markdown" default:"html"`
+	InReplyTo  string   `help:"Message ID to reply to (for threading)"`
+	Template   string   `help:"Message template ID to use as the body; fills in {{contact.name}}-style variables"`
+	Var        []string `help:"Template variable as name=value; repeatable" sep:"none"`
+	To         []string `help:"Send to these addresses instead of the conversation's recipients; repeatable or comma-separated"`
+	Subject    string   `help:"Reply subject (defaults to the conversation's)"`
+	Archive    bool     `help:"Archive the conversation once the reply is sent"`
+	Status     string   `help:"Set the conversation status after replying (open, archived, trashed)" enum:"open,archived,trashed," default:""`
+	SendAt     string   `help:"Schedule the reply instead of sending it now, e.g. tomorrow 9:00, 2h, 2024-01-15 14:00"`
+
+	EnvelopeFlags `embed:""`
 }
 
 func (c *MsgReplyCmd) Run(flags *RootFlags) error {
@@ -179,18 +394,52 @@ func (c *MsgReplyCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	body := c.Body
-	if c.BodyFile != "" {
-		data, err := os.ReadFile(c.BodyFile)
-		if err != nil {
-			return fmt.Errorf("read body file: %w", err)
+	status := c.Status
+	if c.Archive {
+		if status != "" && status != "archived" {
+			return fmt.Errorf("--archive conflicts with --status %s", status)
+		}
+
+		status = "archived"
+	}
+
+	scheduled, err := parseSendAt(c.SendAt)
+	if err != nil {
+		return err
+	}
+
+	// Statuses other than archived are set right after the request, long
+	// before a scheduled reply goes out.
+	if scheduled != 0 && status != "" && status != "archived" {
+		return fmt.Errorf("--status %s cannot be combined with --send-at", status)
+	}
+
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	body, err = renderBody(body, c.BodyFormat)
+	if err != nil {
+		return err
+	}
+
+	if c.Template != "" {
+		if body != "" {
+			return fmt.Errorf("use either --template or --body/--body-file")
 		}
 
-		body = string(data)
+		src := messageTemplateSource{ConvID: c.ConvID, Vars: c.Var}
+
+		if _, body, err = renderMessageTemplate(ctx, client, c.Template, src); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
 	}
 
 	if body == "" {
-		return fmt.Errorf("body is required (use --body or --body-file)")
+		return fmt.Errorf("body is required (use --body, --body-file or --template)")
 	}
 
 	req := map[string]any{
@@ -202,6 +451,24 @@ func (c *MsgReplyCmd) Run(flags *RootFlags) error {
 		req["in_reply_to_message_id"] = c.InReplyTo
 	}
 
+	if len(c.To) > 0 {
+		req["to"] = c.To
+	}
+
+	if c.Subject != "" {
+		req["subject"] = c.Subject
+	}
+
+	c.EnvelopeFlags.apply(req)
+
+	scheduleSend(req, scheduled)
+
+	// Front archives in the same request; other statuses need a follow-up
+	// update once the reply is accepted.
+	if status == "archived" {
+		req["options"] = map[string]any{"archive": true}
+	}
+
 	var result map[string]any
 	if err := client.Post(ctx, fmt.Sprintf("/conversations/%s/messages", c.ConvID), req, &result); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
@@ -209,8 +476,29 @@ func (c *MsgReplyCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if status != "" && status != "archived" {
+		if err := client.Patch(ctx, "/conversations/"+c.ConvID, map[string]string{"status": status}, nil); err != nil {
+			fmt.Fprintln(os.Stderr, "Reply sent, but the conversation status was not changed.")
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
+	}
+
+	if scheduled != 0 {
+		fmt.Fprintf(os.Stdout, "Reply scheduled for %s\n", output.FormatTimestamp(scheduled))
+
+		return nil
+	}
+
+	if status != "" {
+		fmt.Fprintf(os.Stdout, "Reply sent; conversation %s\n", status)
+
+		return nil
 	}
 
 	fmt.Fprintln(os.Stdout, "Reply sent successfully")
@@ -243,7 +531,7 @@ func (c *MsgAttachmentsCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, map[string]any{"attachments": msg.Attachments})
+		return mode.Write(os.Stdout, map[string]any{"attachments": msg.Attachments})
 	}
 
 	if len(msg.Attachments) == 0 {
@@ -268,7 +556,7 @@ type MsgAttachmentCmd struct {
 
 type MsgAttachmentDownloadCmd struct {
 	ID     string `arg:"" help:"Attachment ID"`
-	Output string `short:"o" help:"Output file path"`
+	Output string `name:"out" short:"o" help:"Output file path"`
 }
 
 func (c *MsgAttachmentDownloadCmd) Run(flags *RootFlags) error {
@@ -304,3 +592,71 @@ func (c *MsgAttachmentDownloadCmd) Run(flags *RootFlags) error {
 
 	return nil
 }
+
+type MsgSeenCmd struct {
+	ID string `arg:"" help:"Message ID"`
+}
+
+func (c *MsgSeenCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := client.MarkMessageSeen(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Marked message %s as seen\n", c.ID)
+
+	return nil
+}
+
+type MsgSourceCmd struct {
+	ID     string `arg:"" help:"Message ID"`
+	Output string `name:"out" short:"o" help:"Save the source to this file (e.g. message.eml)"`
+}
+
+func (c *MsgSourceCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(c.Output) == "" {
+		if err := client.MessageSource(ctx, c.ID, os.Stdout); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		return nil
+	}
+
+	path, err := config.ExpandPath(c.Output)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path) //nolint:gosec // Path is cleaned by config.ExpandPath
+	if err != nil {
+		return fmt.Errorf("create output file: %w", err)
+	}
+	defer f.Close()
+
+	if err := client.MessageSource(ctx, c.ID, f); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Source saved to %s\n", path)
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+)
+
+type MsgImportCmd struct {
+	Inbox      string   `help:"Inbox to import into (ID or name)" xor:"target" required:""`
+	Channel    string   `help:"Channel ID; imports into the channel's inbox" xor:"target" required:""`
+	From       string   `required:"" help:"Sender handle"`
+	FromName   string   `help:"Sender display name"`
+	To         []string `required:"" help:"Recipient handle; repeatable"`
+	Cc         []string `help:"Cc handle; repeatable"`
+	Bcc        []string `help:"Bcc handle; repeatable"`
+	Subject    string   `help:"Message subject"`
+	Body       string   `help:"Message body"`
+	BodyFile   string   `help:"Read body from file (- for stdin)" type:"existingfile"`
+	Markdown   bool     `help:"Body is Markdown rather than HTML"`
+	SentAt     string   `required:"" help:"When the message was sent (RFC3339, YYYY-MM-DD or Unix seconds)"`
+	ExternalID string   `help:"Unique ID in the source system (default: derived from sender, date, subject and body)"`
+	Type       string   `help:"Message type" enum:"email,sms,intercom,custom" default:"email"`
+	Outbound   bool     `help:"Import as sent by your team instead of received"`
+	Archive    bool     `help:"Archive the imported conversation"`
+	SkipRules  bool     `help:"Don't run rules on the imported message"`
+	ThreadRef  string   `help:"Thread imported messages sharing this reference into one conversation"`
+	ConvID     string   `help:"Import into this existing conversation" name:"conversation"`
+	Tag        []string `help:"Tag name to add; repeatable"`
+}
+
+func (c *MsgImportCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	if body == "" {
+		return fmt.Errorf("body is required (use --body or --body-file)")
+	}
+
+	sentAt, err := parseTimeFlag(c.SentAt)
+	if err != nil {
+		return fmt.Errorf("invalid --sent-at: %w", err)
+	}
+
+	inboxID, err := c.inboxID(ctx, client)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	req := c.request(body, sentAt)
+
+	var accepted api.MessageAccepted
+	if err := client.Post(ctx, fmt.Sprintf("/inboxes/%s/imported_messages", inboxID), req, &accepted); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, accepted)
+	}
+
+	fmt.Fprintf(os.Stdout, "Message queued for import: %s\n", accepted.MessageUID)
+
+	return nil
+}
+
+// inboxID resolves the import target: --inbox directly, or the inbox behind
+// --channel.
+func (c *MsgImportCmd) inboxID(ctx context.Context, client *api.Client) (string, error) {
+	if c.Inbox != "" {
+		id, err := client.ResolveInbox(ctx, c.Inbox)
+		if err != nil {
+			return "", err
+		}
+
+		return api.SanitizeID(id)
+	}
+
+	channel, err := client.GetChannel(ctx, c.Channel)
+	if err != nil {
+		return "", err
+	}
+
+	id := channel.InboxID()
+	if id == "" {
+		return "", fmt.Errorf("channel %s is not linked to an inbox; use --inbox", c.Channel)
+	}
+
+	return id, nil
+}
+
+// request builds the imported_messages payload.
+func (c *MsgImportCmd) request(body string, sentAt float64) map[string]any {
+	sender := map[string]any{"handle": c.From}
+	if c.FromName != "" {
+		sender["name"] = c.FromName
+	}
+
+	externalID := c.ExternalID
+	if externalID == "" {
+		externalID = importExternalID(c.From, sentAt, c.Subject, body)
+	}
+
+	metadata := map[string]any{"is_inbound": !c.Outbound}
+
+	if c.Archive {
+		metadata["is_archived"] = true
+	}
+
+	if c.SkipRules {
+		metadata["should_skip_rules"] = true
+	}
+
+	if c.ThreadRef != "" {
+		metadata["thread_ref"] = c.ThreadRef
+	}
+
+	if c.ConvID != "" {
+		metadata["conversation_id"] = c.ConvID
+	}
+
+	req := map[string]any{
+		"sender":      sender,
+		"to":          c.To,
+		"body":        body,
+		"external_id": externalID,
+		"created_at":  int64(sentAt),
+		"type":        c.Type,
+		"metadata":    metadata,
+	}
+
+	if len(c.Cc) > 0 {
+		req["cc"] = c.Cc
+	}
+
+	if len(c.Bcc) > 0 {
+		req["bcc"] = c.Bcc
+	}
+
+	if c.Subject != "" {
+		req["subject"] = c.Subject
+	}
+
+	if c.Markdown {
+		req["body_format"] = "markdown"
+	}
+
+	if len(c.Tag) > 0 {
+		req["tags"] = c.Tag
+	}
+
+	return req
+}
+
+// importExternalID derives a stable external ID from the message itself, so
+// re-running an import doesn't create duplicates.
+func importExternalID(from string, sentAt float64, subject, body string) string {
+	h := sha256.New()
+
+	for _, part := range []string{strings.ToLower(from), time.Unix(int64(sentAt), 0).UTC().Format(time.RFC3339), subject, body} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return "frontcli-" + hex.EncodeToString(h.Sum(nil))[:32]
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMsgImportViaChannel(t *testing.T) {
+	var sent map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/channels/cha_1":
+			_, _ = w.Write([]byte(`{"id":"cha_1","_links":{"related":{"inbox":"https://api2.frontapp.com/inboxes/inb_7"}}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/inboxes/inb_7/imported_messages":
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &sent)
+
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"status":"accepted","message_uid":"uid_1"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := MsgImportCmd{
+		Channel:   "cha_1",
+		From:      "old@example.com",
+		To:        []string{"support@acme.com"},
+		Subject:   "Legacy ticket",
+		Body:      "<p>Hello</p>",
+		SentAt:    "2020-05-01T10:00:00Z",
+		Type:      "email",
+		Archive:   true,
+		ThreadRef: "ticket-42",
+	}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if sent["created_at"] != float64(1588327200) {
+		t.Fatalf("created_at = %v", sent["created_at"])
+	}
+
+	metadata, _ := sent["metadata"].(map[string]any)
+	if metadata["is_inbound"] != true || metadata["is_archived"] != true || metadata["thread_ref"] != "ticket-42" {
+		t.Fatalf("metadata = %v", metadata)
+	}
+
+	want := importExternalID("old@example.com", 1588327200, "Legacy ticket", "<p>Hello</p>")
+	if sent["external_id"] != want {
+		t.Fatalf("external_id = %v, want %s", sent["external_id"], want)
+	}
+}
+
+func TestImportExternalIDIsStable(t *testing.T) {
+	a := importExternalID("A@example.com", 100, "s", "b")
+	if a != importExternalID("a@example.com", 100, "s", "b") {
+		t.Fatal("external ID should ignore sender case")
+	}
+
+	if a == importExternalID("a@example.com", 101, "s", "b") {
+		t.Fatal("external ID should depend on the send time")
+	}
+}
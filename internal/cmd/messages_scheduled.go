@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type MsgScheduledCmd struct {
+	List   MsgScheduledListCmd   `cmd:"" help:"List messages waiting to be sent"`
+	Cancel MsgScheduledCancelCmd `cmd:"" help:"Cancel scheduled messages before they are sent"`
+}
+
+type MsgScheduledListCmd struct {
+	PaginationFlags `embed:""`
+
+	Conversation string `help:"Only messages scheduled in this conversation"`
+}
+
+func (c *MsgScheduledListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	path := "/scheduled_messages"
+	if c.Conversation != "" {
+		path = fmt.Sprintf("/conversations/%s/scheduled_messages", c.Conversation)
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.ScheduledMessage]{
+		Path:    path,
+		Empty:   "No scheduled messages.",
+		Headers: []string{"ID", "SEND AT", "CONVERSATION", "SUBJECT"},
+		Row: func(m api.ScheduledMessage) []string {
+			return []string{m.ID, output.FormatTimestamp(m.ScheduledAt), m.ConversationID(), m.Subject}
+		},
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type MsgScheduledCancelCmd struct {
+	IDs []string `arg:"" help:"Scheduled message IDs"`
+}
+
+func (c *MsgScheduledCancelCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range c.IDs {
+		if err := client.Delete(ctx, "/scheduled_messages/"+id); err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		fmt.Fprintf(os.Stdout, "Canceled scheduled message %s\n", id)
+	}
+
+	return nil
+}
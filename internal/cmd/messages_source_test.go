@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMsgSourceSavesEML(t *testing.T) {
+	const source = "From: jane@customer.com\r\nSubject: Refund\r\n\r\nHi there\r\n"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/msg_1" || r.Header.Get("Accept") != "message/rfc822" {
+			t.Errorf("unexpected request %s %s (Accept %q)", r.Method, r.URL.Path, r.Header.Get("Accept"))
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "message/rfc822")
+		_, _ = w.Write([]byte(source))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out := filepath.Join(t.TempDir(), "message.eml")
+
+	cmd := MsgSourceCmd{ID: "msg_1", Output: out}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != source {
+		t.Fatalf("saved source = %q", data)
+	}
+}
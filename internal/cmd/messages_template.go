@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// templateVarPattern matches {{name}} placeholders in message templates.
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][\w.]*)\s*\}\}`)
+
+// messageTemplateSource says where template variables get their values.
+type messageTemplateSource struct {
+	ConvID string   // the conversation being replied to (conversation.*, contact.*)
+	To     string   // the recipient of a new message (contact.*)
+	Vars   []string // key=value overrides from --var
+}
+
+// renderMessageTemplate fetches a message template and fills in its
+// variables: contact.{name,first_name,last_name,handle},
+// conversation.{id,subject,status}, me.{name,first_name,last_name,email} and
+// any --var. Only the data a template refers to is fetched. A variable with
+// no value is an error, so placeholders never reach a customer.
+func renderMessageTemplate(ctx context.Context, client *api.Client, templateID string, src messageTemplateSource) (subject, body string, err error) {
+	tmpl, err := client.GetTemplate(ctx, templateID)
+	if err != nil {
+		return "", "", err
+	}
+
+	values, err := src.values(ctx, client, templateVarNames(tmpl.Subject+tmpl.Body))
+	if err != nil {
+		return "", "", err
+	}
+
+	subject = templateVarPattern.ReplaceAllStringFunc(tmpl.Subject, func(m string) string {
+		return values[templateVarPattern.FindStringSubmatch(m)[1]]
+	})
+
+	body = templateVarPattern.ReplaceAllStringFunc(tmpl.Body, func(m string) string {
+		return html.EscapeString(values[templateVarPattern.FindStringSubmatch(m)[1]])
+	})
+
+	return subject, body, nil
+}
+
+// templateVarNames returns the distinct variable names used in text.
+func templateVarNames(text string) []string {
+	seen := map[string]bool{}
+
+	var names []string
+
+	for _, m := range templateVarPattern.FindAllStringSubmatch(text, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+
+	return names
+}
+
+func (s messageTemplateSource) values(ctx context.Context, client *api.Client, names []string) (map[string]string, error) {
+	overrides := map[string]string{}
+
+	for _, kv := range s.Vars {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --var %q (use key=value)", kv)
+		}
+
+		overrides[strings.TrimSpace(key)] = value
+	}
+
+	needs := func(prefix string) bool {
+		for _, name := range names {
+			if _, ok := overrides[name]; !ok && strings.HasPrefix(name, prefix+".") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	values := map[string]string{}
+
+	var conv *api.Conversation
+
+	if s.ConvID != "" && (needs("conversation") || needs("contact")) {
+		var err error
+		if conv, err = client.GetConversation(ctx, s.ConvID); err != nil {
+			return nil, err
+		}
+
+		values["conversation.id"] = conv.ID
+		values["conversation.subject"] = conv.Subject
+		values["conversation.status"] = conv.Status
+	}
+
+	if needs("contact") {
+		if err := s.contactValues(ctx, client, conv, values); err != nil {
+			return nil, err
+		}
+	}
+
+	if needs("me") {
+		me, err := client.Me(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		values["me.first_name"] = me.FirstName
+		values["me.last_name"] = me.LastName
+		values["me.name"] = strings.TrimSpace(me.FirstName + " " + me.LastName)
+		values["me.email"] = me.Email
+	}
+
+	for k, v := range overrides {
+		values[k] = v
+	}
+
+	var missing []string
+
+	for _, name := range names {
+		if values[name] == "" {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+
+		return nil, fmt.Errorf("no value for template variables %s (set them with --var name=value)", strings.Join(missing, ", "))
+	}
+
+	return values, nil
+}
+
+// contactValues fills contact.* from the conversation's main recipient or,
+// for new messages, from the --to handle. A handle without a contact still
+// provides contact.handle.
+func (s messageTemplateSource) contactValues(ctx context.Context, client *api.Client, conv *api.Conversation, values map[string]string) error {
+	handle := s.To
+	contactID := ""
+
+	if handle != "" {
+		contactID = "alt:email:" + handle
+	}
+
+	if conv != nil && conv.Recipient != nil {
+		handle = conv.Recipient.Handle
+
+		if link := conv.Recipient.Links.Related["contact"]; link != "" {
+			contactID = link[strings.LastIndex(link, "/")+1:]
+		}
+	}
+
+	values["contact.handle"] = handle
+
+	if contactID == "" {
+		return nil
+	}
+
+	contact, err := client.GetContact(ctx, contactID)
+	if err != nil {
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	values["contact.name"] = contact.Name
+
+	first, last, _ := strings.Cut(strings.TrimSpace(contact.Name), " ")
+	values["contact.first_name"] = first
+	values["contact.last_name"] = strings.TrimSpace(last)
+
+	return nil
+}
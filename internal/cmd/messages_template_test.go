@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTemplateServer(t *testing.T, sent *map[string]any) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/message_templates/rsp_1":
+			_, _ = w.Write([]byte(`{"id":"rsp_1","subject":"About {{conversation.subject}}",` +
+				`"body":"Hi {{ contact.first_name }}, re: {{conversation.subject}}. {{me.first_name}}"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/conversations/cnv_1":
+			_, _ = w.Write([]byte(`{"id":"cnv_1","subject":"Order <42>","recipient":{"handle":"jane@example.com",` +
+				`"_links":{"related":{"contact":"https://api2.frontapp.com/contacts/crd_1"}}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/contacts/crd_1":
+			_, _ = w.Write([]byte(`{"id":"crd_1","name":"Jane Doe"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/me":
+			_, _ = w.Write([]byte(`{"id":"tea_1","first_name":"Alice"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/conversations/cnv_1/messages":
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, sent)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestMsgReplyTemplateSubstitutesVariables(t *testing.T) {
+	var sent map[string]any
+
+	srv := newTemplateServer(t, &sent)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := MsgReplyCmd{ConvID: "cnv_1", Template: "rsp_1"}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := "Hi Jane, re: Order &lt;42&gt;. Alice"; sent["body"] != want {
+		t.Fatalf("body = %q, want %q", sent["body"], want)
+	}
+}
+
+func TestMsgReplyTemplateVarOverridesAndMissing(t *testing.T) {
+	var sent map[string]any
+
+	srv := newTemplateServer(t, &sent)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := MsgReplyCmd{ConvID: "cnv_1", Template: "rsp_1", Var: []string{"me.first_name=Bob, from support"}}
+	if err := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if body, _ := sent["body"].(string); !strings.HasSuffix(body, "Bob, from support") {
+		t.Fatalf("body = %q", body)
+	}
+
+	_, err := (messageTemplateSource{}).values(t.Context(), nil, []string{"deal.amount"})
+	if err == nil || !strings.Contains(err.Error(), "deal.amount") {
+		t.Fatalf("values error = %v, want missing deal.amount", err)
+	}
+}
+
+func TestTemplateRenderPreviewsWithConversation(t *testing.T) {
+	srv := newTemplateServer(t, nil)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "templates", "render", "rsp_1", "--conversation", "cnv_1", "--raw"})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if want := "Subject: About Order <42>\n\nHi Jane, re: Order &lt;42&gt;. Alice\n"; out != want {
+		t.Fatalf("output = %q, want %q", out, want)
+	}
+}
@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestPrintMessageDetailsShowsDeliveryFailure(t *testing.T) {
+	var msg api.Message
+
+	data := `{
+		"id": "msg_1",
+		"is_inbound": false,
+		"error_type": "recipient_rejected",
+		"recipients": [{"handle": "jane@customer.com", "role": "to"}],
+		"metadata": {"headers": {"message_id": "<abc@front.example>", "x-failed-recipients": "jane@customer.com"}}
+	}`
+	if err := json.Unmarshal([]byte(data), &msg); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	printMessageDetails(&buf, &msg, []api.SeenReceipt{{FirstSeenAt: "2024-01-02T12:00:00Z", SeenBy: &api.Recipient{Handle: "jane@customer.com"}}})
+
+	out := buf.String()
+	for _, want := range []string{
+		"to   jane@customer.com",
+		"Delivery:  failed (recipient rejected)",
+		"Message-ID: <abc@front.example>",
+		"X-Failed-Recipients: jane@customer.com",
+		"  jane@customer.com at 2024-01-02",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("details missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMsgReplySendsEnvelopeOverrides(t *testing.T) {
+	var sent map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/conversations/cnv_1/messages" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &sent)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted","message_uid":"uid_1"}`))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	if err := Execute([]string{
+		"--json", "--account", "test@example.com",
+		"msg", "reply", "cnv_1", "--body", "Thanks!",
+		"--to", "a@example.com,b@example.com", "--cc", "c@example.com", "--bcc", "d@example.com",
+		"--sender-name", "Support", "--subject", "Your refund",
+	}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	got, _ := json.Marshal(map[string]any{"to": sent["to"], "cc": sent["cc"], "bcc": sent["bcc"], "sender_name": sent["sender_name"], "subject": sent["subject"]})
+	want := `{"bcc":["d@example.com"],"cc":["c@example.com"],"sender_name":"Support","subject":"Your refund","to":["a@example.com","b@example.com"]}`
+
+	if string(got) != want {
+		t.Fatalf("reply request = %s, want %s", got, want)
+	}
+}
+
+func TestMsgReplyStatus(t *testing.T) {
+	for _, tc := range []struct {
+		args      []string
+		archive   bool
+		wantPatch string
+	}{
+		{args: []string{"--archive"}, archive: true},
+		{args: []string{"--status", "archived"}, archive: true},
+		{args: []string{"--status", "open"}, wantPatch: `{"status":"open"}`},
+	} {
+		var sent map[string]any
+
+		var patched string
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+
+			switch {
+			case r.Method == http.MethodPost && r.URL.Path == "/conversations/cnv_1/messages":
+				_ = json.Unmarshal(data, &sent)
+
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{"status":"accepted","message_uid":"uid_1"}`))
+			case r.Method == http.MethodPatch && r.URL.Path == "/conversations/cnv_1":
+				patched = string(data)
+
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		useTestServer(t, srv)
+
+		args := append([]string{"--json", "--account", "test@example.com", "msg", "reply", "cnv_1", "--body", "Done"}, tc.args...)
+		if err := Execute(args); err != nil {
+			t.Fatalf("%v: %v", tc.args, err)
+		}
+
+		srv.Close()
+
+		options, _ := sent["options"].(map[string]any)
+		if archive, _ := options["archive"].(bool); archive != tc.archive {
+			t.Errorf("%v: options = %v", tc.args, sent["options"])
+		}
+
+		if strings.TrimSpace(patched) != tc.wantPatch {
+			t.Errorf("%v: patch = %q, want %q", tc.args, patched, tc.wantPatch)
+		}
+	}
+}
+
+func TestMsgSendConvertsMarkdownBody(t *testing.T) {
+	var sent map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &sent)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted","message_uid":"uid_1"}`))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	if err := Execute([]string{
+		"--json", "--account", "test@example.com",
+		"msg", "send", "--channel", "cha_1", "--to", "a@example.com",
+		"--body", "Hi **Jane**,\n\n- refund issued\n- [receipt](https://example.com/r/1)",
+		"--body-format", "markdown",
+	}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	body, _ := sent["body"].(string)
+	for _, want := range []string{"<strong>Jane</strong>", "<li>refund issued</li>", `<a href="https://example.com/r/1">receipt</a>`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body misses %q: %s", want, body)
+		}
+	}
+}
+
+func TestMsgReplySchedulesWithSendAt(t *testing.T) {
+	var sent map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &sent)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted","message_uid":"uid_1"}`))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	before := time.Now().Add(2 * time.Hour).Add(-time.Minute)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "msg", "reply", "cnv_1", "--body", "Later", "--send-at", "2h"})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	at, err := time.Parse(time.RFC3339, fmt.Sprint(sent["scheduled_at"]))
+	if err != nil || at.Before(before) || !strings.HasPrefix(out, "Reply scheduled for ") {
+		t.Fatalf("scheduled_at = %v, output %q", sent["scheduled_at"], out)
+	}
+
+	err = Execute([]string{"--account", "test@example.com", "msg", "reply", "cnv_1", "--body", "Later", "--send-at", "2h", "--status", "open"})
+	if err == nil || !strings.Contains(err.Error(), "--send-at") {
+		t.Fatalf("--status with --send-at: err = %v", err)
+	}
+
+	err = Execute([]string{"--account", "test@example.com", "msg", "send", "--channel", "cha_1", "--to", "a@example.com", "--body", "Hi", "--send-at", "2020-01-01"})
+	if err == nil || !strings.Contains(err.Error(), "in the past") {
+		t.Fatalf("past --send-at: err = %v", err)
+	}
+}
+
+func TestMsgScheduledListAndCancel(t *testing.T) {
+	var requests []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"_results":[{"id":"smg_1","scheduled_at":1704196800,"subject":"Later",
+			"_links":{"related":{"conversation":"https://api.example.com/conversations/cnv_1"}}}]}`))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--plain", "--utc", "--account", "test@example.com", "msg", "scheduled", "list", "--conversation", "cnv_1"})
+	})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+
+	if !strings.Contains(out, "smg_1\t2024-01-02 12:00\tcnv_1\tLater") {
+		t.Fatalf("list output:\n%s", out)
+	}
+
+	if _, err := captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "msg", "scheduled", "cancel", "smg_1", "smg_2"})
+	}); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	want := "GET /conversations/cnv_1/scheduled_messages,DELETE /scheduled_messages/smg_1,DELETE /scheduled_messages/smg_2"
+	if got := strings.Join(requests, ","); got != want {
+		t.Fatalf("requests = %s, want %s", got, want)
+	}
+}
+
+func TestMsgReplyReadsBodyFromStdin(t *testing.T) {
+	var sent map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &sent)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted","message_uid":"uid_1"}`))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer r.Close()
+
+	_, _ = w.WriteString("# Shipped\n\nYour order left the warehouse.\n")
+	_ = w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = oldStdin })
+
+	if err := Execute([]string{
+		"--json", "--account", "test@example.com",
+		"msg", "reply", "cnv_1", "--body-file", "-", "--body-format", "markdown",
+	}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if body, _ := sent["body"].(string); !strings.Contains(body, "<h1>Shipped</h1>") {
+		t.Fatalf("body = %q", body)
+	}
+}
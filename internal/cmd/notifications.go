@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// Reasons a conversation shows up in notifications.
+const (
+	reasonMentioned = "mentioned"
+	reasonAssigned  = "assigned"
+	reasonOpen      = "open"
+)
+
+type NotificationsCmd struct {
+	Since         string   `help:"How far back to look for mentions and assignments (e.g. 3d, yesterday, RFC3339)" default:"7d"`
+	Reason        []string `help:"Only these reasons: mentioned (in a comment), assigned (recently), open (assigned to you and still open); repeatable" enum:"mentioned,assigned,open" default:"mentioned,assigned,open"`
+	Limit         int      `help:"Maximum number of conversations" default:"50"`
+	IncludeClosed bool     `help:"Include archived and trashed conversations"`
+}
+
+// notification is a conversation that needs the current teammate's
+// attention, with why and when it last did.
+type notification struct {
+	Conversation api.Conversation `json:"conversation"`
+	Reasons      []string         `json:"reasons"`
+	LastAt       float64          `json:"last_at"`
+}
+
+func (c *NotificationsCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	since, err := parseTimeFlag(c.Since)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	me, err := client.Me(ctx)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	byConv := map[string]*notification{}
+
+	add := func(conv api.Conversation, reason string, at float64) {
+		if !c.IncludeClosed && isClosedStatus(conv.Status) {
+			return
+		}
+
+		n, ok := byConv[conv.ID]
+		if !ok {
+			n = &notification{Conversation: conv}
+			byConv[conv.ID] = n
+		}
+
+		if !slices.Contains(n.Reasons, reason) {
+			n.Reasons = append(n.Reasons, reason)
+		}
+
+		n.LastAt = max(n.LastAt, at)
+	}
+
+	if err := c.collectEvents(ctx, client, me, since, add); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if slices.Contains(c.Reason, reasonOpen) {
+		query := fmt.Sprintf("assignee:%s is:open", me.ID)
+
+		_, err := searchConversations(ctx, client, query, convSearchOptions{PageSize: 100, MaxResults: c.Limit, All: true}, func(convs []api.Conversation) error {
+			for _, conv := range convs {
+				add(conv, reasonOpen, max(conv.WaitingSince, conv.CreatedAt))
+			}
+
+			return nil
+		})
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+	}
+
+	results := make([]notification, 0, len(byConv))
+	for _, n := range byConv {
+		results = append(results, *n)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].LastAt != results[j].LastAt {
+			return results[i].LastAt > results[j].LastAt
+		}
+
+		return results[i].Conversation.ID < results[j].Conversation.ID
+	})
+
+	if c.Limit > 0 && len(results) > c.Limit {
+		results = results[:c.Limit]
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, map[string]any{"notifications": results})
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(os.Stdout, "Nothing needs your attention.")
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("ID", "REASON", "SUBJECT", "STATUS", "WHEN")
+
+	for _, n := range results {
+		tbl.AddRow(
+			n.Conversation.ID,
+			strings.Join(n.Reasons, ","),
+			n.Conversation.Subject,
+			output.StyleStatus(n.Conversation.Status),
+			output.FormatTimestamp(n.LastAt),
+		)
+	}
+
+	return tbl.Flush()
+}
+
+// collectEvents walks assign and mention events since the given time and
+// reports the ones aimed at me.
+func (c *NotificationsCmd) collectEvents(
+	ctx context.Context,
+	client *api.Client,
+	me *api.Me,
+	since float64,
+	add func(api.Conversation, string, float64),
+) error {
+	var types []string
+
+	if slices.Contains(c.Reason, reasonMentioned) {
+		types = append(types, "mention")
+	}
+
+	if slices.Contains(c.Reason, reasonAssigned) {
+		types = append(types, "assign")
+	}
+
+	if len(types) == 0 {
+		return nil
+	}
+
+	path := "/events?" + api.ListEventsOptions{Types: types, After: since, Limit: 100}.Query()
+
+	_, err := listPages(ctx, client, path, PaginationFlags{All: true}, func(resp *api.ListResponse[api.Event]) error {
+		for _, event := range resp.Results {
+			if event.Conversation == nil || !eventConcerns(event, me) {
+				continue
+			}
+
+			reason := reasonAssigned
+			if event.Type == "mention" {
+				reason = reasonMentioned
+			}
+
+			add(*event.Conversation, reason, event.EmittedAt)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// eventConcerns reports whether an event targets me: an assignment to me, or
+// a mention of me. Mentions without a teammate target are matched on the
+// comment body.
+func eventConcerns(event api.Event, me *api.Me) bool {
+	var data struct {
+		ID   string `json:"id"`
+		Body string `json:"body"`
+	}
+
+	if event.Target != nil && event.Target.Meta.Type == "teammate" {
+		_ = json.Unmarshal(event.Target.Data, &data)
+
+		return data.ID == me.ID
+	}
+
+	if event.Type == "mention" && event.Source != nil && me.Username != "" {
+		_ = json.Unmarshal(event.Source.Data, &data)
+
+		return strings.Contains(strings.ToLower(data.Body), "@"+strings.ToLower(me.Username))
+	}
+
+	return false
+}
+
+func isClosedStatus(status string) bool {
+	switch status {
+	case "archived", "trashed", "deleted":
+		return true
+	default:
+		return false
+	}
+}
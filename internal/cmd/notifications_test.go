@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNotificationsCombinesEventsAndSearch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/me":
+			_, _ = w.Write([]byte(`{"id":"tea_me","email":"me@example.com","username":"me"}`))
+		case r.URL.Path == "/events":
+			if got := r.URL.Query()["q[types][]"]; strings.Join(got, ",") != "mention,assign" {
+				t.Errorf("event types = %v", got)
+			}
+
+			_, _ = w.Write([]byte(`{"_results":[
+				{"id":"evt_1","type":"assign","emitted_at":100,
+				 "conversation":{"id":"cnv_a","subject":"Assigned to me","status":"assigned"},
+				 "target":{"_meta":{"type":"teammate"},"data":{"id":"tea_me"}}},
+				{"id":"evt_2","type":"assign","emitted_at":110,
+				 "conversation":{"id":"cnv_b","subject":"Assigned to Bob","status":"assigned"},
+				 "target":{"_meta":{"type":"teammate"},"data":{"id":"tea_bob"}}},
+				{"id":"evt_3","type":"mention","emitted_at":120,
+				 "conversation":{"id":"cnv_c","subject":"Mentioned me","status":"unassigned"},
+				 "source":{"_meta":{"type":"comment"},"data":{"body":"@Me can you look?"}}},
+				{"id":"evt_4","type":"mention","emitted_at":130,
+				 "conversation":{"id":"cnv_d","subject":"Archived","status":"archived"},
+				 "target":{"_meta":{"type":"teammate"},"data":{"id":"tea_me"}}}
+			]}`))
+		case strings.HasPrefix(r.URL.Path, "/conversations/search/"):
+			if q := strings.TrimPrefix(r.URL.Path, "/conversations/search/"); q != "assignee:tea_me is:open" {
+				t.Errorf("search query = %q", q)
+			}
+
+			_, _ = w.Write([]byte(`{"_results":[
+				{"id":"cnv_a","subject":"Assigned to me","status":"assigned","created_at":50},
+				{"id":"cnv_e","subject":"Old open one","status":"assigned","created_at":40}
+			],"_total":2}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	os.Stdout = w
+
+	cmd := NotificationsCmd{Since: "7d", Reason: []string{"mentioned", "assigned", "open"}, Limit: 50}
+	runErr := cmd.Run(&RootFlags{JSON: true, Account: "test@example.com"})
+
+	os.Stdout = oldStdout
+	_ = w.Close()
+
+	out, _ := io.ReadAll(r)
+	_ = r.Close()
+
+	if runErr != nil {
+		t.Fatalf("Run: %v", runErr)
+	}
+
+	var got struct {
+		Notifications []notification `json:"notifications"`
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(out)).Decode(&got); err != nil {
+		t.Fatalf("decode %s: %v", out, err)
+	}
+
+	var rows []string
+	for _, n := range got.Notifications {
+		rows = append(rows, n.Conversation.ID+"="+strings.Join(n.Reasons, "+"))
+	}
+
+	if want := "cnv_c=mentioned cnv_a=assigned+open cnv_e=open"; strings.Join(rows, " ") != want {
+		t.Fatalf("notifications = %v, want %s", rows, want)
+	}
+}
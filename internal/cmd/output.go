@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/dedene/frontapp-cli/internal/config"
 	"github.com/dedene/frontapp-cli/internal/output"
@@ -13,42 +15,113 @@ func resolveOutputMode(flags *RootFlags) (output.Mode, error) {
 		return output.Mode{}, err
 	}
 
-	mode := output.Mode{}
+	format := output.FormatTable
 
-	if cfg.DefaultOutput != "" {
-		switch cfg.DefaultOutput {
-		case "json":
-			mode.JSON = true
-			mode.Plain = false
-		case "plain":
-			mode.Plain = true
-			mode.JSON = false
-		default:
-		}
+	// Later sources override earlier ones: config, environment, flags.
+	switch cfg.DefaultOutput {
+	case "json":
+		format = output.FormatJSON
+	case "plain":
+		format = output.FormatTSV
+	case output.FormatYAML, output.FormatCSV, output.FormatTSV, output.FormatNDJSON:
+		format = cfg.DefaultOutput
+	default:
 	}
 
 	envMode := output.FromEnv()
+	if envMode.Format != "" {
+		format = envMode.Format
+	}
+
 	if envMode.JSON {
-		mode.JSON = true
-		mode.Plain = false
+		format = output.FormatJSON
 	}
+
 	if envMode.Plain {
-		mode.Plain = true
-		mode.JSON = false
+		format = output.FormatTSV
+	}
+
+	if flags.JSON && flags.Plain {
+		return output.Mode{}, fmt.Errorf("cannot use both JSON and plain output")
+	}
+
+	if flags.NDJSON && (flags.JSON || flags.Plain) {
+		return output.Mode{}, fmt.Errorf("--ndjson cannot be combined with --json or --plain")
+	}
+
+	if flags.Output != "" {
+		format = strings.ToLower(flags.Output)
+
+		if (flags.JSON && format != output.FormatJSON) || (flags.Plain && format != output.FormatTSV) ||
+			(flags.NDJSON && format != output.FormatNDJSON) {
+			return output.Mode{}, fmt.Errorf("--output %s conflicts with --json/--plain/--ndjson", flags.Output)
+		}
 	}
 
 	if flags.JSON {
-		mode.JSON = true
-		mode.Plain = false
+		format = output.FormatJSON
 	}
 
 	if flags.Plain {
-		mode.Plain = true
-		mode.JSON = false
+		format = output.FormatTSV
 	}
 
-	if mode.JSON && mode.Plain {
-		return output.Mode{}, fmt.Errorf("cannot use both JSON and plain output")
+	if flags.NDJSON {
+		format = output.FormatNDJSON
+	}
+
+	if !slices.Contains(output.Formats, format) {
+		return output.Mode{}, fmt.Errorf("invalid output format %q (use %s)", format, strings.Join(output.Formats, ", "))
+	}
+
+	mode := output.Mode{
+		Format:   format,
+		Fields:   output.ParseFields(flags.Fields),
+		Template: flags.Format,
+		JQ:       flags.JQ,
+	}
+
+	if mode.JQ != "" {
+		if flags.Format != "" || flags.Plain || flags.NDJSON || len(mode.Fields) > 0 ||
+			(flags.Output != "" && format != output.FormatJSON) {
+			return output.Mode{}, fmt.Errorf("--jq cannot be combined with --format, --plain, --ndjson, --fields or a non-JSON --output")
+		}
+
+		if _, err := output.ParseJQ(mode.JQ); err != nil {
+			return output.Mode{}, err
+		}
+
+		// jq always reads the JSON form, whatever config or env selected.
+		mode.Format = output.FormatJSON
+	}
+
+	if mode.Template != "" {
+		if flags.JSON || flags.Plain || flags.NDJSON || flags.Output != "" || len(mode.Fields) > 0 {
+			return output.Mode{}, fmt.Errorf("--format cannot be combined with --json, --plain, --ndjson, --output or --fields")
+		}
+
+		if _, err := output.ParseTemplate(mode.Template); err != nil {
+			return output.Mode{}, err
+		}
+
+		// Templates replace whatever default format config or env selected.
+		mode.Format = output.FormatTable
+	}
+
+	mode.Plain = mode.Format == output.FormatTSV
+	mode.JSON = mode.Template != "" || mode.JQ != "" || len(mode.Fields) > 0 ||
+		(mode.Format != output.FormatTable && mode.Format != output.FormatTSV)
+
+	mode.Columns = output.ParseColumns(flags.Columns)
+
+	if flags.Sort != "" {
+		if mode.SortBy, mode.SortDesc, err = output.ParseSort(flags.Sort); err != nil {
+			return output.Mode{}, err
+		}
+	}
+
+	if mode.JSON && (len(mode.Columns) > 0 || mode.SortBy != "") {
+		return output.Mode{}, fmt.Errorf("--columns and --sort apply to tables; use --fields or --jq for structured output")
 	}
 
 	return mode, nil
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+func TestResolveOutputMode(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("FRONT_JSON", "")
+	t.Setenv("FRONT_PLAIN", "")
+	t.Setenv("FRONT_OUTPUT", "")
+
+	tests := []struct {
+		flags     RootFlags
+		format    string
+		json      bool
+		plain     bool
+		wantError bool
+	}{
+		{flags: RootFlags{}, format: output.FormatTable},
+		{flags: RootFlags{JSON: true}, format: output.FormatJSON, json: true},
+		{flags: RootFlags{Plain: true}, format: output.FormatTSV, plain: true},
+		{flags: RootFlags{Output: "yaml"}, format: output.FormatYAML, json: true},
+		{flags: RootFlags{Output: "CSV"}, format: output.FormatCSV, json: true},
+		{flags: RootFlags{Fields: "id,subject"}, format: output.FormatTable, json: true},
+		{flags: RootFlags{Output: "json", JSON: true}, format: output.FormatJSON, json: true},
+		{flags: RootFlags{Output: "yaml", JSON: true}, wantError: true},
+		{flags: RootFlags{Output: "xml"}, wantError: true},
+		{flags: RootFlags{Format: "{{.ID}}"}, format: output.FormatTable, json: true},
+		{flags: RootFlags{Format: "{{.ID}}", JSON: true}, wantError: true},
+		{flags: RootFlags{Format: "{{.ID"}, wantError: true},
+		{flags: RootFlags{JQ: ".id"}, format: output.FormatJSON, json: true},
+		{flags: RootFlags{JQ: ".id", JSON: true}, format: output.FormatJSON, json: true},
+		{flags: RootFlags{JQ: ".id", Output: "csv"}, wantError: true},
+		{flags: RootFlags{JQ: ".id", Fields: "id"}, wantError: true},
+		{flags: RootFlags{JQ: ".id | "}, wantError: true},
+		{flags: RootFlags{NDJSON: true}, format: output.FormatNDJSON, json: true},
+		{flags: RootFlags{NDJSON: true, Fields: "id"}, format: output.FormatNDJSON, json: true},
+		{flags: RootFlags{NDJSON: true, JSON: true}, wantError: true},
+		{flags: RootFlags{NDJSON: true, Output: "csv"}, wantError: true},
+		{flags: RootFlags{NDJSON: true, JQ: ".id"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		mode, err := resolveOutputMode(&tt.flags)
+		if (err != nil) != tt.wantError {
+			t.Fatalf("resolveOutputMode(%+v) error = %v", tt.flags, err)
+		}
+
+		if tt.wantError {
+			continue
+		}
+
+		if mode.Format != tt.format || mode.JSON != tt.json || mode.Plain != tt.plain {
+			t.Fatalf("resolveOutputMode(%+v) = %+v", tt.flags, mode)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// PaginationFlags are shared by list commands backed by paginated endpoints.
+type PaginationFlags struct {
+	All       bool   `help:"Fetch all pages of results (--limit sets the page size)"`
+	PageToken string `help:"Resume listing from a page token" name:"page-token"`
+}
+
+// listPages walks a list endpoint starting at path, handing each page to fn as
+// it arrives. Without --all only the first page is fetched. It returns the
+// token for the next page, or "" when the listing is exhausted.
+func listPages[T any](
+	ctx context.Context,
+	client *api.Client,
+	path string,
+	p PaginationFlags,
+	fn func(*api.ListResponse[T]) error,
+) (string, error) {
+	pageURL := api.WithPageToken(path, p.PageToken)
+
+	for {
+		resp, err := api.GetPage[T](ctx, client, pageURL)
+		if err != nil {
+			return "", err
+		}
+
+		if err := fn(resp); err != nil {
+			return "", err
+		}
+
+		next := resp.Pagination.Next
+		if next == "" {
+			return "", nil
+		}
+
+		if !p.All {
+			return api.PageToken(next), nil
+		}
+
+		pageURL = next
+	}
+}
+
+// pagedList describes how a paginated listing is rendered as a table.
+type pagedList[T any] struct {
+	Path    string
+	Empty   string
+	Headers []string
+	Row     func(T) []string
+	// Keep, when set, drops the items it rejects from every output mode.
+	Keep func(T) bool
+	// Prepare, when set, runs on each page of table rows before they are
+	// rendered, to fetch what Row needs beyond the items themselves.
+	Prepare func([]T) error
+}
+
+// runPagedList fetches and renders a paginated listing. Table rows and NDJSON
+// lines are flushed page by page so long listings stream; other structured
+// output merges all fetched pages into a single response.
+func runPagedList[T any](ctx context.Context, client *api.Client, mode output.Mode, p PaginationFlags, l pagedList[T]) error {
+	var (
+		merged *api.ListResponse[T]
+		tbl    output.TableWriter
+		count  int
+		sorted []T // with --sort, rows are held back until every page is in
+	)
+
+	l, err := l.withColumns(mode)
+	if err != nil {
+		return err
+	}
+
+	nextToken, err := listPages(ctx, client, l.Path, p, func(page *api.ListResponse[T]) error {
+		if l.Keep != nil {
+			page.Results = slices.DeleteFunc(page.Results, func(item T) bool { return !l.Keep(item) })
+		}
+
+		if mode.Streaming() {
+			if len(page.Results) == 0 {
+				return nil
+			}
+
+			return mode.Write(os.Stdout, page.Results)
+		}
+
+		if mode.JSON {
+			if merged == nil {
+				merged = page
+			} else {
+				merged.Results = append(merged.Results, page.Results...)
+				merged.Pagination = page.Pagination
+			}
+
+			return nil
+		}
+
+		if len(page.Results) == 0 {
+			return nil
+		}
+
+		if l.Prepare != nil {
+			if err := l.Prepare(page.Results); err != nil {
+				return err
+			}
+		}
+
+		count += len(page.Results)
+
+		if mode.SortBy != "" {
+			sorted = append(sorted, page.Results...)
+
+			return nil
+		}
+
+		return l.writeRows(&tbl, mode, page.Results)
+	})
+	if err != nil {
+		return err
+	}
+
+	if mode.SortBy != "" && len(sorted) > 0 {
+		output.SortByColumn(sorted, mode.SortBy, mode.SortDesc)
+
+		if err := l.writeRows(&tbl, mode, sorted); err != nil {
+			return err
+		}
+	}
+
+	if mode.JSON && !mode.Streaming() {
+		return mode.Write(os.Stdout, merged)
+	}
+
+	if count == 0 && !mode.Streaming() {
+		fmt.Fprintln(os.Stdout, l.Empty)
+	}
+
+	if nextToken != "" {
+		fmt.Fprintf(os.Stderr, "More results available; use --all or --page-token %s\n", nextToken)
+	}
+
+	return nil
+}
+
+// withColumns returns l with the --columns table layout, after checking the
+// --columns and --sort names against T.
+func (l pagedList[T]) withColumns(mode output.Mode) (pagedList[T], error) {
+	if err := output.CheckColumns[T](append(mode.Columns, mode.SortBy)...); err != nil {
+		return l, err
+	}
+
+	if len(mode.Columns) > 0 {
+		l.Headers = output.ColumnHeaders(mode.Columns)
+		l.Row = func(item T) []string { return output.ColumnRow(item, mode.Columns) }
+	}
+
+	return l, nil
+}
+
+// writeRows adds items to the table, starting it with the headers.
+func (l pagedList[T]) writeRows(tbl *output.TableWriter, mode output.Mode, items []T) error {
+	if *tbl == nil {
+		*tbl = output.NewTableWriter(os.Stdout, mode.Plain)
+		(*tbl).AddRow(l.Headers...)
+	}
+
+	for _, item := range items {
+		(*tbl).AddRow(l.Row(item)...)
+	}
+
+	return (*tbl).Flush()
+}
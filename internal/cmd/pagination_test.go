@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newPagedServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RequestURI())
+
+		if r.URL.Query().Get("page_token") == "" {
+			_, _ = io.WriteString(w, `{"_results":[{"id":"tag_1"}],"_pagination":{"next":"https://api2.frontapp.com/tags?page_token=p2"}}`)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"_results":[{"id":"tag_2"}],"_pagination":{}}`)
+	}))
+}
+
+func TestListCommandFetchesAllPages(t *testing.T) {
+	var requests []string
+
+	srv := newPagedServer(t, &requests)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := TagListCmd{PaginationFlags: PaginationFlags{All: true}}
+	flags := &RootFlags{Plain: true, Account: "test@example.com"}
+
+	if err := cmd.Run(flags); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d: %v", len(requests), requests)
+	}
+
+	if requests[1] != "/tags?page_token=p2" {
+		t.Fatalf("unexpected second request: %s", requests[1])
+	}
+}
+
+func TestListCommandStopsAfterFirstPage(t *testing.T) {
+	var requests []string
+
+	srv := newPagedServer(t, &requests)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := TagListCmd{}
+	flags := &RootFlags{Plain: true, Account: "test@example.com"}
+
+	if err := cmd.Run(flags); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d: %v", len(requests), requests)
+	}
+}
+
+func TestListCommandResumesFromPageToken(t *testing.T) {
+	var requests []string
+
+	srv := newPagedServer(t, &requests)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := TagListCmd{PaginationFlags: PaginationFlags{PageToken: "p2"}}
+	flags := &RootFlags{Plain: true, Account: "test@example.com"}
+
+	if err := cmd.Run(flags); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(requests) != 1 || requests[0] != "/tags?page_token=p2" {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"github.com/alecthomas/kong"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+// profileResolver fills flags not given on the command line from the
+// profile selected with --profile (or FRONT_PROFILE). A profile applies to
+// every command with a flag of the same name; other keys are ignored.
+type profileResolver struct {
+	name  string
+	flags map[string]string
+}
+
+// load reads the selected profile before kong resolves flags, so an unknown
+// profile fails the parse. Kong may call it more than once.
+func (r *profileResolver) load(kctx *kong.Context) error {
+	name := selectedProfile(kctx)
+	if name == "" || name == r.name {
+		return nil
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	flags, err := cfg.Profile(name)
+	if err != nil {
+		return err
+	}
+
+	r.name, r.flags = name, flags
+
+	return nil
+}
+
+func (r *profileResolver) Validate(*kong.Application) error {
+	return nil
+}
+
+func (r *profileResolver) Resolve(_ *kong.Context, _ *kong.Path, flag *kong.Flag) (any, error) {
+	value, ok := r.flags[flag.Name]
+	if !ok || flag.Name == "profile" {
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+func selectedProfile(kctx *kong.Context) string {
+	for _, f := range kctx.Flags() {
+		if f.Name == "profile" {
+			name, _ := kctx.FlagValue(f).(string)
+
+			return name
+		}
+	}
+
+	return ""
+}
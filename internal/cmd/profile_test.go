@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestConfig(t *testing.T, yaml string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "frontcli"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "frontcli", "config.yaml"), []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProfileSetsFlagDefaults(t *testing.T) {
+	writeTestConfig(t, "profiles:\n  triage:\n    inbox: Support\n    limit: 50\n    columns: id,subject\n")
+
+	parser, cli, err := newParser()
+	if err != nil {
+		t.Fatalf("newParser: %v", err)
+	}
+
+	if _, err := parser.Parse([]string{"--profile", "triage", "conv", "list", "--limit", "5"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	list := cli.Conv.List
+	if strings.Join(list.Inbox, ",") != "Support" || cli.Columns != "id,subject" {
+		t.Fatalf("profile not applied: inbox=%q columns=%q", list.Inbox, cli.Columns)
+	}
+
+	if list.Limit != 5 {
+		t.Fatalf("limit = %d, want the explicit 5", list.Limit)
+	}
+}
+
+func TestProfileUnknown(t *testing.T) {
+	writeTestConfig(t, "profiles:\n  triage:\n    limit: 50\n")
+
+	parser, _, err := newParser()
+	if err != nil {
+		t.Fatalf("newParser: %v", err)
+	}
+
+	_, err = parser.Parse([]string{"--profile", "nope", "conv", "list"})
+	if err == nil || !strings.Contains(err.Error(), `unknown profile "nope"`) {
+		t.Fatalf("Parse error = %v, want unknown profile", err)
+	}
+}
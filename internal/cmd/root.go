@@ -1,46 +1,125 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/log"
+	"github.com/dedene/frontapp-cli/internal/output"
 )
 
 type RootFlags struct {
-	Account string `help:"Account email for multi-account support"`
-	Client  string `help:"OAuth client name override"`
-	JSON    bool   `help:"Output JSON to stdout (best for scripting)"`
-	Plain   bool   `help:"Output TSV (stable for scripts)"`
-	Verbose bool   `help:"Enable verbose logging"`
+	Account       string         `help:"Account email for multi-account support"`
+	AllAccounts   bool           `help:"Run a read-only command for every authenticated account, merging the results with an account column" name:"all-accounts"`
+	Profile       string         `help:"Apply flag defaults from a profile in config.yaml" env:"FRONT_PROFILE"`
+	Client        string         `help:"OAuth client name override"`
+	JSON          bool           `help:"Output JSON to stdout (best for scripting)"`
+	Plain         bool           `help:"Output TSV (stable for scripts)"`
+	NDJSON        bool           `name:"ndjson" help:"Output one JSON object per line, streamed as pages arrive"`
+	Output        string         `help:"Output format: table, json, yaml, csv, tsv or ndjson"`
+	Fields        string         `help:"Comma-separated fields to output (e.g. id,subject,assignee.email)"`
+	Format        string         `help:"Render each result with a Go template (e.g. '{{.ID}} {{.Subject}}')"`
+	JQ            string         `name:"jq" help:"Filter JSON output with a jq expression (e.g. '._results[].id')"`
+	Columns       string         `help:"Comma-separated table columns (e.g. id,subject,tags,updated)"`
+	Sort          string         `help:"Sort table rows by a column; append :desc to reverse (e.g. updated:desc)"`
+	NoCache       bool           `help:"Bypass the on-disk response cache"`
+	Timeout       *time.Duration `help:"Give up on an API request after this long, retries included (default: request_timeout from config, else no limit)"`
+	Verbose       int            `help:"Trace HTTP requests to stderr (-vv adds redacted bodies)" short:"v" type:"counter"`
+	ShowRateLimit bool           `help:"Print the API rate-limit quota left to stderr after the command (also shown with -v)" name:"show-rate-limit"`
+
+	RetryFlags `embed:""`
+	LogFlags   `embed:""`
+	TimeFlags  `embed:""`
+	VCRFlags   `embed:""`
+}
+
+// VCRFlags record HTTP traffic to a cassette file or replay it from one, for
+// writing tests against real API responses.
+type VCRFlags struct {
+	Record string `help:"Record HTTP requests and responses to this cassette file (secrets redacted)" type:"path" xor:"vcr"`
+	Replay string `help:"Answer HTTP requests from this cassette file instead of the API" type:"path" xor:"vcr"`
+}
+
+// TimeFlags control how timestamps are shown in tables and detail views.
+type TimeFlags struct {
+	TZ       string `name:"tz" help:"Show times in this IANA timezone (default: timezone from config, then local)"`
+	UTC      bool   `name:"utc" help:"Show times in UTC"`
+	Relative bool   `help:"Show times relative to now (e.g. 3h ago)"`
+}
+
+// LogFlags control diagnostic logging (warnings, retries, debug details).
+type LogFlags struct {
+	LogLevel  string `help:"Log level: debug, info, warn or error" default:"warn" enum:"debug,info,warn,error"`
+	LogFormat string `help:"Log format: text or json" default:"text" enum:"text,json"`
+	LogFile   string `help:"Append logs to this file instead of stderr" type:"path"`
+}
+
+// RetryFlags override the retry settings from the config file.
+type RetryFlags struct {
+	MaxRetries       *int           `help:"Retries for rate-limited (429) and failed (5xx) requests (default 3 and 1)"`
+	RetryBaseDelay   *time.Duration `help:"First backoff delay when the API gives no Retry-After (default 1s)"`
+	RetryBudget      *time.Duration `help:"Give up once retries have waited this long in total during the command (default: retry_budget from config, else no limit)"`
+	NoRetry          bool           `help:"Fail on the first rate limit or server error"`
+	Throttle         string         `help:"Client-side throttling: aggressive, balanced or conservative (default: throttle from config, else balanced)"`
+	NoCircuitBreaker bool           `help:"Keep sending requests after repeated server errors instead of pausing them"`
 }
 
 type CLI struct {
 	RootFlags `embed:""`
 
-	Version    kong.VersionFlag `help:"Print version and exit"`
-	VersionCmd VersionCmd       `cmd:"" name:"version" help:"Print version"`
-	Config     ConfigCmd        `cmd:"" help:"Manage configuration"`
-	Auth       AuthCmd          `cmd:"" help:"Authentication and credentials"`
-	Conv       ConvCmd          `cmd:"" name:"conversations" aliases:"conv" help:"Conversations"`
-	Msg        MsgCmd           `cmd:"" name:"messages" aliases:"msg" help:"Messages"`
-	Draft      DraftCmd         `cmd:"" name:"drafts" help:"Drafts"`
-	Tag        TagCmd           `cmd:"" name:"tags" help:"Tags"`
-	Inbox      InboxCmd         `cmd:"" name:"inboxes" help:"Inboxes"`
-	Teammate   TeammateCmd      `cmd:"" name:"teammates" help:"Teammates"`
-	Contact    ContactCmd       `cmd:"" name:"contacts" help:"Contacts"`
-	Channel    ChannelCmd       `cmd:"" name:"channels" help:"Channels"`
-	Comment    CommentCmd       `cmd:"" name:"comments" help:"Comments (internal discussions)"`
-	Template   TemplateCmd      `cmd:"" name:"templates" help:"Templates (canned responses)"`
-	Completion CompletionCmd    `cmd:"" help:"Generate shell completions"`
-	Whoami     WhoamiCmd        `cmd:"" help:"Show authenticated user info"`
+	Version     kong.VersionFlag `help:"Print version and exit"`
+	VersionCmd  VersionCmd       `cmd:"" name:"version" help:"Print version"`
+	Config      ConfigCmd        `cmd:"" help:"Manage configuration"`
+	Alias       AliasCmd         `cmd:"" help:"Manage command aliases"`
+	Auth        AuthCmd          `cmd:"" help:"Authentication and credentials"`
+	Conv        ConvCmd          `cmd:"" name:"conversations" aliases:"conv" help:"Conversations"`
+	Msg         MsgCmd           `cmd:"" name:"messages" aliases:"msg" help:"Messages"`
+	Draft       DraftCmd         `cmd:"" name:"drafts" help:"Drafts"`
+	Searches    SearchesCmd      `cmd:"" help:"Saved conversation searches"`
+	Tag         TagCmd           `cmd:"" name:"tags" help:"Tags"`
+	Inbox       InboxCmd         `cmd:"" name:"inboxes" help:"Inboxes"`
+	Teammate    TeammateCmd      `cmd:"" name:"teammates" help:"Teammates"`
+	Team        TeamCmd          `cmd:"" name:"teams" help:"Teams (workspaces)"`
+	Contact     ContactCmd       `cmd:"" name:"contacts" help:"Contacts"`
+	Account     AccountCmd       `cmd:"" name:"accounts" help:"Company accounts (CRM)"`
+	Channel     ChannelCmd       `cmd:"" name:"channels" help:"Channels"`
+	Comment     CommentCmd       `cmd:"" name:"comments" help:"Comments (internal discussions)"`
+	Template    TemplateCmd      `cmd:"" name:"templates" help:"Templates (canned responses)"`
+	Fields      CustomFieldCmd   `cmd:"" name:"custom-fields" help:"Custom field definitions and values"`
+	KB          KBCmd            `cmd:"" name:"kb" help:"Knowledge bases"`
+	Event       EventCmd         `cmd:"" name:"events" help:"Activity events (audit trail)"`
+	Shift       ShiftCmd         `cmd:"" name:"shifts" help:"Shifts (work schedules)"`
+	Rule        RuleCmd          `cmd:"" name:"rules" help:"Automation rules"`
+	Analytics   AnalyticsCmd     `cmd:"" help:"Analytics reports and exports"`
+	UI          UICmd            `cmd:"" name:"ui" help:"Interactive inbox browser"`
+	Listen      ListenCmd        `cmd:"" help:"Receive Front webhooks and print events as JSON lines"`
+	Sync        SyncCmd          `cmd:"" help:"Mirror conversations, messages and contacts locally"`
+	SearchLocal SearchLocalCmd   `cmd:"" name:"search-local" help:"Search the local mirror offline (see sync)"`
+	Cache       CacheCmd         `cmd:"" help:"Manage the API response cache"`
+	Limits      LimitsCmd        `cmd:"" help:"Show the API rate-limit quota left"`
+	History     HistoryCmd       `cmd:"" help:"Review the API calls that changed data (audit log)"`
+	Undo        UndoCmd          `cmd:"" help:"Revert the last batch archive, open, trash, tag or untag"`
+	API         APICmd           `cmd:"" name:"api" help:"Make an authenticated request to any Front API endpoint"`
+	Complete    CompleteCmd      `cmd:"" name:"__complete" hidden:"" help:"Print shell completion candidates"`
+	Completion  CompletionCmd    `cmd:"" help:"Generate shell completions"`
+	Whoami      WhoamiCmd        `cmd:"" help:"Show authenticated user info"`
+	Notify      NotificationsCmd `cmd:"" name:"notifications" aliases:"notif" help:"Conversations where you were recently mentioned or assigned (your work queue)"`
+	Dashboard   DashboardCmd     `cmd:"" help:"Summary of your open, waiting and snoozed conversations and recent mentions"`
 }
 
 type exitPanic struct{ code int }
 
 func Execute(args []string) (err error) {
-	parser, err := newParser()
+	parser, cli, err := newParser()
 	if err != nil {
 		return err
 	}
@@ -68,6 +147,13 @@ func Execute(args []string) (err error) {
 		args = []string{"--help"}
 	}
 
+	args, err = expandAlias(parser.Model.Node, args)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+
+		return &ExitError{Code: api.ExitUsage, Err: err}
+	}
+
 	kctx, err := parser.Parse(args)
 	if err != nil {
 		parsedErr := wrapParseError(err)
@@ -76,16 +162,106 @@ func Execute(args []string) (err error) {
 		return parsedErr
 	}
 
-	err = kctx.Run()
+	closeLog, err := log.Setup(log.Options{Level: cli.LogLevel, Format: cli.LogFormat, File: cli.LogFile})
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
 
-		return err
+		return &ExitError{Code: api.ExitUsage, Err: err}
+	}
+
+	defer func() { _ = closeLog() }()
+
+	output.SetColorProfile(terminalProfile(os.Stdout, colorMode(args)))
+
+	if err := applyTimeFlags(cli.TimeFlags); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+
+		return &ExitError{Code: api.ExitUsage, Err: err}
+	}
+
+	stopInterrupt := notifyInterrupt()
+	defer stopInterrupt()
+
+	runningCommand = kctx.Command()
+
+	lastClient = nil
+
+	if cli.AllAccounts {
+		// --show-rate-limit and -v are passed on with the other arguments:
+		// each account's run prints its own quota, prefixed with the account.
+		err = runAllAccounts(context.Background(), withoutAllAccounts(args), 1)
+	} else {
+		err = kctx.Run()
+
+		if cli.ShowRateLimit || cli.Verbose > 0 {
+			printRateLimit()
+		}
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+
+		return withExitCode(err)
 	}
 
 	return nil
 }
 
+// withoutAllAccounts returns args without --all-accounts, in either
+// spelling kong accepts, for the per-account runs.
+func withoutAllAccounts(args []string) []string {
+	return slices.DeleteFunc(slices.Clone(args), func(arg string) bool {
+		return arg == "--all-accounts" || strings.HasPrefix(arg, "--all-accounts=")
+	})
+}
+
+// runningCommand is the command being run, such as "conversations archive
+// <ids>", for the audit log. Arguments are left out as they may hold
+// message bodies.
+var runningCommand string
+
+// interruptCtx is canceled with api.ErrInterrupted on the first Ctrl-C while
+// a command runs; getClient ties every client to it, so requests in flight
+// are aborted and the command returns.
+var interruptCtx = context.Background()
+
+// notifyInterrupt sets up interruptCtx until the returned stop is called. A
+// second Ctrl-C exits at once, for commands waiting on something else.
+func notifyInterrupt() (stop func()) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigs:
+			signal.Stop(sigs)
+			cancel(api.ErrInterrupted)
+		case <-done:
+		}
+	}()
+
+	old := interruptCtx
+	interruptCtx = ctx
+
+	return func() {
+		close(done)
+		signal.Stop(sigs)
+		cancel(nil)
+
+		interruptCtx = old
+	}
+}
+
+// interrupted reports whether Ctrl-C was pressed, for batch commands that
+// stop starting new work and report what they completed.
+func interrupted() bool {
+	return interruptCtx.Err() != nil
+}
+
 func wrapParseError(err error) error {
 	if err == nil {
 		return nil
@@ -93,18 +269,19 @@ func wrapParseError(err error) error {
 
 	var parseErr *kong.ParseError
 	if errors.As(err, &parseErr) {
-		return &ExitError{Code: 2, Err: parseErr}
+		return &ExitError{Code: api.ExitUsage, Err: parseErr}
 	}
 
 	return err
 }
 
-func newParser() (*kong.Kong, error) {
+func newParser() (*kong.Kong, *CLI, error) {
 	vars := kong.Vars{
 		"version": VersionString(),
 	}
 
 	cli := &CLI{}
+	profiles := &profileResolver{}
 	parser, err := kong.New(
 		cli,
 		kong.Name("frontcli"),
@@ -113,12 +290,35 @@ func newParser() (*kong.Kong, error) {
 		kong.Writers(os.Stdout, os.Stderr),
 		kong.Exit(func(code int) { panic(exitPanic{code: code}) }),
 		kong.Bind(&cli.RootFlags),
+		kong.Resolvers(profiles),
+		kong.WithBeforeResolve(profiles.load),
 		kong.Help(helpPrinter),
 		kong.ConfigureHelp(helpOptions()),
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	return parser, cli, nil
+}
+
+// applyTimeFlags sets the timezone and style of rendered timestamps.
+func applyTimeFlags(f TimeFlags) error {
+	switch {
+	case f.UTC && f.TZ != "":
+		return fmt.Errorf("use either --utc or --tz, not both")
+	case f.UTC:
+		output.SetLocation(time.UTC)
+	case f.TZ != "":
+		loc, err := time.LoadLocation(f.TZ)
+		if err != nil {
+			return fmt.Errorf("invalid --tz %q: %w", f.TZ, err)
+		}
+
+		output.SetLocation(loc)
 	}
 
-	return parser, nil
+	output.SetRelativeTimes(f.Relative)
+
+	return nil
 }
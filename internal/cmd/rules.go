@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type RuleCmd struct {
+	List RuleListCmd `cmd:"" help:"List rules"`
+	Get  RuleGetCmd  `cmd:"" help:"Get a rule"`
+}
+
+type RuleListCmd struct {
+	PaginationFlags `embed:""`
+
+	Company  bool   `help:"Only list company-wide rules"`
+	Teammate string `help:"Only list rules owned by this teammate (ID, email or username)"`
+	Team     string `help:"Only list rules owned by this team ID"`
+}
+
+// path returns the rules endpoint for the selected scope.
+func (c *RuleListCmd) path() (string, error) {
+	scopes := 0
+	for _, set := range []bool{c.Company, c.Teammate != "", c.Team != ""} {
+		if set {
+			scopes++
+		}
+	}
+
+	if scopes > 1 {
+		return "", fmt.Errorf("use only one of --company, --teammate or --team")
+	}
+
+	switch {
+	case c.Company:
+		return "/company/rules", nil
+	case strings.TrimSpace(c.Teammate) != "":
+		id, err := api.SanitizeID(c.Teammate)
+		if err != nil {
+			return "", fmt.Errorf("invalid teammate ID %q: %w", c.Teammate, err)
+		}
+
+		return "/teammates/" + id + "/rules", nil
+	case strings.TrimSpace(c.Team) != "":
+		id, err := api.SanitizeID(c.Team)
+		if err != nil {
+			return "", fmt.Errorf("invalid team ID %q: %w", c.Team, err)
+		}
+
+		return "/teams/" + id + "/rules", nil
+	}
+
+	return "/rules", nil
+}
+
+func (c *RuleListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	if c.Teammate, err = client.ResolveTeammate(ctx, c.Teammate); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	path, err := c.path()
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Rule]{
+		Path:    path,
+		Empty:   "No rules found.",
+		Headers: []string{"ID", "NAME", "OWNER", "ACTIONS"},
+		Row:     output.FormatRule,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type RuleGetCmd struct {
+	ID string `arg:"" help:"Rule ID"`
+}
+
+func (c *RuleGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	rule, err := client.GetRule(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, rule)
+	}
+
+	owner := rule.Owner()
+	if owner == "" {
+		owner = "company"
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:      %s\n", rule.ID)
+	fmt.Fprintf(os.Stdout, "Name:    %s\n", rule.Name)
+	fmt.Fprintf(os.Stdout, "Owner:   %s\n", owner)
+	fmt.Fprintf(os.Stdout, "Private: %v\n", rule.IsPrivate)
+
+	if len(rule.Actions) > 0 {
+		fmt.Fprintln(os.Stdout, "Actions:")
+
+		for _, action := range rule.Actions {
+			fmt.Fprintf(os.Stdout, "  - %s\n", action)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import "testing"
+
+func TestRuleListPath(t *testing.T) {
+	tests := []struct {
+		cmd     RuleListCmd
+		want    string
+		wantErr bool
+	}{
+		{cmd: RuleListCmd{}, want: "/rules"},
+		{cmd: RuleListCmd{Company: true}, want: "/company/rules"},
+		{cmd: RuleListCmd{Teammate: "tea_1"}, want: "/teammates/tea_1/rules"},
+		{cmd: RuleListCmd{Team: "tim_1"}, want: "/teams/tim_1/rules"},
+		{cmd: RuleListCmd{Company: true, Team: "tim_1"}, wantErr: true},
+		{cmd: RuleListCmd{Teammate: "../x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.cmd.path()
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("path(%+v) error = %v, wantErr %v", tt.cmd, err, tt.wantErr)
+		}
+
+		if got != tt.want {
+			t.Fatalf("path(%+v) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+}
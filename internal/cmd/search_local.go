@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/mirror"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+var errNotSynced = errors.New("nothing synced yet; run 'frontcli sync' first")
+
+type SearchLocalCmd struct {
+	Query string `arg:"" help:"Words or \"quoted phrases\" that must all appear in the subject or a message"`
+	Limit int    `help:"Maximum results" default:"20"`
+	Open  bool   `help:"Open the best hit in the Front web app"`
+}
+
+func (c *SearchLocalCmd) Run(flags *RootFlags) error {
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	m, err := openMirror(flags)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	state, err := m.State()
+	if err != nil {
+		return err
+	}
+
+	if state.ConversationsSyncedAt == 0 {
+		return errNotSynced
+	}
+
+	hits, err := m.Search(c.Query, c.Limit)
+	if err != nil {
+		return err
+	}
+
+	if c.Open && len(hits) > 0 {
+		if err := openURL(api.WebURL(hits[0].ConversationID)); err != nil {
+			return err
+		}
+	}
+
+	if mode.JSON {
+		if hits == nil {
+			hits = []mirror.Hit{}
+		}
+
+		return mode.Write(os.Stdout, hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Fprintln(os.Stdout, "No matches in the local mirror.")
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("CONVERSATION", "SUBJECT", "MATCH", "DATE")
+
+	for _, hit := range hits {
+		subject := hit.Subject
+		if len(subject) > 40 {
+			subject = subject[:37] + "..."
+		}
+
+		tbl.AddRow(hit.ConversationID, subject, hit.Snippet, output.FormatTimestamp(hit.CreatedAt))
+	}
+
+	return tbl.Flush()
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+var (
+	errUnknownSearch = errors.New("unknown saved search")
+	errInvalidSearch = errors.New("invalid saved search name")
+)
+
+type SearchesCmd struct {
+	List   SearchesListCmd   `cmd:"" help:"List saved conversation searches"`
+	Delete SearchesDeleteCmd `cmd:"" help:"Delete a saved search"`
+}
+
+type SearchesListCmd struct{}
+
+func (c *SearchesListCmd) Run(flags *RootFlags) error {
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if mode.JSON {
+		searches := cfg.SavedSearches
+		if searches == nil {
+			searches = map[string]config.SavedSearch{}
+		}
+
+		return mode.Write(os.Stdout, searches)
+	}
+
+	if len(cfg.SavedSearches) == 0 {
+		fmt.Fprintln(os.Stdout, "No saved searches. Save one with 'frontcli conv search ... --save <name>'.")
+
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.SavedSearches))
+	for name := range cfg.SavedSearches {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("NAME", "FILTERS")
+
+	for _, name := range names {
+		tbl.AddRow(name, strings.Join(savedSearchArgs(cfg.SavedSearches[name]), " "))
+	}
+
+	return tbl.Flush()
+}
+
+type SearchesDeleteCmd struct {
+	Name string `arg:"" help:"Saved search name"`
+}
+
+func (c *SearchesDeleteCmd) Run() error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cfg.SavedSearches[c.Name]; !ok {
+		return fmt.Errorf("%w %q", errUnknownSearch, c.Name)
+	}
+
+	delete(cfg.SavedSearches, c.Name)
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Deleted saved search %s\n", c.Name)
+
+	return nil
+}
+
+// applySavedSearch merges the --saved search into c and stores c's filters
+// under --save.
+func (c *ConvSearchCmd) applySavedSearch() error {
+	if c.Saved == "" && c.Save == "" {
+		return nil
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if c.Saved != "" {
+		saved, ok := cfg.SavedSearches[c.Saved]
+		if !ok {
+			return fmt.Errorf("%w %q (see 'frontcli searches list')", errUnknownSearch, c.Saved)
+		}
+
+		c.merge(saved)
+	}
+
+	if c.Save == "" {
+		return nil
+	}
+
+	if strings.TrimSpace(c.Save) == "" || strings.ContainsAny(c.Save, " \t\n") {
+		return fmt.Errorf("%w %q: use a single word", errInvalidSearch, c.Save)
+	}
+
+	search := c.filters()
+	if len(savedSearchArgs(search)) == 0 {
+		return fmt.Errorf("nothing to save: give a query or filter flags")
+	}
+
+	if cfg.SavedSearches == nil {
+		cfg.SavedSearches = map[string]config.SavedSearch{}
+	}
+
+	cfg.SavedSearches[c.Save] = search
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Saved search %s\n", c.Save)
+
+	return nil
+}
+
+// merge fills the filters not given on the command line from saved; tags
+// and query text are combined.
+func (c *ConvSearchCmd) merge(saved config.SavedSearch) {
+	fill := func(dst *string, value string) {
+		if *dst == "" {
+			*dst = value
+		}
+	}
+
+	fill(&c.RawQuery, saved.RawQuery)
+	fill(&c.From, saved.From)
+	fill(&c.To, saved.To)
+	fill(&c.Recipient, saved.Recipient)
+	fill(&c.Inbox, saved.Inbox)
+	fill(&c.Status, saved.Status)
+	fill(&c.Assignee, saved.Assignee)
+	fill(&c.Before, saved.Before)
+	fill(&c.After, saved.After)
+
+	c.Tag = append(slices.Clone(saved.Tag), c.Tag...)
+	c.Unassigned = c.Unassigned || saved.Unassigned
+	c.Query = strings.TrimSpace(saved.Query + " " + c.Query)
+}
+
+func (c *ConvSearchCmd) filters() config.SavedSearch {
+	return config.SavedSearch{
+		Query:      strings.TrimSpace(c.Query),
+		RawQuery:   strings.TrimSpace(c.RawQuery),
+		From:       c.From,
+		To:         c.To,
+		Recipient:  c.Recipient,
+		Inbox:      c.Inbox,
+		Tag:        c.Tag,
+		Status:     c.Status,
+		Assignee:   c.Assignee,
+		Unassigned: c.Unassigned,
+		Before:     c.Before,
+		After:      c.After,
+	}
+}
+
+// savedSearchArgs renders s as the conv search arguments that reproduce it.
+func savedSearchArgs(s config.SavedSearch) []string {
+	var args []string
+
+	flag := func(name, value string) {
+		if value != "" {
+			args = append(args, "--"+name, quoteArg(value))
+		}
+	}
+
+	flag("query", s.RawQuery)
+	flag("from", s.From)
+	flag("to", s.To)
+	flag("recipient", s.Recipient)
+	flag("inbox", s.Inbox)
+
+	for _, tag := range s.Tag {
+		flag("tag", tag)
+	}
+
+	flag("status", s.Status)
+	flag("assignee", s.Assignee)
+
+	if s.Unassigned {
+		args = append(args, "--unassigned")
+	}
+
+	flag("before", s.Before)
+	flag("after", s.After)
+
+	if s.Query != "" {
+		args = append(args, quoteArg(s.Query))
+	}
+
+	return args
+}
+
+// quoteArg single-quotes value for display when it is not a plain word.
+func quoteArg(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n'\"\\$") {
+		return value
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
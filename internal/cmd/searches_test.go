@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+)
+
+func TestConvSearchSaveAndRunSaved(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	save := ConvSearchCmd{Query: "escalation", Tag: []string{"vip"}, Status: "open", After: "3d", Save: "vip"}
+	if err := save.applySavedSearch(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+
+	if got := cfg.SavedSearches["vip"]; got.After != "3d" || got.Query != "escalation" {
+		t.Fatalf("saved search = %+v, want the filters as typed", got)
+	}
+
+	run := ConvSearchCmd{Tag: []string{"urgent"}, Status: "archived", Saved: "vip"}
+	if err := run.applySavedSearch(); err != nil {
+		t.Fatalf("saved: %v", err)
+	}
+
+	if run.Status != "archived" || len(run.Tag) != 2 || run.Query != "escalation" || run.After != "3d" {
+		t.Fatalf("merged search = %+v", run)
+	}
+
+	if err := (&ConvSearchCmd{Saved: "missing"}).applySavedSearch(); err == nil {
+		t.Fatal("expected an error for an unknown saved search")
+	}
+}
+
+func TestSavedSearchArgs(t *testing.T) {
+	args := savedSearchArgs(config.SavedSearch{Inbox: "Support Team", Tag: []string{"vip"}, Unassigned: true, Query: "refund"})
+
+	want := "--inbox 'Support Team' --tag vip --unassigned refund"
+	if got := strings.Join(args, " "); got != want {
+		t.Fatalf("savedSearchArgs = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// shiftDays lists the day keys used by the Front shifts API, in week order.
+var shiftDays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+type ShiftCmd struct {
+	List      ShiftListCmd     `cmd:"" help:"List shifts"`
+	Get       ShiftGetCmd      `cmd:"" help:"Get a shift"`
+	Create    ShiftCreateCmd   `cmd:"" help:"Create a shift"`
+	Update    ShiftUpdateCmd   `cmd:"" help:"Update a shift"`
+	Teammates ShiftTeammateCmd `cmd:"" help:"Manage teammates on a shift"`
+}
+
+type ShiftListCmd struct {
+	PaginationFlags `embed:""`
+
+	Teammate string `help:"Only list shifts of this teammate (ID, email or username)"`
+	Team     string `help:"Only list shifts of this team ID"`
+}
+
+func (c *ShiftListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	path := "/shifts"
+
+	switch {
+	case c.Teammate != "" && c.Team != "":
+		return fmt.Errorf("use either --teammate or --team, not both")
+	case c.Teammate != "":
+		teammateID, err := client.ResolveTeammate(ctx, c.Teammate)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		path = fmt.Sprintf("/teammates/%s/shifts", teammateID)
+	case c.Team != "":
+		path = fmt.Sprintf("/teams/%s/shifts", c.Team)
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Shift]{
+		Path:    path,
+		Empty:   "No shifts found.",
+		Headers: []string{"ID", "NAME", "TIMEZONE", "HOURS"},
+		Row:     formatShift,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func formatShift(shift api.Shift) []string {
+	hours := strings.Join(shiftHours(shift), ", ")
+	if len(hours) > 60 {
+		hours = hours[:57] + "..."
+	}
+
+	return []string{
+		shift.ID,
+		shift.Name,
+		shift.Timezone,
+		hours,
+	}
+}
+
+// shiftHours returns "day start-end" entries in week order.
+func shiftHours(shift api.Shift) []string {
+	var out []string
+
+	for _, day := range shiftDays {
+		if t, ok := shift.Times[day]; ok {
+			out = append(out, fmt.Sprintf("%s %s-%s", day, t.Start, t.End))
+		}
+	}
+
+	return out
+}
+
+type ShiftGetCmd struct {
+	ID string `arg:"" help:"Shift ID"`
+}
+
+func (c *ShiftGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	var shift api.Shift
+	if err := client.Get(ctx, "/shifts/"+c.ID, &shift); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, shift)
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:       %s\n", shift.ID)
+	fmt.Fprintf(os.Stdout, "Name:     %s\n", shift.Name)
+	fmt.Fprintf(os.Stdout, "Color:    %s\n", shift.Color)
+	fmt.Fprintf(os.Stdout, "Timezone: %s\n", shift.Timezone)
+
+	if hours := shiftHours(shift); len(hours) > 0 {
+		fmt.Fprintln(os.Stdout, "Hours:")
+
+		for _, h := range hours {
+			fmt.Fprintf(os.Stdout, "  %s\n", h)
+		}
+	}
+
+	return nil
+}
+
+type ShiftCreateCmd struct {
+	Name     string   `required:"" help:"Shift name"`
+	Color    string   `help:"Shift color (e.g. blue, red, green)" default:"blue"`
+	Timezone string   `required:"" help:"IANA timezone (e.g. Europe/Brussels)"`
+	Time     []string `required:"" help:"Working hours as day=HH:MM-HH:MM (e.g. mon,tue=09:00-17:00); repeatable"`
+	Teammate []string `help:"Teammate (ID, email or username) to add to the shift; repeatable"`
+}
+
+func (c *ShiftCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	times, err := parseShiftTimes(c.Time)
+	if err != nil {
+		return err
+	}
+
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+
+	req := map[string]any{
+		"name":     c.Name,
+		"color":    c.Color,
+		"timezone": c.Timezone,
+		"times":    times,
+	}
+
+	if len(c.Teammate) > 0 {
+		teammateIDs, err := resolveAll(ctx, c.Teammate, client.ResolveTeammate)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		req["teammate_ids"] = teammateIDs
+	}
+
+	var result api.Shift
+	if err := client.Post(ctx, "/shifts", req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Shift created: %s\n", result.ID)
+
+	return nil
+}
+
+type ShiftUpdateCmd struct {
+	ID       string   `arg:"" help:"Shift ID"`
+	Name     string   `help:"New name"`
+	Color    string   `help:"New color"`
+	Timezone string   `help:"New IANA timezone"`
+	Time     []string `help:"Replace working hours, as day=HH:MM-HH:MM; repeatable"`
+}
+
+func (c *ShiftUpdateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	req := map[string]any{}
+
+	if c.Name != "" {
+		req["name"] = c.Name
+	}
+
+	if c.Color != "" {
+		req["color"] = c.Color
+	}
+
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+		}
+
+		req["timezone"] = c.Timezone
+	}
+
+	if len(c.Time) > 0 {
+		times, err := parseShiftTimes(c.Time)
+		if err != nil {
+			return err
+		}
+
+		req["times"] = times
+	}
+
+	if len(req) == 0 {
+		return fmt.Errorf("no updates specified")
+	}
+
+	var result api.Shift
+	if err := client.Patch(ctx, "/shifts/"+c.ID, req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Shift updated: %s\n", c.ID)
+
+	return nil
+}
+
+// parseShiftTimes parses day=HH:MM-HH:MM specs into the API's times object.
+// Several days may share one interval: "mon,tue,wed=09:00-17:00".
+func parseShiftTimes(specs []string) (map[string]api.ShiftTime, error) {
+	times := make(map[string]api.ShiftTime)
+
+	for _, spec := range specs {
+		days, interval, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --time %q: expected day=HH:MM-HH:MM", spec)
+		}
+
+		start, end, ok := strings.Cut(strings.TrimSpace(interval), "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid --time %q: expected day=HH:MM-HH:MM", spec)
+		}
+
+		for _, t := range []string{start, end} {
+			if _, err := time.Parse("15:04", t); err != nil {
+				return nil, fmt.Errorf("invalid time %q in --time %q", t, spec)
+			}
+		}
+
+		for _, day := range strings.Split(days, ",") {
+			day = strings.ToLower(strings.TrimSpace(day))
+
+			valid := false
+			for _, d := range shiftDays {
+				valid = valid || d == day
+			}
+
+			if !valid {
+				return nil, fmt.Errorf("invalid day %q in --time %q (use mon..sun)", day, spec)
+			}
+
+			times[day] = api.ShiftTime{Start: start, End: end}
+		}
+	}
+
+	return times, nil
+}
+
+type ShiftTeammateCmd struct {
+	List   ShiftTeammateListCmd   `cmd:"" help:"List teammates on a shift"`
+	Add    ShiftTeammateAddCmd    `cmd:"" help:"Add teammates to a shift"`
+	Remove ShiftTeammateRemoveCmd `cmd:"" help:"Remove teammates from a shift"`
+}
+
+type ShiftTeammateListCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Shift ID"`
+}
+
+func (c *ShiftTeammateListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Teammate]{
+		Path:    fmt.Sprintf("/shifts/%s/teammates", c.ID),
+		Empty:   "No teammates found.",
+		Headers: []string{"ID", "EMAIL", "NAME"},
+		Row:     output.FormatTeammate,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type ShiftTeammateAddCmd struct {
+	ID          string   `arg:"" help:"Shift ID"`
+	TeammateIDs []string `arg:"" help:"Teammates to add (IDs, emails or usernames)"`
+}
+
+func (c *ShiftTeammateAddCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	teammateIDs, err := resolveAll(ctx, c.TeammateIDs, client.ResolveTeammate)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	req := map[string][]string{"teammate_ids": teammateIDs}
+	if err := client.Post(ctx, fmt.Sprintf("/shifts/%s/teammates", c.ID), req, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Added %d teammates to %s\n", len(c.TeammateIDs), c.ID)
+
+	return nil
+}
+
+type ShiftTeammateRemoveCmd struct {
+	ID          string   `arg:"" help:"Shift ID"`
+	TeammateIDs []string `arg:"" help:"Teammates to remove (IDs, emails or usernames)"`
+}
+
+func (c *ShiftTeammateRemoveCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	teammateIDs, err := resolveAll(ctx, c.TeammateIDs, client.ResolveTeammate)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	req := map[string][]string{"teammate_ids": teammateIDs}
+	if err := client.DeleteWithBody(ctx, fmt.Sprintf("/shifts/%s/teammates", c.ID), req); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Removed %d teammates from %s\n", len(c.TeammateIDs), c.ID)
+
+	return nil
+}
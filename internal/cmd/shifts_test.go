@@ -0,0 +1,28 @@
+package cmd
+
+import "testing"
+
+func TestParseShiftTimes(t *testing.T) {
+	times, err := parseShiftTimes([]string{"mon,tue=09:00-17:00", "SAT=10:00-14:00"})
+	if err != nil {
+		t.Fatalf("parseShiftTimes: %v", err)
+	}
+
+	if len(times) != 3 {
+		t.Fatalf("expected 3 days, got %v", times)
+	}
+
+	if got := times["tue"]; got.Start != "09:00" || got.End != "17:00" {
+		t.Fatalf("unexpected tue: %+v", got)
+	}
+
+	if got := times["sat"]; got.Start != "10:00" || got.End != "14:00" {
+		t.Fatalf("unexpected sat: %+v", got)
+	}
+
+	for _, bad := range []string{"mon", "mon=9-5", "funday=09:00-17:00", "mon=09:00"} {
+		if _, err := parseShiftTimes([]string{bad}); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/mirror"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// syncPageSize is the page size for sync listings, Front's maximum.
+const syncPageSize = 100
+
+// syncOverlap is subtracted from the cursors so changes made while the last
+// sync was running are fetched again rather than missed.
+const syncOverlap = 60
+
+type SyncCmd struct {
+	Full         bool   `help:"Ignore the sync cursors and fetch everything again"`
+	Since        string `help:"On a first or full sync, only fetch conversations updated after this (e.g. 90d, 2024-01-01)"`
+	SkipMessages bool   `help:"Do not fetch the messages of changed conversations"`
+	SkipContacts bool   `help:"Do not sync contacts"`
+	Concurrency  int    `help:"Conversations whose messages are fetched in parallel" default:"4"`
+	Status       bool   `help:"Show what is mirrored locally without syncing"`
+}
+
+// syncSummary reports a sync (or, with --status, the mirror's contents).
+type syncSummary struct {
+	Dir                   string  `json:"dir"`
+	Conversations         int     `json:"conversations"`
+	Messages              int     `json:"messages"`
+	Contacts              int     `json:"contacts"`
+	ConversationsUpdated  int     `json:"conversations_updated"`
+	ContactsUpdated       int     `json:"contacts_updated"`
+	ConversationsSyncedAt float64 `json:"conversations_synced_at,omitempty"`
+	ContactsSyncedAt      float64 `json:"contacts_synced_at,omitempty"`
+}
+
+func (c *SyncCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	m, err := openMirror(flags)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	state, err := m.State()
+	if err != nil {
+		return err
+	}
+
+	if c.Status {
+		return writeSyncSummary(mode, m, state, syncSummary{})
+	}
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	since, err := parseTimeFlag(c.Since)
+	if err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+
+	if c.Full {
+		state = mirror.State{}
+	}
+
+	// The next cursors: everything changed from here on is fetched next time.
+	started := float64(time.Now().Unix() - syncOverlap)
+
+	var summary syncSummary
+
+	changed, err := c.syncConversations(ctx, client, m, max(state.ConversationsSyncedAt, since))
+
+	summary.ConversationsUpdated = len(changed)
+
+	if err != nil {
+		return stopSync(m, state, summary, err)
+	}
+
+	if !c.SkipMessages {
+		if err := c.syncMessages(ctx, client, m, changed); err != nil {
+			return stopSync(m, state, summary, err)
+		}
+	}
+
+	state.ConversationsSyncedAt = started
+
+	if !c.SkipContacts {
+		n, err := syncContacts(ctx, client, m, state.ContactsSyncedAt)
+
+		summary.ContactsUpdated = n
+
+		if err != nil {
+			return stopSync(m, state, summary, err)
+		}
+
+		state.ContactsSyncedAt = started
+	}
+
+	if err := m.SaveState(state); err != nil {
+		return err
+	}
+
+	return writeSyncSummary(mode, m, state, summary)
+}
+
+// stopSync ends a sync that failed partway. What was fetched is already
+// stored; an interrupted sync saves the cursors without moving them past it,
+// so the next sync fetches the rest.
+func stopSync(m *mirror.Mirror, state mirror.State, summary syncSummary, err error) error {
+	if !errors.Is(err, api.ErrInterrupted) {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if saveErr := m.SaveState(state); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+
+	fmt.Fprintf(os.Stderr, "Interrupted: saved %d updated conversations and %d updated contacts; run sync again to fetch the rest\n",
+		summary.ConversationsUpdated, summary.ContactsUpdated)
+
+	return err
+}
+
+// syncConversations stores conversations updated after cursor and returns
+// their IDs.
+func (c *SyncCmd) syncConversations(ctx context.Context, client *api.Client, m *mirror.Mirror, cursor float64) ([]string, error) {
+	path, err := api.ListConversationsOptions{UpdatedAfter: cursor, Limit: syncPageSize}.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+
+	_, err = listPages(ctx, client, path, PaginationFlags{All: true}, func(page *api.ListResponse[api.Conversation]) error {
+		for _, conv := range page.Results {
+			changed = append(changed, conv.ID)
+		}
+
+		return m.PutConversations(page.Results)
+	})
+
+	return changed, err
+}
+
+// syncMessages replaces the stored messages of each changed conversation.
+func (c *SyncCmd) syncMessages(ctx context.Context, client *api.Client, m *mirror.Mirror, convIDs []string) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(c.Concurrency, 1))
+
+	for _, id := range convIDs {
+		g.Go(func() error {
+			var msgs []api.Message
+
+			path := fmt.Sprintf("/conversations/%s/messages?limit=%d", url.PathEscape(id), syncPageSize)
+
+			_, err := listPages(gctx, client, path, PaginationFlags{All: true}, func(page *api.ListResponse[api.Message]) error {
+				msgs = append(msgs, page.Results...)
+
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("messages of %s: %w", id, err)
+			}
+
+			return m.PutMessages(id, msgs)
+		})
+	}
+
+	return g.Wait()
+}
+
+// syncContacts stores contacts updated after cursor and returns how many.
+func syncContacts(ctx context.Context, client *api.Client, m *mirror.Mirror, cursor float64) (int, error) {
+	opts := api.ListContactsOptions{Limit: syncPageSize, UpdatedAfter: cursor}
+
+	n := 0
+
+	_, err := listPages(ctx, client, opts.Path(), PaginationFlags{All: true}, func(page *api.ListResponse[api.Contact]) error {
+		n += len(page.Results)
+
+		return m.PutContacts(page.Results)
+	})
+
+	return n, err
+}
+
+// openMirror opens the local mirror of the account the command runs as.
+func openMirror(flags *RootFlags) (*mirror.Mirror, error) {
+	email, _, err := resolveAccount(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := config.MirrorDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return mirror.Open(filepath.Join(dir, email))
+}
+
+func writeSyncSummary(mode output.Mode, m *mirror.Mirror, state mirror.State, summary syncSummary) error {
+	counts, err := m.Counts()
+	if err != nil {
+		return err
+	}
+
+	summary.Dir = m.Dir
+	summary.Conversations = counts.Conversations
+	summary.Messages = counts.Messages
+	summary.Contacts = counts.Contacts
+	summary.ConversationsSyncedAt = state.ConversationsSyncedAt
+	summary.ContactsSyncedAt = state.ContactsSyncedAt
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, summary)
+	}
+
+	if state.ConversationsSyncedAt == 0 {
+		fmt.Fprintln(os.Stdout, "Nothing synced yet. Run 'frontcli sync'.")
+
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "Mirror:        %s\n", summary.Dir)
+	fmt.Fprintf(os.Stdout, "Conversations: %d (%d updated)\n", summary.Conversations, summary.ConversationsUpdated)
+	fmt.Fprintf(os.Stdout, "Messages:      %d\n", summary.Messages)
+	fmt.Fprintf(os.Stdout, "Contacts:      %d (%d updated)\n", summary.Contacts, summary.ContactsUpdated)
+	fmt.Fprintf(os.Stdout, "Synced:        %s\n", output.FormatTimestamp(summary.ConversationsSyncedAt))
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/mirror"
+)
+
+func TestSyncIsIncremental(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/conversations":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"cnv_1","subject":"Refund"}]}`))
+		case "/conversations/cnv_1/messages":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"msg_1","text":"Please refund order 42"}]}`))
+		case "/contacts":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"crd_1","name":"Ada"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	flags := &RootFlags{JSON: true, Account: "test@example.com", NoCache: true}
+
+	for range 2 {
+		if err := (&SyncCmd{Concurrency: 2}).Run(flags); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	}
+
+	m, err := openMirror(flags)
+	if err != nil {
+		t.Fatalf("openMirror: %v", err)
+	}
+	defer m.Close()
+
+	counts, err := m.Counts()
+	if err != nil {
+		t.Fatalf("Counts: %v", err)
+	}
+
+	if counts != (mirror.Counts{Conversations: 1, Messages: 1, Contacts: 1}) {
+		t.Fatalf("mirror = %+v", counts)
+	}
+
+	var listings []string
+
+	for _, q := range queries {
+		if strings.HasPrefix(q, "/conversations?") {
+			listings = append(listings, q)
+		}
+	}
+
+	if len(listings) != 2 || strings.Contains(listings[0], "updated_after") || !strings.Contains(listings[1], "updated_after") {
+		t.Fatalf("conversation listings = %v, want only the second to be incremental", listings)
+	}
+}
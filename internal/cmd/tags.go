@@ -20,9 +20,14 @@ type TagCmd struct {
 	Delete   TagDeleteCmd   `cmd:"" help:"Delete a tag"`
 	Children TagChildrenCmd `cmd:"" help:"List child tags"`
 	Convos   TagConvosCmd   `cmd:"" help:"List conversations with a tag"`
+	Apply    TagApplyCmd    `cmd:"" help:"Add a tag to many conversations"`
+	Remove   TagRemoveCmd   `cmd:"" help:"Remove a tag from many conversations"`
+	Stats    TagStatsCmd    `cmd:"" help:"Conversation counts and last use per tag"`
 }
 
 type TagListCmd struct {
+	PaginationFlags `embed:""`
+
 	Tree bool `help:"Show hierarchical tree view"`
 }
 
@@ -39,39 +44,47 @@ func (c *TagListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListTags(ctx)
-	if err != nil {
-		fmt.Fprint(os.Stderr, errfmt.Format(err))
+	if !c.Tree || mode.JSON {
+		err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Tag]{
+			Path:    "/tags",
+			Empty:   "No tags found.",
+			Headers: []string{"ID", "NAME", "COLOR"},
+			Row:     output.FormatTag,
+		})
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
 
-		return err
-	}
+			return err
+		}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+		return nil
 	}
 
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No tags found.")
+	// The tree needs every tag to resolve parents, so always fetch all pages.
+	var tags []api.Tag
+
+	_, err = listPages(ctx, client, "/tags", PaginationFlags{All: true}, func(page *api.ListResponse[api.Tag]) error {
+		tags = append(tags, page.Results...)
 
 		return nil
-	}
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
-	if c.Tree {
-		return renderTagTree(resp.Results)
+		return err
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "COLOR")
+	if len(tags) == 0 {
+		fmt.Fprintln(os.Stdout, "No tags found.")
 
-	for _, tag := range resp.Results {
-		tbl.AddRow(output.FormatTag(tag)...)
+		return nil
 	}
 
-	return tbl.Flush()
+	return renderTagTree(tags)
 }
 
 type TagGetCmd struct {
-	ID string `arg:"" help:"Tag ID"`
+	ID string `arg:"" help:"Tag ID or name"`
 }
 
 func (c *TagGetCmd) Run(flags *RootFlags) error {
@@ -87,6 +100,12 @@ func (c *TagGetCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if c.ID, err = client.ResolveTag(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
 	tag, err := client.GetTag(ctx, c.ID)
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
@@ -95,7 +114,7 @@ func (c *TagGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, tag)
+		return mode.Write(os.Stdout, tag)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:          %s\n", tag.ID)
@@ -158,7 +177,7 @@ func (c *TagCreateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Tag created: %s (%s)\n", result.Name, result.ID)
@@ -167,7 +186,7 @@ func (c *TagCreateCmd) Run(flags *RootFlags) error {
 }
 
 type TagUpdateCmd struct {
-	ID          string `arg:"" help:"Tag ID"`
+	ID          string `arg:"" help:"Tag ID or name"`
 	Name        string `help:"New name"`
 	Description string `help:"New description"`
 	Color       string `help:"New color"`
@@ -186,6 +205,12 @@ func (c *TagUpdateCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if c.ID, err = client.ResolveTag(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
 	req := map[string]any{}
 
 	if c.Name != "" {
@@ -212,7 +237,7 @@ func (c *TagUpdateCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	fmt.Fprintf(os.Stdout, "Tag updated: %s\n", result.Name)
@@ -221,7 +246,7 @@ func (c *TagUpdateCmd) Run(flags *RootFlags) error {
 }
 
 type TagDeleteCmd struct {
-	ID string `arg:"" help:"Tag ID"`
+	ID string `arg:"" help:"Tag ID or name"`
 }
 
 func (c *TagDeleteCmd) Run(flags *RootFlags) error {
@@ -232,6 +257,12 @@ func (c *TagDeleteCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if c.ID, err = client.ResolveTag(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
 	if err := client.Delete(ctx, "/tags/"+c.ID); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
@@ -244,7 +275,9 @@ func (c *TagDeleteCmd) Run(flags *RootFlags) error {
 }
 
 type TagChildrenCmd struct {
-	ID string `arg:"" help:"Parent tag ID"`
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Parent tag ID or name"`
 }
 
 func (c *TagChildrenCmd) Run(flags *RootFlags) error {
@@ -260,35 +293,31 @@ func (c *TagChildrenCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.Tag]
-	if err := client.Get(ctx, fmt.Sprintf("/tags/%s/children", c.ID), &resp); err != nil {
+	if c.ID, err = client.ResolveTag(ctx, c.ID); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No child tags found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "COLOR")
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Tag]{
+		Path:    fmt.Sprintf("/tags/%s/children", c.ID),
+		Empty:   "No child tags found.",
+		Headers: []string{"ID", "NAME", "COLOR"},
+		Row:     output.FormatTag,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
-	for _, tag := range resp.Results {
-		tbl.AddRow(output.FormatTag(tag)...)
+		return err
 	}
 
-	return tbl.Flush()
+	return nil
 }
 
 type TagConvosCmd struct {
-	ID    string `arg:"" help:"Tag ID"`
+	PaginationFlags `embed:""`
+
+	ID    string `arg:"" help:"Tag ID or name"`
 	Limit int    `help:"Maximum number of results" default:"25"`
 }
 
@@ -305,32 +334,126 @@ func (c *TagConvosCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	path := fmt.Sprintf("/tags/%s/conversations?limit=%d", c.ID, c.Limit)
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	if c.ID, err = client.ResolveTag(ctx, c.ID); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Conversation]{
+		Path:    fmt.Sprintf("/tags/%s/conversations?limit=%d", c.ID, c.Limit),
+		Empty:   "No conversations found.",
+		Headers: []string{"ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED"},
+		Row:     output.FormatConversation,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type TagApplyCmd struct {
+	Tag        string   `arg:"" help:"Tag to add (ID or name)"`
+	IDs        []string `arg:"" optional:"" help:"Conversation IDs to tag"`
+	IDsFrom    string   `name:"ids-from" help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	Create     bool     `help:"Create the tag if no tag has this name"`
+	BatchFlags `embed:""`
+}
+
+func (c *TagApplyCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
 	}
 
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No conversations found.")
+	ids, err := collectConversationIDs(c.IDs, c.IDsFrom)
+	if err != nil {
+		return err
+	}
 
-		return nil
+	tagID, err := resolveTagOrCreate(ctx, client, c.Tag, c.Create)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED")
+	payload := map[string][]string{"tag_ids": {tagID}}
+
+	rec := newUndoRecorder("tag", tagID)
+
+	err = forEachID(ctx, ids, "tag", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		conv, err := client.GetConversation(ctx, id)
+		if err != nil {
+			return "", err
+		}
 
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversation(conv)...)
+		if err := client.Post(ctx, "/conversations/"+id+"/tags", payload, nil); err != nil {
+			return "", err
+		}
+
+		rec.note(conv)
+
+		return fmt.Sprintf("Tagged %s with %s", id, c.Tag), nil
+	})
+
+	rec.save(flags)
+
+	return err
+}
+
+type TagRemoveCmd struct {
+	Tag        string   `arg:"" help:"Tag to remove (ID or name)"`
+	IDs        []string `arg:"" optional:"" help:"Conversation IDs to untag"`
+	IDsFrom    string   `name:"ids-from" help:"Read conversation IDs from stdin (use '-' for stdin)"`
+	BatchFlags `embed:""`
+}
+
+func (c *TagRemoveCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	ids, err := collectConversationIDs(c.IDs, c.IDsFrom)
+	if err != nil {
+		return err
+	}
+
+	tagID, err := client.ResolveTag(ctx, c.Tag)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
 	}
 
-	return tbl.Flush()
+	rec := newUndoRecorder("untag", tagID)
+
+	err = forEachID(ctx, ids, "untag", c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		conv, err := client.GetConversation(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		if err := client.Delete(ctx, "/conversations/"+id+"/tags/"+tagID); err != nil {
+			return "", err
+		}
+
+		rec.note(conv)
+
+		return fmt.Sprintf("Untagged %s from %s", c.Tag, id), nil
+	})
+
+	rec.save(flags)
+
+	return err
 }
 
 func renderTagTree(tags []api.Tag) error {
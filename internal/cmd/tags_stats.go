@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type TagStatsCmd struct {
+	Concurrency int `help:"Number of tags counted at once" default:"4"`
+}
+
+// tagStats counts the conversations carrying a tag. Open includes snoozed
+// conversations; Total also counts trashed ones.
+type tagStats struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Open       int     `json:"open"`
+	Archived   int     `json:"archived"`
+	Total      int     `json:"total"`
+	LastUsedAt float64 `json:"last_used_at"`
+}
+
+func (c *TagStatsCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	table, err := pagedList[tagStats]{
+		Headers: []string{"ID", "NAME", "OPEN", "ARCHIVED", "TOTAL", "LAST USED"},
+		Row: func(s tagStats) []string {
+			return []string{s.ID, s.Name, strconv.Itoa(s.Open), strconv.Itoa(s.Archived), strconv.Itoa(s.Total), output.FormatTimestamp(s.LastUsedAt)}
+		},
+	}.withColumns(mode)
+	if err != nil {
+		return err
+	}
+
+	stats, err := c.collect(ctx, client)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.SortBy != "" {
+		output.SortByColumn(stats, mode.SortBy, mode.SortDesc)
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, map[string]any{"tags": stats})
+	}
+
+	if len(stats) == 0 {
+		fmt.Fprintln(os.Stdout, "No tags found.")
+
+		return nil
+	}
+
+	var tbl output.TableWriter
+
+	return table.writeRows(&tbl, mode, stats)
+}
+
+// collect lists every tag and walks each tag's conversations, a few tags at
+// a time, ordered by tag name.
+func (c *TagStatsCmd) collect(ctx context.Context, client *api.Client) ([]tagStats, error) {
+	stats := []tagStats{}
+
+	_, err := listPages(ctx, client, "/tags", PaginationFlags{All: true}, func(resp *api.ListResponse[api.Tag]) error {
+		for _, tag := range resp.Results {
+			stats = append(stats, tagStats{ID: tag.ID, Name: tag.Name})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool { return strings.ToLower(stats[i].Name) < strings.ToLower(stats[j].Name) })
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(c.Concurrency, 1))
+
+	for i := range stats {
+		g.Go(func() error {
+			s := &stats[i]
+			path := "/tags/" + s.ID + "/conversations?limit=100"
+
+			_, err := listPages(gctx, client, path, PaginationFlags{All: true}, func(resp *api.ListResponse[api.Conversation]) error {
+				for _, conv := range resp.Results {
+					s.Total++
+
+					switch conv.Status {
+					case "archived":
+						s.Archived++
+					case "trashed", "deleted":
+					default:
+						s.Open++
+					}
+
+					s.LastUsedAt = max(s.LastUsedAt, conv.WaitingSince, conv.CreatedAt)
+				}
+
+				return nil
+			})
+
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
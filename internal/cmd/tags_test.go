@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTagApplyAndRemoveIDsFromStdin(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet && r.URL.Path == "/tags" {
+			_, _ = io.WriteString(w, `{"_results":[{"id":"tag_vip","name":"VIP"}]}`)
+
+			return
+		}
+
+		// Read for the undo journal before each change.
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"id":"`+strings.TrimPrefix(r.URL.Path, "/conversations/")+`","tags":[]}`)
+
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(data)))
+		mu.Unlock()
+
+		if strings.HasPrefix(r.URL.Path, "/conversations/cnv_bad/") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"_error":{"status":404,"title":"Not found","message":"Conversation not found"}}`)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	withStdin := func(input string) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("pipe: %v", err)
+		}
+
+		_, _ = w.WriteString(input)
+		_ = w.Close()
+
+		oldStdin := os.Stdin
+		os.Stdin = r
+
+		t.Cleanup(func() {
+			os.Stdin = oldStdin
+			_ = r.Close()
+		})
+	}
+
+	withStdin("cnv_1\ncnv_2 cnv_bad\n")
+
+	apply := TagApplyCmd{Tag: "vip", IDsFrom: "-", BatchFlags: BatchFlags{Concurrency: 2}}
+	if err := apply.Run(&RootFlags{Account: "test@example.com"}); err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("apply err = %v, want 1 of 3 failed", err)
+	}
+
+	withStdin("cnv_1\n")
+
+	remove := TagRemoveCmd{Tag: "VIP", IDsFrom: "-", BatchFlags: BatchFlags{Concurrency: 2}}
+	if err := remove.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	sort.Strings(requests)
+
+	want := []string{
+		"DELETE /conversations/cnv_1/tags/tag_vip",
+		`POST /conversations/cnv_1/tags {"tag_ids":["tag_vip"]}`,
+		`POST /conversations/cnv_2/tags {"tag_ids":["tag_vip"]}`,
+		`POST /conversations/cnv_bad/tags {"tag_ids":["tag_vip"]}`,
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("requests:\n%s", strings.Join(requests, "\n"))
+	}
+}
+
+func TestTagStatsCountsConversations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/tags":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"tag_vip","name":"VIP"},{"id":"tag_old","name":"legacy"}]}`)
+		case "/tags/tag_vip/conversations":
+			_, _ = io.WriteString(w, `{"_results":[
+				{"id":"cnv_1","status":"assigned","created_at":100,"waiting_since":500},
+				{"id":"cnv_2","status":"snoozed","created_at":200},
+				{"id":"cnv_3","status":"archived","created_at":300}
+			]}`)
+		case "/tags/tag_old/conversations":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"cnv_4","status":"trashed","created_at":50}]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--plain", "--account", "test@example.com", "tags", "stats", "--sort", "last_used", "--columns", "name,open,archived,total,last_used_at"})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "legacy\t0\t0\t1\t") || !strings.HasPrefix(lines[2], "VIP\t2\t1\t3\t") {
+		t.Fatalf("unexpected stats:\n%s", out)
+	}
+}
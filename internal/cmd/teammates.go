@@ -11,12 +11,19 @@ import (
 )
 
 type TeammateCmd struct {
-	List   TeammateListCmd   `cmd:"" help:"List teammates"`
-	Get    TeammateGetCmd    `cmd:"" help:"Get a teammate"`
-	Convos TeammateConvosCmd `cmd:"" help:"List conversations assigned to a teammate"`
+	List       TeammateListCmd       `cmd:"" help:"List teammates"`
+	Get        TeammateGetCmd        `cmd:"" help:"Get a teammate"`
+	Convos     TeammateConvosCmd     `cmd:"" help:"List conversations assigned to a teammate"`
+	Signatures TeammateSignaturesCmd `cmd:"" help:"List a teammate's signatures"`
+	Inboxes    TeammateInboxesCmd    `cmd:"" help:"List inboxes a teammate has access to"`
+	Groups     TeammateGroupsCmd     `cmd:"" help:"List a teammate's contact groups"`
 }
 
-type TeammateListCmd struct{}
+type TeammateListCmd struct {
+	PaginationFlags `embed:""`
+
+	Team string `help:"Only list members of this team (ID or name)"`
+}
 
 func (c *TeammateListCmd) Run(flags *RootFlags) error {
 	ctx := context.Background()
@@ -31,35 +38,28 @@ func (c *TeammateListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := client.ListTeammates(ctx)
+	if c.Team != "" {
+		err = runTeamMembers(ctx, client, mode, c.Team)
+	} else {
+		err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Teammate]{
+			Path:    "/teammates",
+			Empty:   "No teammates found.",
+			Headers: []string{"ID", "EMAIL", "NAME"},
+			Row:     output.FormatTeammate,
+		})
+	}
+
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No teammates found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "EMAIL", "NAME")
-
-	for _, tm := range resp.Results {
-		tbl.AddRow(output.FormatTeammate(tm)...)
-	}
-
-	return tbl.Flush()
+	return nil
 }
 
 type TeammateGetCmd struct {
-	ID string `arg:"" help:"Teammate ID"`
+	ID string `arg:"" help:"Teammate ID, email or username"`
 }
 
 func (c *TeammateGetCmd) Run(flags *RootFlags) error {
@@ -75,6 +75,12 @@ func (c *TeammateGetCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
+	if c.ID, err = client.ResolveTeammate(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
 	tm, err := client.GetTeammate(ctx, c.ID)
 	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
@@ -83,7 +89,7 @@ func (c *TeammateGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, tm)
+		return mode.Write(os.Stdout, tm)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:        %s\n", tm.ID)
@@ -97,7 +103,9 @@ func (c *TeammateGetCmd) Run(flags *RootFlags) error {
 }
 
 type TeammateConvosCmd struct {
-	ID    string `arg:"" help:"Teammate ID"`
+	PaginationFlags `embed:""`
+
+	ID    string `arg:"" help:"Teammate ID, email or username"`
 	Limit int    `help:"Maximum number of results" default:"25"`
 }
 
@@ -114,30 +122,105 @@ func (c *TeammateConvosCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	path := fmt.Sprintf("/teammates/%s/conversations?limit=%d", c.ID, c.Limit)
-	var resp api.ListResponse[api.Conversation]
-	if err := client.Get(ctx, path, &resp); err != nil {
+	if c.ID, err = client.ResolveTeammate(ctx, c.ID); err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Conversation]{
+		Path:    fmt.Sprintf("/teammates/%s/conversations?limit=%d", c.ID, c.Limit),
+		Empty:   "No conversations found.",
+		Headers: []string{"ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED"},
+		Row:     output.FormatConversation,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type TeammateSignaturesCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Teammate ID, email or username"`
+}
+
+func (c *TeammateSignaturesCmd) Run(flags *RootFlags) error {
+	return runTeammateList(flags, c.PaginationFlags, c.ID, pagedList[api.Signature]{
+		Path:    "signatures",
+		Empty:   "No signatures found.",
+		Headers: []string{"ID", "NAME", "SENDER", "DEFAULT"},
+		Row:     output.FormatSignature,
+	})
+}
+
+type TeammateInboxesCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Teammate ID, email or username"`
+}
+
+func (c *TeammateInboxesCmd) Run(flags *RootFlags) error {
+	return runTeammateList(flags, c.PaginationFlags, c.ID, pagedList[api.Inbox]{
+		Path:    "inboxes",
+		Empty:   "No inboxes found.",
+		Headers: []string{"ID", "NAME"},
+		Row:     output.FormatInbox,
+	})
+}
+
+type TeammateGroupsCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Teammate ID, email or username"`
+}
+
+func (c *TeammateGroupsCmd) Run(flags *RootFlags) error {
+	return runTeammateList(flags, c.PaginationFlags, c.ID, pagedList[api.Group]{
+		Path:    "contact_groups",
+		Empty:   "No contact groups found.",
+		Headers: []string{"ID", "NAME"},
+		Row:     output.FormatGroup,
+	})
+}
+
+// runTeammateList lists a teammate-scoped resource; l.Path is the resource
+// name under /teammates/{id}/.
+func runTeammateList[T any](flags *RootFlags, p PaginationFlags, teammateID string, l pagedList[T]) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
 	}
 
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No conversations found.")
+	if teammateID, err = client.ResolveTeammate(ctx, teammateID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
 
-		return nil
+	id, err := api.SanitizeID(teammateID)
+	if err != nil {
+		return fmt.Errorf("invalid teammate ID %q: %w", teammateID, err)
 	}
 
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "STATUS", "ASSIGNEE", "SUBJECT", "CREATED")
+	l.Path = fmt.Sprintf("/teammates/%s/%s", id, l.Path)
 
-	for _, conv := range resp.Results {
-		tbl.AddRow(output.FormatConversation(conv)...)
+	if err := runPagedList(ctx, client, mode, p, l); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
 	}
 
-	return tbl.Flush()
+	return nil
 }
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTeammateScopedListsUseTeammatePath(t *testing.T) {
+	var paths []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = io.WriteString(w, `{"_results":[]}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	flags := &RootFlags{JSON: true, Account: "test@example.com"}
+
+	for _, run := range []func() error{
+		func() error { return (&TeammateSignaturesCmd{ID: "tea_1"}).Run(flags) },
+		func() error { return (&TeammateInboxesCmd{ID: "tea_1"}).Run(flags) },
+		func() error { return (&TeammateGroupsCmd{ID: "tea_1"}).Run(flags) },
+	} {
+		if err := run(); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	}
+
+	want := []string{"/teammates/tea_1/signatures", "/teammates/tea_1/inboxes", "/teammates/tea_1/contact_groups"}
+	for i, p := range want {
+		if i >= len(paths) || paths[i] != p {
+			t.Fatalf("unexpected paths: %v, want %v", paths, want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type TeamCmd struct {
+	List         TeamListCmd         `cmd:"" help:"List teams"`
+	Get          TeamGetCmd          `cmd:"" help:"Get a team"`
+	Teammates    TeamTeammatesCmd    `cmd:"" help:"List a team's members"`
+	AddMember    TeamAddMemberCmd    `cmd:"" help:"Add teammates to a team"`
+	RemoveMember TeamRemoveMemberCmd `cmd:"" help:"Remove teammates from a team"`
+	Inboxes      TeamInboxesCmd      `cmd:"" help:"List a team's inboxes"`
+}
+
+type TeamListCmd struct {
+	PaginationFlags `embed:""`
+}
+
+func (c *TeamListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Team]{
+		Path:    "/teams",
+		Empty:   "No teams found.",
+		Headers: []string{"ID", "NAME"},
+		Row:     output.FormatTeam,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type TeamGetCmd struct {
+	ID string `arg:"" help:"Team ID or name"`
+}
+
+func (c *TeamGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	if c.ID, err = client.ResolveTeam(ctx, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	team, err := client.GetTeam(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, team)
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:      %s\n", team.ID)
+	fmt.Fprintf(os.Stdout, "Name:    %s\n", team.Name)
+	fmt.Fprintf(os.Stdout, "Members: %d\n", len(team.Members))
+	fmt.Fprintf(os.Stdout, "Inboxes: %d\n", len(team.Inboxes))
+
+	return nil
+}
+
+type TeamTeammatesCmd struct {
+	ID string `arg:"" help:"Team ID or name"`
+}
+
+func (c *TeamTeammatesCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := runTeamMembers(ctx, client, mode, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type TeamAddMemberCmd struct {
+	ID          string   `arg:"" help:"Team ID or name"`
+	TeammateIDs []string `arg:"" help:"Teammates to add (IDs, emails or usernames)"`
+}
+
+func (c *TeamAddMemberCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	teamID, req, err := resolveTeamMembers(ctx, client, c.ID, c.TeammateIDs)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if err := client.Post(ctx, fmt.Sprintf("/teams/%s/teammates", teamID), req, nil); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Added %d teammates to %s\n", len(c.TeammateIDs), teamID)
+
+	return nil
+}
+
+type TeamRemoveMemberCmd struct {
+	ID          string   `arg:"" help:"Team ID or name"`
+	TeammateIDs []string `arg:"" help:"Teammates to remove (IDs, emails or usernames)"`
+}
+
+func (c *TeamRemoveMemberCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	teamID, req, err := resolveTeamMembers(ctx, client, c.ID, c.TeammateIDs)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if err := client.DeleteWithBody(ctx, fmt.Sprintf("/teams/%s/teammates", teamID), req); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Removed %d teammates from %s\n", len(c.TeammateIDs), teamID)
+
+	return nil
+}
+
+// resolveTeamMembers resolves a team and teammates given by name into the
+// team ID and the request body of the team membership endpoints.
+func resolveTeamMembers(ctx context.Context, client *api.Client, team string, teammates []string) (string, map[string][]string, error) {
+	teamID, err := client.ResolveTeam(ctx, team)
+	if err != nil {
+		return "", nil, err
+	}
+
+	teammateIDs, err := resolveAll(ctx, teammates, client.ResolveTeammate)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return teamID, map[string][]string{"teammate_ids": teammateIDs}, nil
+}
+
+type TeamInboxesCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Team ID or name"`
+}
+
+func (c *TeamInboxesCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := runTeamInboxes(ctx, client, mode, c.PaginationFlags, c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// runTeamMembers lists the members of a team. Front embeds them in the team
+// itself, so there is nothing to paginate.
+func runTeamMembers(ctx context.Context, client *api.Client, mode output.Mode, team string) error {
+	id, err := client.ResolveTeam(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	t, err := client.GetTeam(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if mode.JSON {
+		members := t.Members
+		if members == nil {
+			members = []api.Teammate{}
+		}
+
+		return mode.Write(os.Stdout, api.ListResponse[api.Teammate]{Results: members})
+	}
+
+	if len(t.Members) == 0 {
+		fmt.Fprintln(os.Stdout, "No teammates found.")
+
+		return nil
+	}
+
+	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+	tbl.AddRow("ID", "EMAIL", "NAME")
+
+	for _, tm := range t.Members {
+		tbl.AddRow(output.FormatTeammate(tm)...)
+	}
+
+	return tbl.Flush()
+}
+
+// runTeamInboxes lists the inboxes belonging to a team.
+func runTeamInboxes(ctx context.Context, client *api.Client, mode output.Mode, p PaginationFlags, team string) error {
+	id, err := client.ResolveTeam(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	return runPagedList(ctx, client, mode, p, pagedList[api.Inbox]{
+		Path:    fmt.Sprintf("/teams/%s/inboxes", id),
+		Empty:   "No inboxes found.",
+		Headers: []string{"ID", "NAME"},
+		Row:     output.FormatInbox,
+	})
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTeamFiltersResolveTeamNames(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/teams":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tim_1","name":"Support"},{"id":"tim_2","name":"Sales"}]}`))
+		case "/teams/tim_1":
+			_, _ = w.Write([]byte(`{"id":"tim_1","name":"Support","members":[{"id":"tea_1","email":"a@example.com"}]}`))
+		case "/teams/tim_1/inboxes":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"inb_1","name":"Help"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	flags := &RootFlags{JSON: true, Account: "test@example.com"}
+
+	if err := (&TeammateListCmd{Team: "support"}).Run(flags); err != nil {
+		t.Fatalf("teammates list --team: %v", err)
+	}
+
+	if err := (&InboxListCmd{Team: "tim_1"}).Run(flags); err != nil {
+		t.Fatalf("inboxes list --team: %v", err)
+	}
+
+	if err := (&TeamGetCmd{ID: "Marketing"}).Run(flags); err == nil {
+		t.Fatal("unknown team name should fail")
+	}
+
+	want := []string{"/teams", "/teams/tim_1", "/teams/tim_1/inboxes", "/teams"}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Fatalf("requests = %v, want %v", requests, want)
+		}
+	}
+}
+
+func TestTeamAddAndRemoveMembers(t *testing.T) {
+	var (
+		requests []string
+		bodies   []map[string][]string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/teams":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tim_1","name":"Support"}]}`))
+		case "/teammates":
+			_, _ = w.Write([]byte(`{"_results":[{"id":"tea_1","email":"alice@example.com","username":"alice"}]}`))
+		default:
+			var body map[string][]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+
+			bodies = append(bodies, body)
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	flags := &RootFlags{Account: "test@example.com"}
+
+	if _, err := captureStdout(t, func() error {
+		return (&TeamAddMemberCmd{ID: "support", TeammateIDs: []string{"alice@example.com", "tea_2"}}).Run(flags)
+	}); err != nil {
+		t.Fatalf("add-member: %v", err)
+	}
+
+	if _, err := captureStdout(t, func() error {
+		return (&TeamRemoveMemberCmd{ID: "tim_1", TeammateIDs: []string{"tea_2"}}).Run(flags)
+	}); err != nil {
+		t.Fatalf("remove-member: %v", err)
+	}
+
+	want := "GET /teams,GET /teammates,POST /teams/tim_1/teammates,DELETE /teams/tim_1/teammates"
+	if got := strings.Join(requests, ","); got != want {
+		t.Fatalf("requests = %s, want %s", got, want)
+	}
+
+	if strings.Join(bodies[0]["teammate_ids"], ",") != "tea_1,tea_2" || strings.Join(bodies[1]["teammate_ids"], ",") != "tea_2" {
+		t.Fatalf("bodies = %v", bodies)
+	}
+}
@@ -4,19 +4,29 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
-	"github.com/dedene/frontapp-cli/internal/output"
+	"github.com/dedene/frontapp-cli/internal/markdown"
 )
 
 type TemplateCmd struct {
-	List TemplateListCmd `cmd:"" help:"List templates"`
-	Get  TemplateGetCmd  `cmd:"" help:"Get a template"`
-	Use  TemplateUseCmd  `cmd:"" help:"Output a template body for piping"`
+	List    TemplateListCmd   `cmd:"" help:"List templates"`
+	Get     TemplateGetCmd    `cmd:"" help:"Get a template"`
+	Use     TemplateUseCmd    `cmd:"" help:"Output a template body for piping"`
+	Render  TemplateRenderCmd `cmd:"" help:"Preview a template with its variables filled in from a conversation"`
+	Push    TemplatePushCmd   `cmd:"" help:"Create or update templates from a directory of markdown files"`
+	Create  TemplateCreateCmd `cmd:"" help:"Create a template"`
+	Update  TemplateUpdateCmd `cmd:"" help:"Update a template"`
+	Delete  TemplateDeleteCmd `cmd:"" help:"Delete a template"`
+	Folders TemplateFolderCmd `cmd:"" help:"Manage template folders"`
 }
 
-type TemplateListCmd struct{}
+type TemplateListCmd struct {
+	PaginationFlags `embed:""`
+}
 
 func (c *TemplateListCmd) Run(flags *RootFlags) error {
 	ctx := context.Background()
@@ -31,35 +41,27 @@ func (c *TemplateListCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	var resp api.ListResponse[api.Template]
-	if err := client.Get(ctx, "/message_templates", &resp); err != nil {
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Template]{
+		Path:    "/message_templates",
+		Empty:   "No templates found.",
+		Headers: []string{"ID", "NAME", "SUBJECT"},
+		Row:     formatTemplate,
+	})
+	if err != nil {
 		fmt.Fprint(os.Stderr, errfmt.Format(err))
 
 		return err
 	}
 
-	if mode.JSON {
-		return output.WriteJSON(os.Stdout, resp)
-	}
-
-	if len(resp.Results) == 0 {
-		fmt.Fprintln(os.Stdout, "No templates found.")
-
-		return nil
-	}
-
-	tbl := output.NewTableWriter(os.Stdout, mode.Plain)
-	tbl.AddRow("ID", "NAME", "SUBJECT")
+	return nil
+}
 
-	for _, tmpl := range resp.Results {
-		tbl.AddRow(
-			tmpl.ID,
-			tmpl.Name,
-			tmpl.Subject,
-		)
+func formatTemplate(tmpl api.Template) []string {
+	return []string{
+		tmpl.ID,
+		tmpl.Name,
+		tmpl.Subject,
 	}
-
-	return tbl.Flush()
 }
 
 type TemplateGetCmd struct {
@@ -87,7 +89,7 @@ func (c *TemplateGetCmd) Run(flags *RootFlags) error {
 	}
 
 	if mode.JSON {
-		return output.WriteJSON(os.Stdout, tmpl)
+		return mode.Write(os.Stdout, tmpl)
 	}
 
 	fmt.Fprintf(os.Stdout, "ID:      %s\n", tmpl.ID)
@@ -126,3 +128,468 @@ func (c *TemplateUseCmd) Run(flags *RootFlags) error {
 
 	return nil
 }
+
+type TemplateRenderCmd struct {
+	ID           string   `arg:"" help:"Template ID"`
+	Conversation string   `help:"Conversation whose subject, status and contact fill in the variables"`
+	To           string   `help:"Recipient handle for contact.* variables when there is no conversation"`
+	Var          []string `help:"Template variable as name=value; repeatable" sep:"none"`
+	Raw          bool     `help:"Show the rendered body as HTML"`
+}
+
+func (c *TemplateRenderCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	src := messageTemplateSource{ConvID: c.Conversation, To: c.To, Vars: c.Var}
+
+	subject, body, err := renderMessageTemplate(ctx, client, c.ID, src)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, map[string]string{"subject": subject, "body": body})
+	}
+
+	if subject != "" {
+		fmt.Fprintf(os.Stdout, "Subject: %s\n\n", subject)
+	}
+
+	if !c.Raw {
+		if md, err := markdown.ToMarkdown(body); err == nil && strings.TrimSpace(md) != "" {
+			body = md
+		}
+	}
+
+	fmt.Fprintln(os.Stdout, body)
+
+	return nil
+}
+
+type TemplateCreateCmd struct {
+	Name       string `required:"" help:"Template name"`
+	Subject    string `help:"Template subject"`
+	Body       string `help:"Template body (HTML)"`
+	BodyFile   string `help:"Read body from file (- for stdin)" type:"existingfile"`
+	BodyFormat string `help:"Format of --body/--body-file: html, markdown, or auto for markdown when the file ends in .md" enum:"auto,html,markdown" default:"auto"`
+	Folder     string `help:"Folder ID to create the template in"`
+	Inbox      string `help:"Create the template in this inbox (ID or name) instead of company-wide"`
+}
+
+func (c *TemplateCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	if body == "" {
+		return fmt.Errorf("--body or --body-file is required")
+	}
+
+	if body, err = renderBody(body, templateBodyFormat(c.BodyFormat, c.BodyFile)); err != nil {
+		return err
+	}
+
+	req := map[string]any{
+		"name": c.Name,
+		"body": body,
+	}
+
+	if c.Subject != "" {
+		req["subject"] = c.Subject
+	}
+
+	if c.Folder != "" {
+		req["folder_id"] = c.Folder
+	}
+
+	path := "/message_templates"
+	if c.Inbox != "" {
+		inboxID, err := client.ResolveInbox(ctx, c.Inbox)
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+			return err
+		}
+
+		path = fmt.Sprintf("/inboxes/%s/message_templates", inboxID)
+	}
+
+	var result api.Template
+	if err := client.Post(ctx, path, req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Template created: %s\n", result.ID)
+
+	return nil
+}
+
+type TemplateUpdateCmd struct {
+	ID         string `arg:"" help:"Template ID"`
+	Name       string `help:"New name"`
+	Subject    string `help:"New subject"`
+	Body       string `help:"New body (HTML)"`
+	BodyFile   string `help:"Read body from file (- for stdin)" type:"existingfile"`
+	BodyFormat string `help:"Format of --body/--body-file: html, markdown, or auto for markdown when the file ends in .md" enum:"auto,html,markdown" default:"auto"`
+	Folder     string `help:"Move the template to this folder ID"`
+}
+
+func (c *TemplateUpdateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	body, err := readBodyFlag(c.Body, c.BodyFile)
+	if err != nil {
+		return err
+	}
+
+	if body != "" {
+		if body, err = renderBody(body, templateBodyFormat(c.BodyFormat, c.BodyFile)); err != nil {
+			return err
+		}
+	}
+
+	req := map[string]any{}
+
+	if c.Name != "" {
+		req["name"] = c.Name
+	}
+
+	if c.Subject != "" {
+		req["subject"] = c.Subject
+	}
+
+	if body != "" {
+		req["body"] = body
+	}
+
+	if c.Folder != "" {
+		req["folder_id"] = c.Folder
+	}
+
+	if len(req) == 0 {
+		return fmt.Errorf("no updates specified")
+	}
+
+	var result api.Template
+	if err := client.Patch(ctx, "/message_templates/"+c.ID, req, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Template updated: %s\n", c.ID)
+
+	return nil
+}
+
+// templateBodyFormat resolves --body-format auto: markdown for a .md body
+// file, HTML otherwise.
+func templateBodyFormat(format, file string) string {
+	if format != "auto" {
+		return format
+	}
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".md", ".markdown":
+		return "markdown"
+	default:
+		return "html"
+	}
+}
+
+type TemplateDeleteCmd struct {
+	ID string `arg:"" help:"Template ID"`
+}
+
+func (c *TemplateDeleteCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Delete(ctx, "/message_templates/"+c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Template deleted")
+
+	return nil
+}
+
+type TemplateFolderCmd struct {
+	List      TemplateFolderListCmd      `cmd:"" help:"List template folders"`
+	Get       TemplateFolderGetCmd       `cmd:"" help:"Get a template folder"`
+	Templates TemplateFolderTemplatesCmd `cmd:"" help:"List templates in a folder"`
+	Create    TemplateFolderCreateCmd    `cmd:"" help:"Create a template folder"`
+	Update    TemplateFolderUpdateCmd    `cmd:"" help:"Rename a template folder"`
+	Delete    TemplateFolderDeleteCmd    `cmd:"" help:"Delete a template folder"`
+}
+
+type TemplateFolderListCmd struct {
+	PaginationFlags `embed:""`
+
+	Parent string `help:"Only list child folders of this folder ID"`
+}
+
+func (c *TemplateFolderListCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	path := "/message_template_folders"
+	if c.Parent != "" {
+		path = fmt.Sprintf("/message_template_folders/%s/message_template_folders", c.Parent)
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.TemplateFolder]{
+		Path:    path,
+		Empty:   "No template folders found.",
+		Headers: []string{"ID", "NAME", "PARENT"},
+		Row:     formatTemplateFolder,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func formatTemplateFolder(folder api.TemplateFolder) []string {
+	parent := folder.ParentID()
+	if parent == "" {
+		parent = "-"
+	}
+
+	return []string{
+		folder.ID,
+		folder.Name,
+		parent,
+	}
+}
+
+type TemplateFolderGetCmd struct {
+	ID string `arg:"" help:"Folder ID"`
+}
+
+func (c *TemplateFolderGetCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	var folder api.TemplateFolder
+	if err := client.Get(ctx, "/message_template_folders/"+c.ID, &folder); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, folder)
+	}
+
+	fmt.Fprintf(os.Stdout, "ID:     %s\n", folder.ID)
+	fmt.Fprintf(os.Stdout, "Name:   %s\n", folder.Name)
+
+	if parent := folder.ParentID(); parent != "" {
+		fmt.Fprintf(os.Stdout, "Parent: %s\n", parent)
+	}
+
+	return nil
+}
+
+type TemplateFolderTemplatesCmd struct {
+	PaginationFlags `embed:""`
+
+	ID string `arg:"" help:"Folder ID"`
+}
+
+func (c *TemplateFolderTemplatesCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	err = runPagedList(ctx, client, mode, c.PaginationFlags, pagedList[api.Template]{
+		Path:    fmt.Sprintf("/message_template_folders/%s/message_templates", c.ID),
+		Empty:   "No templates found.",
+		Headers: []string{"ID", "NAME", "SUBJECT"},
+		Row:     formatTemplate,
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return nil
+}
+
+type TemplateFolderCreateCmd struct {
+	Name   string `required:"" help:"Folder name"`
+	Parent string `help:"Parent folder ID"`
+}
+
+func (c *TemplateFolderCreateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	path := "/message_template_folders"
+	if c.Parent != "" {
+		path = fmt.Sprintf("/message_template_folders/%s/message_template_folders", c.Parent)
+	}
+
+	var result api.TemplateFolder
+	if err := client.Post(ctx, path, map[string]string{"name": c.Name}, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Template folder created: %s\n", result.ID)
+
+	return nil
+}
+
+type TemplateFolderUpdateCmd struct {
+	ID   string `arg:"" help:"Folder ID"`
+	Name string `required:"" help:"New name"`
+}
+
+func (c *TemplateFolderUpdateCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	var result api.TemplateFolder
+	if err := client.Patch(ctx, "/message_template_folders/"+c.ID, map[string]string{"name": c.Name}, &result); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	if mode.JSON {
+		return mode.Write(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Template folder updated: %s\n", c.ID)
+
+	return nil
+}
+
+type TemplateFolderDeleteCmd struct {
+	ID string `arg:"" help:"Folder ID"`
+}
+
+func (c *TemplateFolderDeleteCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Delete(ctx, "/message_template_folders/"+c.ID); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Template folder deleted")
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+type TemplatePushCmd struct {
+	Dir    string `arg:"" help:"Directory of markdown templates (*.md)" type:"existingdir"`
+	Folder string `help:"Folder ID for templates that are created"`
+	DryRun bool   `help:"Show what would change without changing it"`
+}
+
+// localTemplate is a markdown file to push. Its name and subject come from
+// optional YAML front matter; the name defaults to the file name.
+type localTemplate struct {
+	File    string
+	Name    string `yaml:"name"`
+	Subject string `yaml:"subject"`
+	Body    string `yaml:"-"`
+}
+
+// pushResult is what push did, or would do, with one file.
+type pushResult struct {
+	File   string `json:"file"`
+	Name   string `json:"name"`
+	ID     string `json:"id,omitempty"`
+	Action string `json:"action"`
+}
+
+func (c *TemplatePushCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	locals, err := readLocalTemplates(c.Dir)
+	if err != nil {
+		return err
+	}
+
+	if len(locals) == 0 {
+		return fmt.Errorf("no markdown templates in %s", c.Dir)
+	}
+
+	byName := map[string][]api.Template{}
+
+	_, err = listPages(ctx, client, "/message_templates?limit=100", PaginationFlags{All: true}, func(resp *api.ListResponse[api.Template]) error {
+		for _, t := range resp.Results {
+			byName[t.Name] = append(byName[t.Name], t)
+		}
+
+		return nil
+	})
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	results := make([]pushResult, 0, len(locals))
+	failed := 0
+
+	for _, local := range locals {
+		res, err := c.push(ctx, client, local, byName[local.Name])
+		if err != nil {
+			failed++
+
+			fmt.Fprintf(os.Stderr, "%s: %s\n", local.File, strings.TrimSpace(errfmt.Format(err)))
+
+			res.Action = "failed"
+		}
+
+		results = append(results, res)
+	}
+
+	if mode.JSON {
+		if err := mode.Write(os.Stdout, results); err != nil {
+			return err
+		}
+	} else {
+		tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+		tbl.AddRow("FILE", "NAME", "ID", "ACTION")
+
+		for _, r := range results {
+			tbl.AddRow(r.File, r.Name, r.ID, r.Action)
+		}
+
+		if err := tbl.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d templates failed to push", failed, len(locals))
+	}
+
+	return nil
+}
+
+// push creates the template when no template has its name and updates it
+// when its subject or body differ.
+func (c *TemplatePushCmd) push(ctx context.Context, client *api.Client, local localTemplate, existing []api.Template) (pushResult, error) {
+	res := pushResult{File: local.File, Name: local.Name}
+	prefix := ""
+
+	if c.DryRun {
+		prefix = "would "
+	}
+
+	body, err := renderBody(local.Body, "markdown")
+	if err != nil {
+		return res, err
+	}
+
+	req := map[string]any{"name": local.Name, "body": body}
+	if local.Subject != "" {
+		req["subject"] = local.Subject
+	}
+
+	if len(existing) > 1 {
+		return res, fmt.Errorf("%d templates are named %q", len(existing), local.Name)
+	}
+
+	if len(existing) == 0 {
+		res.Action = prefix + "create"
+
+		if c.Folder != "" {
+			req["folder_id"] = c.Folder
+		}
+
+		if c.DryRun {
+			return res, nil
+		}
+
+		var created api.Template
+		if err := client.Post(ctx, "/message_templates", req, &created); err != nil {
+			return res, err
+		}
+
+		res.ID = created.ID
+
+		return res, nil
+	}
+
+	current := existing[0]
+	res.ID = current.ID
+
+	if current.Subject == local.Subject && strings.TrimSpace(current.Body) == strings.TrimSpace(body) {
+		res.Action = "unchanged"
+
+		return res, nil
+	}
+
+	res.Action = prefix + "update"
+
+	if c.DryRun {
+		return res, nil
+	}
+
+	var updated api.Template
+
+	return res, client.Patch(ctx, "/message_templates/"+current.ID, req, &updated)
+}
+
+// readLocalTemplates reads the *.md files in dir, sorted by file name.
+func readLocalTemplates(dir string) ([]localTemplate, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	locals := make([]localTemplate, 0, len(files))
+
+	for _, file := range files {
+		data, err := os.ReadFile(file) //nolint:gosec // user-provided template directory
+		if err != nil {
+			return nil, err
+		}
+
+		local, err := parseLocalTemplate(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		local.File = filepath.Base(file)
+		if local.Name == "" {
+			local.Name = strings.TrimSuffix(local.File, filepath.Ext(local.File))
+		}
+
+		locals = append(locals, local)
+	}
+
+	return locals, nil
+}
+
+// parseLocalTemplate splits off a leading "---" delimited YAML front matter
+// block, if any, and keeps the rest as the markdown body.
+func parseLocalTemplate(data []byte) (localTemplate, error) {
+	var local localTemplate
+
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	rest, ok := bytes.CutPrefix(data, []byte("---\n"))
+	if !ok {
+		local.Body = string(data)
+
+		return local, nil
+	}
+
+	front, body, ok := bytes.Cut(rest, []byte("\n---\n"))
+	if !ok {
+		if front, ok = bytes.CutSuffix(rest, []byte("\n---")); !ok {
+			return local, fmt.Errorf("front matter is not closed with ---")
+		}
+	}
+
+	if err := yaml.Unmarshal(front, &local); err != nil {
+		return local, fmt.Errorf("front matter: %w", err)
+	}
+
+	local.Body = strings.TrimLeft(string(body), "\n")
+
+	return local, nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplateCreateSendsFolderAndBody(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+
+		_, _ = io.WriteString(w, `{"id":"rsp_1","name":"Refund"}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cmd := TemplateCreateCmd{Name: "Refund", Body: "<p>Hi</p>", Folder: "rsf_1", Inbox: "inb_1"}
+	if err := cmd.Run(&RootFlags{Account: "test@example.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if gotPath != "/inboxes/inb_1/message_templates" {
+		t.Fatalf("unexpected path: %s", gotPath)
+	}
+
+	if gotBody["name"] != "Refund" || gotBody["body"] != "<p>Hi</p>" || gotBody["folder_id"] != "rsf_1" {
+		t.Fatalf("unexpected body: %#v", gotBody)
+	}
+}
+
+func TestTemplatePushCreatesAndUpdatesByName(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"refund.md":    "---\nname: Refund\nsubject: Your refund\n---\nHi **there**\n",
+		"welcome.md":   "Welcome aboard\n",
+		"unchanged.md": "Same\n",
+		"notes.txt":    "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var requests []string
+	var bodies []map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"_results":[
+				{"id":"rsp_1","name":"Refund","subject":"Old","body":"<p>Old</p>"},
+				{"id":"rsp_2","name":"unchanged","body":"<p>Same</p>"}
+			]}`)
+
+			return
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		bodies = append(bodies, body)
+
+		_, _ = io.WriteString(w, `{"id":"rsp_3"}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--plain", "--account", "test@example.com", "templates", "push", dir, "--folder", "rsf_1"})
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	want := []string{"GET /message_templates", "PATCH /message_templates/rsp_1", "POST /message_templates"}
+	if strings.Join(requests, ",") != strings.Join(want, ",") {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+
+	if bodies[0]["subject"] != "Your refund" || !strings.Contains(bodies[0]["body"], "<strong>there</strong>") || bodies[0]["folder_id"] != "" {
+		t.Errorf("update body = %#v", bodies[0])
+	}
+
+	if bodies[1]["name"] != "welcome" || bodies[1]["folder_id"] != "rsf_1" {
+		t.Errorf("create body = %#v", bodies[1])
+	}
+
+	for _, line := range []string{"refund.md\tRefund\trsp_1\tupdate", "unchanged.md\tunchanged\trsp_2\tunchanged", "welcome.md\twelcome\trsp_3\tcreate"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("output misses %q:\n%s", line, out)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/dates"
+)
+
+// parseTimeFlag parses a user-supplied time into a Unix timestamp. Besides
+// RFC3339, dates and Unix seconds it accepts relative forms ("3d",
+// "yesterday", "last monday"), which look back from now. Times without a zone
+// are read in the configured timezone. An empty value returns 0.
+func parseTimeFlag(value string) (float64, error) {
+	return parseTimeFlagDir(value, dates.Past)
+}
+
+// parseFutureTimeFlag is parseTimeFlag for scheduling: bare offsets and
+// weekdays look ahead, so "2d" is two days from now.
+func parseFutureTimeFlag(value string) (float64, error) {
+	return parseTimeFlagDir(value, dates.Future)
+}
+
+func parseTimeFlagDir(value string, dir dates.Direction) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	if ts, err := strconv.ParseFloat(value, 64); err == nil {
+		return ts, nil
+	}
+
+	loc, err := userLocation()
+	if err != nil {
+		return 0, err
+	}
+
+	t, err := dates.Parse(value, time.Now().In(loc), dir)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(t.Unix()), nil
+}
+
+// userLocation is the timezone from the config file, or the local one.
+func userLocation() (*time.Location, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil || strings.TrimSpace(cfg.Timezone) == "" {
+		return time.Local, nil //nolint:nilerr // an unreadable config is reported by the commands that need it
+	}
+
+	loc, err := time.LoadLocation(strings.TrimSpace(cfg.Timezone))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q in config: %w", cfg.Timezone, err)
+	}
+
+	return loc, nil
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+	"github.com/dedene/frontapp-cli/internal/tui"
+)
+
+type UICmd struct {
+	Inbox string `help:"Inbox to open, by ID or name (default: pick from a list)"`
+	Limit int    `help:"Maximum number of conversations to load" default:"50"`
+}
+
+func (c *UICmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	inboxID, err := client.ResolveInbox(ctx, c.Inbox)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return tui.Run(ctx, client, tui.Options{InboxID: inboxID, Limit: c.Limit})
+}
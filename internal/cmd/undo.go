@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/config"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+// undoWindow is how long 'frontcli undo' reverts the last batch without
+// --force. Past it, the conversations have likely moved on.
+const undoWindow = 24 * time.Hour
+
+// undoStatuses maps the status actions that can be undone to the status they
+// set.
+var undoStatuses = map[string]string{
+	"archive": "archived",
+	"open":    "open",
+	"trash":   "trashed",
+}
+
+type UndoCmd struct {
+	DryRun     bool `help:"Show what would be reverted without changing anything"`
+	Force      bool `help:"Revert the last batch even when it is more than 24h old"`
+	BatchFlags `embed:""`
+}
+
+// undoJournal is what the last batch action changed: the conversations it
+// changed and, for status actions, the status each had before.
+type undoJournal struct {
+	Action  string      `json:"action"`
+	TagID   string      `json:"tag_id,omitempty"`
+	At      time.Time   `json:"at"`
+	Entries []undoEntry `json:"entries"`
+}
+
+type undoEntry struct {
+	ID     string `json:"id"`
+	Status string `json:"status,omitempty"`
+}
+
+func (c *UndoCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	mode, err := resolveOutputMode(flags)
+	if err != nil {
+		return err
+	}
+
+	path, err := undoJournalPath(flags)
+	if err != nil {
+		return err
+	}
+
+	journal, err := readUndoJournal(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("nothing to undo")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	age := time.Since(journal.At).Round(time.Minute)
+
+	if age > undoWindow && !c.Force {
+		return fmt.Errorf("the last batch (%s of %d conversations) ran %s ago; use --force to undo it anyway",
+			journal.Action, len(journal.Entries), age)
+	}
+
+	if c.DryRun {
+		if mode.JSON {
+			return mode.Write(os.Stdout, journal)
+		}
+
+		tbl := output.NewTableWriter(os.Stdout, mode.Plain)
+		tbl.AddRow("ID", "UNDO")
+
+		for _, e := range journal.Entries {
+			tbl.AddRow(e.ID, journal.describe(e))
+		}
+
+		if err := tbl.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Dry run: would undo %s of %d conversations from %s ago\n", journal.Action, len(journal.Entries), age)
+
+		return nil
+	}
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string]undoEntry, len(journal.Entries))
+	ids := make([]string, 0, len(journal.Entries))
+
+	for _, e := range journal.Entries {
+		entries[e.ID] = e
+		ids = append(ids, e.ID)
+	}
+
+	var (
+		mu       sync.Mutex
+		reverted = map[string]bool{}
+	)
+
+	err = forEachID(ctx, ids, "undo "+journal.Action, c.BatchFlags, func(ctx context.Context, id string) (string, error) {
+		e := entries[id]
+		if err := journal.revert(ctx, client, e); err != nil {
+			return "", err
+		}
+
+		mu.Lock()
+		reverted[id] = true
+		mu.Unlock()
+
+		return fmt.Sprintf("Reverted %s: %s", id, journal.describe(e)), nil
+	})
+
+	// Keep what could not be reverted, so undo can be run again.
+	remaining := journal.Entries[:0]
+
+	for _, e := range journal.Entries {
+		if !reverted[e.ID] {
+			remaining = append(remaining, e)
+		}
+	}
+
+	journal.Entries = remaining
+
+	if len(remaining) == 0 {
+		if rmErr := os.Remove(path); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+			return rmErr
+		}
+	} else if wErr := writeUndoJournal(path, journal); wErr != nil {
+		return wErr
+	}
+
+	return err
+}
+
+// describe says how undo reverts e.
+func (j *undoJournal) describe(e undoEntry) string {
+	switch j.Action {
+	case "tag":
+		return "remove tag " + j.TagID
+	case "untag":
+		return "add tag " + j.TagID
+	}
+
+	return "set status " + e.Status
+}
+
+func (j *undoJournal) revert(ctx context.Context, client *api.Client, e undoEntry) error {
+	switch j.Action {
+	case "tag":
+		return client.Delete(ctx, "/conversations/"+e.ID+"/tags/"+j.TagID)
+	case "untag":
+		return client.Post(ctx, "/conversations/"+e.ID+"/tags", map[string][]string{"tag_ids": {j.TagID}}, nil)
+	}
+
+	return client.Patch(ctx, "/conversations/"+e.ID, map[string]string{"status": e.Status}, nil)
+}
+
+// undoRecorder collects the undo journal of a running batch. note is safe
+// to call from forEachID workers.
+type undoRecorder struct {
+	mu      sync.Mutex
+	journal undoJournal
+}
+
+// newUndoRecorder starts the journal of a batch: a status action (archive,
+// open, trash) or tag/untag with the tag it applies.
+func newUndoRecorder(action, tagID string) *undoRecorder {
+	return &undoRecorder{journal: undoJournal{Action: action, TagID: tagID, At: time.Now().UTC()}}
+}
+
+// note records conv, as it was before the action, if the action changed it.
+// Conversations that already were archived, or already had the tag, are
+// left out so undo does not touch them.
+func (r *undoRecorder) note(conv *api.Conversation) {
+	e := undoEntry{ID: conv.ID}
+
+	switch r.journal.Action {
+	case "tag", "untag":
+		had := false
+
+		for _, tag := range conv.Tags {
+			if tag.ID == r.journal.TagID {
+				had = true
+
+				break
+			}
+		}
+
+		if had == (r.journal.Action == "tag") {
+			return
+		}
+	default:
+		e.Status = previousStatus(conv.Status)
+		if e.Status == undoStatuses[r.journal.Action] {
+			return
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.journal.Entries = append(r.journal.Entries, e)
+}
+
+// save replaces the account's undo journal with this batch. A batch that
+// changed nothing keeps the previous journal. Failing to save only warns:
+// the batch itself has already run.
+func (r *undoRecorder) save(flags *RootFlags) {
+	if len(r.journal.Entries) == 0 {
+		return
+	}
+
+	path, err := undoJournalPath(flags)
+	if err == nil {
+		err = writeUndoJournal(path, r.journal)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save undo journal: %v\n", err)
+
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Run 'frontcli undo' to revert this %s of %d conversations.\n", r.journal.Action, len(r.journal.Entries))
+}
+
+// previousStatus is the status to set to bring a conversation back to
+// status. Front reports open conversations as assigned, unassigned or
+// snoozed.
+func previousStatus(status string) string {
+	switch status {
+	case "archived":
+		return "archived"
+	case "trashed", "deleted":
+		return "trashed"
+	}
+
+	return "open"
+}
+
+// changeStatus sets the status of conversation id and notes its previous
+// status in rec.
+func changeStatus(ctx context.Context, client *api.Client, rec *undoRecorder, id, status string) error {
+	conv, err := client.GetConversation(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Patch(ctx, "/conversations/"+id, map[string]string{"status": status}, nil); err != nil {
+		return err
+	}
+
+	rec.note(conv)
+
+	return nil
+}
+
+func undoJournalPath(flags *RootFlags) (string, error) {
+	email, _, err := resolveAccount(flags)
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := config.UndoDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, email+".json"), nil
+}
+
+func readUndoJournal(path string) (undoJournal, error) {
+	var journal undoJournal
+
+	data, err := os.ReadFile(path) //nolint:gosec // path under the config dir
+	if err != nil {
+		return journal, err
+	}
+
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return journal, fmt.Errorf("read undo journal: %w", err)
+	}
+
+	return journal, nil
+}
+
+func writeUndoJournal(path string, journal undoJournal) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("ensure undo dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write undo journal: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("commit undo journal: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUndoRevertsLastArchive(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		patches []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /conversations/cnv_1":
+			_, _ = io.WriteString(w, `{"id":"cnv_1","status":"assigned"}`)
+		case "GET /conversations/cnv_2":
+			_, _ = io.WriteString(w, `{"id":"cnv_2","status":"archived"}`)
+		default:
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+
+			mu.Lock()
+			patches = append(patches, r.URL.Path+"="+body["status"])
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	if _, err := captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "conv", "archive", "cnv_1", "cnv_2"})
+	}); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+
+	patches = nil
+
+	out, err := captureStdout(t, func() error {
+		return Execute([]string{"--account", "test@example.com", "undo"})
+	})
+	if err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+
+	// cnv_2 was archived already, so undo leaves it archived.
+	sort.Strings(patches)
+
+	if strings.Join(patches, ",") != "/conversations/cnv_1=open" || !strings.Contains(out, "Reverted cnv_1") {
+		t.Fatalf("patches %v, output %q", patches, out)
+	}
+
+	if err := Execute([]string{"--account", "test@example.com", "undo"}); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Fatalf("second undo: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestRecordThenReplayCommand(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"tag_1","name":"vip","highlight":"red"}`)
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	cassette := filepath.Join(t.TempDir(), "tags_get.json")
+
+	if err := Execute([]string{"--account", "test@example.com", "--json", "--record", cassette, "tags", "get", "tag_1"}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("recording made %d requests, want 1", requests)
+	}
+
+	if err := Execute([]string{"--json", "--replay", cassette, "tags", "get", "tag_1"}); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("replay reached the server (%d requests)", requests)
+	}
+
+	// Replay clients get the same setup as live ones.
+	t.Setenv(readOnlyEnv, "1")
+
+	if err := Execute([]string{"--replay", cassette, "tags", "delete", "tag_1"}); !errors.Is(err, api.ErrReadOnly) {
+		t.Fatalf("read-only replay: err = %v", err)
+	}
+
+	t.Setenv(readOnlyEnv, "")
+
+	t.Setenv(vcrEnv, "replay:"+cassette)
+
+	if err := Execute([]string{"--json", "tags", "get", "tag_2"}); err == nil {
+		t.Fatal("replayed a request that was never recorded")
+	}
+}
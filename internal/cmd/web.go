@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/errfmt"
+)
+
+// openURL opens a link in the browser; tests replace it.
+var openURL = auth.OpenBrowser
+
+type ConvOpenWebCmd struct {
+	ID string `arg:"" help:"Conversation ID"`
+}
+
+func (c *ConvOpenWebCmd) Run(flags *RootFlags) error {
+	ctx := context.Background()
+
+	client, err := getClient(flags)
+	if err != nil {
+		return err
+	}
+
+	conv, err := client.GetConversation(ctx, c.ID)
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.Format(err))
+
+		return err
+	}
+
+	return openWeb(conv.WebURL())
+}
+
+// openWeb prints a Front web app link (handy over SSH) and opens it.
+func openWeb(link string) error {
+	fmt.Fprintln(os.Stdout, link)
+
+	return openURL(link)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConvOpenWebUsesSelfLink(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Front resolves alt: IDs; the self link carries the canonical ID.
+		_, _ = w.Write([]byte(`{"id":"cnv_9","_links":{"self":"https://api2.frontapp.com/conversations/cnv_9"}}`))
+	}))
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	var opened string
+
+	old := openURL
+	openURL = func(link string) error {
+		opened = link
+
+		return nil
+	}
+
+	t.Cleanup(func() { openURL = old })
+
+	if err := (&ConvOpenWebCmd{ID: "alt:ref:order-42"}).Run(&RootFlags{Account: "test@example.com", NoCache: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if opened != "https://app.frontapp.com/open/cnv_9" {
+		t.Fatalf("opened %q", opened)
+	}
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
-	"github.com/dedene/frontapp-cli/internal/auth"
+	"github.com/dedene/frontapp-cli/internal/api"
 	"github.com/dedene/frontapp-cli/internal/errfmt"
-	"github.com/dedene/frontapp-cli/internal/output"
 )
 
 type WhoamiCmd struct{}
@@ -33,68 +33,76 @@ func (c *WhoamiCmd) Run(flags *RootFlags) error {
 		return err
 	}
 
-	// Try to find authenticated teammate
-	var teammate *struct {
-		ID        string `json:"id"`
-		Email     string `json:"email"`
-		Username  string `json:"username"`
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
-		IsAdmin   bool   `json:"is_admin"`
+	// The account this command ran as (--account, FRONT_ACCOUNT or default)
+	storedEmail, clientName, err := resolveAccount(flags)
+	if err != nil {
+		return err
 	}
 
-	// Get stored email from auth
-	storedEmail, _ := auth.GetAuthenticatedEmail(flags.Client)
-
-	// Try to find matching teammate
-	teammates, err := client.ListTeammates(ctx)
-	if err == nil {
-		for _, t := range teammates.Results {
-			if t.Email == storedEmail {
-				teammate = &struct {
-					ID        string `json:"id"`
-					Email     string `json:"email"`
-					Username  string `json:"username"`
-					FirstName string `json:"first_name"`
-					LastName  string `json:"last_name"`
-					IsAdmin   bool   `json:"is_admin"`
-				}{
-					ID:        t.ID,
-					Email:     t.Email,
-					Username:  t.Username,
-					FirstName: t.FirstName,
-					LastName:  t.LastName,
-					IsAdmin:   t.IsAdmin,
-				}
-
-				break
-			}
-		}
+	// Try to find the authenticated teammate; a teammate list the token
+	// cannot read just leaves it out.
+	var teammate *api.Teammate
+
+	if teammates, err := client.Teammates(ctx); err == nil {
+		teammate = findTeammate(teammates, me, storedEmail)
 	}
 
 	if mode.JSON {
 		result := map[string]any{
-			"account": me,
+			"account":        me,
+			"active_account": storedEmail,
+			"client":         clientName,
+			"api_url":        client.BaseURL(),
 		}
 		if teammate != nil {
 			result["teammate"] = teammate
 		}
 
-		return output.WriteJSON(os.Stdout, result)
+		return mode.Write(os.Stdout, result)
 	}
 
 	// Show account info
 	fmt.Fprintf(os.Stdout, "Account:   %s\n", me.ID)
 
+	if me.Name != "" {
+		fmt.Fprintf(os.Stdout, "Company:   %s\n", me.Name)
+	}
+
+	fmt.Fprintf(os.Stdout, "Active:    %s (client: %s)\n", storedEmail, clientName)
+	fmt.Fprintf(os.Stdout, "API:       %s\n", client.BaseURL())
+
 	// Show teammate info if found
 	if teammate != nil {
 		fmt.Fprintf(os.Stdout, "Teammate:  %s\n", teammate.ID)
 		fmt.Fprintf(os.Stdout, "Email:     %s\n", teammate.Email)
 		fmt.Fprintf(os.Stdout, "Username:  %s\n", teammate.Username)
-		fmt.Fprintf(os.Stdout, "Name:      %s %s\n", teammate.FirstName, teammate.LastName)
+		fmt.Fprintf(os.Stdout, "Name:      %s\n", strings.TrimSpace(teammate.FirstName+" "+teammate.LastName))
 		fmt.Fprintf(os.Stdout, "Admin:     %v\n", teammate.IsAdmin)
-	} else if storedEmail != "" {
-		fmt.Fprintf(os.Stdout, "Email:     %s (stored)\n", storedEmail)
+		fmt.Fprintf(os.Stdout, "Available: %v\n", teammate.IsAvailable)
+	}
+
+	return nil
+}
+
+// findTeammate picks the teammate behind the token: the one /me names, else
+// the one whose email matches /me or the stored account.
+func findTeammate(teammates []api.Teammate, me *api.Me, storedEmail string) *api.Teammate {
+	for _, t := range teammates {
+		if t.ID == me.ID {
+			return &t
+		}
+	}
+
+	for _, email := range []string{me.Email, storedEmail} {
+		if email == "" {
+			continue
+		}
+
+		for _, t := range teammates {
+			if strings.EqualFold(t.Email, email) {
+				return &t
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// newWhoamiServer answers /me with a teammate ID but no email, as OAuth
+// tokens do, and lists two teammates.
+func newWhoamiServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/me":
+			_, _ = io.WriteString(w, `{"id":"tea_2","name":"Acme Support"}`)
+		case "/teammates":
+			_, _ = io.WriteString(w, `{"_results":[
+				{"id":"tea_1","email":"alice@example.com","first_name":"Alice"},
+				{"id":"tea_2","email":"bob@example.com","username":"bob","first_name":"Bob","last_name":"Baker","is_admin":true}
+			]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestWhoamiShowsTeammateAndCompany(t *testing.T) {
+	srv := newWhoamiServer(t)
+	defer srv.Close()
+
+	useTestServer(t, srv)
+
+	out, err := captureStdout(t, func() error {
+		cmd := WhoamiCmd{}
+
+		return cmd.Run(&RootFlags{Account: "test@example.com"})
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for _, want := range []string{"Company:   Acme Support", "Teammate:  tea_2", "Name:      Bob Baker", "Admin:     true", "API:       " + srv.URL} {
+		if !strings.Contains(out, want) {
+			t.Errorf("whoami output misses %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFetchEmailMatchesTeammateFromMe(t *testing.T) {
+	srv := newWhoamiServer(t)
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+
+	email, err := fetchEmail(context.Background(), client)
+	if err != nil {
+		t.Fatalf("fetchEmail: %v", err)
+	}
+
+	if email != "bob@example.com" {
+		t.Fatalf("email = %q, want bob@example.com", email)
+	}
+}
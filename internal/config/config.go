@@ -13,6 +13,45 @@ type File struct {
 	AccountDomains map[string]string `yaml:"account_domains,omitempty"`
 	DefaultOutput  string            `yaml:"default_output,omitempty"`
 	Timezone       string            `yaml:"timezone,omitempty"`
+	TokenStore     string            `yaml:"token_store,omitempty"`
+	MaxRetries     *int              `yaml:"max_retries,omitempty"`
+	RetryBaseDelay string            `yaml:"retry_base_delay,omitempty"`
+	RetryBudget    string            `yaml:"retry_budget,omitempty"`
+	RequestTimeout string            `yaml:"request_timeout,omitempty"`
+	Throttle       string            `yaml:"throttle,omitempty"`
+
+	// The circuit breaker pauses requests for CircuitBreakerCooldown after
+	// CircuitBreakerThreshold server errors in a row.
+	CircuitBreakerThreshold *int   `yaml:"circuit_breaker_threshold,omitempty"`
+	CircuitBreakerCooldown  string `yaml:"circuit_breaker_cooldown,omitempty"`
+
+	// Profiles are named sets of flag defaults, selected with --profile:
+	// profile name → flag name (without dashes) → value.
+	Profiles map[string]map[string]string `yaml:"profiles,omitempty"`
+
+	// CommandAliases expand a first word into a command line, e.g.
+	// mine → "conversations list --assignee me".
+	CommandAliases map[string]string `yaml:"command_aliases,omitempty"`
+
+	// SavedSearches are named conversation searches (conv search --saved).
+	SavedSearches map[string]SavedSearch `yaml:"saved_searches,omitempty"`
+}
+
+// SavedSearch holds the filters of a conversation search as typed, so names
+// and relative dates ("3d") are resolved again each time it runs.
+type SavedSearch struct {
+	Query      string   `yaml:"query,omitempty" json:"query,omitempty"`
+	RawQuery   string   `yaml:"raw_query,omitempty" json:"raw_query,omitempty"`
+	From       string   `yaml:"from,omitempty" json:"from,omitempty"`
+	To         string   `yaml:"to,omitempty" json:"to,omitempty"`
+	Recipient  string   `yaml:"recipient,omitempty" json:"recipient,omitempty"`
+	Inbox      string   `yaml:"inbox,omitempty" json:"inbox,omitempty"`
+	Tag        []string `yaml:"tag,omitempty" json:"tag,omitempty"`
+	Status     string   `yaml:"status,omitempty" json:"status,omitempty"`
+	Assignee   string   `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Unassigned bool     `yaml:"unassigned,omitempty" json:"unassigned,omitempty"`
+	Before     string   `yaml:"before,omitempty" json:"before,omitempty"`
+	After      string   `yaml:"after,omitempty" json:"after,omitempty"`
 }
 
 func ConfigExists() (bool, error) {
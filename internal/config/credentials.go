@@ -22,6 +22,10 @@ type OAuthCredentials struct {
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 	RedirectURI  string `json:"redirect_uri,omitempty"`
+
+	// APIBaseURL points the client at another API host, such as a Front
+	// region or a staging or mock server. Empty means the public API.
+	APIBaseURL string `json:"api_base_url,omitempty"`
 }
 
 // NormalizeClientName validates and normalizes a client name.
@@ -0,0 +1,443 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errUnknownKey     = errors.New("unknown config key")
+	errInvalidValue   = errors.New("invalid config value")
+	errUnknownProfile = errors.New("unknown profile")
+)
+
+// outputFormats are the accepted default_output values ("text" and "plain"
+// are older names for table and tsv).
+var outputFormats = []string{"table", "text", "json", "yaml", "csv", "tsv", "ndjson", "plain"}
+
+// tokenStores mirrors the backends in the auth package.
+var tokenStores = []string{"keyring", "encrypted-file"}
+
+// throttleStrategies mirrors the strategies in the api package.
+var throttleStrategies = []string{"aggressive", "balanced", "conservative"}
+
+// Map keys are addressed as prefix.name: aliases.work maps an alias to an
+// account email, domains.example.com maps a domain to an OAuth client, and
+// profiles.triage.limit sets a flag default in the triage profile.
+const (
+	aliasPrefix   = "aliases."
+	domainPrefix  = "domains."
+	profilePrefix = "profiles."
+)
+
+// scalarKey is a plain config.yaml setting.
+type scalarKey struct {
+	get func(*File) string
+	set func(*File, string) error
+}
+
+var scalarKeys = map[string]scalarKey{
+	"default_account": {
+		get: func(f *File) string { return f.DefaultAccount },
+		set: func(f *File, v string) error {
+			f.DefaultAccount = strings.TrimSpace(v)
+
+			return nil
+		},
+	},
+	"default_output": {
+		get: func(f *File) string { return f.DefaultOutput },
+		set: func(f *File, v string) error {
+			v = strings.ToLower(strings.TrimSpace(v))
+			if v != "" && !slices.Contains(outputFormats, v) {
+				return fmt.Errorf("%w: default_output %q (use %s)", errInvalidValue, v, strings.Join(outputFormats, ", "))
+			}
+
+			f.DefaultOutput = v
+
+			return nil
+		},
+	},
+	"timezone": {
+		get: func(f *File) string { return f.Timezone },
+		set: func(f *File, v string) error {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				if _, err := time.LoadLocation(v); err != nil {
+					return fmt.Errorf("%w: timezone %q: %w", errInvalidValue, v, err)
+				}
+			}
+
+			f.Timezone = v
+
+			return nil
+		},
+	},
+	"token_store": {
+		get: func(f *File) string { return f.TokenStore },
+		set: func(f *File, v string) error {
+			v = strings.ToLower(strings.TrimSpace(v))
+			if v != "" && !slices.Contains(tokenStores, v) {
+				return fmt.Errorf("%w: token_store %q (use %s)", errInvalidValue, v, strings.Join(tokenStores, " or "))
+			}
+
+			f.TokenStore = v
+
+			return nil
+		},
+	},
+	"max_retries": {
+		get: func(f *File) string {
+			if f.MaxRetries == nil {
+				return ""
+			}
+
+			return strconv.Itoa(*f.MaxRetries)
+		},
+		set: func(f *File, v string) error {
+			if strings.TrimSpace(v) == "" {
+				f.MaxRetries = nil
+
+				return nil
+			}
+
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil || n < 0 {
+				return fmt.Errorf("%w: max_retries %q (use a number >= 0)", errInvalidValue, v)
+			}
+
+			f.MaxRetries = &n
+
+			return nil
+		},
+	},
+	"retry_base_delay": {
+		get: func(f *File) string { return f.RetryBaseDelay },
+		set: func(f *File, v string) error {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+					return fmt.Errorf("%w: retry_base_delay %q (use a duration such as 500ms or 2s)", errInvalidValue, v)
+				}
+			}
+
+			f.RetryBaseDelay = v
+
+			return nil
+		},
+	},
+	"retry_budget": {
+		get: func(f *File) string { return f.RetryBudget },
+		set: func(f *File, v string) error {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+					return fmt.Errorf("%w: retry_budget %q (use a duration such as 30s or 2m)", errInvalidValue, v)
+				}
+			}
+
+			f.RetryBudget = v
+
+			return nil
+		},
+	},
+	"request_timeout": {
+		get: func(f *File) string { return f.RequestTimeout },
+		set: func(f *File, v string) error {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+					return fmt.Errorf("%w: request_timeout %q (use a duration such as 30s or 2m)", errInvalidValue, v)
+				}
+			}
+
+			f.RequestTimeout = v
+
+			return nil
+		},
+	},
+	"throttle": {
+		get: func(f *File) string { return f.Throttle },
+		set: func(f *File, v string) error {
+			v = strings.ToLower(strings.TrimSpace(v))
+			if v != "" && !slices.Contains(throttleStrategies, v) {
+				return fmt.Errorf("%w: throttle %q (use %s)", errInvalidValue, v, strings.Join(throttleStrategies, ", "))
+			}
+
+			f.Throttle = v
+
+			return nil
+		},
+	},
+	"circuit_breaker_threshold": {
+		get: func(f *File) string {
+			if f.CircuitBreakerThreshold == nil {
+				return ""
+			}
+
+			return strconv.Itoa(*f.CircuitBreakerThreshold)
+		},
+		set: func(f *File, v string) error {
+			if strings.TrimSpace(v) == "" {
+				f.CircuitBreakerThreshold = nil
+
+				return nil
+			}
+
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil || n < 1 {
+				return fmt.Errorf("%w: circuit_breaker_threshold %q (use a number >= 1)", errInvalidValue, v)
+			}
+
+			f.CircuitBreakerThreshold = &n
+
+			return nil
+		},
+	},
+	"circuit_breaker_cooldown": {
+		get: func(f *File) string { return f.CircuitBreakerCooldown },
+		set: func(f *File, v string) error {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+					return fmt.Errorf("%w: circuit_breaker_cooldown %q (use a duration such as 30s or 2m)", errInvalidValue, v)
+				}
+			}
+
+			f.CircuitBreakerCooldown = v
+
+			return nil
+		},
+	},
+}
+
+// Entry is one set config value.
+type Entry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// KeyNames lists the settable keys, with the map keys as patterns.
+func KeyNames() []string {
+	names := make([]string, 0, len(scalarKeys)+2)
+	for name := range scalarKeys {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return append(names, aliasPrefix+"<alias>", domainPrefix+"<domain>", profilePrefix+"<profile>.<flag>")
+}
+
+// Get returns the value of key: a scalar setting, one alias or domain
+// mapping, or a whole map ("aliases", "domains") as name=value lines.
+func (f *File) Get(key string) (string, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+
+	if k, ok := scalarKeys[key]; ok {
+		return k.get(f), nil
+	}
+
+	switch {
+	case key == "aliases" || key == "account_aliases":
+		return joinMap(f.AccountAliases), nil
+	case key == "domains" || key == "account_domains":
+		return joinMap(f.AccountDomains), nil
+	case key == "profiles":
+		names := slices.Sorted(maps.Keys(f.Profiles))
+
+		return strings.Join(names, "\n"), nil
+	case strings.HasPrefix(key, aliasPrefix):
+		return f.AccountAliases[NormalizeAccountAlias(strings.TrimPrefix(key, aliasPrefix))], nil
+	case strings.HasPrefix(key, domainPrefix):
+		domain, err := NormalizeDomain(strings.TrimPrefix(key, domainPrefix))
+		if err != nil {
+			return "", err
+		}
+
+		return f.AccountDomains[domain], nil
+	case strings.HasPrefix(key, profilePrefix):
+		name, flag, ok := strings.Cut(strings.TrimPrefix(key, profilePrefix), ".")
+		if !ok {
+			return joinMap(f.Profiles[name]), nil
+		}
+
+		return f.Profiles[name][flag], nil
+	}
+
+	return "", unknownKey(key)
+}
+
+// Set validates and stores value under key. An empty value removes the
+// setting.
+func (f *File) Set(key, value string) error {
+	key = strings.ToLower(strings.TrimSpace(key))
+
+	if k, ok := scalarKeys[key]; ok {
+		return k.set(f, value)
+	}
+
+	switch {
+	case strings.HasPrefix(key, aliasPrefix):
+		alias := NormalizeAccountAlias(strings.TrimPrefix(key, aliasPrefix))
+		if alias == "" {
+			return errEmptyAlias
+		}
+
+		return setMapEntry(&f.AccountAliases, alias, value, normalizeEmail)
+	case strings.HasPrefix(key, domainPrefix):
+		domain, err := NormalizeDomain(strings.TrimPrefix(key, domainPrefix))
+		if err != nil {
+			return err
+		}
+
+		return setMapEntry(&f.AccountDomains, domain, value, NormalizeClientName)
+	case strings.HasPrefix(key, profilePrefix):
+		return f.setProfileFlag(strings.TrimPrefix(key, profilePrefix), value)
+	}
+
+	return unknownKey(key)
+}
+
+// Entries lists every set value, sorted by key.
+func (f *File) Entries() []Entry {
+	var entries []Entry
+
+	for name, k := range scalarKeys {
+		if v := k.get(f); v != "" {
+			entries = append(entries, Entry{Key: name, Value: v})
+		}
+	}
+
+	for alias, email := range f.AccountAliases {
+		entries = append(entries, Entry{Key: aliasPrefix + alias, Value: email})
+	}
+
+	for domain, email := range f.AccountDomains {
+		entries = append(entries, Entry{Key: domainPrefix + domain, Value: email})
+	}
+
+	for name, profile := range f.Profiles {
+		for flag, value := range profile {
+			entries = append(entries, Entry{Key: profilePrefix + name + "." + flag, Value: value})
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b Entry) int { return strings.Compare(a.Key, b.Key) })
+
+	return entries
+}
+
+// Validate checks every value as Set would, e.g. after a hand edit.
+func (f *File) Validate() error {
+	var scratch File
+
+	for _, e := range f.Entries() {
+		if err := scratch.Set(e.Key, e.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Profile returns the flag defaults of the named profile.
+func (f *File) Profile(name string) (map[string]string, error) {
+	profile, ok := f.Profiles[name]
+	if !ok {
+		profile, ok = f.Profiles[strings.ToLower(name)]
+	}
+
+	if !ok {
+		names := slices.Sorted(maps.Keys(f.Profiles))
+
+		return nil, fmt.Errorf("%w %q (configured: %s)", errUnknownProfile, name, strings.Join(names, ", "))
+	}
+
+	return profile, nil
+}
+
+// setProfileFlag sets "name.flag" in a profile; an empty value removes the
+// flag, and the profile once it has none left.
+func (f *File) setProfileFlag(key, value string) error {
+	name, flag, ok := strings.Cut(key, ".")
+	flag = strings.TrimLeft(flag, "-")
+
+	if !ok || name == "" || flag == "" {
+		return fmt.Errorf("%w %q (use profiles.<profile>.<flag>)", errUnknownKey, profilePrefix+key)
+	}
+
+	if value == "" {
+		delete(f.Profiles[name], flag)
+
+		if len(f.Profiles[name]) == 0 {
+			delete(f.Profiles, name)
+		}
+
+		return nil
+	}
+
+	if f.Profiles == nil {
+		f.Profiles = map[string]map[string]string{}
+	}
+
+	if f.Profiles[name] == nil {
+		f.Profiles[name] = map[string]string{}
+	}
+
+	f.Profiles[name][flag] = value
+
+	return nil
+}
+
+// setMapEntry stores the normalized value in m, or deletes the entry when
+// value is empty.
+func setMapEntry(m *map[string]string, name, value string, normalize func(string) (string, error)) error {
+	if strings.TrimSpace(value) == "" {
+		delete(*m, name)
+
+		return nil
+	}
+
+	value, err := normalize(value)
+	if err != nil {
+		return err
+	}
+
+	if *m == nil {
+		*m = map[string]string{}
+	}
+
+	(*m)[name] = value
+
+	return nil
+}
+
+func normalizeEmail(raw string) (string, error) {
+	email := strings.ToLower(strings.TrimSpace(raw))
+	if !strings.Contains(email, "@") {
+		return "", fmt.Errorf("%w: %q is not an account email", errInvalidValue, raw)
+	}
+
+	return email, nil
+}
+
+func joinMap(m map[string]string) string {
+	lines := make([]string, 0, len(m))
+	for k, v := range m {
+		lines = append(lines, k+"="+v)
+	}
+
+	slices.Sort(lines)
+
+	return strings.Join(lines, "\n")
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("%w %q (known keys: %s)", errUnknownKey, key, strings.Join(KeyNames(), ", "))
+}
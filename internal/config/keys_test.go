@@ -0,0 +1,79 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFileSetGet(t *testing.T) {
+	var cfg File
+
+	sets := map[string]string{
+		"default_account":       "Me@Example.com",
+		"default_output":        "JSON",
+		"timezone":              "Europe/Brussels",
+		"max_retries":           "5",
+		"aliases.Work":          "me@work.example",
+		"domains.example.com":   "Corp",
+		"profiles.triage.limit": "50",
+	}
+
+	for key, value := range sets {
+		if err := cfg.Set(key, value); err != nil {
+			t.Fatalf("Set(%q): %v", key, err)
+		}
+	}
+
+	want := map[string]string{
+		"default_output":      "json",
+		"max_retries":         "5",
+		"aliases.work":        "me@work.example",
+		"domains.example.com": "corp",
+		"aliases":             "work=me@work.example",
+		"profiles.triage":     "limit=50",
+	}
+
+	for key, value := range want {
+		got, err := cfg.Get(key)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", key, err)
+		}
+
+		if got != value {
+			t.Fatalf("Get(%q) = %q, want %q", key, got, value)
+		}
+	}
+
+	if len(cfg.Entries()) != len(sets) {
+		t.Fatalf("Entries() = %v, want %d entries", cfg.Entries(), len(sets))
+	}
+
+	if err := cfg.Set("aliases.work", ""); err != nil || cfg.AccountAliases["work"] != "" {
+		t.Fatalf("clearing alias: err=%v aliases=%v", err, cfg.AccountAliases)
+	}
+}
+
+func TestFileSetRejectsInvalidValues(t *testing.T) {
+	var cfg File
+
+	for key, value := range map[string]string{
+		"default_output":            "xml",
+		"timezone":                  "Mars/Olympus",
+		"max_retries":               "-1",
+		"retry_base_delay":          "soon",
+		"request_timeout":           "0s",
+		"token_store":               "disk",
+		"throttle":                  "reckless",
+		"circuit_breaker_threshold": "0",
+		"circuit_breaker_cooldown":  "never",
+		"aliases.work":              "not-an-email",
+	} {
+		if err := cfg.Set(key, value); err == nil {
+			t.Fatalf("Set(%q, %q) succeeded", key, value)
+		}
+	}
+
+	if _, err := cfg.Get("colour"); !errors.Is(err, errUnknownKey) {
+		t.Fatalf("Get(unknown) error = %v, want errUnknownKey", err)
+	}
+}
@@ -84,6 +84,16 @@ func EnsureKeyringDir() (string, error) {
 	return dir, nil
 }
 
+// TokenFilePath is where the encrypted-file token store keeps its tokens.
+func TokenFilePath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "tokens.enc"), nil
+}
+
 func AttachmentsDir() (string, error) {
 	dir, err := Dir()
 	if err != nil {
@@ -106,6 +116,49 @@ func EnsureAttachmentsDir() (string, error) {
 	return dir, nil
 }
 
+// CacheDir holds cached API responses (see api.ResponseCache).
+func CacheDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "cache"), nil
+}
+
+// MirrorDir holds the local copies made by 'frontcli sync', one directory
+// per account.
+func MirrorDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "mirror"), nil
+}
+
+// UndoDir holds the undo journal of the last batch action, one file per
+// account (see 'frontcli undo').
+func UndoDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "undo"), nil
+}
+
+// HistoryPath is the append-only audit log of the API calls that changed
+// data (see 'frontcli history').
+func HistoryPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
 // ExpandPath expands ~ at the beginning of a path to the user's home directory.
 func ExpandPath(path string) (string, error) {
 	if path == "" {
@@ -0,0 +1,223 @@
+// Package dates parses the dates and times people type on the command line:
+// absolute timestamps, calendar dates, and relative forms such as "3d",
+// "yesterday" or "last monday".
+package dates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Direction says which way a bare offset ("3d") or weekday ("monday") points:
+// filters look back, scheduling looks ahead.
+type Direction int
+
+const (
+	Past Direction = iota
+	Future
+)
+
+var errUnrecognized = errors.New("unrecognized date")
+
+// absoluteLayouts are tried in order; layouts without a zone are read in the
+// caller's location.
+var absoluteLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+var offsetPattern = regexp.MustCompile(`^([+-]?)(\d+)\s*([a-z]+)$`)
+
+// Parse interprets value relative to now, in now's location. It accepts:
+//
+//   - Unix seconds: 1700000000
+//   - RFC3339: 2024-01-15T14:00:00Z
+//   - a date, optionally with a time: 2024-01-15, 2024-01-15 14:00
+//   - now, today, yesterday, tomorrow, optionally with a time: tomorrow 9:00
+//   - a weekday: monday, last monday, next friday 14:00
+//   - an offset: 30m, 5h, 3d, 2w, 1mo, 1y, "2 weeks", "3d ago", "in 2h"
+//
+// Bare offsets and weekdays point in the given direction; "ago", "in",
+// "last", "next", "+" and "-" make it explicit. Dates without a time mean
+// midnight.
+func Parse(value string, now time.Time, dir Direction) (time.Time, error) {
+	value = strings.ToLower(strings.Join(strings.Fields(value), " "))
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%w: empty", errUnrecognized)
+	}
+
+	if secs, err := strconv.ParseFloat(value, 64); err == nil {
+		whole := int64(secs)
+
+		return time.Unix(whole, int64((secs-float64(whole))*1e9)).In(now.Location()), nil
+	}
+
+	upper := strings.ToUpper(value)
+
+	if t, err := time.Parse(time.RFC3339, upper); err == nil {
+		return t, nil
+	}
+
+	for _, layout := range absoluteLayouts {
+		if t, err := time.ParseInLocation(layout, upper, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if t, ok := parseOffset(value, now, dir); ok {
+		return t, nil
+	}
+
+	day, clock, _ := strings.Cut(value, " ")
+	if strings.HasPrefix(value, "last ") || strings.HasPrefix(value, "next ") {
+		var rest string
+
+		day, rest, _ = strings.Cut(value, " ")
+		weekday, clockPart, _ := strings.Cut(rest, " ")
+		day, clock = day+" "+weekday, clockPart
+	}
+
+	t, ok := parseDay(day, now, dir)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w %q", errUnrecognized, value)
+	}
+
+	if clock == "" {
+		return t, nil
+	}
+
+	hour, minute, ok := parseClock(clock)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w %q: bad time of day %q", errUnrecognized, value, clock)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location()), nil
+}
+
+// parseOffset handles "3d", "-2w", "+1h", "3 days ago" and "in 2 hours".
+func parseOffset(value string, now time.Time, dir Direction) (time.Time, bool) {
+	switch {
+	case strings.HasSuffix(value, " ago"):
+		value, dir = strings.TrimSuffix(value, " ago"), Past
+	case strings.HasPrefix(value, "in "):
+		value, dir = strings.TrimPrefix(value, "in "), Future
+	}
+
+	m := offsetPattern.FindStringSubmatch(strings.ReplaceAll(value, " ", ""))
+	if m == nil {
+		return time.Time{}, false
+	}
+
+	switch m[1] {
+	case "-":
+		dir = Past
+	case "+":
+		dir = Future
+	}
+
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if dir == Past {
+		n = -n
+	}
+
+	switch m[3] {
+	case "m", "min", "mins", "minute", "minutes":
+		return now.Add(time.Duration(n) * time.Minute), true
+	case "h", "hr", "hrs", "hour", "hours":
+		return now.Add(time.Duration(n) * time.Hour), true
+	case "d", "day", "days":
+		return now.AddDate(0, 0, n), true
+	case "w", "wk", "wks", "week", "weeks":
+		return now.AddDate(0, 0, 7*n), true
+	case "mo", "month", "months":
+		return now.AddDate(0, n, 0), true
+	case "y", "yr", "yrs", "year", "years":
+		return now.AddDate(n, 0, 0), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// parseDay returns midnight of the named day ("now" keeps the time).
+func parseDay(day string, now time.Time, dir Direction) (time.Time, bool) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch day {
+	case "now":
+		return now, true
+	case "today":
+		return midnight, true
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), true
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), true
+	}
+
+	if name, ok := strings.CutPrefix(day, "last "); ok {
+		day, dir = name, Past
+	} else if name, ok := strings.CutPrefix(day, "next "); ok {
+		day, dir = name, Future
+	}
+
+	weekday, ok := parseWeekday(day)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	// The nearest such day strictly before (or after) today.
+	if dir == Past {
+		diff := (int(midnight.Weekday()) - int(weekday) + 7) % 7
+		if diff == 0 {
+			diff = 7
+		}
+
+		return midnight.AddDate(0, 0, -diff), true
+	}
+
+	diff := (int(weekday) - int(midnight.Weekday()) + 7) % 7
+	if diff == 0 {
+		diff = 7
+	}
+
+	return midnight.AddDate(0, 0, diff), true
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseClock parses "14:00", "9:30" or "9".
+func parseClock(clock string) (hour, minute int, ok bool) {
+	h, m, hasMinutes := strings.Cut(clock, ":")
+
+	hour, err := strconv.Atoi(h)
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, false
+	}
+
+	if hasMinutes {
+		if minute, err = strconv.Atoi(m); err != nil || minute < 0 || minute > 59 {
+			return 0, 0, false
+		}
+	}
+
+	return hour, minute, true
+}
@@ -0,0 +1,66 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	brussels, err := time.LoadLocation("Europe/Brussels")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	// Wednesday.
+	now := time.Date(2024, 1, 17, 10, 30, 0, 0, brussels)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, brussels)
+	}
+
+	tests := []struct {
+		in   string
+		dir  Direction
+		want time.Time
+	}{
+		{"1700000000", Past, time.Unix(1700000000, 0)},
+		{"2024-01-15T14:00:00Z", Past, time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)},
+		{"2024-01-15", Past, at(15, 0, 0)},
+		{"2024-01-15 14:00", Past, at(15, 14, 0)},
+		{"now", Past, now},
+		{"today", Past, at(17, 0, 0)},
+		{"Yesterday", Past, at(16, 0, 0)},
+		{"tomorrow 9:00", Future, at(18, 9, 0)},
+		{"3d", Past, at(14, 10, 30)},
+		{"3d", Future, at(20, 10, 30)},
+		{"2w", Past, time.Date(2024, 1, 3, 10, 30, 0, 0, brussels)},
+		{"5h", Past, at(17, 5, 30)},
+		{"+30m", Past, at(17, 11, 0)},
+		{"2 days ago", Future, at(15, 10, 30)},
+		{"in 2 hours", Past, at(17, 12, 30)},
+		{"monday", Past, at(15, 0, 0)},
+		{"monday", Future, at(22, 0, 0)},
+		{"last wed", Future, at(10, 0, 0)},
+		{"next friday 14:00", Past, at(19, 14, 0)},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in, now, tt.dir)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+
+			continue
+		}
+
+		if !got.Equal(tt.want) {
+			t.Errorf("Parse(%q, %v) = %v, want %v", tt.in, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestParseRejectsGarbage(t *testing.T) {
+	for _, in := range []string{"", "yesterday-ish", "3 fortnights", "monday 25:00"} {
+		if got, err := Parse(in, time.Now(), Past); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", in, got)
+		}
+	}
+}
@@ -20,6 +20,11 @@ func Format(err error) string {
 		return formatWrongResourceTypeError(wrongTypeErr)
 	}
 
+	var nameErr *api.NameResolutionError
+	if errors.As(err, &nameErr) {
+		return formatNameResolutionError(nameErr)
+	}
+
 	var apiErr *api.APIError
 	if errors.As(err, &apiErr) {
 		return formatAPIError(apiErr)
@@ -37,7 +42,7 @@ func Format(err error) string {
 
 	var circuitBreakerErr *api.CircuitBreakerError
 	if errors.As(err, &circuitBreakerErr) {
-		return formatCircuitBreakerError()
+		return formatCircuitBreakerError(circuitBreakerErr)
 	}
 
 	if errors.Is(err, auth.ErrNotAuthenticated) {
@@ -118,11 +123,12 @@ func formatRateLimitError(err *api.RateLimitError) string {
 	return sb.String()
 }
 
-func formatCircuitBreakerError() string {
+func formatCircuitBreakerError(err *api.CircuitBreakerError) string {
 	var sb strings.Builder
 
 	sb.WriteString("Error: Service temporarily unavailable\n\n")
-	sb.WriteString("  Too many consecutive failures. Please wait and try again.\n")
+	fmt.Fprintf(&sb, "  Front returned %d server errors in a row, so requests are paused for %s.\n", err.Failures, err.RetryIn)
+	sb.WriteString("  Wait and try again, or use --no-circuit-breaker to send requests anyway.\n")
 
 	return sb.String()
 }
@@ -152,6 +158,46 @@ func formatWrongResourceTypeError(err *api.WrongResourceTypeError) string {
 	return sb.String()
 }
 
+func formatNameResolutionError(err *api.NameResolutionError) string {
+	var sb strings.Builder
+
+	if len(err.Matches) > 1 {
+		sb.WriteString(fmt.Sprintf("Error: '%s' matches more than one %s\n\n", err.Name, err.Resource))
+
+		for _, match := range err.Matches {
+			sb.WriteString("    " + match + "\n")
+		}
+
+		sb.WriteString("\n  Use the ID instead.\n")
+
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Error: No %s named '%s'\n", err.Resource, err.Name))
+
+	if list := getListCommandForResource(err.Resource); list != "" {
+		sb.WriteString(fmt.Sprintf("\n  Try: %s\n", list))
+	}
+
+	return sb.String()
+}
+
+// getListCommandForResource returns the CLI command listing resources of a type.
+func getListCommandForResource(resourceType string) string {
+	switch resourceType {
+	case "inbox":
+		return "frontcli inboxes list"
+	case "tag":
+		return "frontcli tags list"
+	case "teammate":
+		return "frontcli teammates list"
+	case "team":
+		return "frontcli teams list"
+	default:
+		return ""
+	}
+}
+
 // getWrongIDTypeHint returns a hint if the ID has a wrong prefix for the expected resource.
 func getWrongIDTypeHint(id, expectedResource string) string {
 	actualType := api.GetResourceType(id)
@@ -187,8 +233,22 @@ func getSuggestionForResource(resourceType, id string) string {
 		return fmt.Sprintf("frontcli tags get %s", id)
 	case "inbox":
 		return fmt.Sprintf("frontcli inboxes get %s", id)
+	case "team":
+		return fmt.Sprintf("frontcli teams get %s", id)
+	case "account":
+		return fmt.Sprintf("frontcli accounts get %s", id)
 	case "channel":
 		return fmt.Sprintf("frontcli channels get %s", id)
+	case "event":
+		return fmt.Sprintf("frontcli events get %s", id)
+	case "rule":
+		return fmt.Sprintf("frontcli rules get %s", id)
+	case "shift":
+		return fmt.Sprintf("frontcli shifts get %s", id)
+	case "template":
+		return fmt.Sprintf("frontcli templates get %s", id)
+	case "template folder":
+		return fmt.Sprintf("frontcli templates folders get %s", id)
 	default:
 		return ""
 	}
@@ -37,6 +37,32 @@ func TestFormat_WrongResourceTypeError(t *testing.T) {
 	}
 }
 
+func TestFormat_NameResolutionError(t *testing.T) {
+	result := Format(&api.NameResolutionError{Resource: "tag", Name: "VIP"})
+
+	if !strings.Contains(result, "No tag named 'VIP'") {
+		t.Errorf("expected not-found message in result, got: %s", result)
+	}
+
+	if !strings.Contains(result, "frontcli tags list") {
+		t.Errorf("expected list suggestion in result, got: %s", result)
+	}
+
+	result = Format(&api.NameResolutionError{
+		Resource: "tag",
+		Name:     "vip",
+		Matches:  []string{"tag_1 (VIP)", "tag_2 (Vip)"},
+	})
+
+	if !strings.Contains(result, "matches more than one tag") {
+		t.Errorf("expected ambiguity message in result, got: %s", result)
+	}
+
+	if !strings.Contains(result, "tag_1 (VIP)") || !strings.Contains(result, "tag_2 (Vip)") {
+		t.Errorf("expected candidates in result, got: %s", result)
+	}
+}
+
 func TestFormat_APIError404WithWrongPrefix(t *testing.T) {
 	err := &api.APIError{
 		StatusCode:       404,
@@ -0,0 +1,234 @@
+// Package export renders Front messages as standard mail files (RFC 5322
+// messages and mbox archives).
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// File is an attachment's downloaded content.
+type File struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// WriteEML writes msg as an RFC 5322 message. The body is sent as
+// multipart/alternative when both text and HTML are available, and wrapped in
+// multipart/mixed when there are attachments.
+func WriteEML(w io.Writer, msg api.Message, files []File) error {
+	var buf bytes.Buffer
+
+	writeHeaders(&buf, msg)
+
+	body := &bytes.Buffer{}
+	bodyType, err := writeBody(body, msg)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintf(&buf, "Content-Type: %s\r\n", bodyType.contentType)
+
+		if bodyType.encoding != "" {
+			fmt.Fprintf(&buf, "Content-Transfer-Encoding: %s\r\n", bodyType.encoding)
+		}
+
+		buf.WriteString("\r\n")
+		buf.Write(body.Bytes())
+
+		_, err := w.Write(buf.Bytes())
+
+		return err //nolint:wrapcheck // plain writer error
+	}
+
+	out := &bytes.Buffer{}
+	mixed := multipart.NewWriter(out)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mixed.Boundary())
+
+	bodyHeader := textproto.MIMEHeader{"Content-Type": {bodyType.contentType}}
+	if bodyType.encoding != "" {
+		bodyHeader.Set("Content-Transfer-Encoding", bodyType.encoding)
+	}
+
+	part, err := mixed.CreatePart(bodyHeader)
+	if err != nil {
+		return fmt.Errorf("create body part: %w", err)
+	}
+
+	if _, err := part.Write(body.Bytes()); err != nil {
+		return fmt.Errorf("write body part: %w", err)
+	}
+
+	for _, f := range files {
+		ct := f.ContentType
+		if ct == "" {
+			ct = "application/octet-stream"
+		}
+
+		name := mime.QEncoding.Encode("utf-8", f.Filename)
+
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("%s; name=%q", ct, name)},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", name)},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return fmt.Errorf("create attachment part: %w", err)
+		}
+
+		if err := writeBase64(part, f.Data); err != nil {
+			return err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return fmt.Errorf("close multipart: %w", err)
+	}
+
+	buf.Write(out.Bytes())
+
+	_, err = w.Write(buf.Bytes())
+
+	return err //nolint:wrapcheck // plain writer error
+}
+
+type bodyKind struct {
+	contentType string
+	encoding    string
+}
+
+func writeBody(w *bytes.Buffer, msg api.Message) (bodyKind, error) {
+	text := strings.TrimSpace(msg.Text)
+	html := strings.TrimSpace(msg.Body)
+
+	switch {
+	case text != "" && html != "":
+		alt := multipart.NewWriter(w)
+
+		for _, p := range []struct{ ct, content string }{
+			{"text/plain; charset=utf-8", msg.Text},
+			{"text/html; charset=utf-8", msg.Body},
+		} {
+			part, err := alt.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {p.ct},
+				"Content-Transfer-Encoding": {"base64"},
+			})
+			if err != nil {
+				return bodyKind{}, fmt.Errorf("create body part: %w", err)
+			}
+
+			if err := writeBase64(part, []byte(p.content)); err != nil {
+				return bodyKind{}, err
+			}
+		}
+
+		if err := alt.Close(); err != nil {
+			return bodyKind{}, fmt.Errorf("close multipart: %w", err)
+		}
+
+		return bodyKind{contentType: fmt.Sprintf("multipart/alternative; boundary=%q", alt.Boundary())}, nil
+	case html != "":
+		return bodyKind{contentType: "text/html; charset=utf-8", encoding: "base64"}, writeBase64(w, []byte(msg.Body))
+	default:
+		return bodyKind{contentType: "text/plain; charset=utf-8", encoding: "base64"}, writeBase64(w, []byte(msg.Text))
+	}
+}
+
+func writeHeaders(buf *bytes.Buffer, msg api.Message) {
+	roles := map[string][]string{}
+
+	for _, r := range msg.Recipients {
+		if r.Handle != "" {
+			roles[r.Role] = append(roles[r.Role], formatAddress(r.Handle))
+		}
+	}
+
+	from := roles["from"]
+	if len(from) == 0 && msg.Author != nil && msg.Author.Email != "" {
+		name := strings.TrimSpace(msg.Author.FirstName + " " + msg.Author.LastName)
+		from = []string{(&mail.Address{Name: name, Address: msg.Author.Email}).String()}
+	}
+
+	header := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+		}
+	}
+
+	header("From", strings.Join(from, ", "))
+	header("To", strings.Join(roles["to"], ", "))
+	header("Cc", strings.Join(roles["cc"], ", "))
+	header("Bcc", strings.Join(roles["bcc"], ", "))
+	header("Reply-To", strings.Join(roles["reply-to"], ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", Date(msg).Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@frontapp.com>", msg.ID))
+	header("X-Front-Message-ID", msg.ID)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+}
+
+// Date returns the message's creation time.
+func Date(msg api.Message) time.Time {
+	return time.Unix(int64(msg.CreatedAt), 0).UTC()
+}
+
+// Sender returns the bare address of the message sender, or "MAILER-DAEMON"
+// when unknown (the mbox convention).
+func Sender(msg api.Message) string {
+	for _, r := range msg.Recipients {
+		if r.Role == "from" && r.Handle != "" {
+			return r.Handle
+		}
+	}
+
+	if msg.Author != nil && msg.Author.Email != "" {
+		return msg.Author.Email
+	}
+
+	return "MAILER-DAEMON"
+}
+
+// SortByDate orders messages oldest first.
+func SortByDate(msgs []api.Message) {
+	sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].CreatedAt < msgs[j].CreatedAt })
+}
+
+func formatAddress(handle string) string {
+	if addr, err := mail.ParseAddress(handle); err == nil {
+		return addr.String()
+	}
+
+	return handle
+}
+
+// writeBase64 writes data base64-encoded in 76-character lines.
+func writeBase64(w io.Writer, data []byte) error {
+	enc := base64.StdEncoding.EncodeToString(data)
+
+	for len(enc) > 76 {
+		if _, err := io.WriteString(w, enc[:76]+"\r\n"); err != nil {
+			return fmt.Errorf("write base64: %w", err)
+		}
+
+		enc = enc[76:]
+	}
+
+	if _, err := io.WriteString(w, enc+"\r\n"); err != nil {
+		return fmt.Errorf("write base64: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func testMessage() api.Message {
+	return api.Message{
+		ID:        "msg_1",
+		Subject:   "Hello",
+		CreatedAt: 1700000000,
+		Text:      "From the start\nline two",
+		Body:      "<p>From the start</p>",
+		Recipients: []api.Recipient{
+			{Handle: "alice@example.com", Role: "from"},
+			{Handle: "bob@example.com", Role: "to"},
+			{Handle: "carol@example.com", Role: "cc"},
+		},
+	}
+}
+
+func TestWriteEMLParses(t *testing.T) {
+	var buf bytes.Buffer
+
+	files := []File{{Filename: "notes.txt", ContentType: "text/plain", Data: []byte("attached")}}
+	if err := WriteEML(&buf, testMessage(), files); err != nil {
+		t.Fatalf("WriteEML: %v", err)
+	}
+
+	m, err := mail.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+
+	if got := m.Header.Get("From"); got != "<alice@example.com>" {
+		t.Fatalf("unexpected From: %q", got)
+	}
+
+	if got := m.Header.Get("Cc"); got != "<carol@example.com>" {
+		t.Fatalf("unexpected Cc: %q", got)
+	}
+
+	if !strings.HasPrefix(m.Header.Get("Content-Type"), "multipart/mixed") {
+		t.Fatalf("expected multipart/mixed, got %q", m.Header.Get("Content-Type"))
+	}
+
+	body, _ := io.ReadAll(m.Body)
+	if !strings.Contains(string(body), `filename="notes.txt"`) {
+		t.Fatalf("attachment missing from body:\n%s", body)
+	}
+}
+
+func TestWriteMboxQuotesFromLines(t *testing.T) {
+	var buf bytes.Buffer
+
+	msg := testMessage()
+	msg.Body = ""
+
+	if err := WriteMbox(&buf, msg, nil); err != nil {
+		t.Fatalf("WriteMbox: %v", err)
+	}
+
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "From alice@example.com Tue Nov 14 22:13:20 2023\n") {
+		t.Fatalf("unexpected separator: %q", strings.SplitN(out, "\n", 2)[0])
+	}
+
+	if strings.Contains(out, "\r\n") {
+		t.Fatal("expected LF line endings")
+	}
+
+	if strings.Count(out, "\nFrom ") != 0 {
+		t.Fatalf("unquoted From line in body:\n%s", out)
+	}
+}
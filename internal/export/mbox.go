@@ -0,0 +1,40 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// WriteMbox appends msg to an mboxrd archive: a "From " separator line, the
+// message with CRLF line endings normalized to LF, and any line matching
+// /^>*From / quoted with an extra '>'.
+func WriteMbox(w io.Writer, msg api.Message, files []File) error {
+	var eml bytes.Buffer
+	if err := WriteEML(&eml, msg, files); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "From %s %s\n", Sender(msg), Date(msg).Format("Mon Jan _2 15:04:05 2006"))
+
+	for _, line := range strings.Split(strings.ReplaceAll(eml.String(), "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			line = ">" + line
+		}
+
+		bw.WriteString(line)
+		bw.WriteByte('\n')
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write mbox: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+// Package log is frontcli's diagnostic logging: warnings and debug details
+// that go to stderr (or a log file), never to stdout where command output
+// goes. It wraps log/slog; Setup configures the process-wide logger.
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var errInvalidLevel = errors.New("invalid log level")
+
+// Options configure Setup.
+type Options struct {
+	Level  string // debug, info, warn or error (default warn)
+	Format string // text or json (default text)
+	File   string // append to this file instead of writing to stderr
+}
+
+// Setup installs the process-wide logger. The returned func closes the log
+// file, if any.
+func Setup(opts Options) (func() error, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		out     io.Writer = os.Stderr
+		closeFn           = func() error { return nil }
+	)
+
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("open log file: %w", err)
+		}
+
+		out, closeFn = f, f.Close
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+
+	switch strings.ToLower(opts.Format) {
+	case "", FormatText:
+		if opts.File != "" {
+			handler = slog.NewTextHandler(out, handlerOpts)
+		} else {
+			handler = newConsoleHandler(out, level)
+		}
+	case FormatJSON:
+		handler = slog.NewJSONHandler(out, handlerOpts)
+	default:
+		_ = closeFn()
+
+		return nil, fmt.Errorf("invalid log format %q (use text or json)", opts.Format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+
+	return closeFn, nil
+}
+
+// ParseLevel parses a level name; empty means warn.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "", "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("%w %q (use debug, info, warn or error)", errInvalidLevel, name)
+	}
+}
+
+func Debug(msg string, args ...any) { slog.Debug(msg, args...) }
+func Info(msg string, args ...any)  { slog.Info(msg, args...) }
+func Warn(msg string, args ...any)  { slog.Warn(msg, args...) }
+func Error(msg string, args ...any) { slog.Error(msg, args...) }
+
+// consoleHandler writes one short line per record for people reading a
+// terminal: "warning: msg key=value ...", without timestamps.
+type consoleHandler struct {
+	mu    *sync.Mutex
+	out   io.Writer
+	level slog.Leveler
+	attrs []slog.Attr
+	group string
+}
+
+func newConsoleHandler(out io.Writer, level slog.Leveler) *consoleHandler {
+	return &consoleHandler{mu: &sync.Mutex{}, out: out, level: level}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+
+	b.WriteString(levelLabel(r.Level))
+	b.WriteString(": ")
+	b.WriteString(r.Message)
+
+	for _, a := range h.attrs {
+		writeAttr(&b, "", a)
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(&b, h.group, a)
+
+		return true
+	})
+
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := io.WriteString(h.out, b.String())
+
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), qualify(h.group, attrs)...)
+
+	return &clone
+}
+
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	if clone.group != "" {
+		name = clone.group + "." + name
+	}
+
+	clone.group = name
+
+	return &clone
+}
+
+func qualify(group string, attrs []slog.Attr) []slog.Attr {
+	if group == "" {
+		return attrs
+	}
+
+	out := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		out[i] = slog.Attr{Key: group + "." + a.Key, Value: a.Value}
+	}
+
+	return out
+}
+
+func writeAttr(b *strings.Builder, group string, a slog.Attr) {
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	key := a.Key
+	if group != "" {
+		key = group + "." + key
+	}
+
+	value := a.Value.Resolve().String()
+	if strings.ContainsAny(value, " \t\"=") {
+		value = fmt.Sprintf("%q", value)
+	}
+
+	fmt.Fprintf(b, " %s=%s", key, value)
+}
+
+func levelLabel(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "error"
+	case level >= slog.LevelWarn:
+		return "warning"
+	case level >= slog.LevelInfo:
+		return "info"
+	default:
+		return "debug"
+	}
+}
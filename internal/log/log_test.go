@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConsoleHandlerFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(newConsoleHandler(&buf, slog.LevelWarn))
+	logger.Info("hidden")
+	logger.Warn("token was not granted", "scope", "tags:write", "fix", "frontcli auth login")
+	logger.With("email", "a@b.c").Error("failed")
+
+	want := "warning: token was not granted scope=tags:write fix=\"frontcli auth login\"\n" +
+		"error: failed email=a@b.c\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSetupJSONFile(t *testing.T) {
+	prev := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	path := filepath.Join(t.TempDir(), "frontcli.log")
+
+	closeFn, err := Setup(Options{Level: "debug", Format: FormatJSON, File: path})
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	Debug("retrying", "attempt", 2)
+
+	if err := closeFn(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("decode %q: %v", data, err)
+	}
+
+	if record["level"] != "DEBUG" || record["msg"] != "retrying" || record["attempt"] != float64(2) {
+		t.Fatalf("record = %v", record)
+	}
+}
+
+func TestSetupRejectsInvalidOptions(t *testing.T) {
+	if _, err := Setup(Options{Level: "loud"}); err == nil || !strings.Contains(err.Error(), "invalid log level") {
+		t.Fatalf("level error = %v", err)
+	}
+
+	if _, err := Setup(Options{Format: "xml"}); err == nil || !strings.Contains(err.Error(), "invalid log format") {
+		t.Fatalf("format error = %v", err)
+	}
+}
@@ -1,10 +1,21 @@
 package markdown
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
 	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// toHTML renders GitHub-flavoured markdown. Raw HTML is passed through so a
+// message can mix markdown with the odd inline tag.
+var toHTML = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
 )
 
 func ToMarkdown(input string) (string, error) {
@@ -19,3 +30,17 @@ func ToMarkdown(input string) (string, error) {
 
 	return md, nil
 }
+
+// ToHTML converts markdown to HTML; it is the reverse of ToMarkdown.
+func ToHTML(input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := toHTML.Convert([]byte(input), &buf); err != nil {
+		return "", fmt.Errorf("convert markdown: %w", err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,284 @@
+// Package mirror keeps a local copy of an account's conversations, messages
+// and contacts for offline use, in a SQLite database (mirror.db) in the
+// mirror directory. The cursors table records how far each collection is
+// synced.
+package mirror
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	_ "modernc.org/sqlite" // database/sql driver "sqlite", pure Go
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+const dbFile = "mirror.db"
+
+// Cursor names in the cursors table.
+const (
+	conversationsCursor = "conversations"
+	contactsCursor      = "contacts"
+)
+
+// schema creates the tables on first use. Records are stored as the API
+// returned them (data), with the columns queries need alongside. documents
+// holds what search matches, one row per message (or per conversation
+// without messages) with the conversation's subject; documents_fts is its
+// full-text index, kept in step by the triggers.
+const schema = `
+CREATE TABLE IF NOT EXISTS cursors (
+	name      TEXT PRIMARY KEY,
+	synced_at REAL NOT NULL
+);
+CREATE TABLE IF NOT EXISTS conversations (
+	id         TEXT PRIMARY KEY,
+	subject    TEXT NOT NULL,
+	created_at REAL NOT NULL,
+	data       TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS messages (
+	id              TEXT PRIMARY KEY,
+	conversation_id TEXT NOT NULL,
+	created_at      REAL NOT NULL,
+	text            TEXT NOT NULL,
+	data            TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS messages_conversation ON messages (conversation_id);
+CREATE TABLE IF NOT EXISTS contacts (
+	id   TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS documents (
+	rowid           INTEGER PRIMARY KEY,
+	conversation_id TEXT NOT NULL,
+	message_id      TEXT NOT NULL,
+	subject         TEXT NOT NULL,
+	body            TEXT NOT NULL,
+	created_at      REAL NOT NULL
+);
+CREATE INDEX IF NOT EXISTS documents_conversation ON documents (conversation_id);
+CREATE VIRTUAL TABLE IF NOT EXISTS documents_fts USING fts5(
+	subject, body, content='documents', content_rowid='rowid', tokenize='unicode61 remove_diacritics 2'
+);
+CREATE TRIGGER IF NOT EXISTS documents_insert AFTER INSERT ON documents BEGIN
+	INSERT INTO documents_fts (rowid, subject, body) VALUES (new.rowid, new.subject, new.body);
+END;
+CREATE TRIGGER IF NOT EXISTS documents_delete AFTER DELETE ON documents BEGIN
+	INSERT INTO documents_fts (documents_fts, rowid, subject, body) VALUES ('delete', old.rowid, old.subject, old.body);
+END;
+`
+
+// State holds the sync cursors: the Unix time each collection was last
+// synced from, so the next sync only fetches what changed since.
+type State struct {
+	ConversationsSyncedAt float64 `json:"conversations_synced_at,omitempty"`
+	ContactsSyncedAt      float64 `json:"contacts_synced_at,omitempty"`
+}
+
+// Counts is how much the mirror holds.
+type Counts struct {
+	Conversations int `json:"conversations"`
+	Messages      int `json:"messages"`
+	Contacts      int `json:"contacts"`
+}
+
+// Mirror is an account's local copy. Each Put writes in its own
+// transaction, so a sync that stops partway keeps what it stored.
+type Mirror struct {
+	Dir string
+
+	db *sql.DB
+}
+
+// Open opens the mirror in dir, creating it if needed. Close it when done.
+func Open(dir string) (*Mirror, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create mirror dir: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(dir, dbFile)+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("open mirror: %w", err)
+	}
+
+	// SQLite has one writer; sync's parallel workers take turns.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("open mirror: %w", err)
+	}
+
+	return &Mirror{Dir: dir, db: db}, nil
+}
+
+// Close closes the database.
+func (m *Mirror) Close() error {
+	return m.db.Close()
+}
+
+// State returns the sync cursors; zero when never synced.
+func (m *Mirror) State() (State, error) {
+	var s State
+
+	rows, err := m.db.Query(`SELECT name, synced_at FROM cursors`)
+	if err != nil {
+		return s, fmt.Errorf("read mirror: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			name string
+			at   float64
+		)
+
+		if err := rows.Scan(&name, &at); err != nil {
+			return s, fmt.Errorf("read mirror: %w", err)
+		}
+
+		switch name {
+		case conversationsCursor:
+			s.ConversationsSyncedAt = at
+		case contactsCursor:
+			s.ContactsSyncedAt = at
+		}
+	}
+
+	return s, rows.Err()
+}
+
+// SaveState stores the sync cursors. Sync saves them last, so they never
+// run ahead of the data stored.
+func (m *Mirror) SaveState(s State) error {
+	return m.write(func(tx *sql.Tx) error {
+		for name, at := range map[string]float64{
+			conversationsCursor: s.ConversationsSyncedAt,
+			contactsCursor:      s.ContactsSyncedAt,
+		} {
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO cursors (name, synced_at) VALUES (?, ?)`, name, at); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// PutConversations adds or replaces convs.
+func (m *Mirror) PutConversations(convs []api.Conversation) error {
+	return m.write(func(tx *sql.Tx) error {
+		for _, conv := range convs {
+			data, err := json.Marshal(conv)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO conversations (id, subject, created_at, data) VALUES (?, ?, ?, ?)`,
+				conv.ID, conv.Subject, conv.CreatedAt, string(data)); err != nil {
+				return err
+			}
+
+			if err := index(tx, conv.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// PutMessages replaces the messages of conversation convID with msgs.
+func (m *Mirror) PutMessages(convID string, msgs []api.Message) error {
+	return m.write(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM messages WHERE conversation_id = ?`, convID); err != nil {
+			return err
+		}
+
+		for _, msg := range msgs {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO messages (id, conversation_id, created_at, text, data) VALUES (?, ?, ?, ?, ?)`,
+				msg.ID, convID, msg.CreatedAt, messageText(msg), string(data)); err != nil {
+				return err
+			}
+		}
+
+		return index(tx, convID)
+	})
+}
+
+// index replaces the search documents of conversation convID: one per
+// message, or one for the subject alone when no messages are stored.
+func index(tx *sql.Tx, convID string) error {
+	if _, err := tx.Exec(`DELETE FROM documents WHERE conversation_id = ?`, convID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`INSERT INTO documents (conversation_id, message_id, subject, body, created_at)
+		SELECT c.id, coalesce(m.id, ''), c.subject, coalesce(m.text, ''), coalesce(m.created_at, c.created_at)
+		FROM conversations c LEFT JOIN messages m ON m.conversation_id = c.id
+		WHERE c.id = ?`, convID)
+
+	return err
+}
+
+// PutContacts adds or replaces contacts.
+func (m *Mirror) PutContacts(contacts []api.Contact) error {
+	return m.write(func(tx *sql.Tx) error {
+		for _, contact := range contacts {
+			data, err := json.Marshal(contact)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO contacts (id, data) VALUES (?, ?)`, contact.ID, string(data)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Counts returns how many conversations, messages and contacts are mirrored.
+func (m *Mirror) Counts() (Counts, error) {
+	var c Counts
+
+	err := m.db.QueryRow(`SELECT
+		(SELECT count(*) FROM conversations),
+		(SELECT count(*) FROM messages),
+		(SELECT count(*) FROM contacts)`).Scan(&c.Conversations, &c.Messages, &c.Contacts)
+	if err != nil {
+		return c, fmt.Errorf("read mirror: %w", err)
+	}
+
+	return c, nil
+}
+
+// write runs fn in a transaction.
+func (m *Mirror) write(fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("write mirror: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		return errors.Join(fmt.Errorf("write mirror: %w", err), tx.Rollback())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("write mirror: %w", err)
+	}
+
+	return nil
+}
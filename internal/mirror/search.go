@@ -0,0 +1,120 @@
+package mirror
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// snippetTokens is how many words a snippet shows around the matches.
+const snippetTokens = 16
+
+// subjectWeight makes a match in the subject count more than one in a body.
+const subjectWeight = 3.0
+
+// Hit is the best-matching message of a conversation.
+type Hit struct {
+	ConversationID string  `json:"conversation_id"`
+	MessageID      string  `json:"message_id,omitempty"`
+	Subject        string  `json:"subject"`
+	Snippet        string  `json:"snippet"`
+	CreatedAt      float64 `json:"created_at"`
+	Score          float64 `json:"score"`
+}
+
+// searchQuery ranks the documents matching ?1 with bm25, subject matches
+// counting triple, keeps the best message per conversation and returns ?2
+// conversations, best first, then most recent. bm25 is lower for better
+// matches, so score is its negation.
+const searchQuery = `
+WITH matches AS (
+	SELECT rowid, -bm25(documents_fts, ?3, 1.0) AS score,
+		snippet(documents_fts, 1, '', '', '…', ?4) AS snippet
+	FROM documents_fts WHERE documents_fts MATCH ?1
+), ranked AS (
+	SELECT d.conversation_id, d.message_id, d.subject, m.snippet, d.created_at, m.score,
+		row_number() OVER (PARTITION BY d.conversation_id ORDER BY m.score DESC, d.created_at DESC) AS n
+	FROM matches m JOIN documents d ON d.rowid = m.rowid
+)
+SELECT conversation_id, message_id, subject, snippet, created_at, score
+FROM ranked WHERE n = 1
+ORDER BY score DESC, created_at DESC
+LIMIT ?2`
+
+// Search finds conversations whose subject and message text contain every
+// term of query (words, or "quoted phrases"), ignoring case and accents. The
+// last word of each term also matches as a prefix, so refund finds refunds.
+// At most limit hits are returned; limit <= 0 means all.
+func (m *Mirror) Search(query string, limit int) ([]Hit, error) {
+	terms := ParseTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	if limit <= 0 {
+		limit = -1
+	}
+
+	rows, err := m.db.Query(searchQuery, matchExpr(terms), limit, subjectWeight, snippetTokens)
+	if err != nil {
+		return nil, fmt.Errorf("search mirror: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []Hit
+
+	for rows.Next() {
+		var hit Hit
+		if err := rows.Scan(&hit.ConversationID, &hit.MessageID, &hit.Subject, &hit.Snippet, &hit.CreatedAt, &hit.Score); err != nil {
+			return nil, fmt.Errorf("search mirror: %w", err)
+		}
+
+		hits = append(hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search mirror: %w", err)
+	}
+
+	return hits, nil
+}
+
+// ParseTerms splits a query into lowercase words and "quoted phrases".
+func ParseTerms(query string) []string {
+	var terms []string
+
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			if phrase := strings.Join(strings.Fields(strings.ToLower(part)), " "); phrase != "" {
+				terms = append(terms, phrase)
+			}
+
+			continue
+		}
+
+		terms = append(terms, strings.Fields(strings.ToLower(part))...)
+	}
+
+	return terms
+}
+
+// matchExpr turns terms into an FTS5 query that needs all of them: each is
+// a quoted phrase, so punctuation and FTS5 operators in it are plain text.
+func matchExpr(terms []string) string {
+	phrases := make([]string, len(terms))
+
+	for i, term := range terms {
+		phrases[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+
+	return strings.Join(phrases, " ")
+}
+
+func messageText(msg api.Message) string {
+	if msg.Text != "" {
+		return msg.Text
+	}
+
+	return msg.Blurb
+}
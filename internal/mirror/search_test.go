@@ -0,0 +1,122 @@
+package mirror
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func testMirror(t *testing.T) *Mirror {
+	t.Helper()
+
+	m, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	t.Cleanup(func() { _ = m.Close() })
+
+	err = errors.Join(
+		m.PutConversations([]api.Conversation{
+			{ID: "cnv_1", Subject: "Refund request", CreatedAt: 100},
+			{ID: "cnv_2", Subject: "Shipping delay", CreatedAt: 200},
+			{ID: "cnv_3", Subject: "Refund for order 42", CreatedAt: 300},
+			// Unrelated ones, so bm25 does not treat refund as too common to
+			// rank by.
+			{ID: "cnv_4", Subject: "Invoice copy", CreatedAt: 400},
+			{ID: "cnv_5", Subject: "Password reset", CreatedAt: 500},
+			{ID: "cnv_6", Subject: "Opening hours", CreatedAt: 600},
+			{ID: "cnv_7", Subject: "Address change", CreatedAt: 700},
+		}),
+		m.PutMessages("cnv_1", []api.Message{
+			{ID: "msg_1", Text: "Hi, I would like a refund.", CreatedAt: 110},
+			{ID: "msg_2", Text: "Your refund for the damaged\nlamp is on its way.", CreatedAt: 120},
+		}),
+		m.PutMessages("cnv_2", []api.Message{{ID: "msg_3", Text: "The damaged lamp was shipped late.", CreatedAt: 210}}),
+		m.PutMessages("cnv_4", []api.Message{{ID: "msg_5", Text: "Please send the invoice, no refund needed.", CreatedAt: 410}}),
+	)
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	return m
+}
+
+func search(t *testing.T, m *Mirror, query string) []Hit {
+	t.Helper()
+
+	hits, err := m.Search(query, 0)
+	if err != nil {
+		t.Fatalf("Search(%q): %v", query, err)
+	}
+
+	return hits
+}
+
+func TestSearchRanksAndMatchesPhrases(t *testing.T) {
+	m := testMirror(t)
+
+	hits := search(t, m, `"damaged lamp" refund`)
+	if len(hits) != 1 || hits[0].MessageID != "msg_2" {
+		t.Fatalf("hits = %+v, want msg_2 only", hits)
+	}
+
+	hits = search(t, m, "REFUND")
+	if len(hits) != 3 {
+		t.Fatalf("hits = %+v, want three conversations", hits)
+	}
+
+	// cnv_1 and cnv_3 match in the subject, which counts triple; cnv_4
+	// only in a message body.
+	if hits[2].ConversationID != "cnv_4" || hits[0].Score <= hits[2].Score || hits[1].Score <= hits[2].Score {
+		t.Fatalf("ranking = %+v", hits)
+	}
+
+	if len(search(t, m, "   ")) != 0 {
+		t.Fatal("empty query matched")
+	}
+}
+
+func TestSearchIndexFollowsUpdates(t *testing.T) {
+	m := testMirror(t)
+
+	if hits := search(t, m, "refunds café - order-42"); len(hits) != 0 {
+		t.Fatalf("hits = %+v, want none", hits)
+	}
+
+	// A resync replaces the messages and the subject in the index.
+	err := errors.Join(
+		m.PutMessages("cnv_2", []api.Message{{ID: "msg_4", Text: "Café order-42 refunded.", CreatedAt: 220}}),
+		m.PutConversations([]api.Conversation{{ID: "cnv_2", Subject: "Refunds", CreatedAt: 200}}),
+	)
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if hits := search(t, m, "lamp shipped"); len(hits) != 0 {
+		t.Fatalf("replaced message still matches: %+v", hits)
+	}
+
+	// Prefixes, accents and punctuation: refund matches refunds and
+	// refunded, cafe matches café.
+	hits := search(t, m, `refund cafe "order-42"`)
+	if len(hits) != 1 || hits[0].MessageID != "msg_4" || hits[0].Subject != "Refunds" {
+		t.Fatalf("hits = %+v, want msg_4", hits)
+	}
+
+	hits, err = m.Search("refund", 1)
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("limited search = %+v, %v", hits, err)
+	}
+}
+
+func TestParseTerms(t *testing.T) {
+	got := ParseTerms(`Refund "Damaged  Lamp" order`)
+
+	want := []string{"refund", "damaged lamp", "order"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ParseTerms = %q, want %q", got, want)
+	}
+}
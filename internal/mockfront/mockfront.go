@@ -0,0 +1,1044 @@
+// Package mockfront is an in-memory stand-in for the subset of the Front API
+// that frontcli uses: teammates, teams, inboxes, channels, tags, contacts,
+// conversations with their messages and comments, and search. It keeps no
+// state beyond the process, accepts any bearer token, and is meant for tests
+// and for trying the CLI without a Front account.
+package mockfront
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// defaultPageSize is used when a list request has no limit.
+const defaultPageSize = 50
+
+// Server is a mock Front API. The zero value is not usable; call New.
+type Server struct {
+	mu  sync.Mutex
+	mux *http.ServeMux
+	now func() time.Time
+
+	me            api.Teammate
+	teammates     []*api.Teammate
+	teams         []*api.Team
+	inboxes       []*api.Inbox
+	channels      []*api.Channel
+	tags          []*api.Tag
+	contacts      []*api.Contact
+	conversations []*api.Conversation
+	messages      map[string][]*api.Message // by conversation ID
+	comments      map[string][]*api.Comment // by conversation ID
+	accepted      map[string]string         // message UID -> message ID
+	idempotent    map[string]recorded       // Idempotency-Key -> response
+	nextID        int
+}
+
+// recorded is a response kept for a request with an Idempotency-Key.
+type recorded struct {
+	status int
+	body   any
+}
+
+// New returns a server holding a small sample workspace (see Seed), or an
+// empty one apart from the authenticated teammate when seed is false.
+func New(seed bool) *Server {
+	s := &Server{
+		now:        time.Now,
+		messages:   map[string][]*api.Message{},
+		comments:   map[string][]*api.Comment{},
+		accepted:   map[string]string{},
+		idempotent: map[string]recorded{},
+	}
+
+	s.me = api.Teammate{ID: "tea_mock1", Email: "me@example.com", Username: "me", FirstName: "Mock", LastName: "User", IsAdmin: true, IsAvailable: true}
+	s.teammates = []*api.Teammate{&s.me}
+
+	if seed {
+		s.Seed()
+	}
+
+	s.routes()
+
+	return s
+}
+
+func (s *Server) routes() {
+	s.mux = http.NewServeMux()
+
+	handle := func(pattern string, fn func(w http.ResponseWriter, r *http.Request)) {
+		s.mux.HandleFunc(pattern, fn)
+	}
+
+	handle("GET /me", s.getMe)
+
+	handle("GET /teammates", s.listTeammates)
+	handle("GET /teammates/{id}", s.getTeammate)
+	handle("GET /teammates/{id}/conversations", s.listTeammateConversations)
+
+	handle("GET /teams", s.listTeams)
+	handle("GET /teams/{id}", s.getTeam)
+
+	handle("GET /inboxes", s.listInboxes)
+	handle("GET /inboxes/{id}", s.getInbox)
+	handle("GET /inboxes/{id}/conversations", s.listInboxConversations)
+
+	handle("GET /channels", s.listChannels)
+	handle("GET /channels/{id}", s.getChannel)
+	handle("POST /channels/{id}/messages", s.sendMessage)
+
+	handle("GET /tags", s.listTags)
+	handle("POST /tags", s.createTag)
+	handle("GET /tags/{id}", s.getTag)
+	handle("PATCH /tags/{id}", s.updateTag)
+	handle("DELETE /tags/{id}", s.deleteTag)
+	handle("GET /tags/{id}/conversations", s.listTagConversations)
+
+	handle("GET /contacts", s.listContacts)
+	handle("POST /contacts", s.createContact)
+	handle("GET /contacts/{id}", s.getContact)
+	handle("PATCH /contacts/{id}", s.updateContact)
+	handle("DELETE /contacts/{id}", s.deleteContact)
+	handle("GET /contacts/{id}/conversations", s.listContactConversations)
+
+	handle("GET /conversations", s.listConversations)
+	handle("GET /conversations/{id}", s.getConversation)
+	handle("PATCH /conversations/{id}", s.updateConversation)
+	handle("GET /conversations/{id}/messages", s.listMessages)
+	handle("POST /conversations/{id}/messages", s.replyMessage)
+	handle("GET /conversations/{id}/comments", s.listComments)
+	handle("POST /conversations/{id}/comments", s.createComment)
+	handle("POST /conversations/{id}/tags", s.addConversationTags)
+	handle("DELETE /conversations/{id}/tags", s.removeConversationTags)
+	handle("PATCH /conversations/{id}/reminders", s.setReminder)
+
+	handle("GET /messages/{id}", s.getMessage)
+}
+
+// searchPrefix is routed by hand: the mux cannot tell a query named
+// "messages" from a conversation's messages.
+const searchPrefix = "/conversations/search/"
+
+// ServeHTTP checks for a bearer token, replays responses to repeated
+// Idempotency-Keys, and dispatches to the endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "missing bearer token")
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.Header.Get(api.IdempotencyKeyHeader)
+	if key != "" && r.Method == http.MethodPost {
+		if prev, ok := s.idempotent[key]; ok {
+			writeJSON(w, prev.status, prev.body)
+
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w}
+		s.mux.ServeHTTP(rec, r)
+
+		if rec.status < http.StatusBadRequest {
+			s.idempotent[key] = recorded{status: rec.status, body: rec.body}
+		}
+
+		return
+	}
+
+	if r.Method == http.MethodGet && strings.HasPrefix(r.URL.EscapedPath(), searchPrefix) {
+		s.searchConversations(w, r)
+
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// recorder captures what writeJSON sent so it can be replayed.
+type recorder struct {
+	http.ResponseWriter
+
+	status int
+	body   any
+}
+
+func (s *Server) id(prefix string) string {
+	s.nextID++
+
+	return fmt.Sprintf("%s_mock%d", prefix, s.nextID)
+}
+
+func (s *Server) timestamp() float64 {
+	return float64(s.now().UnixMilli()) / 1000
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	if rec, ok := w.(*recorder); ok {
+		rec.status, rec.body = status, body
+	}
+
+	if body == nil {
+		w.WriteHeader(status)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"_error": map[string]any{
+			"status":  status,
+			"title":   http.StatusText(status),
+			"message": message,
+		},
+	})
+}
+
+func notFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", kind, id))
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+
+		return false
+	}
+
+	return true
+}
+
+// writePage writes items as a Front list response, paged by limit and an
+// offset page_token, with an absolute next link like Front's.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	limit := defaultPageSize
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+		limit = n
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("page_token"))
+	offset = min(max(offset, 0), len(items))
+	end := min(offset+limit, len(items))
+
+	resp := api.ListResponse[T]{Results: items[offset:end]}
+	if resp.Results == nil {
+		resp.Results = []T{}
+	}
+
+	if end < len(items) {
+		next := *r.URL
+		q := next.Query()
+		q.Set("page_token", strconv.Itoa(end))
+		next.RawQuery = q.Encode()
+		next.Scheme, next.Host = "http", r.Host
+
+		resp.Pagination.Next = next.String()
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// values dereferences items for a response.
+func values[T any](items []*T) []T {
+	out := make([]T, len(items))
+	for i, item := range items {
+		out[i] = *item
+	}
+
+	return out
+}
+
+func find[T any](items []*T, match func(*T) bool) *T {
+	for _, item := range items {
+		if match(item) {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) getMe(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.me)
+}
+
+func (s *Server) teammate(id string) *api.Teammate {
+	return find(s.teammates, func(t *api.Teammate) bool {
+		return t.ID == id || strings.EqualFold(t.Email, id) || t.Username == id
+	})
+}
+
+func (s *Server) listTeammates(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, values(s.teammates))
+}
+
+func (s *Server) getTeammate(w http.ResponseWriter, r *http.Request) {
+	t := s.teammate(r.PathValue("id"))
+	if t == nil {
+		notFound(w, "teammate", r.PathValue("id"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, values(s.teams))
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request) {
+	t := find(s.teams, func(t *api.Team) bool { return t.ID == r.PathValue("id") })
+	if t == nil {
+		notFound(w, "team", r.PathValue("id"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) inbox(id string) *api.Inbox {
+	return find(s.inboxes, func(i *api.Inbox) bool { return i.ID == id })
+}
+
+func (s *Server) listInboxes(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, values(s.inboxes))
+}
+
+func (s *Server) getInbox(w http.ResponseWriter, r *http.Request) {
+	i := s.inbox(r.PathValue("id"))
+	if i == nil {
+		notFound(w, "inbox", r.PathValue("id"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, i)
+}
+
+func (s *Server) channel(id string) *api.Channel {
+	return find(s.channels, func(c *api.Channel) bool { return c.ID == id })
+}
+
+func (s *Server) listChannels(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, values(s.channels))
+}
+
+func (s *Server) getChannel(w http.ResponseWriter, r *http.Request) {
+	c := s.channel(r.PathValue("id"))
+	if c == nil {
+		notFound(w, "channel", r.PathValue("id"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) tag(id string) *api.Tag {
+	return find(s.tags, func(t *api.Tag) bool { return t.ID == id })
+}
+
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, values(s.tags))
+}
+
+func (s *Server) getTag(w http.ResponseWriter, r *http.Request) {
+	t := s.tag(r.PathValue("id"))
+	if t == nil {
+		notFound(w, "tag", r.PathValue("id"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request) {
+	var t api.Tag
+	if !decode(w, r, &t) {
+		return
+	}
+
+	if strings.TrimSpace(t.Name) == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+
+		return
+	}
+
+	t.ID = s.id("tag")
+	t.CreatedAt = s.timestamp()
+	t.UpdatedAt = t.CreatedAt
+	s.tags = append(s.tags, &t)
+
+	writeJSON(w, http.StatusCreated, t)
+}
+
+func (s *Server) updateTag(w http.ResponseWriter, r *http.Request) {
+	t := s.tag(r.PathValue("id"))
+	if t == nil {
+		notFound(w, "tag", r.PathValue("id"))
+
+		return
+	}
+
+	var patch map[string]any
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	if v, ok := patch["name"].(string); ok {
+		t.Name = v
+	}
+
+	if v, ok := patch["description"].(string); ok {
+		t.Description = v
+	}
+
+	if v, ok := patch["highlight"].(string); ok {
+		t.Highlight = v
+	}
+
+	t.UpdatedAt = s.timestamp()
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) deleteTag(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.tag(id) == nil {
+		notFound(w, "tag", id)
+
+		return
+	}
+
+	s.tags = slices.DeleteFunc(s.tags, func(t *api.Tag) bool { return t.ID == id })
+
+	for _, c := range s.conversations {
+		c.Tags = slices.DeleteFunc(c.Tags, func(t api.Tag) bool { return t.ID == id })
+	}
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) contact(id string) *api.Contact {
+	return find(s.contacts, func(c *api.Contact) bool {
+		if c.ID == id {
+			return true
+		}
+
+		// Front also addresses contacts as alt:email:<address>.
+		handle, ok := strings.CutPrefix(id, "alt:email:")
+
+		return ok && slices.ContainsFunc(c.Handles, func(h api.Handle) bool { return strings.EqualFold(h.Handle, handle) })
+	})
+}
+
+func (s *Server) listContacts(w http.ResponseWriter, r *http.Request) {
+	contacts := s.contacts
+
+	if after, err := strconv.ParseFloat(r.URL.Query().Get("q[updated_after]"), 64); err == nil {
+		contacts = slices.DeleteFunc(slices.Clone(contacts), func(c *api.Contact) bool { return c.UpdatedAt <= after })
+	}
+
+	writePage(w, r, values(contacts))
+}
+
+func (s *Server) getContact(w http.ResponseWriter, r *http.Request) {
+	c := s.contact(r.PathValue("id"))
+	if c == nil {
+		notFound(w, "contact", r.PathValue("id"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) createContact(w http.ResponseWriter, r *http.Request) {
+	var c api.Contact
+	if !decode(w, r, &c) {
+		return
+	}
+
+	if len(c.Handles) == 0 {
+		writeError(w, http.StatusBadRequest, "handles are required")
+
+		return
+	}
+
+	c.ID = s.id("crd")
+	c.CreatedAt = s.timestamp()
+	c.UpdatedAt = c.CreatedAt
+	s.contacts = append(s.contacts, &c)
+
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (s *Server) updateContact(w http.ResponseWriter, r *http.Request) {
+	c := s.contact(r.PathValue("id"))
+	if c == nil {
+		notFound(w, "contact", r.PathValue("id"))
+
+		return
+	}
+
+	var patch map[string]any
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	if v, ok := patch["name"].(string); ok {
+		c.Name = v
+	}
+
+	if v, ok := patch["description"].(string); ok {
+		c.Description = v
+	}
+
+	c.UpdatedAt = s.timestamp()
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) deleteContact(w http.ResponseWriter, r *http.Request) {
+	c := s.contact(r.PathValue("id"))
+	if c == nil {
+		notFound(w, "contact", r.PathValue("id"))
+
+		return
+	}
+
+	s.contacts = slices.DeleteFunc(s.contacts, func(other *api.Contact) bool { return other == c })
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) conversation(id string) *api.Conversation {
+	return find(s.conversations, func(c *api.Conversation) bool { return c.ID == id })
+}
+
+// listConversations supports Front's q[statuses][], q[tag_id] and
+// q[inbox_id] filters, newest first. Repeated tag or inbox IDs match any.
+func (s *Server) listConversations(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.writeConversations(w, r, func(c *api.Conversation) bool {
+		if statuses := q["q[statuses][]"]; len(statuses) > 0 && !slices.Contains(statuses, c.Status) {
+			return false
+		}
+
+		if tags := q["q[tag_id]"]; len(tags) > 0 && !slices.ContainsFunc(tags, func(id string) bool { return hasTag(c, id) }) {
+			return false
+		}
+
+		if inboxes := q["q[inbox_id]"]; len(inboxes) > 0 && !slices.ContainsFunc(inboxes, func(id string) bool { return inInbox(c, id) }) {
+			return false
+		}
+
+		return true
+	})
+}
+
+func (s *Server) listTeammateConversations(w http.ResponseWriter, r *http.Request) {
+	t := s.teammate(r.PathValue("id"))
+	if t == nil {
+		notFound(w, "teammate", r.PathValue("id"))
+
+		return
+	}
+
+	s.writeConversations(w, r, func(c *api.Conversation) bool { return c.Assignee != nil && c.Assignee.ID == t.ID })
+}
+
+func (s *Server) listInboxConversations(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.inbox(id) == nil {
+		notFound(w, "inbox", id)
+
+		return
+	}
+
+	s.writeConversations(w, r, func(c *api.Conversation) bool { return inInbox(c, id) })
+}
+
+func (s *Server) listTagConversations(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.tag(id) == nil {
+		notFound(w, "tag", id)
+
+		return
+	}
+
+	s.writeConversations(w, r, func(c *api.Conversation) bool { return hasTag(c, id) })
+}
+
+func (s *Server) listContactConversations(w http.ResponseWriter, r *http.Request) {
+	contact := s.contact(r.PathValue("id"))
+	if contact == nil {
+		notFound(w, "contact", r.PathValue("id"))
+
+		return
+	}
+
+	s.writeConversations(w, r, func(c *api.Conversation) bool {
+		return c.Recipient != nil && slices.ContainsFunc(contact.Handles, func(h api.Handle) bool {
+			return strings.EqualFold(h.Handle, c.Recipient.Handle)
+		})
+	})
+}
+
+func (s *Server) writeConversations(w http.ResponseWriter, r *http.Request, keep func(*api.Conversation) bool) {
+	var out []api.Conversation
+
+	for _, c := range slices.Backward(s.conversations) {
+		if keep(c) {
+			out = append(out, *c)
+		}
+	}
+
+	writePage(w, r, out)
+}
+
+func (s *Server) getConversation(w http.ResponseWriter, r *http.Request) {
+	c := s.conversation(r.PathValue("id"))
+	if c == nil {
+		notFound(w, "conversation", r.PathValue("id"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+// updateConversation handles status and assignee_id, the fields the CLI
+// changes. An assignee makes an open conversation assigned and removing it
+// makes it unassigned, as in Front.
+func (s *Server) updateConversation(w http.ResponseWriter, r *http.Request) {
+	c := s.conversation(r.PathValue("id"))
+	if c == nil {
+		notFound(w, "conversation", r.PathValue("id"))
+
+		return
+	}
+
+	var patch map[string]any
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	if assignee, ok := patch["assignee_id"]; ok {
+		id, _ := assignee.(string)
+		if id == "" {
+			c.Assignee = nil
+		} else {
+			t := s.teammate(id)
+			if t == nil {
+				notFound(w, "teammate", id)
+
+				return
+			}
+
+			assigned := *t
+			c.Assignee = &assigned
+		}
+
+		if c.Status == "assigned" || c.Status == "unassigned" {
+			c.Status = openStatus(c)
+		}
+	}
+
+	if status, ok := patch["status"].(string); ok {
+		switch status {
+		case "open":
+			c.Status = openStatus(c)
+		case "archived", "trashed", "deleted":
+			c.Status = status
+		default:
+			writeError(w, http.StatusBadRequest, "invalid status "+status)
+
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func openStatus(c *api.Conversation) string {
+	if c.Assignee != nil {
+		return "assigned"
+	}
+
+	return "unassigned"
+}
+
+func (s *Server) setReminder(w http.ResponseWriter, r *http.Request) {
+	c := s.conversation(r.PathValue("id"))
+	if c == nil {
+		notFound(w, "conversation", r.PathValue("id"))
+
+		return
+	}
+
+	var req struct {
+		ScheduledAt *string `json:"scheduled_at"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	c.Reminders = nil
+
+	if req.ScheduledAt != nil && *req.ScheduledAt != "" {
+		at, err := time.Parse(time.RFC3339, *req.ScheduledAt)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid scheduled_at")
+
+			return
+		}
+
+		c.Reminders = []api.Reminder{{ScheduledAt: float64(at.Unix()), CreatedAt: s.timestamp()}}
+		c.Status = "snoozed"
+	} else if c.Status == "snoozed" {
+		c.Status = openStatus(c)
+	}
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) conversationTags(w http.ResponseWriter, r *http.Request) (*api.Conversation, []string, bool) {
+	c := s.conversation(r.PathValue("id"))
+	if c == nil {
+		notFound(w, "conversation", r.PathValue("id"))
+
+		return nil, nil, false
+	}
+
+	var req struct {
+		TagIDs []string `json:"tag_ids"`
+	}
+	if !decode(w, r, &req) {
+		return nil, nil, false
+	}
+
+	for _, id := range req.TagIDs {
+		if s.tag(id) == nil {
+			notFound(w, "tag", id)
+
+			return nil, nil, false
+		}
+	}
+
+	return c, req.TagIDs, true
+}
+
+func (s *Server) addConversationTags(w http.ResponseWriter, r *http.Request) {
+	c, ids, ok := s.conversationTags(w, r)
+	if !ok {
+		return
+	}
+
+	for _, id := range ids {
+		if !hasTag(c, id) {
+			c.Tags = append(c.Tags, *s.tag(id))
+		}
+	}
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) removeConversationTags(w http.ResponseWriter, r *http.Request) {
+	c, ids, ok := s.conversationTags(w, r)
+	if !ok {
+		return
+	}
+
+	c.Tags = slices.DeleteFunc(c.Tags, func(t api.Tag) bool { return slices.Contains(ids, t.ID) })
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func hasTag(c *api.Conversation, id string) bool {
+	return slices.ContainsFunc(c.Tags, func(t api.Tag) bool { return t.ID == id })
+}
+
+func inInbox(c *api.Conversation, id string) bool {
+	return slices.ContainsFunc(c.Inboxes, func(i api.Inbox) bool { return i.ID == id })
+}
+
+func (s *Server) listMessages(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.conversation(id) == nil {
+		notFound(w, "conversation", id)
+
+		return
+	}
+
+	writePage(w, r, values(s.messages[id]))
+}
+
+func (s *Server) getMessage(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if uid, ok := strings.CutPrefix(id, "alt:uid:"); ok {
+		id = s.accepted[uid]
+	}
+
+	for _, msgs := range s.messages {
+		if m := find(msgs, func(m *api.Message) bool { return m.ID == id }); m != nil {
+			writeJSON(w, http.StatusOK, m)
+
+			return
+		}
+	}
+
+	notFound(w, "message", r.PathValue("id"))
+}
+
+// outgoing is the body of a send or reply.
+type outgoing struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+func (s *Server) addMessage(conv *api.Conversation, req outgoing) *api.Message {
+	author := api.Author{ID: s.me.ID, Email: s.me.Email, Username: s.me.Username, FirstName: s.me.FirstName, LastName: s.me.LastName}
+
+	m := &api.Message{
+		ID:        s.id("msg"),
+		Type:      "email",
+		CreatedAt: s.timestamp(),
+		Blurb:     blurb(req.Body),
+		Author:    &author,
+		Body:      req.Body,
+		Text:      req.Body,
+		Subject:   conv.Subject,
+		Links:     api.Links{Related: map[string]string{"conversation": "/conversations/" + conv.ID}},
+	}
+
+	for _, to := range req.To {
+		m.Recipients = append(m.Recipients, api.Recipient{Handle: to, Role: "to"})
+	}
+
+	s.messages[conv.ID] = append(s.messages[conv.ID], m)
+
+	return m
+}
+
+// sendMessage starts a conversation. Like Front it answers 202 with a
+// message UID; the message is available at /messages/alt:uid:<uid> at once.
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	ch := s.channel(r.PathValue("id"))
+	if ch == nil {
+		notFound(w, "channel", r.PathValue("id"))
+
+		return
+	}
+
+	var req outgoing
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if len(req.To) == 0 || req.Body == "" {
+		writeError(w, http.StatusBadRequest, "to and body are required")
+
+		return
+	}
+
+	conv := &api.Conversation{
+		ID:        s.id("cnv"),
+		Subject:   req.Subject,
+		Status:    "archived",
+		Recipient: &api.Recipient{Handle: req.To[0], Role: "to"},
+		CreatedAt: s.timestamp(),
+	}
+
+	if inbox := s.channelInbox(ch); inbox != nil {
+		conv.Inboxes = []api.Inbox{*inbox}
+	}
+
+	s.conversations = append(s.conversations, conv)
+
+	m := s.addMessage(conv, req)
+	uid := strings.TrimPrefix(m.ID, "msg_")
+	s.accepted[uid] = m.ID
+
+	writeJSON(w, http.StatusAccepted, api.MessageAccepted{Status: "accepted", MessageUID: uid})
+}
+
+func (s *Server) channelInbox(ch *api.Channel) *api.Inbox {
+	if id := ch.Links.Related["inbox"]; id != "" {
+		return s.inbox(id[strings.LastIndex(id, "/")+1:])
+	}
+
+	return nil
+}
+
+func (s *Server) replyMessage(w http.ResponseWriter, r *http.Request) {
+	conv := s.conversation(r.PathValue("id"))
+	if conv == nil {
+		notFound(w, "conversation", r.PathValue("id"))
+
+		return
+	}
+
+	var req outgoing
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Body == "" {
+		writeError(w, http.StatusBadRequest, "body is required")
+
+		return
+	}
+
+	if len(req.To) == 0 && conv.Recipient != nil {
+		req.To = []string{conv.Recipient.Handle}
+	}
+
+	m := s.addMessage(conv, req)
+	uid := strings.TrimPrefix(m.ID, "msg_")
+	s.accepted[uid] = m.ID
+
+	writeJSON(w, http.StatusAccepted, api.MessageAccepted{Status: "accepted", MessageUID: uid})
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.conversation(id) == nil {
+		notFound(w, "conversation", id)
+
+		return
+	}
+
+	writePage(w, r, values(s.comments[id]))
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.conversation(id) == nil {
+		notFound(w, "conversation", id)
+
+		return
+	}
+
+	var req struct {
+		Body string `json:"body"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Body == "" {
+		writeError(w, http.StatusBadRequest, "body is required")
+
+		return
+	}
+
+	c := &api.Comment{
+		ID:       s.id("com"),
+		Author:   &api.Author{ID: s.me.ID, Email: s.me.Email, Username: s.me.Username},
+		Body:     req.Body,
+		PostedAt: s.timestamp(),
+	}
+	s.comments[id] = append(s.comments[id], c)
+
+	writeJSON(w, http.StatusCreated, c)
+}
+
+// searchConversations implements a small part of Front's search syntax:
+// is:open/archived/snoozed/trashed/assigned/unassigned, tag:, inbox:,
+// assignee:, from:/to: and free-text words matched against the subject and
+// messages.
+func (s *Server) searchConversations(w http.ResponseWriter, r *http.Request) {
+	query, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), searchPrefix))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid query")
+
+		return
+	}
+
+	terms := strings.Fields(query)
+
+	var out []api.Conversation
+
+	for _, c := range slices.Backward(s.conversations) {
+		if s.matchesSearch(c, terms) {
+			out = append(out, *c)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, api.ListResponse[api.Conversation]{Results: out, Total: len(out)})
+}
+
+func (s *Server) matchesSearch(c *api.Conversation, terms []string) bool {
+	for _, term := range terms {
+		key, value, hasKey := strings.Cut(term, ":")
+		value = strings.Trim(value, `"`)
+
+		var ok bool
+
+		switch {
+		case hasKey && key == "is":
+			ok = c.Status == value ||
+				value == "open" && (c.Status == "assigned" || c.Status == "unassigned")
+		case hasKey && key == "tag":
+			ok = slices.ContainsFunc(c.Tags, func(t api.Tag) bool { return t.ID == value || strings.EqualFold(t.Name, value) })
+		case hasKey && key == "inbox":
+			ok = slices.ContainsFunc(c.Inboxes, func(i api.Inbox) bool { return i.ID == value || strings.EqualFold(i.Name, value) })
+		case hasKey && key == "assignee":
+			ok = c.Assignee != nil && (c.Assignee.ID == value || strings.EqualFold(c.Assignee.Email, value))
+		case hasKey && (key == "from" || key == "to" || key == "recipient"):
+			ok = c.Recipient != nil && strings.EqualFold(c.Recipient.Handle, value)
+		default:
+			ok = s.containsText(c, strings.Trim(term, `"`))
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Server) containsText(c *api.Conversation, word string) bool {
+	word = strings.ToLower(word)
+
+	if strings.Contains(strings.ToLower(c.Subject), word) {
+		return true
+	}
+
+	return slices.ContainsFunc(s.messages[c.ID], func(m *api.Message) bool {
+		return strings.Contains(strings.ToLower(m.Text), word)
+	})
+}
+
+func blurb(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	if len(body) > 100 {
+		return body[:100]
+	}
+
+	return body
+}
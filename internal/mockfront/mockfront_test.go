@@ -0,0 +1,143 @@
+package mockfront
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func newTestClient(t *testing.T) *api.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(New(true))
+	t.Cleanup(srv.Close)
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "mock"}), srv.URL)
+	client.SetRetryPolicy(api.RetryPolicy{})
+
+	return client
+}
+
+func TestListPagesFollowNextLinks(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	var first api.ListResponse[api.Conversation]
+	if err := client.Get(ctx, "/conversations?limit=4", &first); err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+
+	if len(first.Results) != 4 || first.Pagination.Next == "" {
+		t.Fatalf("first page = %d results, next %q", len(first.Results), first.Pagination.Next)
+	}
+
+	raw, err := client.Raw(ctx, http.MethodGet, first.Pagination.Next, nil)
+	if err != nil {
+		t.Fatalf("next page: %v", err)
+	}
+
+	if !strings.Contains(string(raw), "cnv_refund") {
+		t.Fatalf("next page misses the oldest conversation: %s", raw)
+	}
+}
+
+func TestConversationLifecycle(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	if err := client.Patch(ctx, "/conversations/cnv_crash", map[string]string{"assignee_id": "tea_bob"}, nil); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	if err := client.Post(ctx, "/conversations/cnv_crash/tags", map[string][]string{"tag_ids": {"tag_vip"}}, nil); err != nil {
+		t.Fatalf("tag: %v", err)
+	}
+
+	conv, err := client.GetConversation(ctx, "cnv_crash")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	if conv.Status != "assigned" || conv.Assignee == nil || conv.Assignee.ID != "tea_bob" || len(conv.Tags) != 2 {
+		t.Fatalf("conversation after assign and tag = %+v", conv)
+	}
+
+	if err := client.Patch(ctx, "/conversations/cnv_crash", map[string]string{"status": "archived"}, nil); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+
+	var found api.ListResponse[api.Conversation]
+	if err := client.Get(ctx, "/conversations/search/tag:VIP%20is:archived", &found); err != nil {
+		t.Fatalf("search: %v", err)
+	}
+
+	if len(found.Results) != 1 || found.Results[0].ID != "cnv_crash" {
+		t.Fatalf("search results = %+v", found.Results)
+	}
+
+	if err := client.Patch(ctx, "/conversations/cnv_missing", map[string]string{"status": "open"}, nil); err == nil {
+		t.Fatal("patched a conversation that does not exist")
+	}
+}
+
+func TestSendCreatesConversation(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	var accepted api.MessageAccepted
+
+	body := map[string]any{"to": []string{"new@customer.com"}, "subject": "Hello", "body": "Hi there"}
+	if err := client.Post(ctx, "/channels/cha_support/messages", body, &accepted); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	msg, err := client.GetMessage(ctx, "alt:uid:"+accepted.MessageUID)
+	if err != nil {
+		t.Fatalf("get sent message: %v", err)
+	}
+
+	conv, err := client.GetConversation(ctx, msg.ConversationID())
+	if err != nil || conv.Subject != "Hello" || len(conv.Inboxes) != 1 || conv.Inboxes[0].ID != "inb_support" {
+		t.Fatalf("sent conversation = %+v, %v", conv, err)
+	}
+}
+
+func TestRepeatedIdempotencyKeyReplaysResponse(t *testing.T) {
+	s := New(true)
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/conversations/cnv_refund/comments", strings.NewReader(`{"body":"note"}`))
+		req.Header.Set("Authorization", "Bearer mock")
+		req.Header.Set(api.IdempotencyKeyHeader, "same-key")
+
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	first, second := send(), send()
+
+	if first.Code != http.StatusCreated || second.Code != http.StatusCreated || first.Body.String() != second.Body.String() {
+		t.Fatalf("responses differ: %d %s / %d %s", first.Code, first.Body, second.Code, second.Body)
+	}
+
+	if n := len(s.comments["cnv_refund"]); n != 2 {
+		t.Fatalf("comments = %d, want the seeded one plus one", n)
+	}
+}
+
+func TestRequiresBearerToken(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New(false).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/me", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+}
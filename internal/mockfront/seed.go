@@ -0,0 +1,127 @@
+package mockfront
+
+import (
+	"time"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+// Seed adds a small support workspace: two more teammates, a team, two
+// inboxes with their channels, a few tags and contacts, and conversations in
+// each status with messages and a comment.
+func (s *Server) Seed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created := float64(s.now().Add(-72 * time.Hour).Unix())
+	at := func(hours float64) float64 { return created + hours*3600 }
+
+	alice := &api.Teammate{ID: "tea_alice", Email: "alice@example.com", Username: "alice", FirstName: "Alice", LastName: "Archer", IsAvailable: true}
+	bob := &api.Teammate{ID: "tea_bob", Email: "bob@example.com", Username: "bob", FirstName: "Bob", LastName: "Baker"}
+	s.teammates = append(s.teammates, alice, bob)
+
+	support := &api.Inbox{ID: "inb_support", Name: "Support"}
+	sales := &api.Inbox{ID: "inb_sales", Name: "Sales"}
+	s.inboxes = []*api.Inbox{support, sales}
+
+	s.teams = []*api.Team{{
+		ID:      "tim_support",
+		Name:    "Support Team",
+		Inboxes: []api.Inbox{*support, *sales},
+		Members: []api.Teammate{s.me, *alice, *bob},
+	}}
+
+	s.channels = []*api.Channel{
+		{ID: "cha_support", Name: "Support email", Type: "email", Address: "support@example.com", SendAs: "support@example.com", IsValid: true, Links: api.Links{Related: map[string]string{"inbox": "/inboxes/inb_support"}}},
+		{ID: "cha_sales", Name: "Sales email", Type: "email", Address: "sales@example.com", SendAs: "sales@example.com", IsValid: true, Links: api.Links{Related: map[string]string{"inbox": "/inboxes/inb_sales"}}},
+	}
+
+	vip := &api.Tag{ID: "tag_vip", Name: "VIP", Highlight: "red", CreatedAt: at(0)}
+	bug := &api.Tag{ID: "tag_bug", Name: "Bug", Highlight: "orange", CreatedAt: at(0)}
+	billing := &api.Tag{ID: "tag_billing", Name: "Billing", Highlight: "blue", CreatedAt: at(0)}
+	s.tags = []*api.Tag{vip, bug, billing}
+
+	s.contacts = []*api.Contact{
+		{ID: "crd_jane", Name: "Jane Doe", Handles: []api.Handle{{Handle: "jane@customer.com", Source: "email"}}, CreatedAt: at(0), UpdatedAt: at(1)},
+		{ID: "crd_omar", Name: "Omar Ali", Handles: []api.Handle{{Handle: "omar@acme.io", Source: "email"}, {Handle: "+15550100", Source: "phone"}}, CreatedAt: at(0), UpdatedAt: at(2)},
+		{ID: "crd_li", Name: "Li Wei", Handles: []api.Handle{{Handle: "li@shop.example", Source: "email"}}, CreatedAt: at(1), UpdatedAt: at(3)},
+	}
+
+	conv := func(id, subject, status, contact string, assignee *api.Teammate, inbox *api.Inbox, hours float64, tags ...*api.Tag) *api.Conversation {
+		c := &api.Conversation{
+			ID:           id,
+			Subject:      subject,
+			Status:       status,
+			Recipient:    &api.Recipient{Handle: contact, Role: "from"},
+			Inboxes:      []api.Inbox{*inbox},
+			CreatedAt:    at(hours),
+			WaitingSince: at(hours + 1),
+		}
+
+		if assignee != nil {
+			a := *assignee
+			c.Assignee = &a
+		}
+
+		for _, t := range tags {
+			c.Tags = append(c.Tags, *t)
+		}
+
+		s.conversations = append(s.conversations, c)
+
+		return c
+	}
+
+	inbound := func(c *api.Conversation, id, from, text string, hours float64) {
+		s.messages[c.ID] = append(s.messages[c.ID], &api.Message{
+			ID:         id,
+			Type:       "email",
+			IsInbound:  true,
+			CreatedAt:  at(hours),
+			Blurb:      blurb(text),
+			Author:     &api.Author{},
+			Recipients: []api.Recipient{{Handle: from, Role: "from"}, {Handle: "support@example.com", Role: "to"}},
+			Body:       "<p>" + text + "</p>",
+			Text:       text,
+			Subject:    c.Subject,
+			Links:      api.Links{Related: map[string]string{"conversation": "/conversations/" + c.ID}},
+		})
+	}
+
+	refund := conv("cnv_refund", "Refund for order #1042", "assigned", "jane@customer.com", alice, support, 2, vip, billing)
+	inbound(refund, "msg_refund1", "jane@customer.com", "Hi, I was charged twice for order #1042. Could you refund one of the payments?", 2)
+	s.messages[refund.ID] = append(s.messages[refund.ID], &api.Message{
+		ID:         "msg_refund2",
+		Type:       "email",
+		CreatedAt:  at(5),
+		Blurb:      "Sorry about that, Jane. The duplicate charge has been refunded.",
+		Author:     &api.Author{ID: alice.ID, Email: alice.Email, Username: alice.Username, FirstName: alice.FirstName, LastName: alice.LastName},
+		Recipients: []api.Recipient{{Handle: "support@example.com", Role: "from"}, {Handle: "jane@customer.com", Role: "to"}},
+		Body:       "<p>Sorry about that, Jane. The duplicate charge has been refunded.</p>",
+		Text:       "Sorry about that, Jane. The duplicate charge has been refunded.",
+		Subject:    "Re: " + refund.Subject,
+		Links:      api.Links{Related: map[string]string{"conversation": "/conversations/" + refund.ID}},
+	})
+	s.comments[refund.ID] = []*api.Comment{{
+		ID:       "com_refund",
+		Author:   &api.Author{ID: alice.ID, Email: alice.Email, Username: alice.Username},
+		Body:     "Refund issued in Stripe.",
+		PostedAt: at(4),
+	}}
+
+	crash := conv("cnv_crash", "App crashes on login", "unassigned", "omar@acme.io", nil, support, 10, bug)
+	inbound(crash, "msg_crash1", "omar@acme.io", "Since the last update the app crashes right after I log in.", 10)
+
+	quote := conv("cnv_quote", "Quote for 50 seats", "assigned", "li@shop.example", &s.me, sales, 20)
+	inbound(quote, "msg_quote1", "li@shop.example", "Could you send us a quote for 50 seats on the annual plan?", 20)
+
+	invoice := conv("cnv_invoice", "Invoice address change", "archived", "jane@customer.com", bob, support, 30, billing)
+	inbound(invoice, "msg_invoice1", "jane@customer.com", "Please update the address on our invoices.", 30)
+
+	followUp := conv("cnv_followup", "Following up on the demo", "snoozed", "omar@acme.io", &s.me, sales, 40)
+	inbound(followUp, "msg_followup1", "omar@acme.io", "Thanks for the demo! We will get back to you next week.", 40)
+	followUp.Reminders = []api.Reminder{{ScheduledAt: float64(s.now().Add(48 * time.Hour).Unix()), CreatedAt: at(41)}}
+
+	spam := conv("cnv_spam", "You won a prize", "trashed", "winner@spam.example", nil, support, 50)
+	inbound(spam, "msg_spam1", "winner@spam.example", "Click here to claim your prize.", 50)
+}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// colorProfile is the terminal's color support; Ascii (the default) disables
+// styling. See SetColorProfile.
+var colorProfile = termenv.Ascii
+
+// ansiPattern matches the SGR escape sequences termenv emits.
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// statusColors are the colors of conversation statuses.
+var statusColors = map[string]string{
+	"open":       "#22c55e",
+	"unassigned": "#22c55e",
+	"assigned":   "#60a5fa",
+	"snoozed":    "#f59e0b",
+	"archived":   "#9ca3af",
+	"trashed":    "#ef4444",
+	"deleted":    "#ef4444",
+}
+
+// highlightColors are Front's tag highlight names.
+var highlightColors = map[string]string{
+	"grey":       "#9ca3af",
+	"pink":       "#f472b6",
+	"red":        "#ef4444",
+	"orange":     "#f97316",
+	"yellow":     "#eab308",
+	"green":      "#22c55e",
+	"light-blue": "#38bdf8",
+	"blue":       "#3b82f6",
+	"purple":     "#a78bfa",
+}
+
+// SetColorProfile enables styled table and detail output for the profile;
+// termenv.Ascii turns it off.
+func SetColorProfile(p termenv.Profile) {
+	colorProfile = p
+}
+
+// ColorEnabled reports whether output is styled.
+func ColorEnabled() bool {
+	return colorProfile != termenv.Ascii
+}
+
+func colored(s, hex string) string {
+	if !ColorEnabled() || hex == "" || s == "" {
+		return s
+	}
+
+	return termenv.String(s).Foreground(colorProfile.Color(hex)).String()
+}
+
+// StyleStatus colors a conversation status.
+func StyleStatus(status string) string {
+	return colored(status, statusColors[strings.ToLower(status)])
+}
+
+// StyleDirection colors a message direction label: inbound cyan, outbound
+// magenta.
+func StyleDirection(label string, inbound bool) string {
+	if inbound {
+		return colored(label, "#22d3ee")
+	}
+
+	return colored(label, "#e879f9")
+}
+
+// StyleHighlight colors s with a tag highlight color.
+func StyleHighlight(s, highlight string) string {
+	return colored(s, highlightColors[strings.ToLower(highlight)])
+}
+
+// StyleDim renders secondary text, such as placeholders.
+func StyleDim(s string) string {
+	return colored(s, "#6b7280")
+}
+
+// StripANSI removes color escape sequences from s.
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
+	return ansiPattern.ReplaceAllString(s, "")
+}
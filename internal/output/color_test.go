@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestTableAlignsColoredCells(t *testing.T) {
+	SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { SetColorProfile(termenv.Ascii) })
+
+	var buf bytes.Buffer
+
+	tbl := NewTableWriter(&buf, false)
+	tbl.AddRow("ID", "STATUS", "SUBJECT")
+	tbl.AddRow("cnv_1", StyleStatus("archived"), "Refund")
+	tbl.AddRow("cnv_22", StyleStatus("open"), "Invoice")
+
+	if err := tbl.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "\x1b[") {
+		t.Fatalf("expected colored output: %q", buf.String())
+	}
+
+	want := "ID      STATUS    SUBJECT\n" +
+		"cnv_1   archived  Refund\n" +
+		"cnv_22  open      Invoice\n"
+	if got := StripANSI(buf.String()); got != want {
+		t.Fatalf("table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPlainTableStripsColor(t *testing.T) {
+	SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { SetColorProfile(termenv.Ascii) })
+
+	var buf bytes.Buffer
+
+	tbl := NewTableWriter(&buf, true)
+	tbl.AddRow("tag_1", StyleHighlight("VIP", "red"), "red")
+
+	if got := buf.String(); got != "tag_1\tVIP\tred\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestStylesAreNoOpsWithoutColor(t *testing.T) {
+	if got := StyleStatus("open") + StyleDirection("IN", true) + StyleHighlight("VIP", "red"); got != "openINVIP" {
+		t.Fatalf("styled without color: %q", got)
+	}
+}
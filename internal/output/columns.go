@@ -0,0 +1,383 @@
+package output
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+var errUnknownColumn = errors.New("unknown column")
+
+// timestamp marks a Unix time so cells render it with FormatTimestamp.
+type timestamp float64
+
+// waiting marks a waiting_since time so cells render it with FormatWaiting
+// and it sorts by how long it has waited.
+type waiting float64
+
+// derivedColumns are table columns that are not plain fields.
+var derivedColumns = map[reflect.Type]map[string]func(reflect.Value) any{
+	reflect.TypeFor[api.Conversation](): {
+		// Matches the UPDATED column of conversation tables.
+		"updated": func(v reflect.Value) any {
+			conv, _ := v.Interface().(api.Conversation)
+			if conv.WaitingSince != 0 {
+				return timestamp(conv.WaitingSince)
+			}
+
+			return timestamp(conv.CreatedAt)
+		},
+		// Matches the WAITING column: time since waiting_since.
+		"waiting": func(v reflect.Value) any {
+			conv, _ := v.Interface().(api.Conversation)
+
+			return waiting(conv.WaitingSince)
+		},
+	},
+}
+
+// labelKeys are the fields that name a nested resource in a cell, in order
+// of preference: a teammate shows its email, a tag its name.
+var labelKeys = []string{"email", "name", "handle", "username", "id"}
+
+// ParseColumns splits a --columns value into lowercase column names.
+func ParseColumns(value string) []string {
+	fields := ParseFields(value)
+	for i, f := range fields {
+		fields[i] = strings.ToLower(f)
+	}
+
+	return fields
+}
+
+// ParseSort splits a --sort value such as "updated" or "updated:desc".
+func ParseSort(value string) (column string, desc bool, err error) {
+	column, order, _ := strings.Cut(strings.ToLower(strings.TrimSpace(value)), ":")
+
+	if column, desc = strings.CutPrefix(column, "-"); desc && order != "" {
+		return "", false, fmt.Errorf("invalid sort %q: use either -column or column:desc", value)
+	}
+
+	switch order {
+	case "", "asc":
+	case "desc":
+		desc = true
+	default:
+		return "", false, fmt.Errorf("invalid sort order %q (use asc or desc)", order)
+	}
+
+	return column, desc, nil
+}
+
+// Columns lists the column names available for items of type T: its JSON
+// fields (timestamps also without their _at suffix) and derived columns.
+func Columns[T any]() []string {
+	t := reflect.TypeFor[T]()
+
+	var names []string
+
+	for name := range derivedColumns[t] {
+		names = append(names, name)
+	}
+
+	for _, f := range jsonFields(t) {
+		names = append(names, f.name)
+		if short, ok := strings.CutSuffix(f.name, "_at"); ok {
+			names = append(names, short)
+		}
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+// CheckColumns reports the first of columns that items of type T lack.
+func CheckColumns[T any](columns ...string) error {
+	available := Columns[T]()
+
+	for _, col := range columns {
+		if col == "" {
+			continue
+		}
+
+		root, _, _ := strings.Cut(col, ".")
+		if !slices.Contains(available, root) {
+			return fmt.Errorf("%w %q (available: %s)", errUnknownColumn, col, strings.Join(available, ", "))
+		}
+	}
+
+	return nil
+}
+
+// ColumnRow renders item's cells for columns, formatted as in the built-in
+// tables: timestamps as dates, nested resources by email or name.
+func ColumnRow[T any](item T, columns []string) []string {
+	v := reflect.ValueOf(item)
+
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		row[i] = cell(columnValue(v, col))
+	}
+
+	return row
+}
+
+// ColumnHeaders returns the table headers for columns.
+func ColumnHeaders(columns []string) []string {
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = strings.ToUpper(col)
+	}
+
+	return headers
+}
+
+// SortByColumn sorts items by column, stably. Numbers and timestamps sort
+// numerically, everything else by its cell text, case-insensitively.
+func SortByColumn[T any](items []T, column string, desc bool) {
+	keys := make(map[int]any, len(items))
+	index := make([]int, len(items))
+
+	for i, item := range items {
+		index[i] = i
+		keys[i] = sortKey(columnValue(reflect.ValueOf(item), column))
+	}
+
+	slices.SortStableFunc(index, func(a, b int) int {
+		c := compareKeys(keys[a], keys[b])
+		if desc {
+			return -c
+		}
+
+		return c
+	})
+
+	sorted := make([]T, len(items))
+	for i, idx := range index {
+		sorted[i] = items[idx]
+	}
+
+	copy(items, sorted)
+}
+
+type jsonField struct {
+	name  string
+	index int
+}
+
+func jsonFields(t reflect.Type) []jsonField {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []jsonField
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || strings.HasPrefix(name, "_") {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+
+		fields = append(fields, jsonField{name: name, index: i})
+	}
+
+	return fields
+}
+
+// columnValue resolves a dot-separated column on v. Through a slice, the
+// rest of the path is resolved on each element and the results collected.
+func columnValue(v reflect.Value, path string) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	name, rest, nested := strings.Cut(path, ".")
+
+	if v.Kind() == reflect.Slice {
+		values := make([]any, 0, v.Len())
+		for i := range v.Len() {
+			values = append(values, columnValue(v.Index(i), path))
+		}
+
+		return values
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if derive, ok := derivedColumns[v.Type()][name]; ok && !nested {
+		return derive(v)
+	}
+
+	for _, f := range jsonFields(v.Type()) {
+		if f.name != name && f.name != name+"_at" {
+			continue
+		}
+
+		fv := v.Field(f.index)
+
+		if nested {
+			return columnValue(fv, rest)
+		}
+
+		if fv.Kind() == reflect.Float64 && isTimeField(f.name) {
+			return timestamp(fv.Float())
+		}
+
+		return fv.Interface()
+	}
+
+	return nil
+}
+
+func isTimeField(name string) bool {
+	return strings.HasSuffix(name, "_at") || strings.HasSuffix(name, "_since")
+}
+
+// cell renders a column value for a table.
+func cell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case timestamp:
+		if v == 0 {
+			return "-"
+		}
+
+		return FormatTimestamp(float64(v))
+	case waiting:
+		return FormatWaiting(float64(v))
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		return joinCells(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "-"
+		}
+
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return labelOf(rv)
+	case reflect.Slice:
+		items := make([]any, rv.Len())
+		for i := range rv.Len() {
+			items[i] = rv.Index(i).Interface()
+		}
+
+		return joinCells(items)
+	default:
+		return fmt.Sprint(rv.Interface())
+	}
+}
+
+func joinCells(items []any) string {
+	if len(items) == 0 {
+		return "-"
+	}
+
+	cells := make([]string, len(items))
+	for i, item := range items {
+		cells[i] = cell(item)
+	}
+
+	return strings.Join(cells, ", ")
+}
+
+// labelOf names a nested resource by its first non-empty label field.
+func labelOf(v reflect.Value) string {
+	fields := jsonFields(v.Type())
+
+	for _, key := range labelKeys {
+		for _, f := range fields {
+			if f.name != key {
+				continue
+			}
+
+			if s, ok := v.Field(f.index).Interface().(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+
+	return "-"
+}
+
+// sortKey is a float64 for numbers and timestamps and lowercase cell text
+// otherwise.
+func sortKey(value any) any {
+	switch v := value.(type) {
+	case timestamp:
+		return float64(v)
+	case waiting:
+		if v == 0 {
+			return 0.0
+		}
+
+		return float64(nowFunc().Unix()) - float64(v)
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case bool:
+		if v {
+			return 1.0
+		}
+
+		return 0.0
+	}
+
+	return strings.ToLower(cell(value))
+}
+
+func compareKeys(a, b any) int {
+	af, aNum := a.(float64)
+	bf, bNum := b.(float64)
+
+	switch {
+	case aNum && bNum:
+		return cmp.Compare(af, bf)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+
+	as, _ := a.(string)
+	bs, _ := b.(string)
+
+	return strings.Compare(as, bs)
+}
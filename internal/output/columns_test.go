@@ -0,0 +1,92 @@
+package output
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestColumnRow(t *testing.T) {
+	conv := api.Conversation{
+		ID:           "cnv_1",
+		Subject:      "Refund",
+		Assignee:     &api.Teammate{ID: "tea_1", Email: "ann@example.com"},
+		Tags:         []api.Tag{{ID: "tag_1", Name: "billing"}, {ID: "tag_2", Name: "vip"}},
+		CreatedAt:    1704067200,
+		WaitingSince: 1704070800,
+	}
+
+	got := ColumnRow(conv, []string{"id", "assignee", "tags", "tags.id", "updated", "recipient"})
+	want := []string{"cnv_1", "ann@example.com", "billing, vip", "tag_1, tag_2", FormatTimestamp(1704070800), "-"}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("ColumnRow = %q, want %q", got, want)
+	}
+}
+
+func TestCheckColumns(t *testing.T) {
+	if err := CheckColumns[api.Conversation]("id", "created", "assignee.email", "updated"); err != nil {
+		t.Fatalf("CheckColumns: %v", err)
+	}
+
+	if err := CheckColumns[api.Conversation]("priority"); err == nil {
+		t.Fatal("expected an error for an unknown column")
+	}
+}
+
+func TestSortByColumn(t *testing.T) {
+	convs := []api.Conversation{
+		{ID: "cnv_1", Subject: "beta", CreatedAt: 300},
+		{ID: "cnv_2", Subject: "Alpha", CreatedAt: 100, WaitingSince: 400},
+		{ID: "cnv_3", Subject: "gamma", CreatedAt: 200},
+	}
+
+	ids := func() []string {
+		out := make([]string, len(convs))
+		for i, c := range convs {
+			out[i] = c.ID
+		}
+
+		return out
+	}
+
+	SortByColumn(convs, "subject", false)
+
+	if got := ids(); !slices.Equal(got, []string{"cnv_2", "cnv_1", "cnv_3"}) {
+		t.Fatalf("by subject: %v", got)
+	}
+
+	SortByColumn(convs, "updated", true)
+
+	if got := ids(); !slices.Equal(got, []string{"cnv_2", "cnv_1", "cnv_3"}) {
+		t.Fatalf("by updated desc: %v", got)
+	}
+
+	SortByColumn(convs, "created", false)
+
+	if got := ids(); !slices.Equal(got, []string{"cnv_2", "cnv_3", "cnv_1"}) {
+		t.Fatalf("by created: %v", got)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	for in, want := range map[string]struct {
+		col  string
+		desc bool
+	}{
+		"updated":      {"updated", false},
+		"Updated:DESC": {"updated", true},
+		"-created":     {"created", true},
+		"subject:asc":  {"subject", false},
+	} {
+		col, desc, err := ParseSort(in)
+		if err != nil || col != want.col || desc != want.desc {
+			t.Errorf("ParseSort(%q) = %q, %v, %v", in, col, desc, err)
+		}
+	}
+
+	if _, _, err := ParseSort("subject:up"); err == nil {
+		t.Error("expected an error for an unknown order")
+	}
+}
@@ -0,0 +1,401 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output.
+const (
+	FormatTable  = "table"
+	FormatJSON   = "json"
+	FormatYAML   = "yaml"
+	FormatCSV    = "csv"
+	FormatTSV    = "tsv"
+	FormatNDJSON = "ndjson"
+)
+
+// Formats lists the valid --output values.
+var Formats = []string{FormatTable, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatNDJSON}
+
+// Write renders a command result in the mode's format. List responses are
+// rendered as their _results; with Fields set each record is projected onto
+// those (dot-separated) paths first. A Template or JQ expression takes
+// precedence over both.
+func (m Mode) Write(w io.Writer, v any) error {
+	if m.JQ != "" {
+		return m.writeJQ(w, v)
+	}
+
+	if m.Template != "" {
+		return m.writeTemplate(w, v)
+	}
+
+	if m.Format == FormatJSON && len(m.Fields) == 0 {
+		return WriteJSON(w, v)
+	}
+
+	root, err := toNode(v)
+	if err != nil {
+		return err
+	}
+
+	records, isList := recordsOf(root)
+
+	columns := m.Fields
+	if len(columns) > 0 {
+		for i, rec := range records {
+			records[i] = project(rec, columns)
+		}
+
+		if isList {
+			root = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: records}
+		} else {
+			root = records[0]
+		}
+	} else if len(records) > 0 {
+		columns = keysOf(records[0])
+	}
+
+	switch m.Format {
+	case FormatNDJSON:
+		return writeNDJSON(w, records)
+	case FormatYAML:
+		return writeYAML(w, root)
+	case FormatCSV:
+		return writeCSV(w, columns, records)
+	case FormatTable, FormatTSV:
+		tbl := NewTableWriter(w, m.Format == FormatTSV)
+
+		header := make([]string, len(columns))
+		for i, col := range columns {
+			header[i] = strings.ToUpper(col)
+		}
+
+		tbl.AddRow(header...)
+
+		for _, rec := range records {
+			tbl.AddRow(rowOf(rec, columns)...)
+		}
+
+		return tbl.Flush()
+	default:
+		data, err := marshalNodeJSON(root)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+
+		buf.WriteByte('\n')
+
+		_, err = buf.WriteTo(w)
+
+		return err
+	}
+}
+
+// ParseFields splits a --fields value into trimmed, non-empty paths.
+func ParseFields(value string) []string {
+	var fields []string
+
+	for _, f := range strings.Split(value, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// toNode converts v to a YAML node tree via its JSON encoding, so field names
+// follow the json tags and key order follows the struct.
+func toNode(v any) (*yaml.Node, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode json: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+
+	root := doc.Content[0]
+	resetStyle(root)
+
+	return root, nil
+}
+
+// resetStyle drops the flow and quoting styles inherited from JSON so YAML
+// output uses block style. Strings that would read back as another type stay
+// quoted.
+func resetStyle(n *yaml.Node) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" || !ambiguousString(n.Value) {
+		n.Style = 0
+	}
+
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
+
+// ambiguousString reports whether s, written as a plain YAML scalar, would be
+// read as something other than the string s (including YAML 1.1 booleans).
+func ambiguousString(s string) bool {
+	switch strings.ToLower(s) {
+	case "y", "n", "yes", "no", "on", "off":
+		return true
+	}
+
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return true
+	}
+
+	str, ok := v.(string)
+
+	return !ok || str != s
+}
+
+// recordsOf returns the records in a result: the _results of a list
+// response, the elements of an array, or the value itself.
+func recordsOf(n *yaml.Node) ([]*yaml.Node, bool) {
+	if results := lookup(n, "_results"); results != nil && results.Kind == yaml.SequenceNode {
+		return results.Content, true
+	}
+
+	if n.Kind == yaml.SequenceNode {
+		return n.Content, true
+	}
+
+	return []*yaml.Node{n}, false
+}
+
+// lookup resolves a dot-separated path; numeric segments index arrays.
+func lookup(n *yaml.Node, path string) *yaml.Node {
+	for _, key := range strings.Split(path, ".") {
+		if n == nil {
+			return nil
+		}
+
+		switch n.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == key {
+					next = n.Content[i+1]
+
+					break
+				}
+			}
+
+			n = next
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(n.Content) {
+				return nil
+			}
+
+			n = n.Content[idx]
+		default:
+			return nil
+		}
+	}
+
+	return n
+}
+
+func project(rec *yaml.Node, fields []string) *yaml.Node {
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, f := range fields {
+		val := lookup(rec, f)
+		if val == nil {
+			val = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+		}
+
+		out.Content = append(out.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f}, val)
+	}
+
+	return out
+}
+
+func keysOf(n *yaml.Node) []string {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	keys := make([]string, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		keys = append(keys, n.Content[i].Value)
+	}
+
+	return keys
+}
+
+func rowOf(rec *yaml.Node, columns []string) []string {
+	row := make([]string, len(columns))
+
+	for i, col := range columns {
+		row[i] = cellOf(field(rec, col))
+	}
+
+	return row
+}
+
+// field returns the value for a column: an exact key (as in projected
+// records, whose keys are the requested paths) or a dot-separated path.
+func field(rec *yaml.Node, path string) *yaml.Node {
+	if rec.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(rec.Content); i += 2 {
+			if rec.Content[i].Value == path {
+				return rec.Content[i+1]
+			}
+		}
+	}
+
+	return lookup(rec, path)
+}
+
+// cellOf renders a value for a CSV or table cell: scalars as-is, nested
+// values as compact JSON.
+func cellOf(n *yaml.Node) string {
+	if n == nil || n.Tag == "!!null" {
+		return ""
+	}
+
+	if n.Kind == yaml.ScalarNode {
+		return n.Value
+	}
+
+	data, err := marshalNodeJSON(n)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+func writeYAML(w io.Writer, n *yaml.Node) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(n); err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+
+	return enc.Close()
+}
+
+// writeNDJSON writes each record as compact JSON on its own line.
+func writeNDJSON(w io.Writer, records []*yaml.Node) error {
+	var buf bytes.Buffer
+
+	for _, rec := range records {
+		if err := encodeNodeJSON(&buf, rec); err != nil {
+			return err
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	_, err := buf.WriteTo(w)
+
+	return err
+}
+
+func writeCSV(w io.Writer, columns []string, records []*yaml.Node) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(columns); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+
+	for _, rec := range records {
+		if err := cw.Write(rowOf(rec, columns)); err != nil {
+			return fmt.Errorf("write csv: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// marshalNodeJSON encodes a node as compact JSON, preserving mapping order.
+func marshalNodeJSON(n *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := encodeNodeJSON(&buf, n); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeNodeJSON(buf *bytes.Buffer, n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			key, _ := json.Marshal(n.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+
+			if err := encodeNodeJSON(buf, n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+
+		for i, c := range n.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := encodeNodeJSON(buf, c); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	default:
+		var v any
+		if err := n.Decode(&v); err != nil {
+			return fmt.Errorf("decode value: %w", err)
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+
+		buf.Write(data)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func testConversations() api.ListResponse[api.Conversation] {
+	return api.ListResponse[api.Conversation]{Results: []api.Conversation{
+		{ID: "cnv_1", Subject: "Hello, world", Assignee: &api.Teammate{Email: "a@example.com"}},
+		{ID: "cnv_2", Subject: "yes"},
+	}}
+}
+
+func TestModeWriteFields(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{FormatCSV, "id,subject,assignee.email\ncnv_1,\"Hello, world\",a@example.com\ncnv_2,yes,\n"},
+		{FormatTSV, "ID\tSUBJECT\tASSIGNEE.EMAIL\ncnv_1\tHello, world\ta@example.com\ncnv_2\tyes\t\n"},
+		{FormatJSON, "[\n  {\n    \"id\": \"cnv_1\",\n    \"subject\": \"Hello, world\",\n    \"assignee.email\": \"a@example.com\"\n  },\n" +
+			"  {\n    \"id\": \"cnv_2\",\n    \"subject\": \"yes\",\n    \"assignee.email\": null\n  }\n]\n"},
+		{FormatYAML, "- id: cnv_1\n  subject: Hello, world\n  assignee.email: a@example.com\n- id: cnv_2\n  subject: \"yes\"\n  assignee.email: null\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+
+		mode := Mode{Format: tt.format, Fields: []string{"id", "subject", "assignee.email"}}
+		if err := mode.Write(&buf, testConversations()); err != nil {
+			t.Fatalf("%s: Write: %v", tt.format, err)
+		}
+
+		if got := buf.String(); got != tt.want {
+			t.Fatalf("%s: got\n%s\nwant\n%s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestModeWriteYAMLKeepsFieldOrder(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := (Mode{Format: FormatYAML}).Write(&buf, api.Tag{ID: "tag_1", Name: "vip"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := buf.String(); got[:len("id: tag_1\nname: vip\n")] != "id: tag_1\nname: vip\n" {
+		t.Fatalf("unexpected yaml:\n%s", got)
+	}
+}
+
+func TestModeWriteCSVDefaultsToTopLevelKeys(t *testing.T) {
+	var buf bytes.Buffer
+
+	tags := []api.Tag{{ID: "tag_1", Name: "vip"}}
+	if err := (Mode{Format: FormatCSV}).Write(&buf, tags); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := buf.String(); got[:len("id,name")] != "id,name" {
+		t.Fatalf("unexpected csv:\n%s", got)
+	}
+}
+
+func TestModeWriteTemplate(t *testing.T) {
+	var buf bytes.Buffer
+
+	mode := Mode{Template: `{{.ID}} {{truncate 8 .Subject}} {{if .Assignee}}{{.Assignee.Email}}{{else}}-{{end}}`}
+	if err := mode.Write(&buf, testConversations()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := "cnv_1 Hello... a@example.com\ncnv_2 yes -\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestModeWriteTemplateSingleValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	tag := &api.Tag{ID: "tag_1", Name: "vip"}
+	if err := (Mode{Template: "{{upper .Name}}\n"}).Write(&buf, tag); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := buf.String(); got != "VIP\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestModeWriteJQ(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"._results[].id", "cnv_1\ncnv_2\n"},
+		{"._results | length", "2\n"},
+		{"._results[0].assignee | {email}", "{\n  \"email\": \"a@example.com\"\n}\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+
+		if err := (Mode{Format: FormatJSON, JQ: tt.expr}).Write(&buf, testConversations()); err != nil {
+			t.Fatalf("%s: Write: %v", tt.expr, err)
+		}
+
+		if got := buf.String(); got != tt.want {
+			t.Fatalf("%s: got %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestModeWriteNDJSON(t *testing.T) {
+	tests := []struct {
+		fields []string
+		want   string
+	}{
+		{nil, "{\"id\":\"cnv_1\",\"subject\":\"Hello, world\","},
+		{[]string{"id", "assignee.email"}, "{\"id\":\"cnv_1\",\"assignee.email\":\"a@example.com\"}\n{\"id\":\"cnv_2\",\"assignee.email\":null}\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+
+		if err := (Mode{Format: FormatNDJSON, Fields: tt.fields}).Write(&buf, testConversations()); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+
+		got := buf.String()
+		if !strings.HasPrefix(got, tt.want) || strings.Count(got, "\n") != 2 {
+			t.Fatalf("got %q, want prefix %q on two lines", got, tt.want)
+		}
+	}
+}
@@ -9,9 +9,27 @@ import (
 	"strings"
 )
 
+// Mode describes how command results are rendered.
+//
+// JSON is set whenever results are written from data via Write (json, yaml,
+// csv, a --fields projection, a --format template or a --jq filter) rather
+// than through a command's own table; Plain selects tab-separated tables.
 type Mode struct {
-	JSON  bool
-	Plain bool
+	JSON     bool
+	Plain    bool
+	Format   string   // one of Formats; "" means table
+	Fields   []string // optional dot-separated field paths
+	Template string   // optional text/template rendered per record
+	JQ       string   // optional jq expression applied to the JSON result
+	Columns  []string // optional table columns replacing the default ones
+	SortBy   string   // optional table column to sort rows by
+	SortDesc bool     // sort SortBy descending
+}
+
+// Streaming reports whether records are written one line at a time, so list
+// pages can be emitted as they arrive instead of merged first.
+func (m Mode) Streaming() bool {
+	return m.Format == FormatNDJSON && m.Template == "" && m.JQ == ""
 }
 
 type ctxKey struct{}
@@ -36,8 +54,9 @@ func IsJSON(ctx context.Context) bool {
 
 func FromEnv() Mode {
 	return Mode{
-		JSON:  envBool("FRONT_JSON"),
-		Plain: envBool("FRONT_PLAIN"),
+		JSON:   envBool("FRONT_JSON"),
+		Plain:  envBool("FRONT_PLAIN"),
+		Format: strings.ToLower(strings.TrimSpace(os.Getenv("FRONT_OUTPUT"))),
 	}
 }
 
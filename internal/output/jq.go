@@ -0,0 +1,68 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/gojq"
+)
+
+// ParseJQ compiles a --jq expression.
+func ParseJQ(expr string) (*gojq.Code, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --jq expression: %w", err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --jq expression: %w", err)
+	}
+
+	return code, nil
+}
+
+// writeJQ runs the mode's jq expression against the JSON form of v and
+// prints each result: strings raw (like jq -r), everything else as JSON.
+func (m Mode) writeJQ(w io.Writer, v any) error {
+	code, err := ParseJQ(m.JQ)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+
+	var input any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("decode json: %w", err)
+	}
+
+	iter := code.Run(input)
+
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+
+		if err, isErr := result.(error); isErr {
+			return fmt.Errorf("--jq: %w", err)
+		}
+
+		if s, isStr := result.(string); isStr {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := WriteJSON(w, result); err != nil {
+			return err
+		}
+	}
+}
@@ -1,17 +1,22 @@
 package output
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
+	"unicode/utf8"
 
 	"github.com/dedene/frontapp-cli/internal/api"
 )
 
-// Table provides simple table output using tabwriter.
+// Table aligns columns like a tabwriter, but measures cells by their visible
+// width so colored cells line up.
 type Table struct {
-	w *tabwriter.Writer
+	out  io.Writer
+	rows [][]string
 }
 
 type TableWriter interface {
@@ -19,12 +24,13 @@ type TableWriter interface {
 	Flush() error
 }
 
+// PlainTable writes tab-separated rows, with any styling removed.
 type PlainTable struct {
 	w io.Writer
 }
 
 func (t *PlainTable) AddRow(cols ...string) {
-	fmt.Fprintln(t.w, strings.Join(cols, "\t"))
+	fmt.Fprintln(t.w, StripANSI(strings.Join(cols, "\t")))
 }
 
 func (t *PlainTable) Flush() error {
@@ -32,9 +38,7 @@ func (t *PlainTable) Flush() error {
 }
 
 func NewTable(out io.Writer) *Table {
-	return &Table{
-		w: tabwriter.NewWriter(out, 0, 0, 2, ' ', 0),
-	}
+	return &Table{out: out}
 }
 
 func NewPlainTable(out io.Writer) *PlainTable {
@@ -50,17 +54,54 @@ func NewTableWriter(out io.Writer, plain bool) TableWriter {
 }
 
 func (t *Table) AddRow(cols ...string) {
-	fmt.Fprintln(t.w, strings.Join(cols, "\t"))
+	t.rows = append(t.rows, cols)
 }
 
+// tablePadding is the space between columns.
+const tablePadding = 2
+
+// Flush writes the rows added since the last Flush, aligned among
+// themselves. Every cell but the last of a row is padded to its column.
 func (t *Table) Flush() error {
-	if err := t.w.Flush(); err != nil {
+	var widths []int
+
+	for _, row := range t.rows {
+		for i := 0; i < len(row)-1; i++ {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+
+			widths[i] = max(widths[i], visibleWidth(row[i]))
+		}
+	}
+
+	var b strings.Builder
+
+	for _, row := range t.rows {
+		for i, cell := range row {
+			b.WriteString(cell)
+
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-visibleWidth(cell)+tablePadding))
+			}
+		}
+
+		b.WriteByte('\n')
+	}
+
+	t.rows = t.rows[:0]
+
+	if _, err := io.WriteString(t.out, b.String()); err != nil {
 		return fmt.Errorf("flush table: %w", err)
 	}
 
 	return nil
 }
 
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(StripANSI(s))
+}
+
 // FormatConversation formats a conversation for table output.
 func FormatConversation(conv api.Conversation) []string {
 	assignee := "-"
@@ -78,7 +119,7 @@ func FormatConversation(conv api.Conversation) []string {
 
 	return []string{
 		conv.ID,
-		conv.Status,
+		StyleStatus(conv.Status),
 		assignee,
 		subject,
 		FormatTimestamp(conv.CreatedAt),
@@ -109,7 +150,7 @@ func FormatConversationWithUpdated(conv api.Conversation) []string {
 
 	return []string{
 		conv.ID,
-		conv.Status,
+		StyleStatus(conv.Status),
 		assignee,
 		subject,
 		FormatTimestamp(conv.CreatedAt),
@@ -117,6 +158,12 @@ func FormatConversationWithUpdated(conv api.Conversation) []string {
 	}
 }
 
+// FormatConversationWithWaiting is FormatConversationWithUpdated plus a
+// WAITING column: how long the conversation has waited for a reply.
+func FormatConversationWithWaiting(conv api.Conversation) []string {
+	return append(FormatConversationWithUpdated(conv), FormatWaiting(conv.WaitingSince))
+}
+
 // FormatMessage formats a message for table output.
 func FormatMessage(msg api.Message) []string {
 	direction := "OUT"
@@ -139,18 +186,140 @@ func FormatMessage(msg api.Message) []string {
 
 	return []string{
 		msg.ID,
-		direction,
+		StyleDirection(direction, msg.IsInbound),
 		author,
 		blurb,
 		FormatTimestamp(msg.CreatedAt),
 	}
 }
 
+// FormatComment formats a comment for table output.
+func FormatComment(comment api.Comment) []string {
+	author := "-"
+	if comment.Author != nil {
+		author = comment.Author.Email
+		if author == "" {
+			author = comment.Author.Username
+		}
+	}
+
+	body := comment.Body
+	if len(body) > 50 {
+		body = body[:47] + "..."
+	}
+
+	return []string{
+		comment.ID,
+		author,
+		body,
+		FormatTimestamp(comment.PostedAt),
+	}
+}
+
+// FormatEvent formats an event for table output.
+func FormatEvent(event api.Event) []string {
+	convID := "-"
+	if event.Conversation != nil && event.Conversation.ID != "" {
+		convID = event.Conversation.ID
+	}
+
+	return []string{
+		event.ID,
+		event.Type,
+		eventResourceLabel(event.Source),
+		eventResourceLabel(event.Target),
+		convID,
+		FormatTimestamp(event.EmittedAt),
+	}
+}
+
+// eventResourceLabel returns a short human label for an event source/target:
+// an email for teammates, a name for tags/inboxes, or the ID otherwise.
+func eventResourceLabel(res *api.EventResource) string {
+	if res == nil || len(res.Data) == 0 {
+		return "-"
+	}
+
+	var data struct {
+		ID     string `json:"id"`
+		Email  string `json:"email"`
+		Name   string `json:"name"`
+		Handle string `json:"handle"`
+	}
+
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		return res.Meta.Type
+	}
+
+	for _, v := range []string{data.Email, data.Name, data.Handle, data.ID} {
+		if v != "" {
+			return v
+		}
+	}
+
+	return res.Meta.Type
+}
+
+// FormatAnalyticsMetric formats an analytics metric as a (metric, value) row.
+// Durations are reported by Front in seconds; table metrics show a row count.
+func FormatAnalyticsMetric(m api.AnalyticsMetric) []string {
+	value := strings.TrimSpace(string(m.Value))
+
+	switch m.Type {
+	case "duration":
+		var secs float64
+		if err := json.Unmarshal(m.Value, &secs); err == nil {
+			value = (time.Duration(secs) * time.Second).String()
+		}
+	case "percentage":
+		var pct float64
+		if err := json.Unmarshal(m.Value, &pct); err == nil {
+			value = strconv.FormatFloat(pct, 'f', 1, 64) + "%"
+		}
+	case "table":
+		var rows []json.RawMessage
+		if err := json.Unmarshal(m.Value, &rows); err == nil {
+			value = fmt.Sprintf("%d rows", len(rows))
+		}
+	case "string":
+		var str string
+		if err := json.Unmarshal(m.Value, &str); err == nil {
+			value = str
+		}
+	}
+
+	if value == "" || value == "null" {
+		value = "-"
+	}
+
+	return []string{m.ID, value}
+}
+
+// FormatRule formats a rule for table output.
+func FormatRule(rule api.Rule) []string {
+	scope := "company"
+	if owner := rule.Owner(); owner != "" {
+		scope = owner
+	}
+
+	actions := strings.Join(rule.Actions, ", ")
+	if len(actions) > 50 {
+		actions = actions[:47] + "..."
+	}
+
+	return []string{
+		rule.ID,
+		rule.Name,
+		scope,
+		actions,
+	}
+}
+
 // FormatTag formats a tag for table output.
 func FormatTag(tag api.Tag) []string {
 	return []string{
 		tag.ID,
-		tag.Name,
+		StyleHighlight(tag.Name, tag.Highlight),
 		tag.Highlight,
 	}
 }
@@ -163,6 +332,14 @@ func FormatInbox(inbox api.Inbox) []string {
 	}
 }
 
+// FormatTeam formats a team for table output.
+func FormatTeam(team api.Team) []string {
+	return []string{
+		team.ID,
+		team.Name,
+	}
+}
+
 // FormatTeammate formats a teammate for table output.
 func FormatTeammate(tm api.Teammate) []string {
 	name := strings.TrimSpace(tm.FirstName + " " + tm.LastName)
@@ -177,6 +354,24 @@ func FormatTeammate(tm api.Teammate) []string {
 	}
 }
 
+// FormatSignature formats a signature for table output.
+func FormatSignature(sig api.Signature) []string {
+	return []string{
+		sig.ID,
+		sig.Name,
+		sig.SenderInfo,
+		fmt.Sprintf("%v", sig.IsDefault),
+	}
+}
+
+// FormatGroup formats a contact group for table output.
+func FormatGroup(group api.Group) []string {
+	return []string{
+		group.ID,
+		group.Name,
+	}
+}
+
 // FormatContact formats a contact for table output.
 func FormatContact(contact api.Contact) []string {
 	handle := "-"
@@ -191,6 +386,20 @@ func FormatContact(contact api.Contact) []string {
 	}
 }
 
+// FormatAccount formats an account for table output.
+func FormatAccount(account api.Account) []string {
+	domains := "-"
+	if len(account.Domains) > 0 {
+		domains = strings.Join(account.Domains, ", ")
+	}
+
+	return []string{
+		account.ID,
+		account.Name,
+		domains,
+	}
+}
+
 // FormatChannel formats a channel for table output.
 func FormatChannel(ch api.Channel) []string {
 	return []string{
@@ -2,7 +2,10 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
+
+	"github.com/dedene/frontapp-cli/internal/api"
 )
 
 func TestPlainTableWriterUsesTabs(t *testing.T) {
@@ -19,3 +22,22 @@ func TestPlainTableWriterUsesTabs(t *testing.T) {
 		t.Fatalf("unexpected output: %q", got)
 	}
 }
+
+func TestFormatAnalyticsMetric(t *testing.T) {
+	tests := []struct {
+		metric api.AnalyticsMetric
+		want   string
+	}{
+		{api.AnalyticsMetric{ID: "n", Type: "number", Value: json.RawMessage(`42`)}, "42"},
+		{api.AnalyticsMetric{ID: "d", Type: "duration", Value: json.RawMessage(`3725`)}, "1h2m5s"},
+		{api.AnalyticsMetric{ID: "p", Type: "percentage", Value: json.RawMessage(`87.25`)}, "87.2%"},
+		{api.AnalyticsMetric{ID: "t", Type: "table", Value: json.RawMessage(`[{},{}]`)}, "2 rows"},
+		{api.AnalyticsMetric{ID: "x", Type: "number", Value: json.RawMessage(`null`)}, "-"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatAnalyticsMetric(tt.metric)[1]; got != tt.want {
+			t.Fatalf("FormatAnalyticsMetric(%s) = %q, want %q", tt.metric.ID, got, tt.want)
+		}
+	}
+}
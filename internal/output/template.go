@@ -0,0 +1,117 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are available to --format templates.
+var templateFuncs = template.FuncMap{
+	"time":    FormatTimestamp,
+	"rfc3339": FormatTimestampRFC3339,
+	"timefmt": func(layout string, ts float64) string { return FormatTimestampLayout(ts, layout) },
+	"truncate": func(n int, s string) string {
+		if n <= 0 || len([]rune(s)) <= n {
+			return s
+		}
+
+		if n <= 3 {
+			return string([]rune(s)[:n])
+		}
+
+		return string([]rune(s)[:n-3]) + "..."
+	},
+	"join":  func(sep string, items []string) string { return strings.Join(items, sep) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		return string(data), nil
+	},
+	"default": func(def string, v any) string {
+		if v == nil {
+			return def
+		}
+
+		if s := fmt.Sprint(v); s != "" && s != "<nil>" {
+			return s
+		}
+
+		return def
+	},
+}
+
+// ParseTemplate parses a --format template.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// writeTemplate renders each result record through the mode's template. The
+// template sees the Go values (e.g. {{.ID}} {{.Subject}}); list responses are
+// rendered once per result. A newline is added after each record unless the
+// template already ends with one.
+func (m Mode) writeTemplate(w io.Writer, v any) error {
+	tmpl, err := ParseTemplate(m.Template)
+	if err != nil {
+		return err
+	}
+
+	newline := !strings.HasSuffix(m.Template, "\n")
+
+	for _, rec := range templateRecords(v) {
+		if err := tmpl.Execute(w, rec); err != nil {
+			return fmt.Errorf("render --format template: %w", err)
+		}
+
+		if newline {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// templateRecords returns the Results of a list response, the elements of a
+// slice, or v itself.
+func templateRecords(v any) []any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() == reflect.Struct {
+		if results := rv.FieldByName("Results"); results.IsValid() && results.Kind() == reflect.Slice {
+			rv = results
+		}
+	}
+
+	if rv.Kind() != reflect.Slice {
+		return []any{rv.Interface()}
+	}
+
+	records := make([]any, rv.Len())
+	for i := range records {
+		records[i] = rv.Index(i).Interface()
+	}
+
+	return records
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -10,8 +11,25 @@ import (
 var (
 	timezoneOnce sync.Once
 	timezoneLoc  *time.Location
+
+	// relativeTimes renders FormatTimestamp as "3h ago"; see SetRelativeTimes.
+	relativeTimes bool
+	nowFunc       = time.Now
 )
 
+// SetLocation overrides the configured timezone for rendered timestamps.
+func SetLocation(loc *time.Location) {
+	timezoneOnce.Do(func() {})
+
+	timezoneLoc = loc
+}
+
+// SetRelativeTimes makes FormatTimestamp render times relative to now
+// ("5m ago", "in 2d") instead of as dates.
+func SetRelativeTimes(relative bool) {
+	relativeTimes = relative
+}
+
 func loadLocation() *time.Location {
 	timezoneOnce.Do(func() {
 		cfg, err := config.ReadConfig()
@@ -38,10 +56,50 @@ func loadLocation() *time.Location {
 	return timezoneLoc
 }
 
+// FormatTimestamp renders ts for tables and detail views: a date and time in
+// the configured timezone, or a relative time with SetRelativeTimes.
 func FormatTimestamp(ts float64) string {
+	if relativeTimes && ts != 0 {
+		return FormatRelative(ts, nowFunc())
+	}
+
 	return FormatTimestampLayout(ts, "2006-01-02 15:04")
 }
 
+// FormatRelative renders ts relative to now: "just now", "5m ago", "3h ago",
+// "2d ago", or "in 4h" for future times. Past a month away it falls back to
+// the date.
+func FormatRelative(ts float64, now time.Time) string {
+	if ts == 0 {
+		return ""
+	}
+
+	t := time.Unix(int64(ts), 0)
+	d := now.Sub(t)
+
+	format := "%s ago"
+	if d < 0 {
+		d, format = -d, "in %s"
+	}
+
+	var span string
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		span = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		span = fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		span = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	default:
+		return t.In(loadLocation()).Format("2006-01-02")
+	}
+
+	return fmt.Sprintf(format, span)
+}
+
 func FormatTimestampRFC3339(ts float64) string {
 	return FormatTimestampLayout(ts, time.RFC3339)
 }
@@ -55,3 +113,38 @@ func FormatTimestampLayout(ts float64, layout string) string {
 
 	return time.Unix(int64(ts), 0).In(loc).Format(layout)
 }
+
+// FormatDuration renders d in its two largest units: "45m", "3h 20m",
+// "2d 4h". Anything under a minute is "<1m".
+func FormatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// FormatWaiting renders how long a conversation has waited for a reply since
+// its waiting_since timestamp, or "-" when it is not waiting.
+func FormatWaiting(since float64) string {
+	if since == 0 {
+		return "-"
+	}
+
+	return FormatDuration(nowFunc().Sub(time.Unix(int64(since), 0)))
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/dedene/frontapp-cli/internal/config"
 )
@@ -32,3 +33,72 @@ func TestFormatTimestampUsesConfigTimezone(t *testing.T) {
 		t.Fatalf("unexpected timestamp: %s", got)
 	}
 }
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Unix(1704067200, 0)
+
+	tests := []struct {
+		ts   float64
+		want string
+	}{
+		{0, ""},
+		{1704067200 - 20, "just now"},
+		{1704067200 - 5*60, "5m ago"},
+		{1704067200 - 3*3600 - 59, "3h ago"},
+		{1704067200 - 2*86400, "2d ago"},
+		{1704067200 + 4*3600, "in 4h"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatRelative(tt.ts, now); got != tt.want {
+			t.Errorf("FormatRelative(%v) = %q, want %q", tt.ts, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "<1m"},
+		{45 * time.Minute, "45m"},
+		{3 * time.Hour, "3h"},
+		{3*time.Hour + 20*time.Minute, "3h 20m"},
+		{2*24*time.Hour + 4*time.Hour + 59*time.Minute, "2d 4h"},
+		{9 * 24 * time.Hour, "9d"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestSetLocationAndRelativeTimes(t *testing.T) {
+	t.Cleanup(func() {
+		timezoneOnce = sync.Once{}
+		timezoneLoc = nil
+		relativeTimes = false
+		nowFunc = time.Now
+	})
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	SetLocation(tokyo)
+
+	if got := FormatTimestamp(1704067200); got != "2024-01-01 09:00" {
+		t.Fatalf("FormatTimestamp in Tokyo = %q", got)
+	}
+
+	nowFunc = func() time.Time { return time.Unix(1704067200+90*60, 0) }
+	SetRelativeTimes(true)
+
+	if got := FormatTimestamp(1704067200); got != "1h ago" {
+		t.Fatalf("relative FormatTimestamp = %q", got)
+	}
+}
@@ -0,0 +1,562 @@
+// Package tui implements the interactive inbox browser behind `frontcli ui`.
+package tui
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+	"github.com/dedene/frontapp-cli/internal/markdown"
+	"github.com/dedene/frontapp-cli/internal/output"
+)
+
+const (
+	ansiClear      = "\x1b[H\x1b[2J"
+	ansiAltScreen  = "\x1b[?1049h"
+	ansiMainScreen = "\x1b[?1049l"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+	ansiReverse    = "\x1b[7m"
+	ansiBold       = "\x1b[1m"
+	ansiDim        = "\x1b[2m"
+	ansiReset      = "\x1b[0m"
+)
+
+var errQuit = errors.New("quit")
+
+type view int
+
+const (
+	viewInboxes view = iota
+	viewConversations
+	viewPreview
+)
+
+// Options configures the browser.
+type Options struct {
+	InboxID string // open this inbox directly instead of showing the picker
+	Limit   int    // conversations fetched per inbox
+}
+
+type app struct {
+	ctx    context.Context
+	client *api.Client
+	opts   Options
+	in     *bufio.Reader
+	out    io.Writer
+	width  int
+	height int
+
+	view     view
+	inboxes  []api.Inbox
+	inbox    *api.Inbox
+	convs    []api.Conversation
+	preview  []string
+	cursor   int
+	offset   int
+	status   string
+	prompt   string
+	response string
+}
+
+// Run starts the browser on the current terminal and blocks until the user
+// quits. Stdin and stdout must both be terminals.
+func Run(ctx context.Context, client *api.Client, opts Options) error {
+	inFd := int(os.Stdin.Fd())   //nolint:gosec // file descriptors fit in int
+	outFd := int(os.Stdout.Fd()) //nolint:gosec // file descriptors fit in int
+
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return errors.New("ui requires an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("enter raw mode: %w", err)
+	}
+
+	defer func() { _ = term.Restore(inFd, state) }()
+
+	fmt.Fprint(os.Stdout, ansiAltScreen+ansiHideCursor)
+	defer fmt.Fprint(os.Stdout, ansiShowCursor+ansiMainScreen)
+
+	a := newApp(ctx, client, opts, os.Stdin, os.Stdout)
+	a.width, a.height, err = term.GetSize(outFd)
+	if err != nil {
+		a.width, a.height = 80, 24
+	}
+
+	return a.loop()
+}
+
+func newApp(ctx context.Context, client *api.Client, opts Options, in io.Reader, out io.Writer) *app {
+	if opts.Limit <= 0 {
+		opts.Limit = 50
+	}
+
+	return &app{
+		ctx:    ctx,
+		client: client,
+		opts:   opts,
+		in:     bufio.NewReader(in),
+		out:    out,
+		width:  80,
+		height: 24,
+	}
+}
+
+func (a *app) loop() error {
+	if a.opts.InboxID != "" {
+		a.openInbox(&api.Inbox{ID: a.opts.InboxID, Name: a.opts.InboxID})
+	} else {
+		a.loadInboxes()
+	}
+
+	for {
+		a.render()
+
+		k, err := readKey(a.in)
+		if err != nil {
+			return err
+		}
+
+		if err := a.handle(k); err != nil {
+			if errors.Is(err, errQuit) {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+// handle applies a keypress to the current view.
+func (a *app) handle(k key) error {
+	if k.code == keyCtrlC {
+		return errQuit
+	}
+
+	a.status = ""
+
+	switch k.code {
+	case keyUp:
+		a.move(-1)
+	case keyDown:
+		a.move(1)
+	case keyPageUp:
+		a.move(-a.pageSize())
+	case keyPageDown:
+		a.move(a.pageSize())
+	case keyEnter, keyRight:
+		a.enter()
+	case keyEsc, keyLeft, keyBackspace:
+		a.back()
+	case keyRune:
+		return a.handleRune(k.r)
+	}
+
+	return nil
+}
+
+func (a *app) handleRune(r rune) error {
+	switch r {
+	case 'q':
+		if a.view == viewPreview {
+			a.back()
+
+			return nil
+		}
+
+		return errQuit
+	case 'k':
+		a.move(-1)
+	case 'j':
+		a.move(1)
+	case 'r':
+		a.refresh()
+	}
+
+	conv := a.selected()
+	if conv == nil {
+		return nil
+	}
+
+	switch r {
+	case 'a':
+		a.archive(conv)
+	case 'A':
+		if id := a.ask("Assign to teammate ID: "); id != "" {
+			a.apply(conv, "Assigned to "+id, func() error {
+				return a.client.Patch(a.ctx, "/conversations/"+conv.ID, map[string]string{"assignee_id": id}, nil)
+			})
+		}
+	case 't':
+		if id := a.ask("Tag ID: "); id != "" {
+			a.apply(conv, "Tagged "+id, func() error {
+				return a.client.Post(a.ctx, "/conversations/"+conv.ID+"/tags", map[string][]string{"tag_ids": {id}}, nil)
+			})
+		}
+	case 's':
+		a.snooze(conv)
+	}
+
+	return nil
+}
+
+func (a *app) selected() *api.Conversation {
+	if a.view == viewInboxes || a.cursor >= len(a.convs) {
+		return nil
+	}
+
+	return &a.convs[a.cursor]
+}
+
+func (a *app) itemCount() int {
+	switch a.view {
+	case viewInboxes:
+		return len(a.inboxes)
+	case viewConversations:
+		return len(a.convs)
+	default:
+		return len(a.preview)
+	}
+}
+
+// pageSize is the number of body rows between the header and status lines.
+func (a *app) pageSize() int {
+	return max(a.height-4, 1)
+}
+
+func (a *app) move(delta int) {
+	if a.view == viewPreview {
+		a.offset = clamp(a.offset+delta, 0, max(len(a.preview)-a.pageSize(), 0))
+
+		return
+	}
+
+	a.cursor = clamp(a.cursor+delta, 0, max(a.itemCount()-1, 0))
+
+	if a.cursor < a.offset {
+		a.offset = a.cursor
+	}
+
+	if a.cursor >= a.offset+a.pageSize() {
+		a.offset = a.cursor - a.pageSize() + 1
+	}
+}
+
+func (a *app) enter() {
+	switch a.view {
+	case viewInboxes:
+		if a.cursor < len(a.inboxes) {
+			a.openInbox(&a.inboxes[a.cursor])
+		}
+	case viewConversations:
+		if conv := a.selected(); conv != nil {
+			a.openPreview(conv)
+		}
+	case viewPreview:
+	}
+}
+
+func (a *app) back() {
+	switch a.view {
+	case viewPreview:
+		a.view = viewConversations
+		a.offset = max(a.cursor-a.pageSize()+1, 0)
+	case viewConversations:
+		if a.inboxes == nil {
+			a.loadInboxes()
+
+			return
+		}
+
+		a.view = viewInboxes
+		a.cursor, a.offset = 0, 0
+	case viewInboxes:
+	}
+}
+
+func (a *app) refresh() {
+	switch a.view {
+	case viewInboxes:
+		a.loadInboxes()
+	case viewConversations:
+		a.openInbox(a.inbox)
+	case viewPreview:
+		if conv := a.selected(); conv != nil {
+			a.openPreview(conv)
+		}
+	}
+}
+
+func (a *app) loadInboxes() {
+	a.view = viewInboxes
+	a.cursor, a.offset = 0, 0
+
+	resp, err := api.GetPage[api.Inbox](a.ctx, a.client, "/inboxes")
+	if err != nil {
+		a.status = "Error: " + err.Error()
+
+		return
+	}
+
+	a.inboxes = resp.Results
+}
+
+func (a *app) openInbox(inbox *api.Inbox) {
+	a.view = viewConversations
+	a.inbox = inbox
+	a.cursor, a.offset = 0, 0
+
+	path := fmt.Sprintf("/inboxes/%s/conversations?limit=%d", inbox.ID, a.opts.Limit)
+
+	resp, err := api.GetPage[api.Conversation](a.ctx, a.client, path)
+	if err != nil {
+		a.convs = nil
+		a.status = "Error: " + err.Error()
+
+		return
+	}
+
+	a.convs = resp.Results
+}
+
+func (a *app) openPreview(conv *api.Conversation) {
+	resp, err := a.client.ListConversationMessages(a.ctx, conv.ID, 25)
+	if err != nil {
+		a.status = "Error: " + err.Error()
+
+		return
+	}
+
+	a.view = viewPreview
+	a.offset = 0
+	a.preview = timelineLines(resp.Results, a.width)
+}
+
+func (a *app) archive(conv *api.Conversation) {
+	err := a.client.Patch(a.ctx, "/conversations/"+conv.ID, map[string]string{"status": "archived"}, nil)
+	if err != nil {
+		a.status = "Error: " + err.Error()
+
+		return
+	}
+
+	a.status = "Archived " + conv.ID
+	a.convs = append(a.convs[:a.cursor], a.convs[a.cursor+1:]...)
+	a.cursor = clamp(a.cursor, 0, max(len(a.convs)-1, 0))
+
+	if a.view == viewPreview {
+		a.back()
+	}
+}
+
+func (a *app) snooze(conv *api.Conversation) {
+	input := a.ask("Snooze for (e.g. 2h, 30m): ")
+	if input == "" {
+		return
+	}
+
+	d, err := time.ParseDuration(input)
+	if err != nil {
+		a.status = "Error: invalid duration " + input
+
+		return
+	}
+
+	until := time.Now().Add(d).UTC().Format(time.RFC3339)
+
+	a.apply(conv, "Snoozed until "+until, func() error {
+		return a.client.Patch(a.ctx, fmt.Sprintf("/conversations/%s/reminders", conv.ID), map[string]string{"scheduled_at": until}, nil)
+	})
+}
+
+// apply runs an action against conv and reports the outcome in the status line.
+func (a *app) apply(conv *api.Conversation, done string, fn func() error) {
+	if err := fn(); err != nil {
+		a.status = "Error: " + err.Error()
+
+		return
+	}
+
+	a.status = fmt.Sprintf("%s: %s", conv.ID, done)
+}
+
+// ask reads a line of input in the status bar. Esc cancels and returns "".
+func (a *app) ask(prompt string) string {
+	a.prompt, a.response = prompt, ""
+	defer func() { a.prompt, a.response = "", "" }()
+
+	for {
+		a.render()
+
+		k, err := readKey(a.in)
+		if err != nil {
+			return ""
+		}
+
+		switch k.code {
+		case keyEnter:
+			return strings.TrimSpace(a.response)
+		case keyEsc, keyCtrlC:
+			return ""
+		case keyBackspace:
+			if r := []rune(a.response); len(r) > 0 {
+				a.response = string(r[:len(r)-1])
+			}
+		case keyRune:
+			a.response += string(k.r)
+		default:
+		}
+	}
+}
+
+func (a *app) render() {
+	var b strings.Builder
+
+	b.WriteString(ansiClear)
+
+	switch a.view {
+	case viewInboxes:
+		a.writeHeader(&b, "Inboxes")
+
+		for i := a.offset; i < len(a.inboxes) && i < a.offset+a.pageSize(); i++ {
+			a.writeRow(&b, i == a.cursor, fmt.Sprintf("%-24s %s", a.inboxes[i].ID, a.inboxes[i].Name))
+		}
+
+		if len(a.inboxes) == 0 {
+			b.WriteString("No inboxes found.\r\n")
+		}
+	case viewConversations:
+		a.writeHeader(&b, "Inbox: "+a.inbox.Name)
+
+		for i := a.offset; i < len(a.convs) && i < a.offset+a.pageSize(); i++ {
+			a.writeRow(&b, i == a.cursor, conversationLine(a.convs[i]))
+		}
+
+		if len(a.convs) == 0 {
+			b.WriteString("No conversations found.\r\n")
+		}
+	case viewPreview:
+		title := "Conversation"
+		if conv := a.selected(); conv != nil {
+			title = conv.ID + "  " + conv.Subject
+		}
+
+		a.writeHeader(&b, title)
+
+		for i := a.offset; i < len(a.preview) && i < a.offset+a.pageSize(); i++ {
+			b.WriteString(a.preview[i] + "\r\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "\x1b[%d;1H", a.height-1)
+
+	switch {
+	case a.prompt != "":
+		b.WriteString(a.prompt + a.response)
+	case a.status != "":
+		b.WriteString(truncate(a.status, a.width))
+	}
+
+	fmt.Fprintf(&b, "\x1b[%d;1H%s%s%s", a.height, ansiDim, truncate(a.helpLine(), a.width), ansiReset)
+
+	_, _ = io.WriteString(a.out, b.String())
+}
+
+func (a *app) writeHeader(b *strings.Builder, title string) {
+	b.WriteString(ansiBold + truncate(title, a.width) + ansiReset + "\r\n\r\n")
+}
+
+func (a *app) writeRow(b *strings.Builder, selected bool, line string) {
+	line = truncate(line, a.width)
+	if selected {
+		line = ansiReverse + line + ansiReset
+	}
+
+	b.WriteString(line + "\r\n")
+}
+
+func (a *app) helpLine() string {
+	switch a.view {
+	case viewInboxes:
+		return "↑/↓ move  enter open  r refresh  q quit"
+	case viewPreview:
+		return "↑/↓ scroll  a archive  A assign  t tag  s snooze  esc back"
+	default:
+		return "↑/↓ move  enter preview  a archive  A assign  t tag  s snooze  r refresh  esc inboxes  q quit"
+	}
+}
+
+func conversationLine(conv api.Conversation) string {
+	row := output.FormatConversation(conv)
+
+	return fmt.Sprintf("%-10s %-28s %s  %s", row[1], row[2], row[4], row[3])
+}
+
+// timelineLines renders messages oldest-first as wrapped lines for the preview.
+func timelineLines(msgs []api.Message, width int) []string {
+	var lines []string
+
+	for i := len(msgs) - 1; i >= 0; i-- {
+		msg := msgs[i]
+		row := output.FormatMessage(msg)
+
+		lines = append(lines, fmt.Sprintf("%s── %s  %s  %s%s", ansiBold, row[1], row[2], row[4], ansiReset))
+
+		body := msg.Text
+		if strings.TrimSpace(body) == "" {
+			if md, err := markdown.ToMarkdown(msg.Body); err == nil {
+				body = md
+			}
+		}
+
+		for _, l := range strings.Split(strings.TrimSpace(body), "\n") {
+			lines = append(lines, wrap(strings.TrimRight(l, "\r "), width)...)
+		}
+
+		lines = append(lines, "")
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "No messages found.")
+	}
+
+	return lines
+}
+
+func wrap(line string, width int) []string {
+	r := []rune(line)
+	if width <= 0 || len(r) <= width {
+		return []string{line}
+	}
+
+	var out []string
+	for len(r) > width {
+		out = append(out, string(r[:width]))
+		r = r[width:]
+	}
+
+	return append(out, string(r))
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if width <= 0 || len(r) <= width {
+		return s
+	}
+
+	return string(r[:width])
+}
+
+func clamp(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/frontapp-cli/internal/api"
+)
+
+func TestAppArchivesSelectedConversation(t *testing.T) {
+	var patched []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/inboxes/inb_1/conversations":
+			_, _ = io.WriteString(w, `{"_results":[{"id":"cnv_1","subject":"First"},{"id":"cnv_2","subject":"Second"}]}`)
+		case r.Method == http.MethodPatch:
+			patched = append(patched, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), srv.URL)
+
+	var out bytes.Buffer
+
+	// Move down to the second conversation, archive it, then quit.
+	a := newApp(context.Background(), client, Options{InboxID: "inb_1"}, strings.NewReader("ja q"), &out)
+
+	if err := a.loop(); err != nil {
+		t.Fatalf("loop: %v", err)
+	}
+
+	if len(patched) != 1 || patched[0] != "/conversations/cnv_2" {
+		t.Fatalf("unexpected PATCH requests: %v", patched)
+	}
+
+	if len(a.convs) != 1 || a.convs[0].ID != "cnv_1" {
+		t.Fatalf("expected cnv_2 to be removed, got %+v", a.convs)
+	}
+
+	if !strings.Contains(out.String(), "Archived cnv_2") {
+		t.Fatal("expected archive status to be rendered")
+	}
+}
+
+func TestTimelineLinesOldestFirst(t *testing.T) {
+	msgs := []api.Message{
+		{ID: "msg_2", Text: "second"},
+		{ID: "msg_1", Text: "first"},
+	}
+
+	lines := strings.Join(timelineLines(msgs, 80), "\n")
+
+	if strings.Index(lines, "first") > strings.Index(lines, "second") {
+		t.Fatalf("expected oldest message first:\n%s", lines)
+	}
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+)
+
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyEnter
+	keyEsc
+	keyBackspace
+	keyCtrlC
+	keyUnknown
+)
+
+// key is a single decoded keypress. r is only set for keyRune.
+type key struct {
+	code keyCode
+	r    rune
+}
+
+// readKey decodes one keypress from a terminal in raw mode, including the
+// ANSI escape sequences sent for arrow and paging keys.
+func readKey(r *bufio.Reader) (key, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return key{}, fmt.Errorf("read key: %w", err)
+	}
+
+	switch b {
+	case 3:
+		return key{code: keyCtrlC}, nil
+	case '\r', '\n':
+		return key{code: keyEnter}, nil
+	case 127, 8:
+		return key{code: keyBackspace}, nil
+	case 0x1b:
+		return readEscape(r)
+	}
+
+	if b < 0x20 {
+		return key{code: keyUnknown}, nil
+	}
+
+	if err := r.UnreadByte(); err != nil {
+		return key{}, fmt.Errorf("read key: %w", err)
+	}
+
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return key{}, fmt.Errorf("read key: %w", err)
+	}
+
+	return key{code: keyRune, r: ch}, nil
+}
+
+func readEscape(r *bufio.Reader) (key, error) {
+	// A lone ESC has nothing buffered behind it.
+	if r.Buffered() == 0 {
+		return key{code: keyEsc}, nil
+	}
+
+	b, err := r.ReadByte()
+	if err != nil || (b != '[' && b != 'O') {
+		return key{code: keyEsc}, nil //nolint:nilerr // treat a truncated sequence as ESC
+	}
+
+	b, err = r.ReadByte()
+	if err != nil {
+		return key{code: keyEsc}, nil //nolint:nilerr // treat a truncated sequence as ESC
+	}
+
+	switch b {
+	case 'A':
+		return key{code: keyUp}, nil
+	case 'B':
+		return key{code: keyDown}, nil
+	case 'C':
+		return key{code: keyRight}, nil
+	case 'D':
+		return key{code: keyLeft}, nil
+	case '5', '6':
+		if next, err := r.ReadByte(); err != nil || next != '~' {
+			return key{code: keyUnknown}, nil //nolint:nilerr // ignore malformed sequences
+		}
+
+		if b == '5' {
+			return key{code: keyPageUp}, nil
+		}
+
+		return key{code: keyPageDown}, nil
+	}
+
+	return key{code: keyUnknown}, nil
+}
@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		in   string
+		want key
+	}{
+		{in: "a", want: key{code: keyRune, r: 'a'}},
+		{in: "é", want: key{code: keyRune, r: 'é'}},
+		{in: "\r", want: key{code: keyEnter}},
+		{in: "\x7f", want: key{code: keyBackspace}},
+		{in: "\x03", want: key{code: keyCtrlC}},
+		{in: "\x1b", want: key{code: keyEsc}},
+		{in: "\x1b[A", want: key{code: keyUp}},
+		{in: "\x1b[B", want: key{code: keyDown}},
+		{in: "\x1bOC", want: key{code: keyRight}},
+		{in: "\x1b[D", want: key{code: keyLeft}},
+		{in: "\x1b[5~", want: key{code: keyPageUp}},
+		{in: "\x1b[6~", want: key{code: keyPageDown}},
+	}
+
+	for _, tt := range tests {
+		got, err := readKey(bufio.NewReader(strings.NewReader(tt.in)))
+		if err != nil {
+			t.Fatalf("readKey(%q): %v", tt.in, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("readKey(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Package webhook validates and decodes Front webhook deliveries.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Front signs legacy webhooks with HMAC-SHA1
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Header names used by Front webhook deliveries.
+const (
+	SignatureHeader = "X-Front-Signature"
+	TimestampHeader = "X-Front-Request-Timestamp"
+	ChallengeHeader = "X-Front-Challenge"
+)
+
+var (
+	ErrMissingSignature = errors.New("missing webhook signature")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// VerifySignature checks the X-Front-Signature header of a delivery against
+// secret. Application webhooks sign "timestamp:body" with HMAC-SHA256; rule
+// and legacy webhooks sign the body alone with HMAC-SHA1.
+func VerifySignature(secret string, header http.Header, body []byte) error {
+	sig := header.Get(SignatureHeader)
+	if sig == "" {
+		return ErrMissingSignature
+	}
+
+	got, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	var mac []byte
+
+	if ts := header.Get(TimestampHeader); ts != "" {
+		h := hmac.New(sha256.New, []byte(secret))
+		_, _ = h.Write([]byte(ts + ":"))
+		_, _ = h.Write(body)
+		mac = h.Sum(nil)
+	} else {
+		h := hmac.New(sha1.New, []byte(secret))
+		_, _ = h.Write(body)
+		mac = h.Sum(nil)
+	}
+
+	if !hmac.Equal(got, mac) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// EventType returns the event type of a webhook payload. Application webhooks
+// wrap the event in a "payload" object; rule webhooks deliver it directly.
+func EventType(body []byte) (string, error) {
+	var envelope struct {
+		Type    string `json:"type"`
+		Payload *struct {
+			Type string `json:"type"`
+		} `json:"payload"`
+	}
+
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return "", fmt.Errorf("decode webhook payload: %w", err)
+	}
+
+	if envelope.Payload != nil && envelope.Payload.Type != "" {
+		return envelope.Payload.Type, nil
+	}
+
+	return envelope.Type, nil
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Front signs legacy webhooks with HMAC-SHA1
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func sign(secret, payload string, sha256Sig bool) string {
+	h := hmac.New(sha1.New, []byte(secret))
+	if sha256Sig {
+		h = hmac.New(sha256.New, []byte(secret))
+	}
+
+	_, _ = h.Write([]byte(payload))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"type":"archive"}`)
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		wantErr error
+	}{
+		{
+			name:   "legacy sha1",
+			header: http.Header{SignatureHeader: {sign("s3cret", string(body), false)}},
+		},
+		{
+			name: "app sha256 with timestamp",
+			header: http.Header{
+				SignatureHeader: {sign("s3cret", "1700000000:"+string(body), true)},
+				TimestampHeader: {"1700000000"},
+			},
+		},
+		{
+			name:    "missing",
+			header:  http.Header{},
+			wantErr: ErrMissingSignature,
+		},
+		{
+			name:    "wrong secret",
+			header:  http.Header{SignatureHeader: {sign("other", string(body), false)}},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "not base64",
+			header:  http.Header{SignatureHeader: {"%%%"}},
+			wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature("s3cret", tt.header, body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifySignature() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventType(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{body: `{"type":"assign","id":"evt_1"}`, want: "assign"},
+		{body: `{"type":"sync","payload":{"type":"inbound"}}`, want: "inbound"},
+	}
+
+	for _, tt := range tests {
+		got, err := EventType([]byte(tt.body))
+		if err != nil {
+			t.Fatalf("EventType(%s): %v", tt.body, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("EventType(%s) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+
+	if _, err := EventType([]byte("not json")); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+}